@@ -1,6 +1,7 @@
 package controllers
 
 import (
+    "errors"
     "net/http"
     "task_manager/data"
     "task_manager/models"
@@ -28,7 +29,15 @@ func CreateTask(c *gin.Context) {
         c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
         return
     }
-    createdTask := data.CreateTask(newTask)
+    createdTask, err := data.CreateTask(newTask)
+    if errors.Is(err, data.ErrTaskExists) {
+        c.IndentedJSON(http.StatusConflict, gin.H{"message": err.Error()})
+        return
+    }
+    if err != nil {
+        c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+        return
+    }
     c.IndentedJSON(http.StatusCreated, createdTask)
 }
 
@@ -39,7 +48,11 @@ func UpdateTask(c *gin.Context) {
         c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
         return
     }
-    task, found := data.UpdateTask(id, updatedTask)
+    task, found, err := data.UpdateTask(id, updatedTask)
+    if err != nil {
+        c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+        return
+    }
     if !found {
         c.IndentedJSON(http.StatusNotFound, gin.H{"message": "Task not found!"})
         return
@@ -49,7 +62,12 @@ func UpdateTask(c *gin.Context) {
 
 func DeleteTask(c *gin.Context) {
     id := c.Param("id")
-    if !data.DeleteTask(id) {
+    found, err := data.DeleteTask(id)
+    if err != nil {
+        c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+        return
+    }
+    if !found {
         c.IndentedJSON(http.StatusNotFound, gin.H{"message": "Task not found!"})
         return
     }
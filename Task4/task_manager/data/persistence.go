@@ -0,0 +1,291 @@
+package data
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"task_manager/models"
+	"time"
+)
+
+const (
+	snapshotFile = "tasks.snapshot.json"
+	walFile      = "tasks.wal"
+
+	opPut    = "put"
+	opDelete = "delete"
+)
+
+// entry is one line of the write-ahead log. A put carries the whole task as it is after the
+// change, so replaying the log never needs the previous state.
+type entry struct {
+	Op   string       `json:"op"`
+	ID   string       `json:"id,omitempty"`
+	Task *models.Task `json:"task,omitempty"`
+}
+
+type wal struct {
+	file    *os.File
+	entries int
+}
+
+// append writes the entry as one JSON line and syncs it before returning, so a change the
+// client has been told about survives a crash.
+func (w *wal) append(e entry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if _, err := w.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	if err := w.file.Sync(); err != nil {
+		return err
+	}
+	w.entries++
+	return nil
+}
+
+func (w *wal) reset() error {
+	if err := w.file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := w.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	w.entries = 0
+	return w.file.Sync()
+}
+
+// persistence ties the default store to a directory holding the latest snapshot and the log
+// of changes made since.
+type persistence struct {
+	dir   string
+	store *store
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+var active *persistence
+
+// Open loads the tasks saved in dir, replays the changes logged since the last snapshot and
+// logs every change from then on. A snapshot is taken every interval and the log is emptied.
+// A fresh directory starts with the sample tasks.
+func Open(dir string, interval time.Duration) error {
+	if active != nil {
+		return errors.New("task store is already open")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	tasks, fresh, err := readSnapshot(dir)
+	if err != nil {
+		return err
+	}
+	s := newStore(tasks)
+
+	file, err := os.OpenFile(filepath.Join(dir, walFile), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	replayed, err := replay(file, s)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	if fresh && replayed == 0 {
+		s = newStore(sampleTasks())
+	}
+	s.log = &wal{file: file, entries: replayed}
+
+	p := &persistence{dir: dir, store: s, stop: make(chan struct{}), done: make(chan struct{})}
+	// Start from a compact state: the recovered tasks become the snapshot and the log is emptied.
+	if err := p.snapshot(); err != nil {
+		file.Close()
+		return err
+	}
+
+	defaultStore = s
+	active = p
+	go p.run(interval)
+	return nil
+}
+
+// Close takes a final snapshot and closes the log.
+func Close() error {
+	if active == nil {
+		return nil
+	}
+	p := active
+	close(p.stop)
+	<-p.done
+
+	err := p.snapshot()
+
+	// Later changes stay in memory only instead of failing on a closed file.
+	p.store.mu.Lock()
+	file := p.store.log.file
+	p.store.log = nil
+	p.store.mu.Unlock()
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	active = nil
+	return err
+}
+
+func (p *persistence) run(interval time.Duration) {
+	defer close(p.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			if err := p.snapshot(); err != nil {
+				log.Printf("task snapshot failed: %v", err)
+			}
+		}
+	}
+}
+
+// snapshot holds the write lock while it saves the tasks and empties the log, so no change can
+// land in the log after the copy and then be lost with the truncate.
+func (p *persistence) snapshot() error {
+	s := p.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.log.entries == 0 && fileExists(filepath.Join(p.dir, snapshotFile)) {
+		return nil
+	}
+	if err := writeSnapshot(p.dir, s.snapshot()); err != nil {
+		return err
+	}
+	return s.log.reset()
+}
+
+// writeSnapshot replaces the snapshot atomically: it writes a temporary file, syncs it and
+// renames it over the old one.
+func writeSnapshot(dir string, tasks []models.Task) error {
+	data, err := json.MarshalIndent(tasks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, snapshotFile+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, snapshotFile)); err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+// readSnapshot returns the saved tasks, or fresh=true when there is no snapshot yet.
+func readSnapshot(dir string) (tasks []models.Task, fresh bool, err error) {
+	// Temporary files are left behind only by a crash in the middle of writeSnapshot.
+	leftovers, _ := filepath.Glob(filepath.Join(dir, snapshotFile+".*.tmp"))
+	for _, leftover := range leftovers {
+		os.Remove(leftover)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, snapshotFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, true, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if err := json.Unmarshal(data, &tasks); err != nil {
+		return nil, false, fmt.Errorf("reading %s: %w", snapshotFile, err)
+	}
+	return tasks, false, nil
+}
+
+// replay applies the logged entries to s and returns how many there were. A last line without
+// its newline is what a crash during append leaves behind; it is cut off, since that change was
+// never acknowledged. Any other bad line means the log is corrupt and recovery stops.
+func replay(file *os.File, s *store) (int, error) {
+	reader := bufio.NewReader(file)
+	var offset int64
+	replayed := 0
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) == 0 && err == io.EOF {
+			break
+		}
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+
+		if !bytes.HasSuffix(line, []byte("\n")) {
+			log.Printf("discarding incomplete entry at the end of %s", walFile)
+			if err := file.Truncate(offset); err != nil {
+				return 0, err
+			}
+			break
+		}
+		var e entry
+		if err := json.Unmarshal(line, &e); err != nil || !e.valid() {
+			return 0, fmt.Errorf("%s is corrupt at byte %d", walFile, offset)
+		}
+
+		s.apply(e)
+		replayed++
+		offset += int64(len(line))
+	}
+
+	_, err := file.Seek(0, io.SeekEnd)
+	return replayed, err
+}
+
+func (e entry) valid() bool {
+	switch e.Op {
+	case opPut:
+		return e.Task != nil
+	case opDelete:
+		return e.ID != ""
+	}
+	return false
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,190 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"task_manager/models"
+	"testing"
+	"time"
+)
+
+// open opens dir with a snapshot interval long enough that only the tests take snapshots.
+func open(t *testing.T, dir string) {
+	t.Helper()
+	if err := Open(dir, time.Hour); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { Close() })
+}
+
+// crash stops the store the way a killed process would: without the final snapshot of Close.
+func crash(t *testing.T) {
+	t.Helper()
+	p := active
+	close(p.stop)
+	<-p.done
+	p.store.mu.Lock()
+	p.store.log.file.Close()
+	p.store.log = nil
+	p.store.mu.Unlock()
+	active = nil
+}
+
+func mustCreate(t *testing.T, task models.Task) {
+	t.Helper()
+	if _, err := CreateTask(task); err != nil {
+		t.Fatalf("CreateTask(%s): %v", task.ID, err)
+	}
+}
+
+func taskIDs() []string {
+	var ids []string
+	for _, task := range GetTasks() {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+func equalIDs(t *testing.T, want []string) {
+	t.Helper()
+	got := taskIDs()
+	if len(got) != len(want) {
+		t.Fatalf("tasks = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("tasks = %v, want %v", got, want)
+		}
+	}
+}
+
+func fileSize(t *testing.T, path string) int64 {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat %s: %v", path, err)
+	}
+	return info.Size()
+}
+
+func TestReplaysTheLogAfterARestart(t *testing.T) {
+	dir := t.TempDir()
+	open(t, dir)
+	mustCreate(t, models.Task{ID: "10", Title: "Write report"})
+	if _, _, err := UpdateTask("10", models.Task{Status: "Done"}); err != nil {
+		t.Fatalf("UpdateTask: %v", err)
+	}
+	if _, err := DeleteTask("1"); err != nil {
+		t.Fatalf("DeleteTask: %v", err)
+	}
+	crash(t)
+
+	open(t, dir)
+
+	equalIDs(t, []string{"2", "3", "10"})
+	task, _ := GetTaskByID("10")
+	if task.Title != "Write report" || task.Status != "Done" {
+		t.Fatalf("task 10 = %+v, want the updated task", task)
+	}
+}
+
+func TestDiscardsATornLastLine(t *testing.T) {
+	dir := t.TempDir()
+	open(t, dir)
+	mustCreate(t, models.Task{ID: "10", Title: "Logged"})
+	crash(t)
+
+	path := filepath.Join(dir, walFile)
+	complete := fileSize(t, path)
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.WriteString(`{"op":"put","task":{"id":"11","tit`); err != nil {
+		t.Fatal(err)
+	}
+
+	s := newStore(nil)
+	if _, err := file.Seek(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	replayed, err := replay(file, s)
+	file.Close()
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if replayed != 1 {
+		t.Fatalf("replayed %d entries, want 1", replayed)
+	}
+	if size := fileSize(t, path); size != complete {
+		t.Fatalf("log is %d bytes after replay, want the %d bytes of complete entries", size, complete)
+	}
+
+	open(t, dir)
+	equalIDs(t, []string{"1", "2", "3", "10"})
+}
+
+func TestRefusesACorruptLog(t *testing.T) {
+	dir := t.TempDir()
+	open(t, dir)
+	crash(t)
+	if err := os.WriteFile(filepath.Join(dir, walFile), []byte("not json\n{\"op\":\"delete\",\"id\":\"1\"}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Open(dir, time.Hour); err == nil {
+		Close()
+		t.Fatal("Open succeeded with a corrupt log")
+	}
+}
+
+func TestReplaysTheLogOnTopOfTheSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	open(t, dir)
+	mustCreate(t, models.Task{ID: "10", Title: "In the snapshot"})
+	if err := active.snapshot(); err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	mustCreate(t, models.Task{ID: "11", Title: "Only in the log"})
+	if _, err := DeleteTask("2"); err != nil {
+		t.Fatalf("DeleteTask: %v", err)
+	}
+	crash(t)
+
+	saved, _, err := readSnapshot(dir)
+	if err != nil {
+		t.Fatalf("readSnapshot: %v", err)
+	}
+	if len(saved) != 4 {
+		t.Fatalf("snapshot has %d tasks, want the 4 from before it was taken", len(saved))
+	}
+
+	open(t, dir)
+	equalIDs(t, []string{"1", "3", "10", "11"})
+}
+
+func TestSnapshotEmptiesTheLog(t *testing.T) {
+	dir := t.TempDir()
+	open(t, dir)
+	mustCreate(t, models.Task{ID: "10", Title: "Logged"})
+	path := filepath.Join(dir, walFile)
+	if fileSize(t, path) == 0 {
+		t.Fatal("the change was not logged")
+	}
+
+	if err := active.snapshot(); err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+
+	if size := fileSize(t, path); size != 0 {
+		t.Fatalf("log is %d bytes after a snapshot, want 0", size)
+	}
+	if active.store.log.entries != 0 {
+		t.Fatalf("log counts %d entries after a snapshot, want 0", active.store.log.entries)
+	}
+	mustCreate(t, models.Task{ID: "11", Title: "Logged after"})
+	crash(t)
+
+	open(t, dir)
+	equalIDs(t, []string{"1", "2", "3", "10", "11"})
+}
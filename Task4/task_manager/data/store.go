@@ -0,0 +1,143 @@
+package data
+
+import (
+	"errors"
+	"sync"
+	"task_manager/models"
+)
+
+var ErrTaskExists = errors.New("task with the given id already exists")
+
+// store keeps the tasks in insertion order behind a RWMutex. When a log is attached every
+// change is written to it before it is applied, while the write lock is held, so the log
+// order always matches the in-memory order.
+type store struct {
+	mu    sync.RWMutex
+	tasks map[string]models.Task
+	order []string
+	log   *wal
+}
+
+func newStore(tasks []models.Task) *store {
+	s := &store{tasks: make(map[string]models.Task)}
+	for _, task := range tasks {
+		s.put(task)
+	}
+	return s
+}
+
+func (s *store) list() []models.Task {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.snapshot()
+}
+
+func (s *store) get(id string) (models.Task, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	task, ok := s.tasks[id]
+	return task, ok
+}
+
+func (s *store) create(task models.Task) (models.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tasks[task.ID]; ok {
+		return models.Task{}, ErrTaskExists
+	}
+	if err := s.record(entry{Op: opPut, Task: &task}); err != nil {
+		return models.Task{}, err
+	}
+	s.put(task)
+	return task, nil
+}
+
+// update changes only the fields that are provided through updatedTask.
+func (s *store) update(id string, updatedTask models.Task) (models.Task, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, ok := s.tasks[id]
+	if !ok {
+		return models.Task{}, false, nil
+	}
+	if updatedTask.Title != "" {
+		task.Title = updatedTask.Title
+	}
+	if updatedTask.Description != "" {
+		task.Description = updatedTask.Description
+	}
+	if updatedTask.Status != "" {
+		task.Status = updatedTask.Status
+	}
+	if !updatedTask.DueDate.IsZero() {
+		task.DueDate = updatedTask.DueDate
+	}
+
+	if err := s.record(entry{Op: opPut, Task: &task}); err != nil {
+		return models.Task{}, true, err
+	}
+	s.put(task)
+	return task, true, nil
+}
+
+func (s *store) delete(id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tasks[id]; !ok {
+		return false, nil
+	}
+	if err := s.record(entry{Op: opDelete, ID: id}); err != nil {
+		return true, err
+	}
+	s.remove(id)
+	return true, nil
+}
+
+func (s *store) record(e entry) error {
+	if s.log == nil {
+		return nil
+	}
+	return s.log.append(e)
+}
+
+// apply replays a log entry during recovery.
+func (s *store) apply(e entry) {
+	switch e.Op {
+	case opPut:
+		s.put(*e.Task)
+	case opDelete:
+		s.remove(e.ID)
+	}
+}
+
+func (s *store) put(task models.Task) {
+	if _, ok := s.tasks[task.ID]; !ok {
+		s.order = append(s.order, task.ID)
+	}
+	s.tasks[task.ID] = task
+}
+
+func (s *store) remove(id string) {
+	if _, ok := s.tasks[id]; !ok {
+		return
+	}
+	delete(s.tasks, id)
+	for i, existing := range s.order {
+		if existing == id {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// snapshot copies the tasks in order. The caller must hold the lock.
+func (s *store) snapshot() []models.Task {
+	tasks := make([]models.Task, 0, len(s.order))
+	for _, id := range s.order {
+		tasks = append(tasks, s.tasks[id])
+	}
+	return tasks
+}
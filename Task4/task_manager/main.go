@@ -1,10 +1,49 @@
 package main
 
 import (
+    "context"
+    "errors"
+    "log"
+    "net/http"
+    "os"
+    "os/signal"
+    "syscall"
+    "task_manager/data"
     "task_manager/router"
+    "time"
 )
 
+// How often the task store is snapshotted and its write-ahead log emptied.
+const snapshotInterval = time.Minute
+
 func main() {
+    // Tasks are kept in TASK_DATA_DIR so they survive restarts.
+    dir := os.Getenv("TASK_DATA_DIR")
+    if dir == "" {
+        dir = "task_data"
+    }
+    if err := data.Open(dir, snapshotInterval); err != nil {
+        log.Fatalf("Error opening task store: %v", err)
+    }
+
     r := router.SetupRouter()
-    r.Run(":8080") // Run the server on port 8080
+    server := &http.Server{Addr: ":8080", Handler: r} // Run the server on port 8080
+    go func() {
+        if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+            log.Fatalf("Error starting server: %v", err)
+        }
+    }()
+
+    quit := make(chan os.Signal, 1)
+    signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+    <-quit
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+    if err := server.Shutdown(ctx); err != nil {
+        log.Printf("Error shutting down server: %v", err)
+    }
+    if err := data.Close(); err != nil {
+        log.Printf("Error saving tasks: %v", err)
+    }
 }
@@ -0,0 +1,200 @@
+// Package client is a typed Go client for the task manager HTTP API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	domain "test_task_manager/Domain"
+	"time"
+)
+
+// apiVersion prefixes every path, so the client keeps talking to the API it was written for.
+const apiVersion = "/v1"
+
+type Client struct {
+	BaseURL string
+	// Token is sent as a bearer token on every request when set. Login does not set it.
+	Token string
+	// OrgID is sent as X-Org-ID when set, to act in an organization other than the token's
+	// default one.
+	OrgID      string
+	HTTPClient *http.Client
+}
+
+func NewClient(baseURL string, token string) *Client {
+	return &Client{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		Token:      token,
+		HTTPClient: &http.Client{Timeout: time.Second * 30},
+	}
+}
+
+// APIError is returned for any response outside the 2xx range. Message is the "error" (or
+// "message") field of the body when there is one.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// Register creates an account. It can log in once the link mailed to email is followed.
+func (c *Client) Register(ctx context.Context, username string, password string, email string) error {
+	return c.do(ctx, http.MethodPost, "/register", domain.User{Username: username, Password: password, Email: email}, nil)
+}
+
+// MFARequiredError is returned by Login for users with two-factor authentication. Pass
+// MFAToken to VerifyMFA with a code from their authenticator app.
+type MFARequiredError struct {
+	MFAToken string
+}
+
+func (e *MFARequiredError) Error() string {
+	return "two-factor authentication required"
+}
+
+// Login returns a JWT for the user. Set it as Token to authenticate later calls.
+func (c *Client) Login(ctx context.Context, username string, password string) (string, error) {
+	var response struct {
+		Token    string `json:"token"`
+		MFAToken string `json:"mfa_token"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/login", domain.User{Username: username, Password: password}, &response); err != nil {
+		return "", err
+	}
+	if response.MFAToken != "" {
+		return "", &MFARequiredError{MFAToken: response.MFAToken}
+	}
+	return response.Token, nil
+}
+
+// VerifyMFA finishes a login that failed with MFARequiredError. code is a TOTP code or a
+// recovery code.
+func (c *Client) VerifyMFA(ctx context.Context, mfaToken string, code string) (string, error) {
+	var response struct {
+		Token string `json:"token"`
+	}
+	body := map[string]string{"mfa_token": mfaToken, "code": code}
+	if err := c.do(ctx, http.MethodPost, "/login/mfa", body, &response); err != nil {
+		return "", err
+	}
+	return response.Token, nil
+}
+
+func (c *Client) PromoteUser(ctx context.Context, username string) error {
+	return c.do(ctx, http.MethodPost, "/promote/"+url.PathEscape(username), nil, nil)
+}
+
+func (c *Client) GetTasks(ctx context.Context) ([]domain.Task, error) {
+	var tasks []domain.Task
+	if err := c.do(ctx, http.MethodGet, "/tasks", nil, &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (c *Client) GetTask(ctx context.Context, taskID string) (*domain.Task, error) {
+	var task domain.Task
+	if err := c.do(ctx, http.MethodGet, "/tasks/"+url.PathEscape(taskID), nil, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (c *Client) CreateTask(ctx context.Context, task domain.Task) (*domain.Task, error) {
+	var created domain.Task
+	if err := c.do(ctx, http.MethodPost, "/tasks", task, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdateTask changes the non-empty fields of task.
+func (c *Client) UpdateTask(ctx context.Context, taskID string, task domain.Task) (*domain.Task, error) {
+	var updated domain.Task
+	if err := c.do(ctx, http.MethodPut, "/tasks/"+url.PathEscape(taskID), task, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteTask moves the task to the trash.
+func (c *Client) DeleteTask(ctx context.Context, taskID string) error {
+	return c.do(ctx, http.MethodDelete, "/tasks/"+url.PathEscape(taskID), nil, nil)
+}
+
+func (c *Client) SearchTasks(ctx context.Context, query string, page int, limit int) (*domain.SearchResult, error) {
+	params := url.Values{"q": {query}, "page": {strconv.Itoa(page)}, "limit": {strconv.Itoa(limit)}}
+	var result domain.SearchResult
+	if err := c.do(ctx, http.MethodGet, "/tasks/search?"+params.Encode(), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *Client) do(ctx context.Context, method string, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+apiVersion+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	if c.OrgID != "" {
+		req.Header.Set("X-Org-ID", c.OrgID)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newAPIError(resp)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func newAPIError(resp *http.Response) *APIError {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	var body struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	json.Unmarshal(data, &body)
+
+	apiErr := &APIError{StatusCode: resp.StatusCode, Message: body.Error}
+	if apiErr.Message == "" {
+		apiErr.Message = body.Message
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = strings.TrimSpace(string(data))
+	}
+	return apiErr
+}
@@ -0,0 +1,145 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	client "test_task_manager/Client"
+	domain "test_task_manager/Domain"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ClientTestSuite struct {
+	suite.Suite
+	mux    *http.ServeMux
+	server *httptest.Server
+	client *client.Client
+}
+
+func (suite *ClientTestSuite) SetupTest() {
+	suite.mux = http.NewServeMux()
+	suite.server = httptest.NewServer(suite.mux)
+	suite.client = client.NewClient(suite.server.URL+"/", "token")
+}
+
+func (suite *ClientTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *ClientTestSuite) TestLogin() {
+	suite.mux.HandleFunc("POST /v1/login", func(w http.ResponseWriter, r *http.Request) {
+		var user domain.User
+		json.NewDecoder(r.Body).Decode(&user)
+		suite.Equal("alice", user.Username)
+		suite.Equal("secret", user.Password)
+		w.Write([]byte(`{"message": "User logged in successfully", "token": "jwt"}`))
+	})
+
+	token, err := suite.client.Login(context.Background(), "alice", "secret")
+
+	suite.Require().NoError(err)
+	suite.Equal("jwt", token)
+}
+
+func (suite *ClientTestSuite) TestLoginWithMFA() {
+	suite.mux.HandleFunc("POST /v1/login", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"message": "Two-factor authentication required", "mfa_required": true, "mfa_token": "challenge"}`))
+	})
+	suite.mux.HandleFunc("POST /v1/login/mfa", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		suite.Equal(map[string]string{"mfa_token": "challenge", "code": "123456"}, body)
+		w.Write([]byte(`{"message": "User logged in successfully", "token": "jwt"}`))
+	})
+
+	_, err := suite.client.Login(context.Background(), "alice", "secret")
+
+	var mfaErr *client.MFARequiredError
+	suite.Require().ErrorAs(err, &mfaErr)
+	token, err := suite.client.VerifyMFA(context.Background(), mfaErr.MFAToken, "123456")
+	suite.Require().NoError(err)
+	suite.Equal("jwt", token)
+}
+
+func (suite *ClientTestSuite) TestGetTasksSendsToken() {
+	due := time.Date(2024, 8, 7, 12, 0, 0, 0, time.UTC)
+	suite.mux.HandleFunc("GET /v1/tasks", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal("Bearer token", r.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode([]domain.Task{{ID: "1", Title: "Task 1", DueDate: due}})
+	})
+
+	tasks, err := suite.client.GetTasks(context.Background())
+
+	suite.Require().NoError(err)
+	suite.Equal([]domain.Task{{ID: "1", Title: "Task 1", DueDate: due}}, tasks)
+}
+
+func (suite *ClientTestSuite) TestOrgIDHeader() {
+	suite.mux.HandleFunc("GET /v1/tasks", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal("acme", r.Header.Get("X-Org-ID"))
+		w.Write([]byte(`[]`))
+	})
+	suite.client.OrgID = "acme"
+
+	_, err := suite.client.GetTasks(context.Background())
+
+	suite.NoError(err)
+}
+
+func (suite *ClientTestSuite) TestUpdateTask() {
+	suite.mux.HandleFunc("PUT /v1/tasks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal("a b", r.PathValue("id"))
+		var task domain.Task
+		json.NewDecoder(r.Body).Decode(&task)
+		task.ID = r.PathValue("id")
+		json.NewEncoder(w).Encode(task)
+	})
+
+	task, err := suite.client.UpdateTask(context.Background(), "a b", domain.Task{Status: "Completed"})
+
+	suite.Require().NoError(err)
+	suite.Equal("a b", task.ID)
+	suite.Equal("Completed", task.Status)
+}
+
+func (suite *ClientTestSuite) TestDeleteTaskNoContent() {
+	suite.mux.HandleFunc("DELETE /v1/tasks/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	suite.NoError(suite.client.DeleteTask(context.Background(), "1"))
+}
+
+func (suite *ClientTestSuite) TestAPIError() {
+	suite.mux.HandleFunc("GET /v1/tasks/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "Task not found"}`))
+	})
+
+	_, err := suite.client.GetTask(context.Background(), "missing")
+
+	var apiErr *client.APIError
+	suite.Require().ErrorAs(err, &apiErr)
+	suite.Equal(http.StatusNotFound, apiErr.StatusCode)
+	suite.Equal("Task not found", apiErr.Message)
+}
+
+func (suite *ClientTestSuite) TestAPIErrorFromMessageField() {
+	suite.mux.HandleFunc("POST /v1/register", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message": "Invalid input data"}`))
+	})
+
+	err := suite.client.Register(context.Background(), "", "", "")
+
+	suite.EqualError(err, "400 Bad Request: Invalid input data")
+}
+
+func TestClient(t *testing.T) {
+	suite.Run(t, new(ClientTestSuite))
+}
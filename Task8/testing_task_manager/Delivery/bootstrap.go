@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	repositories "test_task_manager/Repositories"
+	usecases "test_task_manager/UseCases"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/term"
+)
+
+// runCreateAdmin handles `create-admin <username> [email]`. It creates the user as an Admin of
+// the default organization, or promotes them if they exist. A new user's password is read from
+// ADMIN_PASSWORD, or else from in, so it stays out of the shell history. A terminal does not echo it.
+func runCreateAdmin(db *mongo.Database, args []string, in io.Reader, out io.Writer, timeout time.Duration) error {
+	if len(args) < 1 || len(args) > 2 {
+		return errors.New("usage: create-admin <username> [email]")
+	}
+	admin := domain.User{Username: args[0]}
+	if len(args) == 2 {
+		admin.Email = args[1]
+	}
+
+	admin.Password = os.Getenv("ADMIN_PASSWORD")
+	if admin.Password == "" {
+		password, err := readPassword(in, out)
+		if err != nil {
+			return err
+		}
+		admin.Password = password
+	}
+
+	clock := infrastructure.NewSystemClock()
+	users := repositories.NewUserRepository(*db, "users")
+	unitOfWork := repositories.NewUnitOfWork(repositories.NewTransactor(*db), domain.Stores{
+		Users:  users,
+		Outbox: repositories.NewOutboxRepository(*db, "outbox", clock),
+	})
+	userUseCase := usecases.NewUserUseCase(
+		users,
+		infrastructure.NewPasswordService(),
+		infrastructure.NewJWTService(clock),
+		// Admins created here need no verification, so nothing is mailed.
+		infrastructure.NewLogMailer(),
+		unitOfWork,
+		usecases.UserOptions{PasswordLogin: true},
+		clock, infrastructure.NewRandomIDGenerator(), timeout,
+	)
+	if err := userUseCase.CreateAdmin(context.Background(), admin); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s is an Admin of the %s organization\n", args[0], domain.DefaultOrgID)
+	return nil
+}
+
+// readPassword prompts on out and reads a password from in: without echo from a terminal, or
+// the first line of anything else, such as a pipe.
+func readPassword(in io.Reader, out io.Writer) (string, error) {
+	fmt.Fprint(out, "Password: ")
+	defer fmt.Fprintln(out)
+
+	if file, ok := in.(*os.File); ok && term.IsTerminal(int(file.Fd())) {
+		password, err := term.ReadPassword(int(file.Fd()))
+		return string(password), err
+	}
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// warnWithoutAdmin logs how to create the first Admin when the default organization has none.
+// Registration only ever creates Users, so nobody could manage the deployment otherwise.
+func warnWithoutAdmin(db *mongo.Database, timeout time.Duration) {
+	c, cancel := context.WithTimeout(domain.WithOrg(context.Background(), domain.DefaultOrgID), timeout)
+	defer cancel()
+
+	users, err := repositories.NewUserRepository(*db, "users").GetUsers(c)
+	if err != nil {
+		log.Printf("checking for an admin: %v", err)
+		return
+	}
+	for _, user := range users {
+		if role, _ := user.OrgRole(domain.DefaultOrgID); role == "Admin" {
+			return
+		}
+	}
+	log.Printf("no admin exists yet; create one with `%s create-admin <username>`", os.Args[0])
+}
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+	domain "test_task_manager/Domain"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type APIKeyController struct {
+	APIKeyUseCase domain.APIKeyUseCase
+}
+
+type createAPIKeyRequest struct {
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type createAPIKeyResponse struct {
+	// Key is only ever returned here.
+	Key    string        `json:"key"`
+	APIKey domain.APIKey `json:"api_key"`
+}
+
+func (a *APIKeyController) CreateServiceAccount(c *gin.Context) {
+	var account domain.ServiceAccount
+	if err := c.ShouldBindJSON(&account); err != nil {
+		respond(c, http.StatusBadRequest, gin.H{"error": "Invalid input data"})
+		return
+	}
+
+	createdAccount, err := a.APIKeyUseCase.CreateServiceAccount(c, account.Name, c.GetString("username"))
+	if err != nil {
+		if err.Error() == "service account name is required" {
+			respond(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		serverError(c, err)
+		return
+	}
+
+	respond(c, http.StatusCreated, createdAccount)
+}
+
+func (a *APIKeyController) GetServiceAccounts(c *gin.Context) {
+	accounts, err := a.APIKeyUseCase.GetServiceAccounts(c)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	respond(c, http.StatusOK, accounts)
+}
+
+func (a *APIKeyController) CreateAPIKey(c *gin.Context) {
+	var request createAPIKeyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respond(c, http.StatusBadRequest, gin.H{"error": "Invalid input data"})
+		return
+	}
+
+	apiKey, key, err := a.APIKeyUseCase.CreateAPIKey(c, c.Param("id"), request.Scopes, request.ExpiresAt)
+	if err != nil {
+		switch {
+		case err.Error() == "service account not found":
+			respond(c, http.StatusNotFound, gin.H{"error": "Service account not found"})
+		case strings.HasPrefix(err.Error(), "api key ") || strings.HasPrefix(err.Error(), "unknown scope"):
+			respond(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			serverError(c, err)
+		}
+		return
+	}
+
+	respond(c, http.StatusCreated, createAPIKeyResponse{Key: key, APIKey: *apiKey})
+}
+
+func (a *APIKeyController) GetAPIKeys(c *gin.Context) {
+	keys, err := a.APIKeyUseCase.GetAPIKeys(c, c.Param("id"))
+	if err != nil {
+		if err.Error() == "service account not found" {
+			respond(c, http.StatusNotFound, gin.H{"error": "Service account not found"})
+			return
+		}
+		serverError(c, err)
+		return
+	}
+	respond(c, http.StatusOK, keys)
+}
+
+func (a *APIKeyController) RevokeAPIKey(c *gin.Context) {
+	err := a.APIKeyUseCase.RevokeAPIKey(c, c.Param("id"), c.Param("keyId"))
+	if err != nil {
+		if err.Error() == "api key not found" {
+			respond(c, http.StatusNotFound, gin.H{"error": "API key not found"})
+			return
+		}
+		serverError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,82 @@
+package controllers_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"test_task_manager/Delivery/controllers"
+	domain "test_task_manager/Domain"
+	mocks "test_task_manager/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type APIKeyControllerTestSuite struct {
+	suite.Suite
+	apiKeyUseCase *mocks.APIKeyUseCase
+	router        *gin.Engine
+	controller    *controllers.APIKeyController
+}
+
+func (suite *APIKeyControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.apiKeyUseCase = new(mocks.APIKeyUseCase)
+	suite.controller = &controllers.APIKeyController{APIKeyUseCase: suite.apiKeyUseCase}
+	suite.router = gin.New()
+	suite.router.POST("/service-accounts/:id/keys", suite.controller.CreateAPIKey)
+	suite.router.DELETE("/service-accounts/:id/keys/:keyId", suite.controller.RevokeAPIKey)
+}
+
+func (suite *APIKeyControllerTestSuite) TestCreateAPIKeyPositive() {
+	createdAt := time.Date(2024, 8, 7, 12, 0, 0, 0, time.UTC)
+	suite.apiKeyUseCase.On("CreateAPIKey", mock.Anything, "sa1", []string{"read"}, (*time.Time)(nil)).Return(&domain.APIKey{
+		ID:               "k1",
+		ServiceAccountID: "sa1",
+		OrgID:            "acme",
+		Hash:             "stored-hash",
+		Scopes:           []string{"read"},
+		CreatedAt:        createdAt,
+	}, "tmk_k1_secret", nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/service-accounts/sa1/keys", bytes.NewBufferString(`{"scopes":["read"]}`))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	assert.JSONEq(suite.T(), `{"key":"tmk_k1_secret","api_key":{"id":"k1","service_account_id":"sa1","org_id":"acme","scopes":["read"],"created_at":"2024-08-07T12:00:00Z"}}`, w.Body.String())
+}
+
+func (suite *APIKeyControllerTestSuite) TestCreateAPIKeyUnknownScope() {
+	suite.apiKeyUseCase.On("CreateAPIKey", mock.Anything, "sa1", []string{"delete"}, (*time.Time)(nil)).Return(nil, "", errors.New("unknown scope: delete"))
+
+	req := httptest.NewRequest(http.MethodPost, "/service-accounts/sa1/keys", bytes.NewBufferString(`{"scopes":["delete"]}`))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"error":"unknown scope: delete"}`, w.Body.String())
+}
+
+func (suite *APIKeyControllerTestSuite) TestRevokeAPIKeyNotFound() {
+	suite.apiKeyUseCase.On("RevokeAPIKey", mock.Anything, "sa1", "k1").Return(errors.New("api key not found"))
+
+	req := httptest.NewRequest(http.MethodDelete, "/service-accounts/sa1/keys/k1", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func TestAPIKeyControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyControllerTestSuite))
+}
@@ -1,60 +1,142 @@
 package controllers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type TaskController struct {
 	TaskUseCase domain.TaskUseCase
+	// MaxBodySize caps JSON request bodies in bytes; DefaultMaxBodySize when zero.
+	MaxBodySize int64
 }
 
 type UserController struct {
 	UserUseCase domain.UserUseCase
+	// MaxBodySize caps JSON request bodies in bytes; DefaultMaxBodySize when zero.
+	MaxBodySize int64
+}
+
+// serverError reports an unexpected error of a use case. Errors from a database that cannot
+// be reached are 503, so clients know to try again later.
+func serverError(c *gin.Context, err error) {
+	if err.Error() == "database unavailable" {
+		respond(c, http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+	respond(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
 }
 
 // user controllers
 func (u *UserController) Register(c *gin.Context) {
 	var user domain.User
 
-	if err := c.ShouldBindJSON(&user); err != nil {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "Invalid input data"})
+	if !bindJSON(c, u.MaxBodySize, &user) {
 		return
 	}
 
 	err := u.UserUseCase.CreateUser(c, user)
 	if err != nil {
-		if err.Error() == "password length must be greater than 4" {
-			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if err.Error() == "password length must be greater than 4" || err.Error() == "a valid email address is required" {
+			respond(c, http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if err.Error() == "password login is disabled" || err.Error() == "an invitation is required to register" || err.Error() == "invalid or expired invitation" || err.Error() == "email does not match the invitation" {
+			respond(c, http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "username already exists" || err.Error() == "email already exists" {
+			respond(c, http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		serverError(c, err)
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, gin.H{"message": "user registered successfully"})
+	respond(c, http.StatusOK, gin.H{"message": "user registered successfully"})
+}
+
+// VerifyEmail is the target of the link in verification emails.
+func (u *UserController) VerifyEmail(c *gin.Context) {
+	err := u.UserUseCase.VerifyEmail(c, c.Query("token"))
+	if err != nil {
+		if err.Error() == "invalid or expired verification token" {
+			respond(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		serverError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, gin.H{"message": "Email address verified, you can now log in"})
+}
+
+func (u *UserController) ResendVerification(c *gin.Context) {
+	var request struct {
+		Email string `json:"email" binding:"required"`
+	}
+	if !bindJSON(c, u.MaxBodySize, &request) {
+		return
+	}
+
+	err := u.UserUseCase.ResendVerification(c, request.Email)
+	if err != nil {
+		if err.Error() == "a valid email address is required" {
+			respond(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		serverError(c, err)
+		return
+	}
+
+	// The same answer whether or not the address is registered.
+	respond(c, http.StatusAccepted, gin.H{"message": "If the address belongs to an unverified account, a new link is on its way"})
 }
 
 func (u *UserController) Login(c *gin.Context) {
 	var user domain.User
-	if err := c.ShouldBindJSON(&user); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data"})
+	if !bindJSON(c, u.MaxBodySize, &user) {
 		return
 	}
 
-	token, err := u.UserUseCase.Login(c, user)
+	result, err := u.UserUseCase.Login(c, user)
 	if err != nil {
 		if err.Error() == "password length must be greater than 4" {
-			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			respond(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "password login is disabled" || err.Error() == "email address is not verified" {
+			respond(c, http.StatusForbidden, gin.H{"error": err.Error()})
 			return
 		}
-		c.IndentedJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		respond(c, http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, gin.H{"message": "User logged in successfully", "token": token})
+	respondLogin(c, result)
+}
+
+// respondLogin answers a password or single sign-on login: with a token, or with the MFA token
+// to send to /login/mfa with a code.
+func respondLogin(c *gin.Context, result *domain.LoginResult) {
+	if result.MFAToken != "" {
+		respond(c, http.StatusOK, gin.H{"message": "Two-factor authentication required", "mfa_required": true, "mfa_token": result.MFAToken})
+		return
+	}
+	if result.MFAEnrollmentRequired {
+		respond(c, http.StatusOK, gin.H{"message": "User logged in successfully", "token": result.Token, "mfa_enrollment_required": true})
+		return
+	}
+
+	respond(c, http.StatusOK, gin.H{"message": "User logged in successfully", "token": result.Token})
 }
 
 func (u *UserController) PromoteUser(c *gin.Context) {
@@ -63,24 +145,26 @@ func (u *UserController) PromoteUser(c *gin.Context) {
 	_, err := u.UserUseCase.PromoteUser(c, username)
 	if err != nil {
 		if err.Error() == "user not found" {
-			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			respond(c, http.StatusNotFound, gin.H{"error": "User not found"})
+		} else if err.Error() == "user is already an admin" {
+			respond(c, http.StatusConflict, gin.H{"error": "User is already an admin"})
 		} else {
-			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			serverError(c, err)
 		}
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, gin.H{"message": "User promoted successfully"})
+	respond(c, http.StatusOK, gin.H{"message": "User promoted successfully"})
 }
 
 // task controllers
 func (t *TaskController) GetTasks(c *gin.Context) {
 	tasks, err := t.TaskUseCase.GetTasks(c)
 	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		serverError(c, err)
 		return
 	}
-	c.IndentedJSON(http.StatusOK, tasks)
+	respond(c, http.StatusOK, tasks)
 }
 
 func (t *TaskController) GetTaskByID(c *gin.Context) {
@@ -88,55 +172,317 @@ func (t *TaskController) GetTaskByID(c *gin.Context) {
 	task, err := t.TaskUseCase.GetTaskByID(c, id)
 	if err != nil {
 		if err.Error() == "mongo: no documents in result" {
-			c.IndentedJSON(404, gin.H{"error": "Task not found"})
+			respond(c, 404, gin.H{"error": "Task not found"})
 		} else {
-			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			serverError(c, err)
 		}
 		return
 	}
-	c.IndentedJSON(http.StatusOK, task)
+	respond(c, http.StatusOK, task)
 }
 
 func (t *TaskController) CreateTask(c *gin.Context) {
 	var newTask domain.Task
-	if err := c.ShouldBindJSON(&newTask); err != nil {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "Invalid input data"})
+	if !bindJSON(c, t.MaxBodySize, &newTask) {
 		return
 	}
 	createdTask, err := t.TaskUseCase.CreateTask(c, newTask)
 	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		serverError(c, err)
 		return
 	}
-	c.IndentedJSON(http.StatusCreated, createdTask)
+	respond(c, http.StatusCreated, createdTask)
 }
 
 func (t *TaskController) UpdateTask(c *gin.Context) {
 	id := c.Param("id")
 	var updatedTask domain.Task
-	if err := c.ShouldBindJSON(&updatedTask); err != nil {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "Invalid input data"})
+	if !bindJSON(c, t.MaxBodySize, &updatedTask) {
 		return
 	}
 	task, err := t.TaskUseCase.UpdateTask(c, id, updatedTask)
 	if err != nil {
 		if err.Error() == "mongo: no documents in result" {
-			c.IndentedJSON(404, gin.H{"error": "Task not found"})
+			respond(c, 404, gin.H{"error": "Task not found"})
 		} else {
-			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			serverError(c, err)
 		}
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, task)
+	respond(c, http.StatusOK, task)
 }
 
 func (t *TaskController) DeleteTask(c *gin.Context) {
 	id := c.Param("id")
 	err := t.TaskUseCase.DeleteTask(c, id)
 	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if err.Error() == "task not found" {
+			respond(c, http.StatusNotFound, gin.H{"error": "Task not found"})
+		} else {
+			serverError(c, err)
+		}
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
+
+func (t *TaskController) GetTrash(c *gin.Context) {
+	tasks, err := t.TaskUseCase.GetTrash(c)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	respond(c, http.StatusOK, tasks)
+}
+
+func (t *TaskController) RestoreTask(c *gin.Context) {
+	id := c.Param("id")
+	task, err := t.TaskUseCase.RestoreTask(c, id)
+	if err != nil {
+		if err.Error() == "task not found in trash" {
+			respond(c, http.StatusNotFound, gin.H{"error": "Task not found in trash"})
+		} else {
+			serverError(c, err)
+		}
+		return
+	}
+	respond(c, http.StatusOK, task)
+}
+
+// PurgeTask permanently deletes a task, whether or not it is in the trash.
+func (t *TaskController) PurgeTask(c *gin.Context) {
+	id := c.Param("id")
+	err := t.TaskUseCase.PurgeTask(c, id)
+	if err != nil {
+		if err.Error() == "task not found" {
+			respond(c, http.StatusNotFound, gin.H{"error": "Task not found"})
+		} else {
+			serverError(c, err)
+		}
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+type bulkRequest struct {
+	Mode       string                 `json:"mode"`
+	Operations []domain.BulkOperation `json:"operations" binding:"required,dive"`
+}
+
+func (t *TaskController) BulkTasks(c *gin.Context) {
+	var request bulkRequest
+	if !bindJSON(c, t.MaxBodySize, &request) {
+		return
+	}
+
+	var atomic bool
+	switch request.Mode {
+	case "", "best_effort":
+		request.Mode = "best_effort"
+	case "atomic":
+		atomic = true
+	default:
+		respond(c, http.StatusBadRequest, gin.H{"error": "mode must be either atomic or best_effort"})
+		return
+	}
+
+	results, err := t.TaskUseCase.BulkWrite(c, request.Operations, atomic)
+	if err != nil && results == nil {
+		if strings.HasPrefix(err.Error(), "bulk request must") {
+			respond(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		serverError(c, err)
+		return
+	}
+
+	succeeded := 0
+	for _, result := range results {
+		if result.Status == domain.BulkStatusOK {
+			succeeded++
+		}
+	}
+
+	response := gin.H{"mode": request.Mode, "succeeded": succeeded, "failed": len(results) - succeeded, "results": results}
+	if err != nil {
+		response["error"] = err.Error()
+		respond(c, http.StatusUnprocessableEntity, response)
+		return
+	}
+	respond(c, http.StatusOK, response)
+}
+
+const (
+	maxImportSize    = 10 << 20
+	exportFlushEvery = 100
+)
+
+// ExportTasks streams every task in the requested format. Rows are flushed as they are read,
+// so the response starts before the whole collection has been loaded.
+func (t *TaskController) ExportTasks(c *gin.Context) {
+	format := c.Query("format")
+	contentType := infrastructure.ContentType(format)
+	if contentType == "" {
+		respond(c, http.StatusBadRequest, gin.H{"error": "format must be one of csv, jsonl or ics"})
+		return
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", `attachment; filename="tasks.`+format+`"`)
+	c.Status(http.StatusOK)
+
+	encoder, err := infrastructure.NewTaskEncoder(format, c.Writer)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	written := 0
+	err = t.TaskUseCase.ExportTasks(c.Request.Context(), func(task domain.Task) error {
+		if err := encoder.Encode(task); err != nil {
+			return err
+		}
+		written++
+		if written%exportFlushEvery == 0 {
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		// The status line is gone already; all we can do is cut the response short.
+		c.Error(err)
+		return
+	}
+
+	if err := encoder.Close(); err != nil {
+		c.Error(err)
+		return
+	}
+	c.Writer.Flush()
+}
+
+// ImportTasks creates tasks from an uploaded CSV, JSON Lines or iCalendar file. The format comes
+// from ?format= or the Content-Type header. With ?dry_run=true the file is only validated.
+func (t *TaskController) ImportTasks(c *gin.Context) {
+	format := c.Query("format")
+	if format == "" {
+		format = infrastructure.FormatFromContentType(c.ContentType())
+	}
+	if infrastructure.ContentType(format) == "" {
+		respond(c, http.StatusBadRequest, gin.H{"error": "format must be one of csv, jsonl or ics"})
+		return
+	}
+
+	dryRun := false
+	if value := c.Query("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			respond(c, http.StatusBadRequest, gin.H{"error": "dry_run must be true or false"})
+			return
+		}
+		dryRun = parsed
+	}
+
+	rows, err := infrastructure.DecodeTasks(format, http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respond(c, http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("import file must not be larger than %d bytes", maxImportSize)})
+			return
+		}
+		respond(c, http.StatusBadRequest, gin.H{"error": "Invalid import file: " + err.Error()})
+		return
+	}
+
+	report, err := t.TaskUseCase.ImportTasks(c, rows, dryRun)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	respond(c, http.StatusOK, report)
+}
+
+const defaultSearchLimit = 20
+
+// SearchTasks handles GET /tasks/search?q=&page=&limit=.
+func (t *TaskController) SearchTasks(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		respond(c, http.StatusBadRequest, gin.H{"error": "page must be a number"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultSearchLimit)))
+	if err != nil {
+		respond(c, http.StatusBadRequest, gin.H{"error": "limit must be a number"})
+		return
+	}
+
+	result, err := t.TaskUseCase.SearchTasks(c, c.Query("q"), page, limit)
+	if err != nil {
+		message := err.Error()
+		if message == "search query is required" || strings.HasPrefix(message, "page must") || message == "page is too large" || strings.HasPrefix(message, "limit must") {
+			respond(c, http.StatusBadRequest, gin.H{"error": message})
+			return
+		}
+		serverError(c, err)
+		return
+	}
+	respond(c, http.StatusOK, result)
+}
+
+// GetSummary handles GET /reports/summary?from=&to=.
+func (t *TaskController) GetSummary(c *gin.Context) {
+	from, to, ok := reportDates(c)
+	if !ok {
+		return
+	}
+
+	summary, err := t.TaskUseCase.GetSummary(c, from, to)
+	if err != nil {
+		reportError(c, err)
+		return
+	}
+	respond(c, http.StatusOK, summary)
+}
+
+// GetBurndown handles GET /reports/burndown?from=&to=.
+func (t *TaskController) GetBurndown(c *gin.Context) {
+	from, to, ok := reportDates(c)
+	if !ok {
+		return
+	}
+
+	burndown, err := t.TaskUseCase.GetBurndown(c, from, to)
+	if err != nil {
+		reportError(c, err)
+		return
+	}
+	respond(c, http.StatusOK, burndown)
+}
+
+// reportDates reads the optional from and to days of a report. Missing ones are zero.
+func reportDates(c *gin.Context) (time.Time, time.Time, bool) {
+	var dates [2]time.Time
+	for i, name := range []string{"from", "to"} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		date, err := time.Parse(domain.ReportDateLayout, value)
+		if err != nil {
+			respond(c, http.StatusBadRequest, gin.H{"error": name + " must be a date such as 2024-08-31"})
+			return time.Time{}, time.Time{}, false
+		}
+		dates[i] = date
+	}
+	return dates[0], dates[1], true
+}
+
+func reportError(c *gin.Context, err error) {
+	if err.Error() == "from must not be after to" || strings.HasPrefix(err.Error(), "a report must not span") {
+		respond(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	serverError(c, err)
+}
@@ -6,7 +6,9 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"test_task_manager/Delivery/controllers"
 	domain "test_task_manager/Domain"
@@ -33,6 +35,8 @@ func (suite *UserControllerTestSuite) SetupTest() {
 	suite.router.POST("/register", suite.controller.Register)
 	suite.router.POST("/login", suite.controller.Login)
 	suite.router.PUT("/promote/:username", suite.controller.PromoteUser)
+	suite.router.GET("/verify-email", suite.controller.VerifyEmail)
+	suite.router.POST("/verify-email/resend", suite.controller.ResendVerification)
 }
 
 func (suite *UserControllerTestSuite) TestRegisterPositive() {
@@ -79,7 +83,7 @@ func (suite *UserControllerTestSuite) TestRegisterNegative() {
 
 func (suite *UserControllerTestSuite) TestLoginPositive() {
 	user := domain.User{Username: "testuser", Password: "short"}
-	suite.userUseCase.On("Login", mock.Anything, user).Return("validToken", nil)
+	suite.userUseCase.On("Login", mock.Anything, user).Return(&domain.LoginResult{Token: "validToken"}, nil)
 
 	userJSON, err := json.Marshal(user)
 	if err != nil {
@@ -97,7 +101,7 @@ func (suite *UserControllerTestSuite) TestLoginPositive() {
 
 func (suite *UserControllerTestSuite) TestLoginNegative() {
 	user := domain.User{Username: "testuser", Password: "password"}
-	suite.userUseCase.On("Login", mock.Anything, user).Return("", errors.New("invalid credentials"))
+	suite.userUseCase.On("Login", mock.Anything, user).Return(nil, errors.New("invalid credentials"))
 
 	userJSON, err := json.Marshal(user)
 	if err != nil {
@@ -113,6 +117,59 @@ func (suite *UserControllerTestSuite) TestLoginNegative() {
 	assert.JSONEq(suite.T(), `{"error": "invalid credentials"}`, w.Body.String())
 }
 
+func (suite *UserControllerTestSuite) TestLoginMFARequired() {
+	user := domain.User{Username: "testuser", Password: "password"}
+	suite.userUseCase.On("Login", mock.Anything, user).Return(&domain.LoginResult{MFAToken: "mfaToken"}, nil)
+
+	userJSON, _ := json.Marshal(user)
+	req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewBuffer(userJSON))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"message": "Two-factor authentication required", "mfa_required": true, "mfa_token": "mfaToken"}`, w.Body.String())
+}
+
+func (suite *UserControllerTestSuite) TestLoginEmailNotVerified() {
+	user := domain.User{Username: "testuser", Password: "password"}
+	suite.userUseCase.On("Login", mock.Anything, user).Return(nil, errors.New("email address is not verified"))
+
+	userJSON, _ := json.Marshal(user)
+	req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewBuffer(userJSON))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+	assert.JSONEq(suite.T(), `{"error": "email address is not verified"}`, w.Body.String())
+}
+
+func (suite *UserControllerTestSuite) TestVerifyEmail() {
+	suite.userUseCase.On("VerifyEmail", mock.Anything, "good").Return(nil)
+	suite.userUseCase.On("VerifyEmail", mock.Anything, "expired").Return(errors.New("invalid or expired verification token"))
+
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/verify-email?token=good", nil))
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/verify-email?token=expired", nil))
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *UserControllerTestSuite) TestResendVerification() {
+	suite.userUseCase.On("ResendVerification", mock.Anything, "testuser@example.com").Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/verify-email/resend", bytes.NewBufferString(`{"email": "testuser@example.com"}`))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusAccepted, w.Code)
+	suite.userUseCase.AssertExpectations(suite.T())
+}
+
 func (suite *UserControllerTestSuite) TestPromoteUserPositive() {
 	username := "testuser"
 	suite.userUseCase.On("PromoteUser", mock.Anything, username).Return(&domain.User{Username: username, Role: "Admin"}, nil)
@@ -139,6 +196,19 @@ func (suite *UserControllerTestSuite) TestPromoteUserNegative() {
 	assert.JSONEq(suite.T(), `{"error": "User not found"}`, w.Body.String())
 }
 
+func (suite *UserControllerTestSuite) TestPromoteUserAlreadyAdmin() {
+	username := "testuser"
+	suite.userUseCase.On("PromoteUser", mock.Anything, username).Return(nil, errors.New("user is already an admin"))
+
+	req := httptest.NewRequest(http.MethodPut, "/promote/testuser", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.JSONEq(suite.T(), `{"error": "User is already an admin"}`, w.Body.String())
+}
+
 type TaskControllerTestSuite struct {
 	suite.Suite
 	taskUseCase *mocks.TaskUseCase
@@ -156,6 +226,15 @@ func (suite *TaskControllerTestSuite) SetupTest() {
 	suite.router.POST("/tasks", suite.controller.CreateTask)
 	suite.router.PUT("/tasks/:id", suite.controller.UpdateTask)
 	suite.router.DELETE("/tasks/:id", suite.controller.DeleteTask)
+	suite.router.POST("/tasks/bulk", suite.controller.BulkTasks)
+	suite.router.GET("/tasks/export", suite.controller.ExportTasks)
+	suite.router.GET("/tasks/search", suite.controller.SearchTasks)
+	suite.router.GET("/tasks/trash", suite.controller.GetTrash)
+	suite.router.POST("/tasks/:id/restore", suite.controller.RestoreTask)
+	suite.router.DELETE("/tasks/:id/purge", suite.controller.PurgeTask)
+	suite.router.POST("/tasks/import", suite.controller.ImportTasks)
+	suite.router.GET("/reports/summary", suite.controller.GetSummary)
+	suite.router.GET("/reports/burndown", suite.controller.GetBurndown)
 }
 
 func (suite *TaskControllerTestSuite) TestGetTasksPositive() {
@@ -194,6 +273,18 @@ func (suite *TaskControllerTestSuite) TestGetTasksNegative() {
 	assert.JSONEq(suite.T(), `{"error": "database connection error"}`, w.Body.String())
 }
 
+func (suite *TaskControllerTestSuite) TestGetTasksDatabaseUnavailable() {
+	suite.taskUseCase.On("GetTasks", mock.Anything).Return(nil, errors.New("database unavailable"))
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusServiceUnavailable, w.Code)
+	assert.JSONEq(suite.T(), `{"error": "database unavailable"}`, w.Body.String())
+}
+
 func (suite *TaskControllerTestSuite) TestGetTaskByIDPositive() {
 	task := domain.Task{
 		ID:          "1",
@@ -264,7 +355,7 @@ func (suite *TaskControllerTestSuite) TestCreateTaskInvalidInput() {
 	suite.router.ServeHTTP(w, req)
 
 	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
-	assert.JSONEq(suite.T(), `{"error": "Invalid input data"}`, w.Body.String())
+	assert.JSONEq(suite.T(), `{"error": "request body is required"}`, w.Body.String())
 }
 
 func (suite *TaskControllerTestSuite) TestUpdateTaskPositive() {
@@ -338,6 +429,250 @@ func (suite *TaskControllerTestSuite) TestDeleteTaskNegative() {
 	assert.JSONEq(suite.T(), `{"error": "task deletion failed"}`, w.Body.String())
 }
 
+func (suite *TaskControllerTestSuite) TestDeleteTaskNotFound() {
+	suite.taskUseCase.On("DeleteTask", mock.Anything, "1").Return(errors.New("task not found"))
+
+	req := httptest.NewRequest(http.MethodDelete, "/tasks/1", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+	assert.JSONEq(suite.T(), `{"error": "Task not found"}`, w.Body.String())
+}
+
+func (suite *TaskControllerTestSuite) TestBulkTasksBestEffort() {
+	body := `{"operations":[{"op":"delete","id":"1"},{"op":"delete","id":"2"}]}`
+	suite.taskUseCase.On("BulkWrite", mock.Anything, mock.Anything, false).Return([]domain.BulkResult{
+		{Index: 0, Op: "delete", ID: "1", Status: domain.BulkStatusOK},
+		{Index: 1, Op: "delete", ID: "2", Status: domain.BulkStatusFailed, Error: "task not found"},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks/bulk", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	expectedResponse := `{"mode":"best_effort","succeeded":1,"failed":1,"results":[{"index":0,"op":"delete","id":"1","status":"ok"},{"index":1,"op":"delete","id":"2","status":"failed","error":"task not found"}]}`
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), expectedResponse, w.Body.String())
+}
+
+func (suite *TaskControllerTestSuite) TestBulkTasksAtomicRolledBack() {
+	body := `{"mode":"atomic","operations":[{"op":"delete","id":"1"}]}`
+	suite.taskUseCase.On("BulkWrite", mock.Anything, mock.Anything, true).Return([]domain.BulkResult{
+		{Index: 0, Op: "delete", ID: "1", Status: domain.BulkStatusFailed, Error: "task not found"},
+	}, errors.New("bulk operation rolled back"))
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks/bulk", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"error":"bulk operation rolled back"`)
+}
+
+func (suite *TaskControllerTestSuite) TestBulkTasksInvalidMode() {
+	req := httptest.NewRequest(http.MethodPost, "/tasks/bulk", bytes.NewBufferString(`{"mode":"yolo","operations":[{"op":"delete","id":"1"}]}`))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"error": "mode must be either atomic or best_effort"}`, w.Body.String())
+}
+
+func (suite *TaskControllerTestSuite) TestExportTasksCSV() {
+	suite.taskUseCase.On("ExportTasks", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(domain.Task) error)
+			fn(domain.Task{ID: "1", Title: "Task 1", Description: "a, b", Status: "pending"})
+		}).
+		Return(nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks/export?format=csv", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(suite.T(), "id,title,description,due_date,status\n1,Task 1,\"a, b\",,pending\n", w.Body.String())
+}
+
+func (suite *TaskControllerTestSuite) TestExportTasksUnknownFormat() {
+	req := httptest.NewRequest(http.MethodGet, "/tasks/export?format=xml", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.taskUseCase.AssertNotCalled(suite.T(), "ExportTasks", mock.Anything, mock.Anything)
+}
+
+func (suite *TaskControllerTestSuite) TestImportTasksDryRun() {
+	body := "{\"id\":\"1\",\"title\":\"Task 1\"}\nnot json\n"
+	report := &domain.ImportReport{DryRun: true, Total: 2, Valid: 1, Failed: 1, Errors: []domain.ImportError{{Row: 2, Error: "invalid JSON"}}}
+	suite.taskUseCase.On("ImportTasks", mock.Anything, mock.MatchedBy(func(rows []domain.ImportRow) bool {
+		return len(rows) == 2 && rows[0].Task.ID == "1" && rows[1].Error != ""
+	}), true).Return(report, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks/import?dry_run=true", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	expectedResponse := `{"dry_run":true,"total":2,"valid":1,"imported":0,"failed":1,"errors":[{"row":2,"error":"invalid JSON"}]}`
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), expectedResponse, w.Body.String())
+}
+
+func (suite *TaskControllerTestSuite) TestImportTasksTooLarge() {
+	body := "id,title\n1," + strings.Repeat("a", 10<<20)
+	req := httptest.NewRequest(http.MethodPost, "/tasks/import", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "text/csv")
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusRequestEntityTooLarge, w.Code)
+	suite.taskUseCase.AssertNotCalled(suite.T(), "ImportTasks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TaskControllerTestSuite) TestImportTasksUnknownFormat() {
+	req := httptest.NewRequest(http.MethodPost, "/tasks/import", bytes.NewBufferString("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *TaskControllerTestSuite) TestSearchTasksPositive() {
+	result := &domain.SearchResult{Query: "report", Page: 2, Limit: 5, Total: 6, Hits: []domain.SearchHit{
+		{Task: domain.Task{ID: "6", Title: "Quarterly report"}, Score: 2, Highlights: map[string]string{"title": "Quarterly <mark>report</mark>"}},
+	}}
+	suite.taskUseCase.On("SearchTasks", mock.Anything, "report", 2, 5).Return(result, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks/search?q=report&page=2&limit=5", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	expectedResponse := `{"query":"report","page":2,"limit":5,"total":6,"hits":[{"task":{"id":"6","title":"Quarterly report","description":"","due_date":"0001-01-01T00:00:00Z","status":""},"score":2,"highlights":{"title":"Quarterly <mark>report</mark>"}}]}`
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), expectedResponse, w.Body.String())
+}
+
+func (suite *TaskControllerTestSuite) TestSearchTasksMissingQuery() {
+	suite.taskUseCase.On("SearchTasks", mock.Anything, "", 1, 20).Return(nil, errors.New("search query is required"))
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks/search", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"error":"search query is required"}`, w.Body.String())
+}
+
+func (suite *TaskControllerTestSuite) TestSearchTasksDatabaseUnavailable() {
+	suite.taskUseCase.On("SearchTasks", mock.Anything, "report", 1, 20).Return(nil, errors.New("database unavailable"))
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks/search?q=report", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusServiceUnavailable, w.Code)
+}
+
+func (suite *TaskControllerTestSuite) TestGetTrashPositive() {
+	deletedAt := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+	suite.taskUseCase.On("GetTrash", mock.Anything).Return([]domain.Task{{ID: "1", Title: "Task 1", DeletedAt: &deletedAt}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks/trash", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	expectedResponse := `[{"id":"1","title":"Task 1","description":"","due_date":"0001-01-01T00:00:00Z","status":"","deleted_at":"2024-08-01T10:00:00Z"}]`
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), expectedResponse, w.Body.String())
+}
+
+func (suite *TaskControllerTestSuite) TestRestoreTaskNotInTrash() {
+	suite.taskUseCase.On("RestoreTask", mock.Anything, "1").Return(nil, errors.New("task not found in trash"))
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks/1/restore", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+	assert.JSONEq(suite.T(), `{"error":"Task not found in trash"}`, w.Body.String())
+}
+
+func (suite *TaskControllerTestSuite) TestPurgeTaskPositive() {
+	suite.taskUseCase.On("PurgeTask", mock.Anything, "1").Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/tasks/1/purge", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusNoContent, w.Code)
+}
+
+func (suite *TaskControllerTestSuite) TestGetSummaryPositive() {
+	summary := &domain.TaskSummary{
+		GeneratedAt: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+		TaskStats: domain.TaskStats{
+			TaskCounts: domain.TaskCounts{Total: 2, Open: 1, Completed: 1, Overdue: 1},
+			ByStatus:   map[string]int{"Pending": 1, "Completed": 1},
+			ByAssignee: []domain.AssigneeCounts{{Assignee: "alice", TaskCounts: domain.TaskCounts{Total: 2, Open: 1, Completed: 1, Overdue: 1}}},
+		},
+		From:       "2024-01-01",
+		To:         "2024-01-01",
+		Completion: []domain.CompletionPoint{{Date: "2024-01-01", Created: 2, Completed: 1, CompletionRate: 0.5}},
+	}
+	suite.taskUseCase.On("GetSummary", mock.Anything, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}).Return(summary, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/reports/summary?from=2024-01-01", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	expectedResponse := `{"generated_at":"2024-01-01T09:00:00Z","total":2,"open":1,"completed":1,"overdue":1,"by_status":{"Completed":1,"Pending":1},"by_assignee":[{"assignee":"alice","total":2,"open":1,"completed":1,"overdue":1}],"from":"2024-01-01","to":"2024-01-01","completion":[{"date":"2024-01-01","created":2,"completed":1,"completion_rate":0.5}]}`
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), expectedResponse, w.Body.String())
+}
+
+func (suite *TaskControllerTestSuite) TestGetBurndownInvalidDates() {
+	req := httptest.NewRequest(http.MethodGet, "/reports/burndown?from=2024-01-01&to=tomorrow", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"error":"to must be a date such as 2024-08-31"}`, w.Body.String())
+
+	suite.taskUseCase.On("GetBurndown", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("from must not be after to"))
+	req = httptest.NewRequest(http.MethodGet, "/reports/burndown?from=2024-01-02&to=2024-01-01", nil)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"error":"from must not be after to"}`, w.Body.String())
+}
+
 func TestUserController(t *testing.T) {
 	suite.Run(t, new(UserControllerTestSuite))
 }
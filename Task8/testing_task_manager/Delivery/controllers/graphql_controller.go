@@ -0,0 +1,347 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	domain "test_task_manager/Domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// Limits applied to every operation before it runs. Complexity is the number of fields the
+// operation selects with fragments expanded, introspection fields included. Introspection
+// fields such as __schema and __type have a depth limit of their own, because the chains of
+// ofType in the usual introspection query are deeper than any query of the API.
+const (
+	MaxGraphQLDepth              = 8
+	MaxGraphQLComplexity         = 200
+	MaxGraphQLIntrospectionDepth = 15
+)
+
+const graphQLWebSocketProtocol = "graphql-transport-ws"
+
+type GraphQLController struct {
+	schema   graphql.Schema
+	Upgrader websocket.Upgrader
+	// MaxBodySize caps POST bodies in bytes; DefaultMaxBodySize when zero.
+	MaxBodySize int64
+}
+
+func NewGraphQLController(taskUseCase domain.TaskUseCase, userUseCase domain.UserUseCase, mfaUseCase domain.MFAUseCase, eventBus domain.EventBus) (*GraphQLController, error) {
+	schema, err := newGraphQLSchema(taskUseCase, userUseCase, mfaUseCase, eventBus)
+	if err != nil {
+		return nil, err
+	}
+	return &GraphQLController{
+		schema:   schema,
+		Upgrader: websocket.Upgrader{Subprotocols: []string{graphQLWebSocketProtocol}},
+	}, nil
+}
+
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// ServeGraphQL answers queries and mutations sent with POST (or queries sent with GET), and
+// upgrades GET requests that ask for it to a graphql-transport-ws WebSocket for subscriptions.
+func (g *GraphQLController) ServeGraphQL(c *gin.Context) {
+	if websocket.IsWebSocketUpgrade(c.Request) {
+		g.serveWebSocket(c)
+		return
+	}
+
+	var request graphQLRequest
+	if c.Request.Method == http.MethodGet {
+		request.Query = c.Query("query")
+		request.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+				respondJSON(c, http.StatusBadRequest, graphQLErrors("Invalid GraphQL request"))
+				return
+			}
+		}
+	} else if err := g.bind(c, &request); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondJSON(c, http.StatusRequestEntityTooLarge, graphQLErrors(fmt.Sprintf("request body must not be larger than %d bytes", tooLarge.Limit)))
+			return
+		}
+		respondJSON(c, http.StatusBadRequest, graphQLErrors("Invalid GraphQL request"))
+		return
+	}
+
+	operation, err := g.prepare(request)
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, graphQLErrors(err.Error()))
+		return
+	}
+	switch {
+	case operation == ast.OperationTypeSubscription:
+		respondJSON(c, http.StatusBadRequest, graphQLErrors("subscriptions require a WebSocket connection"))
+		return
+	case operation == ast.OperationTypeMutation && c.Request.Method == http.MethodGet:
+		respondJSON(c, http.StatusMethodNotAllowed, graphQLErrors("mutations must be sent with POST"))
+		return
+	}
+
+	respondJSON(c, http.StatusOK, g.execute(viewerContext(c), request))
+}
+
+// bind reads the POST body into request, reading at most MaxBodySize bytes.
+func (g *GraphQLController) bind(c *gin.Context, request *graphQLRequest) error {
+	limit := g.MaxBodySize
+	if limit <= 0 {
+		limit = DefaultMaxBodySize
+	}
+	if c.Request.Body != nil {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+	}
+	return c.ShouldBindJSON(request)
+}
+
+// prepare parses the request, picks the operation to run and checks it against the depth and
+// complexity limits. It returns the operation type.
+func (g *GraphQLController) prepare(request graphQLRequest) (string, error) {
+	document, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(request.Query), Name: "GraphQL request"})})
+	if err != nil {
+		return "", err
+	}
+
+	fragments := make(map[string]*ast.FragmentDefinition)
+	var operations []*ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			if request.OperationName == "" || (definition.Name != nil && definition.Name.Value == request.OperationName) {
+				operations = append(operations, definition)
+			}
+		}
+	}
+	if len(operations) != 1 {
+		if request.OperationName != "" {
+			return "", fmt.Errorf("unknown operation named %q", request.OperationName)
+		}
+		return "", errors.New("operationName is required when the document contains several operations")
+	}
+
+	measurer := &queryMeasurer{fragments: fragments, memo: make(map[string][2]int), visiting: make(map[string]bool)}
+	depth, complexity := measurer.measure(operations[0].SelectionSet)
+	if depth > MaxGraphQLDepth {
+		return "", fmt.Errorf("query depth %d exceeds the limit of %d", depth, MaxGraphQLDepth)
+	}
+	if measurer.introspectionDepth > MaxGraphQLIntrospectionDepth {
+		return "", fmt.Errorf("introspection depth %d exceeds the limit of %d", measurer.introspectionDepth, MaxGraphQLIntrospectionDepth)
+	}
+	if complexity > MaxGraphQLComplexity {
+		return "", fmt.Errorf("query complexity %d exceeds the limit of %d", complexity, MaxGraphQLComplexity)
+	}
+
+	return operations[0].Operation, nil
+}
+
+func (g *GraphQLController) execute(ctx context.Context, request graphQLRequest) *graphql.Result {
+	return graphql.Do(graphql.Params{
+		Schema:         g.schema,
+		RequestString:  request.Query,
+		OperationName:  request.OperationName,
+		VariableValues: request.Variables,
+		Context:        ctx,
+	})
+}
+
+type graphQLMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// serveWebSocket speaks the graphql-transport-ws protocol. Every operation sent with a
+// "subscribe" message runs until it completes, the client sends "complete" or the socket closes.
+func (g *GraphQLController) serveWebSocket(c *gin.Context) {
+	conn, err := g.Upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(viewerContext(c))
+	defer cancel()
+
+	var writeMu sync.Mutex
+	send := func(id string, messageType string, payload interface{}) {
+		message := graphQLMessage{ID: id, Type: messageType}
+		if payload != nil {
+			message.Payload, _ = json.Marshal(payload)
+		}
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		conn.WriteJSON(message)
+	}
+
+	var operationsMu sync.Mutex
+	operations := make(map[string]context.CancelFunc)
+	stop := func(id string) {
+		operationsMu.Lock()
+		defer operationsMu.Unlock()
+		if cancelOperation, ok := operations[id]; ok {
+			cancelOperation()
+			delete(operations, id)
+		}
+	}
+
+	acknowledged := false
+	for {
+		var message graphQLMessage
+		if err := conn.ReadJSON(&message); err != nil {
+			return
+		}
+
+		switch message.Type {
+		case "connection_init":
+			acknowledged = true
+			send("", "connection_ack", nil)
+		case "ping":
+			send("", "pong", nil)
+		case "complete":
+			stop(message.ID)
+		case "subscribe":
+			if !acknowledged {
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(4401, "Unauthorized"))
+				return
+			}
+
+			var request graphQLRequest
+			if err := json.Unmarshal(message.Payload, &request); err != nil {
+				send(message.ID, "error", graphQLErrors("Invalid GraphQL request")["errors"])
+				continue
+			}
+			operation, err := g.prepare(request)
+			if err != nil {
+				send(message.ID, "error", graphQLErrors(err.Error())["errors"])
+				continue
+			}
+
+			operationsMu.Lock()
+			if _, exists := operations[message.ID]; exists {
+				operationsMu.Unlock()
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(4409, "Subscriber for "+message.ID+" already exists"))
+				return
+			}
+			operationCtx, cancelOperation := context.WithCancel(ctx)
+			operations[message.ID] = cancelOperation
+			operationsMu.Unlock()
+
+			go func(id string) {
+				defer stop(id)
+
+				if operation != ast.OperationTypeSubscription {
+					send(id, "next", g.execute(operationCtx, request))
+					send(id, "complete", nil)
+					return
+				}
+
+				results := graphql.Subscribe(graphql.Params{
+					Schema:         g.schema,
+					RequestString:  request.Query,
+					OperationName:  request.OperationName,
+					VariableValues: request.Variables,
+					Context:        operationCtx,
+				})
+				for result := range results {
+					if operationCtx.Err() != nil {
+						continue
+					}
+					send(id, "next", result)
+				}
+				if operationCtx.Err() == nil {
+					send(id, "complete", nil)
+				}
+			}(message.ID)
+		}
+	}
+}
+
+// viewerContext carries the identity AuthMiddleware put on the gin context into resolvers.
+func viewerContext(c *gin.Context) context.Context {
+	ctx := c.Request.Context()
+	role, ok := c.Get("role")
+	if !ok {
+		return ctx
+	}
+	_, apiKey := c.Get("api_key")
+	return withViewer(ctx, fmt.Sprint(c.MustGet("username")), fmt.Sprint(role), apiKey)
+}
+
+func graphQLErrors(message string) gin.H {
+	return gin.H{"errors": []gqlerrors.FormattedError{{Message: message}}}
+}
+
+// queryMeasurer computes the depth and complexity of a selection set. Fragments are measured
+// once and reused, so deeply nested fragment spreads cannot make the check itself expensive.
+// The depth below introspection fields goes to introspectionDepth instead.
+type queryMeasurer struct {
+	fragments          map[string]*ast.FragmentDefinition
+	memo               map[string][2]int
+	visiting           map[string]bool
+	introspectionDepth int
+}
+
+func (m *queryMeasurer) measure(set *ast.SelectionSet) (depth int, complexity int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	for _, selection := range set.Selections {
+		var d, c int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			d, c = m.measure(selection.SelectionSet)
+			d, c = d+1, c+1
+			if strings.HasPrefix(selection.Name.Value, "__") {
+				m.introspectionDepth = max(m.introspectionDepth, d)
+				d = 0
+			}
+		case *ast.InlineFragment:
+			d, c = m.measure(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			d, c = m.measureFragment(selection.Name.Value)
+		}
+
+		depth = max(depth, d)
+		complexity += c
+	}
+	return depth, complexity
+}
+
+func (m *queryMeasurer) measureFragment(name string) (int, int) {
+	if measured, ok := m.memo[name]; ok {
+		return measured[0], measured[1]
+	}
+	fragment, ok := m.fragments[name]
+	// Unknown and cyclic fragments are rejected by validation; they count as empty here.
+	if !ok || m.visiting[name] {
+		return 0, 0
+	}
+
+	m.visiting[name] = true
+	depth, complexity := m.measure(fragment.SelectionSet)
+	delete(m.visiting, name)
+
+	m.memo[name] = [2]int{depth, complexity}
+	return depth, complexity
+}
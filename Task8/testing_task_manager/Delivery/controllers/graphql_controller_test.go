@@ -0,0 +1,241 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"test_task_manager/Delivery/controllers"
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	mocks "test_task_manager/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type GraphQLControllerTestSuite struct {
+	suite.Suite
+	taskUseCase *mocks.TaskUseCase
+	userUseCase *mocks.UserUseCase
+	mfaUseCase  *mocks.MFAUseCase
+	eventBus    *infrastructure.InMemoryEventBus
+	router      *gin.Engine
+}
+
+func (suite *GraphQLControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.taskUseCase = new(mocks.TaskUseCase)
+	suite.userUseCase = new(mocks.UserUseCase)
+	suite.mfaUseCase = new(mocks.MFAUseCase)
+	suite.eventBus = infrastructure.NewEventBus(10)
+
+	controller, err := controllers.NewGraphQLController(suite.taskUseCase, suite.userUseCase, suite.mfaUseCase, suite.eventBus)
+	suite.Require().NoError(err)
+
+	// Stands in for OptionalAuth: the role comes from a header so each test picks its caller.
+	authenticated := func(c *gin.Context) {
+		if role := c.GetHeader("X-Role"); role != "" {
+			c.Set("username", "tester")
+			c.Set("role", role)
+		}
+		c.Next()
+	}
+
+	suite.router = gin.New()
+	suite.router.GET("/graphql", authenticated, controller.ServeGraphQL)
+	suite.router.POST("/graphql", authenticated, controller.ServeGraphQL)
+}
+
+func (suite *GraphQLControllerTestSuite) post(role string, query string, variables map[string]interface{}) (int, map[string]interface{}) {
+	body, _ := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	req, _ := http.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if role != "" {
+		req.Header.Set("X-Role", role)
+	}
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	var response map[string]interface{}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	return w.Code, response
+}
+
+func (suite *GraphQLControllerTestSuite) TestQueryTasks() {
+	suite.taskUseCase.On("GetTasks", mock.Anything).Return([]domain.Task{{ID: "1", Title: "Task 1", Status: "pending"}}, nil)
+
+	code, response := suite.post("User", `{ tasks { id title status dueDate } }`, nil)
+
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(response["errors"])
+	suite.Equal(map[string]interface{}{
+		"tasks": []interface{}{map[string]interface{}{"id": "1", "title": "Task 1", "status": "pending", "dueDate": nil}},
+	}, response["data"])
+}
+
+func (suite *GraphQLControllerTestSuite) TestQueryRequiresAuthentication() {
+	code, response := suite.post("", `{ tasks { id } }`, nil)
+
+	suite.Equal(http.StatusOK, code)
+	suite.Contains(response["errors"].([]interface{})[0].(map[string]interface{})["message"], "authentication required")
+	suite.taskUseCase.AssertNotCalled(suite.T(), "GetTasks", mock.Anything)
+}
+
+func (suite *GraphQLControllerTestSuite) TestCreateTaskForbiddenForUser() {
+	code, response := suite.post("User", `mutation ($input: TaskInput!) { createTask(input: $input) { id } }`,
+		map[string]interface{}{"input": map[string]interface{}{"id": "1", "title": "Task 1"}})
+
+	suite.Equal(http.StatusOK, code)
+	suite.Contains(response["errors"].([]interface{})[0].(map[string]interface{})["message"], "User role not allowed to perform this operation")
+	suite.taskUseCase.AssertNotCalled(suite.T(), "CreateTask", mock.Anything, mock.Anything)
+}
+
+func (suite *GraphQLControllerTestSuite) TestCreateTaskAsAdmin() {
+	task := domain.Task{ID: "1", Title: "Task 1", Status: "pending"}
+	suite.taskUseCase.On("CreateTask", mock.Anything, mock.MatchedBy(func(t domain.Task) bool { return t.ID == "1" && t.Title == "Task 1" })).Return(&task, nil)
+
+	code, response := suite.post("Admin", `mutation ($input: TaskInput!) { createTask(input: $input) { id title } }`,
+		map[string]interface{}{"input": map[string]interface{}{"id": "1", "title": "Task 1", "status": "pending"}})
+
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(response["errors"])
+	suite.Equal(map[string]interface{}{"createTask": map[string]interface{}{"id": "1", "title": "Task 1"}}, response["data"])
+}
+
+func (suite *GraphQLControllerTestSuite) TestLoginWithMFACode() {
+	suite.userUseCase.On("Login", mock.Anything, domain.User{Username: "alice", Password: "secret"}).Return(&domain.LoginResult{MFAToken: "mfaToken"}, nil)
+	suite.mfaUseCase.On("VerifyLogin", mock.Anything, "mfaToken", "123456").Return("token", nil)
+	login := `mutation ($code: String) { login(username: "alice", password: "secret", mfaCode: $code) }`
+
+	_, response := suite.post("", login, nil)
+	suite.Contains(response["errors"].([]interface{})[0].(map[string]interface{})["message"], "two-factor authentication code required")
+
+	_, response = suite.post("", login, map[string]interface{}{"code": "123456"})
+	suite.Nil(response["errors"])
+	suite.Equal(map[string]interface{}{"login": "token"}, response["data"])
+}
+
+func (suite *GraphQLControllerTestSuite) TestDepthLimit() {
+	query := "{ tasks " + strings.Repeat("{ a ", controllers.MaxGraphQLDepth) + strings.Repeat("} ", controllers.MaxGraphQLDepth) + "}"
+
+	code, response := suite.post("User", query, nil)
+
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Contains(response["errors"].([]interface{})[0].(map[string]interface{})["message"], "query depth 9 exceeds the limit of 8")
+}
+
+func (suite *GraphQLControllerTestSuite) TestIntrospection() {
+	query := "{ __schema { types { name fields { name type { name ofType { name ofType { name ofType { name } } } } } } } }"
+
+	code, response := suite.post("User", query, nil)
+
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(response["errors"])
+}
+
+func (suite *GraphQLControllerTestSuite) TestIntrospectionDepthLimit() {
+	query := `{ __type(name: "Task") { ` + strings.Repeat("ofType { ", controllers.MaxGraphQLIntrospectionDepth) + "name" + strings.Repeat(" }", controllers.MaxGraphQLIntrospectionDepth) + " } }"
+
+	code, response := suite.post("User", query, nil)
+
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Contains(response["errors"].([]interface{})[0].(map[string]interface{})["message"], "introspection depth 17 exceeds the limit of 15")
+}
+
+func (suite *GraphQLControllerTestSuite) TestComplexityLimitCountsIntrospection() {
+	query := "{ " + strings.Repeat("__typename ", controllers.MaxGraphQLComplexity+1) + "}"
+
+	code, response := suite.post("User", query, nil)
+
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Contains(response["errors"].([]interface{})[0].(map[string]interface{})["message"], "query complexity 201 exceeds the limit of 200")
+}
+
+func (suite *GraphQLControllerTestSuite) TestComplexityLimitCountsFragments() {
+	query := "fragment F on Task { " + strings.Repeat("id ", 50) + "} { a: tasks { ...F } b: tasks { ...F } c: tasks { ...F } d: tasks { ...F } }"
+
+	code, response := suite.post("User", query, nil)
+
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Contains(response["errors"].([]interface{})[0].(map[string]interface{})["message"], "query complexity 204 exceeds the limit of 200")
+}
+
+func (suite *GraphQLControllerTestSuite) TestOversizedBodyRejected() {
+	query := "{ tasks { id } }" + strings.Repeat(" ", controllers.DefaultMaxBodySize)
+
+	status, response := suite.post("Admin", query, nil)
+
+	suite.Equal(http.StatusRequestEntityTooLarge, status)
+	suite.Contains(response["errors"].([]interface{})[0].(map[string]interface{})["message"], "must not be larger than")
+	suite.taskUseCase.AssertNotCalled(suite.T(), "GetTasks", mock.Anything)
+}
+
+func (suite *GraphQLControllerTestSuite) TestSubscriptionOverHTTPRejected() {
+	code, response := suite.post("User", `subscription { taskChanged { sequence } }`, nil)
+
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Contains(response["errors"].([]interface{})[0].(map[string]interface{})["message"], "subscriptions require a WebSocket connection")
+}
+
+func (suite *GraphQLControllerTestSuite) TestMutationOverGETRejected() {
+	req, _ := http.NewRequest(http.MethodGet, "/graphql?query="+strings.ReplaceAll(`mutation { deleteTask(id: "1") }`, " ", "+"), nil)
+	req.Header.Set("X-Role", "Admin")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusMethodNotAllowed, w.Code)
+	suite.taskUseCase.AssertNotCalled(suite.T(), "DeleteTask", mock.Anything, mock.Anything)
+}
+
+func (suite *GraphQLControllerTestSuite) TestSubscriptionOverWebSocket() {
+	server := httptest.NewServer(suite.router)
+	defer server.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{"graphql-transport-ws"}}
+	header := http.Header{"X-Role": []string{"User"}}
+	conn, resp, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/graphql", header)
+	suite.Require().NoError(err)
+	defer conn.Close()
+	suite.Equal("graphql-transport-ws", resp.Header.Get("Sec-Websocket-Protocol"))
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+	var message map[string]interface{}
+	suite.Require().NoError(conn.WriteJSON(map[string]interface{}{"type": "connection_init"}))
+	suite.Require().NoError(conn.ReadJSON(&message))
+	suite.Equal("connection_ack", message["type"])
+
+	// Published before subscribing: afterSequence defaults to 0, so the event is replayed.
+	data, _ := json.Marshal(domain.Task{ID: "1", Title: "Task 1"})
+	suite.eventBus.Publish(domain.Event{ID: "e1", Type: domain.EventTaskCreated, Data: data})
+
+	suite.Require().NoError(conn.WriteJSON(map[string]interface{}{
+		"id":      "1",
+		"type":    "subscribe",
+		"payload": map[string]interface{}{"query": `subscription { taskChanged { sequence type taskId task { title } } }`},
+	}))
+
+	var next map[string]interface{}
+	suite.Require().NoError(conn.ReadJSON(&next))
+	suite.Equal("next", next["type"])
+	suite.Equal("1", next["id"])
+	suite.Equal(map[string]interface{}{
+		"taskChanged": map[string]interface{}{
+			"sequence": float64(1),
+			"type":     domain.EventTaskCreated,
+			"taskId":   "1",
+			"task":     map[string]interface{}{"title": "Task 1"},
+		},
+	}, next["payload"].(map[string]interface{})["data"])
+}
+
+func TestGraphQLController(t *testing.T) {
+	suite.Run(t, new(GraphQLControllerTestSuite))
+}
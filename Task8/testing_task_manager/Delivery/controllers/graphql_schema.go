@@ -0,0 +1,450 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	domain "test_task_manager/Domain"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+type viewerKey struct{}
+
+// viewer is the authenticated caller of a GraphQL operation. Anonymous callers have no viewer.
+type viewer struct {
+	username string
+	role     string
+	// apiKey is set for service accounts, which may not administer users.
+	apiKey bool
+}
+
+func withViewer(ctx context.Context, username string, role string, apiKey bool) context.Context {
+	return context.WithValue(ctx, viewerKey{}, viewer{username: username, role: role, apiKey: apiKey})
+}
+
+var (
+	errUnauthenticated = errors.New("authentication required")
+	errForbidden       = errors.New("User role not allowed to perform this operation")
+	errAPIKey          = errors.New("API keys cannot perform this operation")
+)
+
+// requireUser and requireAdmin apply the same rules as AuthMiddleware(false) and AuthMiddleware(true),
+// and requireAdminSession the rules of SessionMiddleware(true).
+func requireUser(ctx context.Context) error {
+	if _, ok := ctx.Value(viewerKey{}).(viewer); !ok {
+		return errUnauthenticated
+	}
+	return nil
+}
+
+func requireAdmin(ctx context.Context) error {
+	v, ok := ctx.Value(viewerKey{}).(viewer)
+	if !ok {
+		return errUnauthenticated
+	}
+	if v.role == "User" {
+		return errForbidden
+	}
+	return nil
+}
+
+func requireAdminSession(ctx context.Context) error {
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+	if ctx.Value(viewerKey{}).(viewer).apiKey {
+		return errAPIKey
+	}
+	return nil
+}
+
+// authorized wraps a resolver with one of the checks above.
+func authorized(check func(context.Context) error, resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		if err := check(p.Context); err != nil {
+			return nil, err
+		}
+		return resolve(p)
+	}
+}
+
+func taskField(get func(task domain.Task) interface{}) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		switch task := p.Source.(type) {
+		case domain.Task:
+			return get(task), nil
+		case *domain.Task:
+			return get(*task), nil
+		}
+		return nil, nil
+	}
+}
+
+var taskType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Task",
+	Fields: graphql.Fields{
+		"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: taskField(func(t domain.Task) interface{} { return t.ID })},
+		"title":       &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: taskField(func(t domain.Task) interface{} { return t.Title })},
+		"description": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: taskField(func(t domain.Task) interface{} { return t.Description })},
+		"status":      &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: taskField(func(t domain.Task) interface{} { return t.Status })},
+		"dueDate": &graphql.Field{Type: graphql.DateTime, Resolve: taskField(func(t domain.Task) interface{} {
+			if t.DueDate.IsZero() {
+				return nil
+			}
+			return t.DueDate
+		})},
+		"deletedAt": &graphql.Field{Type: graphql.DateTime, Resolve: taskField(func(t domain.Task) interface{} { return t.DeletedAt })},
+	},
+})
+
+var userType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "User",
+	Fields: graphql.Fields{
+		"username": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(domain.User).Username, nil
+		}},
+		"role": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(domain.User).Role, nil
+		}},
+	},
+})
+
+var searchHitType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "SearchHit",
+	Fields: graphql.Fields{
+		"task": &graphql.Field{Type: graphql.NewNonNull(taskType), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(domain.SearchHit).Task, nil
+		}},
+		"score": &graphql.Field{Type: graphql.NewNonNull(graphql.Float), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(domain.SearchHit).Score, nil
+		}},
+		"titleHighlight": &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(domain.SearchHit).Highlights["title"], nil
+		}},
+		"descriptionHighlight": &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(domain.SearchHit).Highlights["description"], nil
+		}},
+	},
+})
+
+var searchResultType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "SearchResult",
+	Fields: graphql.Fields{
+		"total": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(*domain.SearchResult).Total, nil
+		}},
+		"page": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(*domain.SearchResult).Page, nil
+		}},
+		"limit": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(*domain.SearchResult).Limit, nil
+		}},
+		"hits": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(searchHitType))), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(*domain.SearchResult).Hits, nil
+		}},
+	},
+})
+
+// taskEvent is what the taskChanged subscription resolves each bus event to.
+type taskEvent struct {
+	event domain.StreamEvent
+	task  *domain.Task
+}
+
+var taskEventType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "TaskEvent",
+	Fields: graphql.Fields{
+		"sequence": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return int(p.Source.(taskEvent).event.Sequence), nil
+		}},
+		"type": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(taskEvent).event.Type, nil
+		}},
+		"taskId": &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(taskEvent).task.ID, nil
+		}},
+		// Deletes and purges only carry the task ID, so task is null for them.
+		"task": &graphql.Field{Type: taskType, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			event := p.Source.(taskEvent)
+			if event.event.Type == domain.EventTaskDeleted || event.event.Type == domain.EventTaskPurged {
+				return nil, nil
+			}
+			return event.task, nil
+		}},
+		"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(taskEvent).event.CreatedAt, nil
+		}},
+	},
+})
+
+var taskInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "TaskInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"id":          &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.ID)},
+		"title":       &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"description": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"dueDate":     &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+		"status":      &graphql.InputObjectFieldConfig{Type: graphql.String},
+	},
+})
+
+var taskUpdateInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "TaskUpdateInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"title":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"description": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"dueDate":     &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+		"status":      &graphql.InputObjectFieldConfig{Type: graphql.String},
+	},
+})
+
+func taskFromInput(input map[string]interface{}) domain.Task {
+	var task domain.Task
+	task.ID, _ = input["id"].(string)
+	task.Title, _ = input["title"].(string)
+	task.Description, _ = input["description"].(string)
+	task.Status, _ = input["status"].(string)
+	task.DueDate, _ = input["dueDate"].(time.Time)
+	return task
+}
+
+func newGraphQLSchema(taskUseCase domain.TaskUseCase, userUseCase domain.UserUseCase, mfaUseCase domain.MFAUseCase, eventBus domain.EventBus) (graphql.Schema, error) {
+	idArgs := graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}}
+	taskList := graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(taskType)))
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"tasks": &graphql.Field{
+				Type: taskList,
+				Resolve: authorized(requireUser, func(p graphql.ResolveParams) (interface{}, error) {
+					tasks, err := taskUseCase.GetTasks(p.Context)
+					if tasks == nil && err == nil {
+						tasks = []domain.Task{}
+					}
+					return tasks, err
+				}),
+			},
+			"task": &graphql.Field{
+				Type: taskType,
+				Args: idArgs,
+				Resolve: authorized(requireUser, func(p graphql.ResolveParams) (interface{}, error) {
+					task, err := taskUseCase.GetTaskByID(p.Context, p.Args["id"].(string))
+					if err != nil && err.Error() == "mongo: no documents in result" {
+						return nil, nil
+					}
+					return task, err
+				}),
+			},
+			"searchTasks": &graphql.Field{
+				Type: graphql.NewNonNull(searchResultType),
+				Args: graphql.FieldConfigArgument{
+					"query": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"page":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1},
+					"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultSearchLimit},
+				},
+				Resolve: authorized(requireUser, func(p graphql.ResolveParams) (interface{}, error) {
+					return taskUseCase.SearchTasks(p.Context, p.Args["query"].(string), p.Args["page"].(int), p.Args["limit"].(int))
+				}),
+			},
+			"trash": &graphql.Field{
+				Type: taskList,
+				Resolve: authorized(requireAdmin, func(p graphql.ResolveParams) (interface{}, error) {
+					return taskUseCase.GetTrash(p.Context)
+				}),
+			},
+			"users": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userType))),
+				Resolve: authorized(requireAdminSession, func(p graphql.ResolveParams) (interface{}, error) {
+					return userUseCase.GetUsers(p.Context)
+				}),
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"register": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: graphql.FieldConfigArgument{
+					"username": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"password": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					// email gets a verification link; login is refused until it is followed.
+					"email": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					// inviteCode is required when registration is invite-only.
+					"inviteCode": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					user := domain.User{Username: p.Args["username"].(string), Password: p.Args["password"].(string), Email: p.Args["email"].(string)}
+					if code, ok := p.Args["inviteCode"].(string); ok {
+						user.InviteCode = code
+					}
+					if err := userUseCase.CreateUser(p.Context, user); err != nil {
+						return nil, err
+					}
+					return true, nil
+				},
+			},
+			"login": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Args: graphql.FieldConfigArgument{
+					"username": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"password": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					// mfaCode is the TOTP or recovery code of users with two-factor authentication.
+					"mfaCode": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					user := domain.User{Username: p.Args["username"].(string), Password: p.Args["password"].(string)}
+					result, err := userUseCase.Login(p.Context, user)
+					if err != nil {
+						return nil, err
+					}
+					if result.MFAToken == "" {
+						return result.Token, nil
+					}
+					code, _ := p.Args["mfaCode"].(string)
+					if code == "" {
+						return nil, errors.New("two-factor authentication code required")
+					}
+					return mfaUseCase.VerifyLogin(p.Context, result.MFAToken, code)
+				},
+			},
+			"promoteUser": &graphql.Field{
+				Type: graphql.NewNonNull(userType),
+				Args: graphql.FieldConfigArgument{"username": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: authorized(requireAdminSession, func(p graphql.ResolveParams) (interface{}, error) {
+					user, err := userUseCase.PromoteUser(p.Context, p.Args["username"].(string))
+					if err != nil {
+						return nil, err
+					}
+					return *user, nil
+				}),
+			},
+			"createTask": &graphql.Field{
+				Type: graphql.NewNonNull(taskType),
+				Args: graphql.FieldConfigArgument{"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(taskInputType)}},
+				Resolve: authorized(requireAdmin, func(p graphql.ResolveParams) (interface{}, error) {
+					return taskUseCase.CreateTask(p.Context, taskFromInput(p.Args["input"].(map[string]interface{})))
+				}),
+			},
+			"updateTask": &graphql.Field{
+				Type: graphql.NewNonNull(taskType),
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(taskUpdateInputType)},
+				},
+				Resolve: authorized(requireAdmin, func(p graphql.ResolveParams) (interface{}, error) {
+					task, err := taskUseCase.UpdateTask(p.Context, p.Args["id"].(string), taskFromInput(p.Args["input"].(map[string]interface{})))
+					if err != nil && err.Error() == "mongo: no documents in result" {
+						return nil, errors.New("task not found")
+					}
+					return task, err
+				}),
+			},
+			"deleteTask": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: idArgs,
+				Resolve: authorized(requireAdmin, func(p graphql.ResolveParams) (interface{}, error) {
+					if err := taskUseCase.DeleteTask(p.Context, p.Args["id"].(string)); err != nil {
+						return nil, err
+					}
+					return true, nil
+				}),
+			},
+			"restoreTask": &graphql.Field{
+				Type: graphql.NewNonNull(taskType),
+				Args: idArgs,
+				Resolve: authorized(requireAdmin, func(p graphql.ResolveParams) (interface{}, error) {
+					return taskUseCase.RestoreTask(p.Context, p.Args["id"].(string))
+				}),
+			},
+			"purgeTask": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: idArgs,
+				Resolve: authorized(requireAdmin, func(p graphql.ResolveParams) (interface{}, error) {
+					if err := taskUseCase.PurgeTask(p.Context, p.Args["id"].(string)); err != nil {
+						return nil, err
+					}
+					return true, nil
+				}),
+			},
+		},
+	})
+
+	subscription := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Subscription",
+		Fields: graphql.Fields{
+			"taskChanged": &graphql.Field{
+				Type: graphql.NewNonNull(taskEventType),
+				Args: graphql.FieldConfigArgument{
+					"afterSequence": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+				},
+				Subscribe: authorized(requireUser, func(p graphql.ResolveParams) (interface{}, error) {
+					after, _ := p.Args["afterSequence"].(int)
+					if after < 0 {
+						return nil, errors.New("afterSequence must not be negative")
+					}
+					return subscribeTaskEvents(p.Context, eventBus, uint64(after)), nil
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source, nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:        query,
+		Mutation:     mutation,
+		Subscription: subscription,
+	})
+}
+
+// subscribeTaskEvents forwards the task events of the viewer's organization from the bus until
+// ctx is cancelled or the bus drops the subscriber for falling behind.
+func subscribeTaskEvents(ctx context.Context, eventBus domain.EventBus, after uint64) chan interface{} {
+	orgID, _ := domain.OrgFromContext(ctx)
+	replay, events, cancel := eventBus.Subscribe(after)
+	out := make(chan interface{})
+
+	go func() {
+		defer close(out)
+		defer cancel()
+
+		send := func(event domain.StreamEvent) bool {
+			if !strings.HasPrefix(event.Type, "task.") || event.OrgID != orgID {
+				return true
+			}
+			var task domain.Task
+			json.Unmarshal(event.Data, &task)
+			select {
+			case out <- taskEvent{event: event, task: &task}:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for _, event := range replay {
+			if !send(event) {
+				return
+			}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-events:
+				if !ok || !send(event) {
+					return
+				}
+			}
+		}
+	}()
+
+	return out
+}
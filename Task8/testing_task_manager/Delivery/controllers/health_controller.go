@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	domain "test_task_manager/Domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthController struct {
+	Checks []domain.HealthCheck
+	// Vars are the expvar variables DebugVars serves, by name.
+	Vars map[string]expvar.Var
+}
+
+// Ready answers 200 when every dependency can serve requests and 503 otherwise, with the
+// result of each check, so load balancers stop sending traffic while the database is down.
+func (h *HealthController) Ready(c *gin.Context) {
+	status := http.StatusOK
+	checks := make(map[string]string, len(h.Checks))
+	for _, check := range h.Checks {
+		if err := check.Check(c); err != nil {
+			status = http.StatusServiceUnavailable
+			checks[check.Name()] = err.Error()
+			continue
+		}
+		checks[check.Name()] = "ok"
+	}
+
+	if status != http.StatusOK {
+		respond(c, status, gin.H{"status": "unavailable", "checks": checks})
+		return
+	}
+	respond(c, status, gin.H{"status": "ready", "checks": checks})
+}
+
+// DebugVars serves the variables of the controller, such as the task cache's counters. Only those:
+// the rest of expvar, such as the command line and memory statistics, is not for API clients.
+func (h *HealthController) DebugVars(c *gin.Context) {
+	vars := make(map[string]interface{}, len(h.Vars))
+	for name, v := range h.Vars {
+		var value interface{}
+		if err := json.Unmarshal([]byte(v.String()), &value); err != nil {
+			respond(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		vars[name] = value
+	}
+	respond(c, http.StatusOK, vars)
+}
@@ -0,0 +1,78 @@
+package controllers_test
+
+import (
+	"errors"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"test_task_manager/Delivery/controllers"
+	domain "test_task_manager/Domain"
+	mocks "test_task_manager/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type HealthControllerTestSuite struct {
+	suite.Suite
+	database *mocks.HealthCheck
+	router   *gin.Engine
+}
+
+func (suite *HealthControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.database = new(mocks.HealthCheck)
+	suite.database.On("Name").Return("database")
+	controller := &controllers.HealthController{Checks: []domain.HealthCheck{suite.database}}
+	suite.router = gin.New()
+	suite.router.GET("/readyz", controller.Ready)
+}
+
+func (suite *HealthControllerTestSuite) TestReady() {
+	suite.database.On("Check", mock.Anything).Return(nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"status":"ready","checks":{"database":"ok"}}`, w.Body.String())
+}
+
+func (suite *HealthControllerTestSuite) TestNotReady() {
+	suite.database.On("Check", mock.Anything).Return(errors.New("circuit breaker is open"))
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusServiceUnavailable, w.Code)
+	assert.JSONEq(suite.T(), `{"status":"unavailable","checks":{"database":"circuit breaker is open"}}`, w.Body.String())
+}
+
+func (suite *HealthControllerTestSuite) TestDebugVarsServesOnlyItsVars() {
+	hits := new(expvar.Int)
+	hits.Set(3)
+	cache := new(expvar.Map)
+	cache.Set("hits", hits)
+	controller := &controllers.HealthController{Vars: map[string]expvar.Var{"task_cache": cache}}
+	suite.router.GET("/debug/vars", controller.DebugVars)
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/vars", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"task_cache":{"hits":3}}`, w.Body.String())
+}
+
+func TestHealthControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthControllerTestSuite))
+}
@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"net/http"
+	domain "test_task_manager/Domain"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InvitationController struct {
+	InvitationUseCase domain.InvitationUseCase
+}
+
+type createInvitationRequest struct {
+	Role string `json:"role"`
+	// Email is optional. When set, the code is mailed there and only that address can use it.
+	Email string `json:"email"`
+	// ExpiresIn is a Go duration such as "48h". Empty means the default of 7 days.
+	ExpiresIn string `json:"expires_in"`
+}
+
+type createInvitationResponse struct {
+	// Code is only ever returned here.
+	Code       string            `json:"code"`
+	Invitation domain.Invitation `json:"invitation"`
+}
+
+func (i *InvitationController) CreateInvitation(c *gin.Context) {
+	var request createInvitationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respond(c, http.StatusBadRequest, gin.H{"error": "Invalid input data"})
+		return
+	}
+	var ttl time.Duration
+	if request.ExpiresIn != "" {
+		var err error
+		if ttl, err = time.ParseDuration(request.ExpiresIn); err != nil || ttl <= 0 {
+			respond(c, http.StatusBadRequest, gin.H{"error": "expires_in must be a positive duration such as 48h"})
+			return
+		}
+	}
+
+	invitation, code, err := i.InvitationUseCase.CreateInvitation(c, request.Role, request.Email, ttl, c.GetString("username"))
+	if err != nil {
+		switch err.Error() {
+		case "role must be Admin or User", "invitation must expire within 30 days", "a valid email address is required":
+			respond(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "the invitation email could not be sent":
+			respond(c, http.StatusBadGateway, gin.H{"error": err.Error()})
+		default:
+			serverError(c, err)
+		}
+		return
+	}
+
+	respond(c, http.StatusCreated, createInvitationResponse{Code: code, Invitation: *invitation})
+}
+
+func (i *InvitationController) GetInvitations(c *gin.Context) {
+	invitations, err := i.InvitationUseCase.GetInvitations(c)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	respond(c, http.StatusOK, invitations)
+}
+
+func (i *InvitationController) RevokeInvitation(c *gin.Context) {
+	err := i.InvitationUseCase.RevokeInvitation(c, c.Param("id"))
+	if err != nil {
+		if err.Error() == "invitation not found" {
+			respond(c, http.StatusNotFound, gin.H{"error": "Invitation not found"})
+			return
+		}
+		serverError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,92 @@
+package controllers_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"test_task_manager/Delivery/controllers"
+	domain "test_task_manager/Domain"
+	mocks "test_task_manager/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type InvitationControllerTestSuite struct {
+	suite.Suite
+	invitationUseCase *mocks.InvitationUseCase
+	router            *gin.Engine
+	controller        *controllers.InvitationController
+}
+
+func (suite *InvitationControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.invitationUseCase = new(mocks.InvitationUseCase)
+	suite.controller = &controllers.InvitationController{InvitationUseCase: suite.invitationUseCase}
+	suite.router = gin.New()
+	suite.router.POST("/invitations", suite.controller.CreateInvitation)
+	suite.router.DELETE("/invitations/:id", suite.controller.RevokeInvitation)
+}
+
+func (suite *InvitationControllerTestSuite) TestCreateInvitationPositive() {
+	createdAt := time.Date(2024, 8, 7, 12, 0, 0, 0, time.UTC)
+	suite.invitationUseCase.On("CreateInvitation", mock.Anything, "Admin", "", 48*time.Hour, "").Return(&domain.Invitation{
+		ID:        "i1",
+		OrgID:     "acme",
+		Role:      "Admin",
+		Hash:      "stored-hash",
+		CreatedAt: createdAt,
+		ExpiresAt: createdAt.Add(48 * time.Hour),
+	}, "secret-code", nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/invitations", bytes.NewBufferString(`{"role":"Admin","expires_in":"48h"}`))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	assert.JSONEq(suite.T(), `{"code":"secret-code","invitation":{"id":"i1","org_id":"acme","role":"Admin","created_by":"","created_at":"2024-08-07T12:00:00Z","expires_at":"2024-08-09T12:00:00Z"}}`, w.Body.String())
+}
+
+func (suite *InvitationControllerTestSuite) TestCreateInvitationInvalidExpiry() {
+	req := httptest.NewRequest(http.MethodPost, "/invitations", bytes.NewBufferString(`{"expires_in":"soon"}`))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.invitationUseCase.AssertNotCalled(suite.T(), "CreateInvitation", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *InvitationControllerTestSuite) TestCreateInvitationInvalidRole() {
+	suite.invitationUseCase.On("CreateInvitation", mock.Anything, "Owner", "", time.Duration(0), "").Return(nil, "", errors.New("role must be Admin or User"))
+
+	req := httptest.NewRequest(http.MethodPost, "/invitations", bytes.NewBufferString(`{"role":"Owner"}`))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"error":"role must be Admin or User"}`, w.Body.String())
+}
+
+func (suite *InvitationControllerTestSuite) TestRevokeInvitationNotFound() {
+	suite.invitationUseCase.On("RevokeInvitation", mock.Anything, "i1").Return(errors.New("invitation not found"))
+
+	req := httptest.NewRequest(http.MethodDelete, "/invitations/i1", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func TestInvitationControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(InvitationControllerTestSuite))
+}
@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"net/http"
+	domain "test_task_manager/Domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MFAController struct {
+	MFAUseCase domain.MFAUseCase
+}
+
+type mfaCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type mfaLoginRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// VerifyLogin is the second step of a login for users with two-factor authentication.
+func (m *MFAController) VerifyLogin(c *gin.Context) {
+	var request mfaLoginRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respond(c, http.StatusBadRequest, gin.H{"error": "Invalid input data"})
+		return
+	}
+
+	token, err := m.MFAUseCase.VerifyLogin(c, request.MFAToken, request.Code)
+	if err != nil {
+		if err.Error() == "too many failed mfa attempts, try again later" {
+			respond(c, http.StatusTooManyRequests, gin.H{"error": err.Error()})
+			return
+		}
+		respond(c, http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	respond(c, http.StatusOK, gin.H{"message": "User logged in successfully", "token": token})
+}
+
+func (m *MFAController) Enroll(c *gin.Context) {
+	username, ok := mfaUsername(c)
+	if !ok {
+		return
+	}
+
+	enrollment, err := m.MFAUseCase.Enroll(c, username)
+	if err != nil {
+		m.respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, enrollment)
+}
+
+func (m *MFAController) ConfirmEnrollment(c *gin.Context) {
+	username, ok := mfaUsername(c)
+	if !ok {
+		return
+	}
+	var request mfaCodeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respond(c, http.StatusBadRequest, gin.H{"error": "Invalid input data"})
+		return
+	}
+
+	recoveryCodes, err := m.MFAUseCase.ConfirmEnrollment(c, username, request.Code)
+	if err != nil {
+		m.respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, gin.H{"message": "Two-factor authentication enabled", "recovery_codes": recoveryCodes})
+}
+
+func (m *MFAController) Disable(c *gin.Context) {
+	username, ok := mfaUsername(c)
+	if !ok {
+		return
+	}
+	var request mfaCodeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respond(c, http.StatusBadRequest, gin.H{"error": "Invalid input data"})
+		return
+	}
+
+	if err := m.MFAUseCase.Disable(c, username, request.Code); err != nil {
+		m.respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+func (m *MFAController) respondError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "invalid mfa code":
+		respond(c, http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case "too many failed mfa attempts, try again later":
+		respond(c, http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	case "mfa is already enabled", "mfa is not enabled", "mfa enrollment has not been started", "mfa settings changed":
+		respond(c, http.StatusConflict, gin.H{"error": err.Error()})
+	case "user not found":
+		respond(c, http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		serverError(c, err)
+	}
+}
+
+// mfaUsername returns the logged in user. Service accounts authenticate with API keys and
+// have nothing to enroll.
+func mfaUsername(c *gin.Context) (string, bool) {
+	username := c.GetString("username")
+	if username == "" {
+		respond(c, http.StatusForbidden, gin.H{"error": "Two-factor authentication is only for user accounts"})
+		return "", false
+	}
+	return username, true
+}
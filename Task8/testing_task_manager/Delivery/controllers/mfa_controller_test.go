@@ -0,0 +1,106 @@
+package controllers_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"test_task_manager/Delivery/controllers"
+	domain "test_task_manager/Domain"
+	mocks "test_task_manager/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MFAControllerTestSuite struct {
+	suite.Suite
+	mfaUseCase *mocks.MFAUseCase
+	router     *gin.Engine
+}
+
+func (suite *MFAControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mfaUseCase = new(mocks.MFAUseCase)
+	controller := &controllers.MFAController{MFAUseCase: suite.mfaUseCase}
+
+	// Stands in for the auth middleware: the username comes from a header.
+	authenticated := func(c *gin.Context) {
+		if username := c.GetHeader("X-Username"); username != "" {
+			c.Set("username", username)
+		}
+		c.Next()
+	}
+
+	suite.router = gin.New()
+	suite.router.POST("/login/mfa", controller.VerifyLogin)
+	suite.router.POST("/mfa/enroll", authenticated, controller.Enroll)
+	suite.router.POST("/mfa/enroll/confirm", authenticated, controller.ConfirmEnrollment)
+}
+
+func (suite *MFAControllerTestSuite) TestVerifyLoginPositive() {
+	suite.mfaUseCase.On("VerifyLogin", mock.Anything, "mfaToken", "123456").Return("token", nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/login/mfa", bytes.NewBufferString(`{"mfa_token":"mfaToken","code":"123456"}`))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"User logged in successfully","token":"token"}`, w.Body.String())
+}
+
+func (suite *MFAControllerTestSuite) TestVerifyLoginLockedOut() {
+	suite.mfaUseCase.On("VerifyLogin", mock.Anything, "mfaToken", "000000").Return("", errors.New("too many failed mfa attempts, try again later"))
+
+	req := httptest.NewRequest(http.MethodPost, "/login/mfa", bytes.NewBufferString(`{"mfa_token":"mfaToken","code":"000000"}`))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusTooManyRequests, w.Code)
+}
+
+func (suite *MFAControllerTestSuite) TestEnrollPositive() {
+	suite.mfaUseCase.On("Enroll", mock.Anything, "alice").Return(&domain.MFAEnrollment{Secret: "SECRET", ProvisioningURI: "otpauth://totp/x"}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/mfa/enroll", nil)
+	req.Header.Set("X-Username", "alice")
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"secret":"SECRET","provisioning_uri":"otpauth://totp/x"}`, w.Body.String())
+}
+
+func (suite *MFAControllerTestSuite) TestEnrollServiceAccount() {
+	req := httptest.NewRequest(http.MethodPost, "/mfa/enroll", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+	suite.mfaUseCase.AssertNotCalled(suite.T(), "Enroll", mock.Anything, mock.Anything)
+}
+
+func (suite *MFAControllerTestSuite) TestConfirmEnrollmentInvalidCode() {
+	suite.mfaUseCase.On("ConfirmEnrollment", mock.Anything, "alice", "000000").Return(nil, errors.New("invalid mfa code"))
+
+	req := httptest.NewRequest(http.MethodPost, "/mfa/enroll/confirm", bytes.NewBufferString(`{"code":"000000"}`))
+	req.Header.Set("X-Username", "alice")
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+	assert.JSONEq(suite.T(), `{"error":"invalid mfa code"}`, w.Body.String())
+}
+
+func TestMFAControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(MFAControllerTestSuite))
+}
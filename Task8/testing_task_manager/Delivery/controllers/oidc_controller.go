@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"net/http"
+	"path"
+	domain "test_task_manager/Domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// oidcStateCookie ties the callback to the browser that started the login, so a link with
+// someone else's code and state cannot log the victim into the attacker's account.
+const oidcStateCookie = "oidc_state"
+
+// oidcCookiePath is the directory of the login and callback routes, such as /v1/auth/oidc, so the
+// cookie reaches the callback of the API version that set it.
+func oidcCookiePath(c *gin.Context) string {
+	return path.Dir(c.FullPath())
+}
+
+type OIDCController struct {
+	OIDCUseCase domain.OIDCUseCase
+}
+
+// Login redirects the browser to the identity provider.
+func (o *OIDCController) Login(c *gin.Context) {
+	authURL, state, err := o.OIDCUseCase.BeginLogin(c)
+	if err != nil {
+		respond(c, http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, state, 600, oidcCookiePath(c), "", c.Request.TLS != nil, true)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// Callback is where the identity provider sends the browser back. It responds like /login.
+func (o *OIDCController) Callback(c *gin.Context) {
+	if providerError := c.Query("error"); providerError != "" {
+		respond(c, http.StatusUnauthorized, gin.H{"error": providerError + ": " + c.Query("error_description")})
+		return
+	}
+
+	state := c.Query("state")
+	cookie, err := c.Cookie(oidcStateCookie)
+	if err != nil || state == "" || cookie != state {
+		respond(c, http.StatusBadRequest, gin.H{"error": "invalid login state"})
+		return
+	}
+	c.SetCookie(oidcStateCookie, "", -1, oidcCookiePath(c), "", c.Request.TLS != nil, true)
+
+	result, err := o.OIDCUseCase.CompleteLogin(c, state, c.Query("code"))
+	if err != nil {
+		if err.Error() == "invalid login state" {
+			respond(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		respond(c, http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondLogin(c, result)
+}
@@ -0,0 +1,115 @@
+package controllers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"test_task_manager/Delivery/controllers"
+	domain "test_task_manager/Domain"
+	mocks "test_task_manager/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type OIDCControllerTestSuite struct {
+	suite.Suite
+	oidcUseCase *mocks.OIDCUseCase
+	router      *gin.Engine
+}
+
+func (suite *OIDCControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.oidcUseCase = new(mocks.OIDCUseCase)
+	controller := &controllers.OIDCController{OIDCUseCase: suite.oidcUseCase}
+	suite.router = gin.New()
+	suite.router.GET("/auth/oidc/login", controller.Login)
+	suite.router.GET("/auth/oidc/callback", controller.Callback)
+}
+
+func (suite *OIDCControllerTestSuite) TestLoginRedirects() {
+	suite.oidcUseCase.On("BeginLogin", mock.Anything).Return("https://idp.example.com/authorize?state=s1", "s1", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/oidc/login", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusFound, w.Code)
+	assert.Equal(suite.T(), "https://idp.example.com/authorize?state=s1", w.Header().Get("Location"))
+	assert.Contains(suite.T(), w.Header().Get("Set-Cookie"), "oidc_state=s1")
+	assert.Contains(suite.T(), w.Header().Get("Set-Cookie"), "HttpOnly")
+	assert.Contains(suite.T(), w.Header().Get("Set-Cookie"), "Path=/auth/oidc;")
+}
+
+func (suite *OIDCControllerTestSuite) TestLoginCookieFollowsTheAPIVersion() {
+	suite.oidcUseCase.On("BeginLogin", mock.Anything).Return("https://idp.example.com/authorize?state=s1", "s1", nil)
+	controller := &controllers.OIDCController{OIDCUseCase: suite.oidcUseCase}
+	suite.router.Group("/v1").GET("/auth/oidc/login", controller.Login)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/auth/oidc/login", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Contains(suite.T(), w.Header().Get("Set-Cookie"), "Path=/v1/auth/oidc;")
+}
+
+func (suite *OIDCControllerTestSuite) TestCallbackPositive() {
+	suite.oidcUseCase.On("CompleteLogin", mock.Anything, "s1", "c1").Return(&domain.LoginResult{Token: "token"}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/oidc/callback?state=s1&code=c1", nil)
+	req.AddCookie(&http.Cookie{Name: "oidc_state", Value: "s1"})
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"User logged in successfully","token":"token"}`, w.Body.String())
+}
+
+func (suite *OIDCControllerTestSuite) TestCallbackMFARequired() {
+	suite.oidcUseCase.On("CompleteLogin", mock.Anything, "s1", "c1").Return(&domain.LoginResult{MFAToken: "mfa-token"}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/oidc/callback?state=s1&code=c1", nil)
+	req.AddCookie(&http.Cookie{Name: "oidc_state", Value: "s1"})
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"Two-factor authentication required","mfa_required":true,"mfa_token":"mfa-token"}`, w.Body.String())
+}
+
+func (suite *OIDCControllerTestSuite) TestCallbackStateFromAnotherBrowser() {
+	req := httptest.NewRequest(http.MethodGet, "/auth/oidc/callback?state=s1&code=c1", nil)
+	req.AddCookie(&http.Cookie{Name: "oidc_state", Value: "s2"})
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"error":"invalid login state"}`, w.Body.String())
+	suite.oidcUseCase.AssertNotCalled(suite.T(), "CompleteLogin", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *OIDCControllerTestSuite) TestCallbackExchangeFails() {
+	suite.oidcUseCase.On("CompleteLogin", mock.Anything, "s1", "c1").Return(nil, errors.New("oidc: id token nonce does not match"))
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/oidc/callback?state=s1&code=c1", nil)
+	req.AddCookie(&http.Cookie{Name: "oidc_state", Value: "s1"})
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+	assert.JSONEq(suite.T(), `{"error":"oidc: id token nonce does not match"}`, w.Body.String())
+}
+
+func TestOIDCControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(OIDCControllerTestSuite))
+}
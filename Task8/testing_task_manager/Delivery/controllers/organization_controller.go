@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"net/http"
+	domain "test_task_manager/Domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizationController struct {
+	OrganizationUseCase domain.OrganizationUseCase
+}
+
+type addMemberRequest struct {
+	Username string `json:"username" binding:"required"`
+	Role     string `json:"role"`
+}
+
+func (o *OrganizationController) CreateOrganization(c *gin.Context) {
+	var org domain.Organization
+	if err := c.ShouldBindJSON(&org); err != nil {
+		respond(c, http.StatusBadRequest, gin.H{"error": "Invalid input data"})
+		return
+	}
+
+	createdOrg, err := o.OrganizationUseCase.CreateOrganization(c, org.Name, c.GetString("username"))
+	if err != nil {
+		if err.Error() == "organization name is required" {
+			respond(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		serverError(c, err)
+		return
+	}
+
+	respond(c, http.StatusCreated, createdOrg)
+}
+
+func (o *OrganizationController) GetOrganizations(c *gin.Context) {
+	orgs, err := o.OrganizationUseCase.GetOrganizations(c, c.GetString("username"))
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	respond(c, http.StatusOK, orgs)
+}
+
+// AddMember adds a user to the organization in the path, which must be the one the request acts
+// in, so an Admin of one organization cannot add members to another.
+func (o *OrganizationController) AddMember(c *gin.Context) {
+	if c.Param("id") != c.GetString("org") {
+		respond(c, http.StatusForbidden, gin.H{"error": "Not a member of this organization"})
+		return
+	}
+
+	var request addMemberRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respond(c, http.StatusBadRequest, gin.H{"error": "Invalid input data"})
+		return
+	}
+
+	err := o.OrganizationUseCase.AddMember(c, request.Username, request.Role)
+	if err != nil {
+		switch err.Error() {
+		case "role must be Admin or User":
+			respond(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "user not found":
+			respond(c, http.StatusNotFound, gin.H{"error": "User not found"})
+		case "user is already a member":
+			respond(c, http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			serverError(c, err)
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, gin.H{"message": "Member added successfully"})
+}
@@ -0,0 +1,90 @@
+package controllers_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"test_task_manager/Delivery/controllers"
+	domain "test_task_manager/Domain"
+	mocks "test_task_manager/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type OrganizationControllerTestSuite struct {
+	suite.Suite
+	organizationUseCase *mocks.OrganizationUseCase
+	router              *gin.Engine
+	controller          *controllers.OrganizationController
+}
+
+func (suite *OrganizationControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.organizationUseCase = new(mocks.OrganizationUseCase)
+	suite.controller = &controllers.OrganizationController{OrganizationUseCase: suite.organizationUseCase}
+	suite.router = gin.New()
+	// Stands in for AuthMiddleware: the caller is an Admin of acme.
+	suite.router.Use(func(c *gin.Context) {
+		c.Set("username", "alice")
+		c.Set("org", "acme")
+		c.Next()
+	})
+	suite.router.POST("/orgs", suite.controller.CreateOrganization)
+	suite.router.POST("/orgs/:id/members", suite.controller.AddMember)
+}
+
+func (suite *OrganizationControllerTestSuite) TestCreateOrganizationPositive() {
+	suite.organizationUseCase.On("CreateOrganization", mock.Anything, "Acme", "alice").Return(&domain.Organization{ID: "1", Name: "Acme"}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/orgs", bytes.NewBufferString(`{"name":"Acme"}`))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	assert.JSONEq(suite.T(), `{"id":"1","name":"Acme","created_at":"0001-01-01T00:00:00Z"}`, w.Body.String())
+}
+
+func (suite *OrganizationControllerTestSuite) TestAddMemberPositive() {
+	suite.organizationUseCase.On("AddMember", mock.Anything, "bob", "").Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/orgs/acme/members", bytes.NewBufferString(`{"username":"bob"}`))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"Member added successfully"}`, w.Body.String())
+}
+
+func (suite *OrganizationControllerTestSuite) TestAddMemberOtherOrganization() {
+	req := httptest.NewRequest(http.MethodPost, "/orgs/globex/members", bytes.NewBufferString(`{"username":"bob"}`))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+	suite.organizationUseCase.AssertNotCalled(suite.T(), "AddMember", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *OrganizationControllerTestSuite) TestAddMemberAlreadyMember() {
+	suite.organizationUseCase.On("AddMember", mock.Anything, "bob", "Admin").Return(errors.New("user is already a member"))
+
+	req := httptest.NewRequest(http.MethodPost, "/orgs/acme/members", bytes.NewBufferString(`{"username":"bob","role":"Admin"}`))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.JSONEq(suite.T(), `{"error":"user is already a member"}`, w.Body.String())
+}
+
+func TestOrganizationControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationControllerTestSuite))
+}
@@ -0,0 +1,298 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// DefaultMaxBodySize is the largest JSON body the task, user and GraphQL controllers read when
+// their MaxBodySize is not set.
+const DefaultMaxBodySize = 1 << 20
+
+// requestError is a request body that could not be bound. Field is the JSON path of the
+// offending value, such as "operations[2].task.due_date", when there is one.
+type requestError struct {
+	status  int
+	message string
+	field   string
+}
+
+func (e *requestError) Error() string {
+	return e.message
+}
+
+func badRequest(field string, format string, args ...interface{}) *requestError {
+	return &requestError{status: http.StatusBadRequest, message: fmt.Sprintf(format, args...), field: field}
+}
+
+// bindJSON is a strict ShouldBindJSON. It reads at most limit bytes and refuses bodies that are
+// not JSON, carry anything after the value, have fields obj has no place for (matched exactly,
+// not case-insensitively) or values of the wrong type. Then the binding tags of obj are checked.
+// On failure it writes a 400, 413 or 415 response and returns false.
+func bindJSON(c *gin.Context, limit int64, obj interface{}) bool {
+	err := decodeJSON(c, limit, obj)
+	if err == nil {
+		return true
+	}
+	response := gin.H{"error": err.message}
+	if err.field != "" {
+		response["field"] = err.field
+	}
+	respond(c, err.status, response)
+	return false
+}
+
+func decodeJSON(c *gin.Context, limit int64, obj interface{}) *requestError {
+	// A missing Content-Type is taken to be JSON; anything else that says it is not is refused.
+	if header := c.GetHeader("Content-Type"); header != "" {
+		mediaType, _, err := mime.ParseMediaType(header)
+		if err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+			return &requestError{status: http.StatusUnsupportedMediaType, message: "Content-Type must be application/json"}
+		}
+	}
+
+	if limit <= 0 {
+		limit = DefaultMaxBodySize
+	}
+	if c.Request.Body == nil {
+		return badRequest("", "request body is required")
+	}
+	data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, limit))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return &requestError{status: http.StatusRequestEntityTooLarge, message: fmt.Sprintf("request body must not be larger than %d bytes", limit)}
+		}
+		return badRequest("", "could not read the request body")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		if err == io.EOF {
+			return badRequest("", "request body is required")
+		}
+		var syntaxError *json.SyntaxError
+		if errors.As(err, &syntaxError) {
+			return badRequest("", "malformed JSON at offset %d: %s", syntaxError.Offset, syntaxError.Error())
+		}
+		return badRequest("", "malformed JSON: %s", err.Error())
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return badRequest("", "request body must hold a single JSON value")
+	}
+
+	if err := checkJSON(value, reflect.TypeOf(obj), ""); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, obj); err != nil {
+		return badRequest("", "invalid request body: %s", err.Error())
+	}
+
+	if err := binding.Validator.ValidateStruct(obj); err != nil {
+		var invalid validator.ValidationErrors
+		if errors.As(err, &invalid) && len(invalid) > 0 {
+			field := jsonPath(reflect.TypeOf(obj), invalid[0].StructNamespace())
+			if invalid[0].Tag() == "required" {
+				return badRequest(field, "%s is required", field)
+			}
+			return badRequest(field, "%s is invalid", field)
+		}
+		return badRequest("", "invalid request body: %s", err.Error())
+	}
+	return nil
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// checkJSON walks a value decoded with UseNumber alongside the type it is about to be bound to,
+// and reports the first field the type has no place for or that holds the wrong kind of value.
+func checkJSON(value interface{}, t reflect.Type, path string) *requestError {
+	if value == nil {
+		return nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		raw, _ := json.Marshal(value)
+		target := reflect.New(t).Interface().(json.Unmarshaler)
+		if err := target.UnmarshalJSON(raw); err != nil {
+			return wrongType(path, err.Error())
+		}
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return nil
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return wrongType(path, "expected an object")
+		}
+		fields := jsonFields(t)
+		for _, key := range sortedKeys(object) {
+			field, ok := fields[key]
+			if !ok {
+				return badRequest(join(path, key), "unknown field %q", join(path, key))
+			}
+			if err := checkJSON(object[key], field.Type, join(path, key)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return wrongType(path, "expected an object")
+		}
+		for _, key := range sortedKeys(object) {
+			if err := checkJSON(object[key], t.Elem(), join(path, key)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if _, ok := value.(string); ok && t.Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+		items, ok := value.([]interface{})
+		if !ok {
+			return wrongType(path, "expected an array")
+		}
+		for i, item := range items {
+			if err := checkJSON(item, t.Elem(), path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			return wrongType(path, "expected a string")
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			return wrongType(path, "expected true or false")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		number, ok := value.(json.Number)
+		if !ok {
+			return wrongType(path, "expected a number")
+		}
+		if err := json.Unmarshal([]byte(number), reflect.New(t).Interface()); err != nil {
+			return wrongType(path, "expected a number that fits in "+t.Kind().String())
+		}
+	}
+	return nil
+}
+
+// sortedKeys makes the first error reported for an object the same on every request.
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func wrongType(path string, reason string) *requestError {
+	if path == "" {
+		return badRequest("", "invalid request body: %s", reason)
+	}
+	return badRequest(path, "invalid value for %s: %s", path, reason)
+}
+
+func join(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// jsonFields maps the JSON names of the fields of struct type t, including the promoted fields
+// of embedded structs, to those fields.
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+		embedded := field.Type
+		if embedded.Kind() == reflect.Pointer {
+			embedded = embedded.Elem()
+		}
+		if field.Anonymous && field.Tag.Get("json") == "" && embedded.Kind() == reflect.Struct {
+			for promoted, inner := range jsonFields(embedded) {
+				if _, taken := fields[promoted]; !taken {
+					fields[promoted] = inner
+				}
+			}
+			continue
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+// jsonName is the name encoding/json gives field, or false when it skips the field.
+func jsonName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() && !field.Anonymous {
+		return "", false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, true
+}
+
+// jsonPath turns the struct namespace of a validation error, such as
+// "bulkRequest.Operations[0].Op", into the JSON path "operations[0].op".
+func jsonPath(t reflect.Type, namespace string) string {
+	segments := strings.Split(namespace, ".")[1:]
+	path := ""
+	for _, segment := range segments {
+		name, index, _ := strings.Cut(segment, "[")
+		if index != "" {
+			index = "[" + index
+		}
+		for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return join(path, segment)
+		}
+		field, ok := t.FieldByName(name)
+		if !ok {
+			return join(path, segment)
+		}
+		if jsonName, ok := jsonName(field); ok {
+			name = jsonName
+		}
+		path = join(path, name+index)
+		t = field.Type
+	}
+	return path
+}
@@ -0,0 +1,121 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"test_task_manager/Delivery/controllers"
+	domain "test_task_manager/Domain"
+	mocks "test_task_manager/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type StrictDecodingTestSuite struct {
+	suite.Suite
+	taskUseCase *mocks.TaskUseCase
+	userUseCase *mocks.UserUseCase
+	router      *gin.Engine
+}
+
+func (suite *StrictDecodingTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.taskUseCase = new(mocks.TaskUseCase)
+	suite.userUseCase = new(mocks.UserUseCase)
+	tc := &controllers.TaskController{TaskUseCase: suite.taskUseCase, MaxBodySize: 256}
+	uc := &controllers.UserController{UserUseCase: suite.userUseCase}
+	suite.router = gin.New()
+	suite.router.POST("/tasks", tc.CreateTask)
+	suite.router.POST("/tasks/bulk", tc.BulkTasks)
+	suite.router.POST("/register", uc.Register)
+}
+
+func (suite *StrictDecodingTestSuite) send(path string, contentType string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *StrictDecodingTestSuite) TestAcceptsKnownFields() {
+	task := domain.Task{ID: "1", Title: "Report"}
+	suite.taskUseCase.On("CreateTask", mock.Anything, task).Return(&task, nil)
+
+	w := suite.send("/tasks", "application/json; charset=utf-8", `{"id": "1", "title": "Report", "due_date": null}`)
+
+	suite.Equal(http.StatusCreated, w.Code)
+}
+
+func (suite *StrictDecodingTestSuite) TestRejectsUnknownFields() {
+	w := suite.send("/tasks", "application/json", `{"id": "1", "due_Date": "2024-01-01T00:00:00Z"}`)
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.JSONEq(`{"error": "unknown field \"due_Date\"", "field": "due_Date"}`, w.Body.String())
+
+	w = suite.send("/tasks/bulk", "application/json", `{"operations": [{"op": "create", "task": {"id": "1"}}, {"op": "create", "task": {"titel": "x"}}]}`)
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.JSONEq(`{"error": "unknown field \"operations[1].task.titel\"", "field": "operations[1].task.titel"}`, w.Body.String())
+
+	// Fields the API never reads are unknown too.
+	w = suite.send("/register", "", `{"username": "alice", "password": "secret", "oidc_subject": "u-1"}`)
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.userUseCase.AssertNotCalled(suite.T(), "CreateUser", mock.Anything, mock.Anything)
+}
+
+func (suite *StrictDecodingTestSuite) TestRejectsWrongTypes() {
+	w := suite.send("/tasks", "application/json", `{"id": "1", "title": 5}`)
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.JSONEq(`{"error": "invalid value for title: expected a string", "field": "title"}`, w.Body.String())
+
+	w = suite.send("/tasks", "application/json", `{"id": "1", "due_date": "tomorrow"}`)
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), `"field":"due_date"`)
+
+	w = suite.send("/tasks", "application/json", `["1"]`)
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.JSONEq(`{"error": "invalid request body: expected an object"}`, w.Body.String())
+}
+
+func (suite *StrictDecodingTestSuite) TestRejectsTrailingData() {
+	w := suite.send("/tasks", "application/json", `{"id": "1"} {"id": "2"}`)
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.JSONEq(`{"error": "request body must hold a single JSON value"}`, w.Body.String())
+
+	w = suite.send("/tasks", "application/json", `{"id": "1"`)
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.taskUseCase.AssertNotCalled(suite.T(), "CreateTask", mock.Anything, mock.Anything)
+}
+
+func (suite *StrictDecodingTestSuite) TestReportsMissingRequiredFields() {
+	w := suite.send("/register", "application/json", `{"username": "alice"}`)
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.JSONEq(`{"error": "password is required", "field": "password"}`, w.Body.String())
+
+	w = suite.send("/tasks/bulk", "application/json", `{"operations": [{"op": "create"}, {"id": "2"}]}`)
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.JSONEq(`{"error": "operations[1].op is required", "field": "operations[1].op"}`, w.Body.String())
+}
+
+func (suite *StrictDecodingTestSuite) TestRejectsLargeBodies() {
+	w := suite.send("/tasks", "application/json", `{"id": "1", "description": "`+strings.Repeat("x", 256)+`"}`)
+
+	suite.Equal(http.StatusRequestEntityTooLarge, w.Code)
+	suite.JSONEq(`{"error": "request body must not be larger than 256 bytes"}`, w.Body.String())
+}
+
+func (suite *StrictDecodingTestSuite) TestRejectsOtherContentTypes() {
+	w := suite.send("/register", "application/x-www-form-urlencoded", `username=alice&password=secret`)
+
+	suite.Equal(http.StatusUnsupportedMediaType, w.Code)
+	suite.JSONEq(`{"error": "Content-Type must be application/json"}`, w.Body.String())
+}
+
+func TestStrictDecodingTestSuite(t *testing.T) {
+	suite.Run(t, new(StrictDecodingTestSuite))
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+)
+
+// The media types respond can answer with. The first of each group is the one sent back.
+var (
+	jsonTypes    = []string{"application/json"}
+	yamlTypes    = []string{"application/yaml", "application/x-yaml", "text/yaml"}
+	msgpackTypes = []string{"application/msgpack", "application/x-msgpack", "application/vnd.msgpack"}
+)
+
+var offeredTypes = append(append(append([]string{}, jsonTypes...), yamlTypes...), msgpackTypes...)
+
+// respond writes obj with status in the encoding the Accept header asks for: JSON, YAML or
+// MessagePack. JSON is compact unless the query has pretty=1, and it is also the answer to an
+// Accept header that names none of them. Every encoding has the field names and values of the
+// JSON one, so clients can switch between them without surprises.
+func respond(c *gin.Context, status int, obj interface{}) {
+	c.Header("Vary", "Accept")
+
+	switch mediaType := c.NegotiateFormat(offeredTypes...); {
+	case contains(yamlTypes, mediaType):
+		value, err := jsonValue(obj)
+		if err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		c.Header("Content-Type", yamlTypes[0]+"; charset=utf-8")
+		c.Render(status, render.YAML{Data: value})
+	case contains(msgpackTypes, mediaType):
+		value, err := jsonValue(obj)
+		if err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		c.Header("Content-Type", msgpackTypes[0])
+		c.Render(status, render.MsgPack{Data: value})
+	default:
+		respondJSON(c, status, obj)
+	}
+}
+
+// respondJSON is respond for endpoints that only speak JSON, such as GraphQL.
+func respondJSON(c *gin.Context, status int, obj interface{}) {
+	if pretty, _ := strconv.ParseBool(c.Query("pretty")); pretty {
+		c.IndentedJSON(status, obj)
+		return
+	}
+	c.JSON(status, obj)
+}
+
+// jsonValue is obj as it reads in JSON: maps, slices, strings, numbers, booleans and nil. Whole
+// numbers stay integers.
+func jsonValue(obj interface{}) (interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return numbers(value), nil
+}
+
+// numbers replaces the json.Numbers in value with int64s or float64s.
+func numbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = numbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = numbers(item)
+		}
+	}
+	return value
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package controllers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"test_task_manager/Delivery/controllers"
+	domain "test_task_manager/Domain"
+	mocks "test_task_manager/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/ugorji/go/codec"
+	"gopkg.in/yaml.v3"
+)
+
+type NegotiationTestSuite struct {
+	suite.Suite
+	taskUseCase *mocks.TaskUseCase
+	router      *gin.Engine
+}
+
+func (suite *NegotiationTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.taskUseCase = new(mocks.TaskUseCase)
+	tc := &controllers.TaskController{TaskUseCase: suite.taskUseCase}
+	suite.router = gin.New()
+	suite.router.GET("/tasks/:id", tc.GetTaskByID)
+
+	due := time.Date(2024, 8, 8, 12, 0, 0, 0, time.UTC)
+	suite.taskUseCase.On("GetTaskByID", mock.Anything, "1").Return(&domain.Task{ID: "1", Title: "Report", DueDate: due}, nil)
+	suite.taskUseCase.On("GetTaskByID", mock.Anything, "2").Return(nil, errors.New("mongo: no documents in result"))
+}
+
+func (suite *NegotiationTestSuite) get(path string, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *NegotiationTestSuite) TestCompactJSONByDefault() {
+	expected := `{"id":"1","title":"Report","description":"","due_date":"2024-08-08T12:00:00Z","status":""}`
+
+	for _, accept := range []string{"", "application/json", "*/*", "text/html"} {
+		w := suite.get("/tasks/1", accept)
+		suite.Equal(http.StatusOK, w.Code)
+		suite.Equal("application/json; charset=utf-8", w.Header().Get("Content-Type"), accept)
+		suite.Equal(expected, w.Body.String(), accept)
+		suite.Equal("Accept", w.Header().Get("Vary"))
+	}
+}
+
+func (suite *NegotiationTestSuite) TestPrettyJSON() {
+	w := suite.get("/tasks/1?pretty=1", "")
+
+	suite.Equal("{\n    \"id\": \"1\",\n    \"title\": \"Report\",\n    \"description\": \"\",\n    \"due_date\": \"2024-08-08T12:00:00Z\",\n    \"status\": \"\"\n}", w.Body.String())
+}
+
+func (suite *NegotiationTestSuite) TestYAML() {
+	for _, accept := range []string{"application/yaml", "application/x-yaml", "text/yaml"} {
+		w := suite.get("/tasks/1", accept)
+
+		suite.Equal(http.StatusOK, w.Code)
+		suite.Equal("application/yaml; charset=utf-8", w.Header().Get("Content-Type"))
+		var task map[string]interface{}
+		suite.Require().NoError(yaml.Unmarshal(w.Body.Bytes(), &task))
+		suite.Equal("Report", task["title"])
+		suite.Equal("2024-08-08T12:00:00Z", task["due_date"], "fields keep their JSON names and values")
+	}
+}
+
+func (suite *NegotiationTestSuite) TestMessagePack() {
+	w := suite.get("/tasks/1", "application/msgpack")
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("application/msgpack", w.Header().Get("Content-Type"))
+	var task map[string]interface{}
+	suite.Require().NoError(codec.NewDecoderBytes(w.Body.Bytes(), &codec.MsgpackHandle{}).Decode(&task))
+	suite.EqualValues("Report", task["title"])
+	suite.EqualValues("2024-08-08T12:00:00Z", task["due_date"])
+}
+
+func (suite *NegotiationTestSuite) TestErrorsAreNegotiatedToo() {
+	w := suite.get("/tasks/2", "application/yaml")
+
+	suite.Equal(http.StatusNotFound, w.Code)
+	suite.Equal("error: Task not found\n", w.Body.String())
+}
+
+func TestNegotiationTestSuite(t *testing.T) {
+	suite.Run(t, new(NegotiationTestSuite))
+}
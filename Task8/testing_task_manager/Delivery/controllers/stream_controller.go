@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	domain "test_task_manager/Domain"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const streamHeartbeat = 15 * time.Second
+
+type StreamController struct {
+	EventBus domain.EventBus
+	Upgrader websocket.Upgrader
+}
+
+// StreamTasks pushes task events as Server-Sent Events. The SSE id of each event is its bus
+// sequence, so browsers resume automatically by sending it back as Last-Event-ID.
+func (s *StreamController) StreamTasks(c *gin.Context) {
+	lastSequence, err := lastEventID(c)
+	if err != nil {
+		respond(c, http.StatusBadRequest, gin.H{"error": "Invalid Last-Event-ID"})
+		return
+	}
+
+	replay, events, cancel := s.EventBus.Subscribe(lastSequence)
+	defer cancel()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	for _, event := range replay {
+		if canSeeTaskEvent(c, event) {
+			writeSSE(c, event)
+		}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if canSeeTaskEvent(c, event) {
+				writeSSE(c, event)
+				c.Writer.Flush()
+			}
+		}
+	}
+}
+
+// StreamTasksWebSocket pushes the same events as StreamTasks as JSON WebSocket messages.
+// Clients resume by reconnecting with ?last_event_id=<sequence>.
+func (s *StreamController) StreamTasksWebSocket(c *gin.Context) {
+	lastSequence, err := lastEventID(c)
+	if err != nil {
+		respond(c, http.StatusBadRequest, gin.H{"error": "Invalid Last-Event-ID"})
+		return
+	}
+
+	conn, err := s.Upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	replay, events, cancel := s.EventBus.Subscribe(lastSequence)
+	defer cancel()
+
+	// The read loop only exists to notice when the client goes away.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for _, event := range replay {
+		if canSeeTaskEvent(c, event) {
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		}
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second)); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "subscriber fell behind"))
+				return
+			}
+			if canSeeTaskEvent(c, event) {
+				if err := conn.WriteJSON(event); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+func lastEventID(c *gin.Context) (uint64, error) {
+	id := c.GetHeader("Last-Event-ID")
+	if id == "" {
+		id = c.Query("last_event_id")
+	}
+	if id == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(id, 10, 64)
+}
+
+// canSeeTaskEvent decides whether the authenticated caller may receive event. Any member may
+// read every task of their organization through GET /tasks, so they see its task events.
+func canSeeTaskEvent(c *gin.Context, event domain.StreamEvent) bool {
+	if _, ok := c.Get("role"); !ok {
+		return false
+	}
+	return strings.HasPrefix(event.Type, "task.") && event.OrgID == c.GetString("org")
+}
+
+func writeSSE(c *gin.Context, event domain.StreamEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.Sequence, event.Type, data)
+}
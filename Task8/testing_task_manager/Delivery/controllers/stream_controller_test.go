@@ -0,0 +1,114 @@
+package controllers_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"test_task_manager/Delivery/controllers"
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type StreamControllerTestSuite struct {
+	suite.Suite
+	eventBus *infrastructure.InMemoryEventBus
+	server   *httptest.Server
+}
+
+func (suite *StreamControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.eventBus = infrastructure.NewEventBus(10)
+	controller := &controllers.StreamController{EventBus: suite.eventBus}
+
+	authenticated := func(c *gin.Context) {
+		c.Set("role", "User")
+		c.Set("org", "acme")
+		c.Next()
+	}
+
+	router := gin.New()
+	router.GET("/tasks/stream", authenticated, controller.StreamTasks)
+	router.GET("/tasks/ws", authenticated, controller.StreamTasksWebSocket)
+	suite.server = httptest.NewServer(router)
+}
+
+func (suite *StreamControllerTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *StreamControllerTestSuite) TestSSEResumesFromLastEventID() {
+	suite.eventBus.Publish(domain.Event{ID: "e1", OrgID: "acme", Type: domain.EventTaskCreated})
+	suite.eventBus.Publish(domain.Event{ID: "e2", OrgID: "acme", Type: domain.EventTaskUpdated})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, suite.server.URL+"/tasks/stream", nil)
+	req.Header.Set("Last-Event-ID", "1")
+	resp, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+	defer resp.Body.Close()
+
+	suite.Equal("text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	suite.Equal("id: 2\n", readLine(suite, reader))
+	suite.Equal("event: task.updated\n", readLine(suite, reader))
+	suite.Contains(readLine(suite, reader), `"id":"e2"`)
+	readLine(suite, reader)
+
+	suite.eventBus.Publish(domain.Event{ID: "e3", OrgID: "globex", Type: domain.EventTaskDeleted})
+	suite.eventBus.Publish(domain.Event{ID: "e4", OrgID: "acme", Type: domain.EventTaskDeleted})
+	suite.Equal("id: 4\n", readLine(suite, reader), "events of other organizations are skipped")
+}
+
+func (suite *StreamControllerTestSuite) TestSSERejectsInvalidLastEventID() {
+	req, _ := http.NewRequest(http.MethodGet, suite.server.URL+"/tasks/stream?last_event_id=abc", nil)
+	resp, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+	defer resp.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, resp.StatusCode)
+}
+
+func (suite *StreamControllerTestSuite) TestWebSocketReceivesEvents() {
+	suite.eventBus.Publish(domain.Event{ID: "e1", OrgID: "acme", Type: domain.EventTaskCreated})
+
+	url := "ws" + strings.TrimPrefix(suite.server.URL, "http") + "/tasks/ws?last_event_id=0"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	suite.Require().NoError(err)
+	defer conn.Close()
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+	var replayed domain.StreamEvent
+	suite.Require().NoError(conn.ReadJSON(&replayed))
+	suite.Equal("e1", replayed.ID)
+	suite.Equal(uint64(1), replayed.Sequence)
+
+	suite.eventBus.Publish(domain.Event{ID: "e2", OrgID: "acme", Type: domain.EventTaskDeleted})
+
+	var live domain.StreamEvent
+	suite.Require().NoError(conn.ReadJSON(&live))
+	suite.Equal("e2", live.ID)
+	suite.Equal(domain.EventTaskDeleted, live.Type)
+}
+
+func readLine(suite *StreamControllerTestSuite, reader *bufio.Reader) string {
+	line, err := reader.ReadString('\n')
+	suite.Require().NoError(err)
+	return line
+}
+
+func TestStreamController(t *testing.T) {
+	suite.Run(t, new(StreamControllerTestSuite))
+}
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+	domain "test_task_manager/Domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookController struct {
+	WebhookUseCase domain.WebhookUseCase
+}
+
+func (w *WebhookController) CreateWebhook(c *gin.Context) {
+	var webhook domain.Webhook
+	if err := c.ShouldBindJSON(&webhook); err != nil {
+		respond(c, http.StatusBadRequest, gin.H{"error": "Invalid input data"})
+		return
+	}
+
+	createdWebhook, err := w.WebhookUseCase.CreateWebhook(c, webhook)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "webhook ") || strings.HasPrefix(err.Error(), "unknown event type") {
+			respond(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		serverError(c, err)
+		return
+	}
+
+	respond(c, http.StatusCreated, createdWebhook)
+}
+
+func (w *WebhookController) GetWebhooks(c *gin.Context) {
+	webhooks, err := w.WebhookUseCase.GetWebhooks(c)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	respond(c, http.StatusOK, webhooks)
+}
+
+func (w *WebhookController) DeleteWebhook(c *gin.Context) {
+	err := w.WebhookUseCase.DeleteWebhook(c, c.Param("id"))
+	if err != nil {
+		if err.Error() == "webhook not found" {
+			respond(c, http.StatusNotFound, gin.H{"error": "Webhook not found"})
+			return
+		}
+		serverError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (w *WebhookController) GetDeliveries(c *gin.Context) {
+	deliveries, err := w.WebhookUseCase.GetDeliveries(c, c.Param("id"))
+	if err != nil {
+		if err.Error() == "webhook not found" {
+			respond(c, http.StatusNotFound, gin.H{"error": "Webhook not found"})
+			return
+		}
+		serverError(c, err)
+		return
+	}
+	respond(c, http.StatusOK, deliveries)
+}
+
+func (w *WebhookController) GetDeadLetters(c *gin.Context) {
+	deadLetters, err := w.WebhookUseCase.GetDeadLetters(c)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+	respond(c, http.StatusOK, deadLetters)
+}
@@ -0,0 +1,99 @@
+package controllers_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"test_task_manager/Delivery/controllers"
+	domain "test_task_manager/Domain"
+	mocks "test_task_manager/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookControllerTestSuite struct {
+	suite.Suite
+	webhookUseCase *mocks.WebhookUseCase
+	router         *gin.Engine
+	controller     *controllers.WebhookController
+}
+
+func (suite *WebhookControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.webhookUseCase = new(mocks.WebhookUseCase)
+	suite.controller = &controllers.WebhookController{WebhookUseCase: suite.webhookUseCase}
+	suite.router = gin.New()
+	suite.router.POST("/webhooks", suite.controller.CreateWebhook)
+	suite.router.GET("/webhooks", suite.controller.GetWebhooks)
+	suite.router.DELETE("/webhooks/:id", suite.controller.DeleteWebhook)
+	suite.router.GET("/webhooks/:id/deliveries", suite.controller.GetDeliveries)
+}
+
+func (suite *WebhookControllerTestSuite) TestCreateWebhookPositive() {
+	body := `{"url":"https://example.com","events":["task.created"],"secret":"s3cret"}`
+	suite.webhookUseCase.On("CreateWebhook", mock.Anything, mock.Anything).Return(&domain.Webhook{ID: "1", URL: "https://example.com", Events: []string{"task.created"}}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	assert.JSONEq(suite.T(), `{"id":"1","url":"https://example.com","events":["task.created"],"created_at":"0001-01-01T00:00:00Z"}`, w.Body.String())
+}
+
+func (suite *WebhookControllerTestSuite) TestCreateWebhookMissingSecret() {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewBufferString(`{"url":"https://example.com","events":["*"]}`))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"error": "Invalid input data"}`, w.Body.String())
+}
+
+func (suite *WebhookControllerTestSuite) TestCreateWebhookValidationError() {
+	suite.webhookUseCase.On("CreateWebhook", mock.Anything, mock.Anything).Return(nil, errors.New("unknown event type: task.archived"))
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewBufferString(`{"url":"https://example.com","events":["task.archived"],"secret":"s"}`))
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"error": "unknown event type: task.archived"}`, w.Body.String())
+}
+
+func (suite *WebhookControllerTestSuite) TestDeleteWebhookNotFound() {
+	suite.webhookUseCase.On("DeleteWebhook", mock.Anything, "1").Return(errors.New("webhook not found"))
+
+	req := httptest.NewRequest(http.MethodDelete, "/webhooks/1", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+	assert.JSONEq(suite.T(), `{"error": "Webhook not found"}`, w.Body.String())
+}
+
+func (suite *WebhookControllerTestSuite) TestGetDeliveriesPositive() {
+	suite.webhookUseCase.On("GetDeliveries", mock.Anything, "1").Return([]domain.WebhookDelivery{{ID: "d1", WebhookID: "1", Status: domain.DeliveryDelivered, Attempts: 1}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/webhooks/1/deliveries", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"status":"delivered"`)
+}
+
+func TestWebhookController(t *testing.T) {
+	suite.Run(t, new(WebhookControllerTestSuite))
+}
@@ -0,0 +1,248 @@
+// Package e2etest boots the whole HTTP API, wired by router.SetupWith, on in-memory stores with
+// the real JWT and bcrypt services, and drives it the way a client would. It needs no MongoDB.
+// It is not meant to be used outside tests.
+package e2etest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"test_task_manager/Delivery/router"
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	repositories "test_task_manager/Repositories"
+	usecases "test_task_manager/UseCases"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const timeout = time.Second * 10
+
+// Password is the password of every user the helpers create.
+const Password = "password123"
+
+// Start is the time on the harness clock when it starts.
+var Start = time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+// Harness is a running API. Its routes, middleware and use cases are the ones main serves.
+type Harness struct {
+	*httptest.Server
+	Deps   router.Dependencies
+	Mailer *Mailer
+	// Clock only moves when the test moves it. Tokens, API keys and the trash expire by it.
+	Clock *infrastructure.FakeClock
+
+	t testing.TB
+}
+
+// New starts the API for the length of the test. Environment variables that Setup reads, such
+// as REGISTRATION, can be set with t.Setenv before calling it.
+func New(t testing.TB) *Harness {
+	t.Helper()
+	t.Setenv("JWT_SECRET", "e2e-secret")
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	mailer := &Mailer{}
+	clock := infrastructure.NewFakeClock(Start)
+	deps := router.NewInMemoryDependencies(clock, infrastructure.NewSequentialIDGenerator("id"), mailer)
+	// Stops the trash retention and webhook dispatcher goroutines with the test.
+	ctx, cancel := context.WithCancel(context.Background())
+	router.SetupWith(ctx, timeout, deps, engine)
+
+	h := &Harness{Server: httptest.NewServer(engine), Deps: deps, Mailer: mailer, Clock: clock, t: t}
+	t.Cleanup(cancel)
+	t.Cleanup(h.Close)
+	return h
+}
+
+// Response is a response with its body read.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Request sends body, encoded as JSON unless it is nil, with token as the bearer token unless
+// it is empty.
+func (h *Harness) Request(method string, path string, token string, body interface{}) *Response {
+	h.t.Helper()
+	return h.Send(h.NewRequest(method, path, token, body))
+}
+
+// NewRequest builds the request that Request sends, for callers that need to add headers.
+func (h *Harness) NewRequest(method string, path string, token string, body interface{}) *http.Request {
+	h.t.Helper()
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			h.t.Fatalf("encoding %s %s: %v", method, path, err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequest(method, h.URL+path, reader)
+	if err != nil {
+		h.t.Fatalf("building %s %s: %v", method, path, err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+// Send sends req and reads the whole response.
+func (h *Harness) Send(req *http.Request) *Response {
+	h.t.Helper()
+	res, err := h.Client().Do(req)
+	if err != nil {
+		h.t.Fatalf("%s %s: %v", req.Method, req.URL.Path, err)
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		h.t.Fatalf("%s %s: reading body: %v", req.Method, req.URL.Path, err)
+	}
+	return &Response{StatusCode: res.StatusCode, Header: res.Header, Body: body}
+}
+
+// Call sends the request like Request, fails the test unless the response has status want and
+// decodes its body into out unless out is nil.
+func (h *Harness) Call(want int, method string, path string, token string, body interface{}, out interface{}) {
+	h.t.Helper()
+	h.Expect(h.Request(method, path, token, body), want, out)
+}
+
+// Expect fails the test unless res has status want and decodes its body into out unless out is
+// nil.
+func (h *Harness) Expect(res *Response, want int, out interface{}) {
+	h.t.Helper()
+	if res.StatusCode != want {
+		h.t.Fatalf("got status %d, want %d: %s", res.StatusCode, want, res.Body)
+	}
+	if out == nil {
+		return
+	}
+	if err := json.Unmarshal(res.Body, out); err != nil {
+		h.t.Fatalf("decoding %s: %v", res.Body, err)
+	}
+}
+
+// Register registers username with username@example.com and Password, and follows the
+// verification link mailed to them.
+func (h *Harness) Register(username string) {
+	h.t.Helper()
+	email := username + "@example.com"
+	h.Call(http.StatusOK, http.MethodPost, "/v1/register", "", domain.User{Username: username, Password: Password, Email: email}, nil)
+	h.Call(http.StatusOK, http.MethodGet, h.Mailer.Link(h.t, email), "", nil, nil)
+}
+
+// Login logs username in with Password and returns the token.
+func (h *Harness) Login(username string) string {
+	h.t.Helper()
+	var res struct {
+		Token string `json:"token"`
+	}
+	h.Call(http.StatusOK, http.MethodPost, "/v1/login", "", domain.User{Username: username, Password: Password}, &res)
+	if res.Token == "" {
+		h.t.Fatalf("login of %s returned no token", username)
+	}
+	return res.Token
+}
+
+// User registers username as a User of the default organization and returns their token.
+func (h *Harness) User(username string) string {
+	h.t.Helper()
+	h.Register(username)
+	return h.Login(username)
+}
+
+// Admin creates username as an Admin of the default organization, as the create-admin command
+// does, and returns their token.
+func (h *Harness) Admin(username string) string {
+	h.t.Helper()
+	unitOfWork := repositories.NewUnitOfWork(h.Deps.Transactor, domain.Stores{
+		Users:  h.Deps.Users,
+		Outbox: h.Deps.Outbox,
+	})
+	userUseCase := usecases.NewUserUseCase(h.Deps.Users, infrastructure.NewPasswordService(), infrastructure.NewJWTService(h.Clock), h.Mailer, unitOfWork, usecases.UserOptions{PasswordLogin: true}, h.Clock, h.Deps.IDs, timeout)
+	if err := userUseCase.CreateAdmin(context.Background(), domain.User{Username: username, Password: Password}); err != nil {
+		h.t.Fatalf("creating admin %s: %v", username, err)
+	}
+	return h.Login(username)
+}
+
+// CreateTask creates task with the token of an Admin and returns it as stored.
+func (h *Harness) CreateTask(token string, task domain.Task) domain.Task {
+	h.t.Helper()
+	var created domain.Task
+	h.Call(http.StatusCreated, http.MethodPost, "/v1/tasks", token, task, &created)
+	return created
+}
+
+// Tasks lists the tasks token can see.
+func (h *Harness) Tasks(token string) []domain.Task {
+	h.t.Helper()
+	var tasks []domain.Task
+	h.Call(http.StatusOK, http.MethodGet, "/v1/tasks", token, nil, &tasks)
+	return tasks
+}
+
+// Mailer keeps the emails the API sends instead of delivering them.
+type Mailer struct {
+	mu   sync.Mutex
+	sent []infrastructure.Email
+}
+
+func (m *Mailer) Send(c context.Context, email infrastructure.Email) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, email)
+	return nil
+}
+
+// Sent returns the emails sent so far, oldest first.
+func (m *Mailer) Sent() []infrastructure.Email {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]infrastructure.Email(nil), m.sent...)
+}
+
+// Last returns the last email sent to address.
+func (m *Mailer) Last(t testing.TB, address string) infrastructure.Email {
+	t.Helper()
+	sent := m.Sent()
+	for i := len(sent) - 1; i >= 0; i-- {
+		if sent[i].To == address {
+			return sent[i]
+		}
+	}
+	t.Fatalf("no email was sent to %s", address)
+	return infrastructure.Email{}
+}
+
+var linkPattern = regexp.MustCompile(`https?://\S+`)
+
+// Link returns the path and query of the first link in the last email sent to address, to be
+// requested from the harness.
+func (m *Mailer) Link(t testing.TB, address string) string {
+	t.Helper()
+	email := m.Last(t, address)
+	link, err := url.Parse(strings.TrimSpace(linkPattern.FindString(email.Body)))
+	if err != nil || link.Path == "" {
+		t.Fatalf("no link in the email to %s: %s", address, email.Body)
+	}
+	return link.RequestURI()
+}
@@ -0,0 +1,437 @@
+package e2etest_test
+
+import (
+	"net/http"
+	"strings"
+	"test_task_manager/Delivery/e2etest"
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/yaml.v3"
+)
+
+type JourneySuite struct {
+	suite.Suite
+	h *e2etest.Harness
+}
+
+func (suite *JourneySuite) SetupTest() {
+	suite.h = e2etest.New(suite.T())
+}
+
+func (suite *JourneySuite) TestRegisterVerifyAndLogin() {
+	h := suite.h
+	h.Call(http.StatusOK, http.MethodPost, "/v1/register", "", domain.User{Username: "alice", Password: e2etest.Password, Email: "alice@example.com"}, nil)
+	h.Call(http.StatusConflict, http.MethodPost, "/v1/register", "", domain.User{Username: "alice", Password: e2etest.Password, Email: "other@example.com"}, nil)
+
+	h.Call(http.StatusForbidden, http.MethodPost, "/v1/login", "", domain.User{Username: "alice", Password: e2etest.Password}, nil)
+
+	h.Call(http.StatusAccepted, http.MethodPost, "/v1/verify-email/resend", "", map[string]string{"email": "alice@example.com"}, nil)
+	suite.Len(h.Mailer.Sent(), 2)
+	h.Call(http.StatusOK, http.MethodGet, h.Mailer.Link(suite.T(), "alice@example.com"), "", nil, nil)
+
+	h.Call(http.StatusUnauthorized, http.MethodPost, "/v1/login", "", domain.User{Username: "alice", Password: "wrong"}, nil)
+	token := h.Login("alice")
+
+	suite.Empty(h.Tasks(token))
+	h.Call(http.StatusForbidden, http.MethodPost, "/v1/tasks", token, domain.Task{ID: "1", Title: "Not allowed"}, nil)
+	h.Call(http.StatusUnauthorized, http.MethodGet, "/v1/tasks", "", nil, nil)
+}
+
+func (suite *JourneySuite) TestTokensExpire() {
+	h := suite.h
+	h.Call(http.StatusOK, http.MethodPost, "/v1/register", "", domain.User{Username: "alice", Password: e2etest.Password, Email: "alice@example.com"}, nil)
+	h.Clock.Advance(time.Hour * 25)
+	h.Call(http.StatusBadRequest, http.MethodGet, h.Mailer.Link(suite.T(), "alice@example.com"), "", nil, nil)
+
+	token := h.User("bob")
+	h.Clock.Advance(time.Hour*24*7 - time.Minute)
+	suite.Empty(h.Tasks(token))
+	h.Clock.Advance(time.Minute * 2)
+	h.Call(http.StatusUnauthorized, http.MethodGet, "/v1/tasks", token, nil, nil)
+}
+
+func (suite *JourneySuite) TestAdminManagesTasks() {
+	h := suite.h
+	admin := h.Admin("root")
+	user := h.User("bob")
+
+	task := h.CreateTask(admin, domain.Task{ID: "1", Title: "Write report", Description: "Quarterly numbers", Status: "Pending"})
+	suite.Equal(domain.DefaultOrgID, task.OrgID)
+
+	var fetched domain.Task
+	h.Call(http.StatusOK, http.MethodGet, "/v1/tasks/"+task.ID, user, nil, &fetched)
+	suite.Equal("Write report", fetched.Title)
+
+	var updated domain.Task
+	h.Call(http.StatusOK, http.MethodPut, "/v1/tasks/"+task.ID, admin, domain.Task{Title: "Write report", Status: "Completed"}, &updated)
+	suite.Equal("Completed", updated.Status)
+	h.Call(http.StatusForbidden, http.MethodPut, "/v1/tasks/"+task.ID, user, domain.Task{Status: "Pending"}, nil)
+
+	var search domain.SearchResult
+	h.Call(http.StatusOK, http.MethodGet, "/v1/tasks/search?q=report", user, nil, &search)
+	suite.Equal(1, search.Total)
+
+	export := h.Request(http.MethodGet, "/v1/tasks/export?format=jsonl", user, nil)
+	h.Expect(export, http.StatusOK, nil)
+	suite.Contains(string(export.Body), task.ID)
+
+	var bulk struct {
+		Succeeded int `json:"succeeded"`
+	}
+	h.Call(http.StatusOK, http.MethodPost, "/v1/tasks/bulk", admin, map[string]interface{}{
+		"mode": "atomic",
+		"operations": []domain.BulkOperation{
+			{Op: domain.BulkCreate, Task: domain.Task{ID: "2", Title: "First"}},
+			{Op: domain.BulkCreate, Task: domain.Task{ID: "3", Title: "Second"}},
+		},
+	}, &bulk)
+	suite.Equal(2, bulk.Succeeded)
+	suite.Len(h.Tasks(user), 3)
+
+	h.Call(http.StatusNoContent, http.MethodDelete, "/v1/tasks/"+task.ID, admin, nil, nil)
+	h.Call(http.StatusNotFound, http.MethodGet, "/v1/tasks/"+task.ID, user, nil, nil)
+	var trash []domain.Task
+	h.Call(http.StatusOK, http.MethodGet, "/v1/tasks/trash", admin, nil, &trash)
+	suite.Len(trash, 1)
+
+	h.Call(http.StatusOK, http.MethodPost, "/v1/tasks/"+task.ID+"/restore", admin, nil, nil)
+	h.Call(http.StatusOK, http.MethodGet, "/v1/tasks/"+task.ID, user, nil, nil)
+
+	h.Call(http.StatusNoContent, http.MethodDelete, "/v1/tasks/"+task.ID, admin, nil, nil)
+	h.Call(http.StatusNoContent, http.MethodDelete, "/v1/tasks/"+task.ID+"/purge", admin, nil, nil)
+	h.Call(http.StatusOK, http.MethodGet, "/v1/tasks/trash", admin, nil, &trash)
+	suite.Empty(trash)
+}
+
+func (suite *JourneySuite) TestFailedAtomicBatchLeavesNothingBehind() {
+	h := suite.h
+	admin := h.Admin("root")
+	h.CreateTask(admin, domain.Task{ID: "1", Title: "Existing"})
+
+	var bulk struct {
+		Results []domain.BulkResult `json:"results"`
+	}
+	h.Call(http.StatusUnprocessableEntity, http.MethodPost, "/v1/tasks/bulk", admin, map[string]interface{}{
+		"mode": "atomic",
+		"operations": []domain.BulkOperation{
+			{Op: domain.BulkCreate, Task: domain.Task{ID: "2", Title: "Quarterly report"}},
+			{Op: domain.BulkUpdate, ID: "1", Task: domain.Task{Title: "Renamed"}},
+			{Op: domain.BulkCreate, Task: domain.Task{ID: "1", Title: "Duplicate"}},
+		},
+	}, &bulk)
+	suite.Require().Len(bulk.Results, 3)
+	suite.Equal(domain.BulkStatusRolledBack, bulk.Results[0].Status)
+	suite.Equal(domain.BulkStatusRolledBack, bulk.Results[1].Status)
+
+	tasks := h.Tasks(admin)
+	suite.Require().Len(tasks, 1)
+	suite.Equal("Existing", tasks[0].Title)
+	h.Call(http.StatusNotFound, http.MethodGet, "/v1/tasks/2", admin, nil, nil)
+	var search domain.SearchResult
+	h.Call(http.StatusOK, http.MethodGet, "/v1/tasks/search?q=quarterly", admin, nil, &search)
+	suite.Zero(search.Total)
+}
+
+func (suite *JourneySuite) TestPromotedUserGetsAdminRoutes() {
+	h := suite.h
+	admin := h.Admin("root")
+	h.User("bob")
+
+	h.Call(http.StatusOK, http.MethodPost, "/v1/promote/bob", admin, nil, nil)
+	h.Call(http.StatusNotFound, http.MethodPost, "/v1/promote/nobody", admin, nil, nil)
+
+	// Roles are read from the token, so the new role needs a new login.
+	h.CreateTask(h.Login("bob"), domain.Task{ID: "1", Title: "Now allowed"})
+}
+
+func (suite *JourneySuite) TestInvitedUserRegistersWithoutVerification() {
+	suite.T().Setenv("REGISTRATION", "invite")
+	suite.h = e2etest.New(suite.T())
+	h := suite.h
+	admin := h.Admin("root")
+
+	h.Call(http.StatusForbidden, http.MethodPost, "/v1/register", "", domain.User{Username: "carol", Password: e2etest.Password, Email: "carol@example.com"}, nil)
+
+	var invitation struct {
+		Code       string            `json:"code"`
+		Invitation domain.Invitation `json:"invitation"`
+	}
+	h.Call(http.StatusCreated, http.MethodPost, "/v1/invitations", admin, map[string]string{"role": "Admin", "email": "carol@example.com"}, &invitation)
+	suite.Contains(h.Mailer.Last(suite.T(), "carol@example.com").Body, invitation.Code)
+
+	h.Call(http.StatusForbidden, http.MethodPost, "/v1/register", "", domain.User{Username: "carol", Password: e2etest.Password, Email: "dave@example.com", InviteCode: invitation.Code}, nil)
+	h.Call(http.StatusOK, http.MethodPost, "/v1/register", "", domain.User{Username: "carol", Password: e2etest.Password, Email: "carol@example.com", InviteCode: invitation.Code}, nil)
+	h.Call(http.StatusForbidden, http.MethodPost, "/v1/register", "", domain.User{Username: "dave", Password: e2etest.Password, Email: "dave@example.com", InviteCode: invitation.Code}, nil)
+
+	// The invitation was for Admin, and its email proves the address.
+	h.CreateTask(h.Login("carol"), domain.Task{ID: "1", Title: "Invited"})
+
+	var invitations []domain.Invitation
+	h.Call(http.StatusOK, http.MethodGet, "/v1/invitations", admin, nil, &invitations)
+	suite.Require().Len(invitations, 1)
+	suite.Equal("carol", invitations[0].UsedBy)
+}
+
+func (suite *JourneySuite) TestOrganizationsKeepTasksApart() {
+	h := suite.h
+	admin := h.Admin("root")
+	h.CreateTask(admin, domain.Task{ID: "1", Title: "Default organization"})
+	h.User("bob")
+	h.User("carol")
+
+	var org domain.Organization
+	h.Call(http.StatusCreated, http.MethodPost, "/v1/orgs", h.Login("bob"), domain.Organization{Name: "Acme"}, &org)
+
+	// The new membership is only in tokens issued after it.
+	bob := h.Login("bob")
+	var orgs []domain.Organization
+	h.Call(http.StatusOK, http.MethodGet, "/v1/orgs", bob, nil, &orgs)
+	suite.Len(orgs, 2)
+
+	inAcme := func(method string, path string, token string, body interface{}) *e2etest.Response {
+		req := h.NewRequest(method, path, token, body)
+		req.Header.Set("X-Org-ID", org.ID)
+		return h.Send(req)
+	}
+	h.Expect(inAcme(http.MethodPost, "/v1/tasks", bob, domain.Task{ID: "1", Title: "Acme"}), http.StatusCreated, nil)
+
+	var tasks []domain.Task
+	h.Expect(inAcme(http.MethodGet, "/v1/tasks", bob, nil), http.StatusOK, &tasks)
+	suite.Require().Len(tasks, 1)
+	suite.Equal("Acme", tasks[0].Title)
+	suite.Len(h.Tasks(bob), 1)
+	suite.Equal("Default organization", h.Tasks(bob)[0].Title)
+
+	h.Expect(inAcme(http.MethodGet, "/v1/tasks", h.Login("carol"), nil), http.StatusForbidden, nil)
+	h.Expect(inAcme(http.MethodPost, "/v1/orgs/"+org.ID+"/members", bob, map[string]string{"username": "carol", "role": "User"}), http.StatusOK, nil)
+	h.Expect(inAcme(http.MethodGet, "/v1/tasks", h.Login("carol"), nil), http.StatusOK, &tasks)
+	suite.Len(tasks, 1)
+}
+
+func (suite *JourneySuite) TestServiceAccountAPIKeys() {
+	h := suite.h
+	admin := h.Admin("root")
+	h.CreateTask(admin, domain.Task{ID: "1", Title: "Visible to the key"})
+
+	var account domain.ServiceAccount
+	h.Call(http.StatusCreated, http.MethodPost, "/v1/service-accounts", admin, domain.ServiceAccount{Name: "ci"}, &account)
+	var created struct {
+		Key    string        `json:"key"`
+		APIKey domain.APIKey `json:"api_key"`
+	}
+	h.Call(http.StatusCreated, http.MethodPost, "/v1/service-accounts/"+account.ID+"/keys", admin, map[string]interface{}{"scopes": []string{domain.ScopeRead}}, &created)
+
+	withKey := func(method string, path string) *e2etest.Response {
+		req := h.NewRequest(method, path, "", nil)
+		req.Header.Set("X-API-Key", created.Key)
+		return h.Send(req)
+	}
+	var tasks []domain.Task
+	h.Expect(withKey(http.MethodGet, "/v1/tasks"), http.StatusOK, &tasks)
+	suite.Len(tasks, 1)
+	h.Expect(withKey(http.MethodDelete, "/v1/tasks/"+tasks[0].ID), http.StatusForbidden, nil)
+
+	var keys []domain.APIKey
+	h.Call(http.StatusOK, http.MethodGet, "/v1/service-accounts/"+account.ID+"/keys", admin, nil, &keys)
+	suite.Require().Len(keys, 1)
+	suite.NotNil(keys[0].LastUsedAt)
+
+	h.Call(http.StatusNoContent, http.MethodDelete, "/v1/service-accounts/"+account.ID+"/keys/"+created.APIKey.ID, admin, nil, nil)
+	h.Expect(withKey(http.MethodGet, "/v1/tasks"), http.StatusUnauthorized, nil)
+}
+
+func (suite *JourneySuite) TestWriteKeysOnlyWriteTasks() {
+	h := suite.h
+	admin := h.Admin("root")
+	h.User("bob")
+
+	var account domain.ServiceAccount
+	h.Call(http.StatusCreated, http.MethodPost, "/v1/service-accounts", admin, domain.ServiceAccount{Name: "ci"}, &account)
+	var created struct {
+		Key string `json:"key"`
+	}
+	h.Call(http.StatusCreated, http.MethodPost, "/v1/service-accounts/"+account.ID+"/keys", admin, map[string]interface{}{"scopes": []string{domain.ScopeWrite}}, &created)
+
+	withKey := func(method string, path string, body interface{}) *e2etest.Response {
+		req := h.NewRequest(method, path, "", body)
+		req.Header.Set("X-API-Key", created.Key)
+		return h.Send(req)
+	}
+	h.Expect(withKey(http.MethodPost, "/v1/tasks", domain.Task{ID: "1", Title: "From CI"}), http.StatusCreated, nil)
+	h.Expect(withKey(http.MethodDelete, "/v1/tasks/1", nil), http.StatusNoContent, nil)
+
+	h.Expect(withKey(http.MethodPost, "/v1/service-accounts/"+account.ID+"/keys", map[string]interface{}{"scopes": []string{domain.ScopeWrite}}), http.StatusForbidden, nil)
+	h.Expect(withKey(http.MethodPost, "/v1/promote/bob", nil), http.StatusForbidden, nil)
+	h.Expect(withKey(http.MethodPost, "/v1/invitations", map[string]string{"role": "Admin"}), http.StatusForbidden, nil)
+	h.Expect(withKey(http.MethodPost, "/v1/orgs", domain.Organization{Name: "Rogue"}), http.StatusForbidden, nil)
+	h.Expect(withKey(http.MethodPost, "/v1/webhooks", map[string]string{"url": "https://example.com"}), http.StatusForbidden, nil)
+
+	var response struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	h.Expect(withKey(http.MethodPost, "/v1/graphql", map[string]string{"query": `mutation { promoteUser(username: "bob") { role } }`}), http.StatusOK, &response)
+	suite.Require().Len(response.Errors, 1)
+	suite.Equal("API keys cannot perform this operation", response.Errors[0].Message)
+}
+
+func (suite *JourneySuite) TestTwoFactorLogin() {
+	h := suite.h
+	token := h.User("bob")
+	totp := infrastructure.NewTOTPService()
+
+	var enrollment domain.MFAEnrollment
+	h.Call(http.StatusOK, http.MethodPost, "/v1/mfa/enroll", token, nil, &enrollment)
+	code, err := totp.GenerateCode(enrollment.Secret, h.Clock.Now())
+	suite.Require().NoError(err)
+	h.Call(http.StatusOK, http.MethodPost, "/v1/mfa/enroll/confirm", token, map[string]string{"code": code}, nil)
+
+	var login struct {
+		MFARequired bool   `json:"mfa_required"`
+		MFAToken    string `json:"mfa_token"`
+	}
+	h.Call(http.StatusOK, http.MethodPost, "/v1/login", "", domain.User{Username: "bob", Password: e2etest.Password}, &login)
+	suite.Require().True(login.MFARequired)
+
+	h.Call(http.StatusUnauthorized, http.MethodPost, "/v1/login/mfa", "", map[string]string{"mfa_token": login.MFAToken, "code": "000000"}, nil)
+	// The code that confirmed the enrollment cannot be used again, so wait for the next one.
+	h.Clock.Advance(time.Second * 30)
+	code, err = totp.GenerateCode(enrollment.Secret, h.Clock.Now())
+	suite.Require().NoError(err)
+	var verified struct {
+		Token string `json:"token"`
+	}
+	h.Call(http.StatusOK, http.MethodPost, "/v1/login/mfa", "", map[string]string{"mfa_token": login.MFAToken, "code": code}, &verified)
+	suite.Empty(h.Tasks(verified.Token))
+}
+
+func (suite *JourneySuite) TestWebhooks() {
+	h := suite.h
+	admin := h.Admin("root")
+
+	var webhook domain.Webhook
+	h.Call(http.StatusCreated, http.MethodPost, "/v1/webhooks", admin, domain.Webhook{URL: "https://example.com/hook", Events: []string{domain.EventTaskCreated}, Secret: "shh"}, &webhook)
+	var webhooks []domain.Webhook
+	h.Call(http.StatusOK, http.MethodGet, "/v1/webhooks", admin, nil, &webhooks)
+	suite.Len(webhooks, 1)
+
+	h.Call(http.StatusOK, http.MethodGet, "/v1/webhooks/"+webhook.ID+"/deliveries", admin, nil, nil)
+	h.Call(http.StatusOK, http.MethodGet, "/v1/webhooks/dead-letters", admin, nil, nil)
+	h.Call(http.StatusForbidden, http.MethodGet, "/v1/webhooks", h.User("bob"), nil, nil)
+
+	h.Call(http.StatusNoContent, http.MethodDelete, "/v1/webhooks/"+webhook.ID, admin, nil, nil)
+	h.Call(http.StatusNotFound, http.MethodDelete, "/v1/webhooks/"+webhook.ID, admin, nil, nil)
+}
+
+func (suite *JourneySuite) TestGraphQL() {
+	h := suite.h
+	admin := h.Admin("root")
+	h.CreateTask(admin, domain.Task{ID: "1", Title: "From REST"})
+
+	var response struct {
+		Data struct {
+			Tasks []domain.Task `json:"tasks"`
+		} `json:"data"`
+	}
+	h.Call(http.StatusOK, http.MethodPost, "/v1/graphql", h.User("bob"), map[string]string{"query": "{ tasks { id title } }"}, &response)
+	suite.Require().Len(response.Data.Tasks, 1)
+	suite.Equal("From REST", response.Data.Tasks[0].Title)
+}
+
+func (suite *JourneySuite) TestOperationalRoutes() {
+	h := suite.h
+	admin := h.Admin("root")
+
+	h.Call(http.StatusOK, http.MethodGet, "/readyz", "", nil, nil)
+
+	vars := h.Request(http.MethodGet, "/v1/debug/vars", admin, nil)
+	h.Expect(vars, http.StatusOK, nil)
+	suite.True(strings.Contains(string(vars.Body), "task_cache"))
+	suite.False(strings.Contains(string(vars.Body), "cmdline"), "only the cache counters are served")
+	h.Call(http.StatusForbidden, http.MethodGet, "/v1/debug/vars", h.User("bob"), nil, nil)
+}
+
+func (suite *JourneySuite) TestVersionsAndFormats() {
+	h := suite.h
+	admin := h.Admin("root")
+	h.CreateTask(admin, domain.Task{ID: "1", Title: "Write report"})
+
+	versioned := h.Request(http.MethodGet, "/v1/tasks/1", admin, nil)
+	h.Expect(versioned, http.StatusOK, nil)
+	suite.Empty(versioned.Header.Get("Deprecation"))
+	suite.NotContains(string(versioned.Body), "\n", "JSON is compact by default")
+
+	unversioned := h.Request(http.MethodGet, "/tasks/1", admin, nil)
+	h.Expect(unversioned, http.StatusOK, nil)
+	suite.Equal("true", unversioned.Header.Get("Deprecation"))
+	suite.Equal(`</v1/tasks/1>; rel="successor-version"`, unversioned.Header.Get("Link"))
+	suite.Equal(versioned.Body, unversioned.Body)
+
+	pretty := h.Request(http.MethodGet, "/v1/tasks/1?pretty=1", admin, nil)
+	suite.Contains(string(pretty.Body), "\n    \"title\": \"Write report\"")
+
+	req := h.NewRequest(http.MethodGet, "/v1/tasks/1", admin, nil)
+	req.Header.Set("Accept", "application/yaml")
+	res := h.Send(req)
+	h.Expect(res, http.StatusOK, nil)
+	suite.Equal("application/yaml; charset=utf-8", res.Header.Get("Content-Type"))
+	var task map[string]interface{}
+	suite.Require().NoError(yaml.Unmarshal(res.Body, &task))
+	suite.Equal("Write report", task["title"])
+	suite.Equal("0001-01-01T00:00:00Z", task["due_date"])
+
+	req = h.NewRequest(http.MethodGet, "/v1/tasks", admin, nil)
+	req.Header.Set("Accept", "application/msgpack")
+	res = h.Send(req)
+	h.Expect(res, http.StatusOK, nil)
+	suite.Equal("application/msgpack", res.Header.Get("Content-Type"))
+
+	probe := h.Request(http.MethodGet, "/readyz", "", nil)
+	suite.Empty(probe.Header.Get("Deprecation"))
+}
+
+func (suite *JourneySuite) TestReports() {
+	h := suite.h
+	admin := h.Admin("root")
+	user := h.User("bob")
+
+	h.CreateTask(admin, domain.Task{ID: "1", Title: "Write report", Status: "Pending", Assignee: "bob", DueDate: e2etest.Start.Add(36 * time.Hour)})
+	h.CreateTask(admin, domain.Task{ID: "2", Title: "Review report", Status: "Pending", Assignee: "bob"})
+	h.CreateTask(admin, domain.Task{ID: "3", Title: "Book room", Status: "Pending", Assignee: "root"})
+
+	h.Clock.Advance(24 * time.Hour)
+	var completed domain.Task
+	h.Call(http.StatusOK, http.MethodPut, "/v1/tasks/3", admin, domain.Task{Status: "Completed"}, &completed)
+	suite.Equal(h.Clock.Now(), *completed.CompletedAt)
+	h.Clock.Advance(24 * time.Hour)
+
+	var summary domain.TaskSummary
+	h.Call(http.StatusOK, http.MethodGet, "/v1/reports/summary?from=2024-01-01", user, nil, &summary)
+	suite.Equal(domain.TaskCounts{Total: 3, Open: 2, Completed: 1, Overdue: 1}, summary.TaskCounts)
+	suite.Equal(map[string]int{"Pending": 2, "Completed": 1}, summary.ByStatus)
+	suite.Equal("bob", summary.ByAssignee[0].Assignee)
+	suite.Equal(domain.TaskCounts{Total: 2, Open: 2, Overdue: 1}, summary.ByAssignee[0].TaskCounts)
+	suite.Equal([]domain.CompletionPoint{
+		{Date: "2024-01-01", Created: 3, CompletionRate: 0},
+		{Date: "2024-01-02", Completed: 1, CompletionRate: 0.333},
+		{Date: "2024-01-03", CompletionRate: 0.333},
+	}, summary.Completion)
+
+	var burndown domain.Burndown
+	h.Call(http.StatusOK, http.MethodGet, "/v1/reports/burndown?from=2024-01-01&to=2024-01-02", user, nil, &burndown)
+	suite.Equal([]domain.BurndownPoint{
+		{Date: "2024-01-01", Created: 3, Remaining: 3},
+		{Date: "2024-01-02", Completed: 1, Remaining: 2},
+	}, burndown.Points)
+
+	h.Call(http.StatusBadRequest, http.MethodGet, "/v1/reports/burndown?from=2024-01-02&to=2024-01-01", user, nil, nil)
+}
+
+func TestJourneySuite(t *testing.T) {
+	suite.Run(t, new(JourneySuite))
+}
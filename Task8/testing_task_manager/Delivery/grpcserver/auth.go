@@ -0,0 +1,115 @@
+package grpcserver
+
+import (
+	"context"
+	"strings"
+
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type access int
+
+const (
+	public access = iota
+	anyUser
+	adminOnly
+)
+
+// methodAccess lists the role each RPC needs, matching the middleware on the REST routes.
+// Methods missing from the map are refused so a new RPC cannot be exposed by accident.
+var methodAccess = map[string]access{
+	"/taskmanager.v1.TaskService/ListTasks":   anyUser,
+	"/taskmanager.v1.TaskService/GetTask":     anyUser,
+	"/taskmanager.v1.TaskService/SearchTasks": anyUser,
+	"/taskmanager.v1.TaskService/CreateTask":  adminOnly,
+	"/taskmanager.v1.TaskService/UpdateTask":  adminOnly,
+	"/taskmanager.v1.TaskService/DeleteTask":  adminOnly,
+	"/taskmanager.v1.TaskService/ListTrash":   adminOnly,
+	"/taskmanager.v1.TaskService/RestoreTask": adminOnly,
+	"/taskmanager.v1.TaskService/PurgeTask":   adminOnly,
+	"/taskmanager.v1.UserService/Register":    public,
+	"/taskmanager.v1.UserService/Login":       public,
+	"/taskmanager.v1.UserService/VerifyMFA":   public,
+	"/taskmanager.v1.UserService/VerifyEmail": public,
+	"/taskmanager.v1.UserService/PromoteUser": adminOnly,
+	"/taskmanager.v1.UserService/ListUsers":   adminOnly,
+}
+
+type claimsKey struct{}
+
+// Claims is the caller identity the interceptor puts on the context of authenticated calls.
+type Claims struct {
+	Username string
+	Role     string
+	OrgID    string
+}
+
+func ClaimsFromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(Claims)
+	return claims, ok
+}
+
+// AuthInterceptor checks the bearer token sent in the "authorization" metadata with the same
+// JWTService as AuthMiddleware. The "x-org-id" metadata picks the organization like the
+// X-Org-ID header does.
+func AuthInterceptor(jwtService infrastructure.JWTService, clock domain.Clock) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		required, ok := methodAccess[info.FullMethod]
+		if !ok {
+			return nil, status.Error(codes.PermissionDenied, "method not allowed")
+		}
+		if required == public {
+			return handler(ctx, req)
+		}
+
+		claims, err := authenticate(ctx, jwtService, clock)
+		if err != nil {
+			return nil, err
+		}
+		if claims.Role == "User" && required == adminOnly {
+			return nil, status.Error(codes.PermissionDenied, "User role not allowed to access this endpoint")
+		}
+
+		ctx = domain.WithOrg(context.WithValue(ctx, claimsKey{}, claims), claims.OrgID)
+		return handler(ctx, req)
+	}
+}
+
+func authenticate(ctx context.Context, jwtService infrastructure.JWTService, clock domain.Clock) (Claims, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 || values[0] == "" {
+		return Claims{}, status.Error(codes.Unauthenticated, "Authorization header is required")
+	}
+
+	authParts := strings.Split(values[0], " ")
+	if len(authParts) != 2 || strings.ToLower(authParts[0]) != "bearer" {
+		return Claims{}, status.Error(codes.Unauthenticated, "Invalid authorization header")
+	}
+
+	claims, err := jwtService.ValidateToken(authParts[1])
+	if err != nil {
+		return Claims{}, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if exp, ok := claims["exp"].(float64); ok && clock.Now().Unix() > int64(exp) {
+		return Claims{}, status.Error(codes.Unauthenticated, "Token expired")
+	}
+
+	var requested string
+	if values := md.Get("x-org-id"); len(values) > 0 {
+		requested = values[0]
+	}
+	orgID, role, ok := infrastructure.ResolveOrg(claims, requested)
+	if !ok {
+		return Claims{}, status.Error(codes.PermissionDenied, "Not a member of this organization")
+	}
+
+	username, _ := claims["username"].(string)
+	return Claims{Username: username, Role: role, OrgID: orgID}, nil
+}
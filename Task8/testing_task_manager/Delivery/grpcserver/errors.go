@@ -0,0 +1,58 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorCodes maps the messages returned by the use cases to gRPC codes, the same way the
+// controllers pick an HTTP status from err.Error().
+var errorCodes = map[string]codes.Code{
+	"task not found":                                            codes.NotFound,
+	"task not found in trash":                                   codes.NotFound,
+	"user not found":                                            codes.NotFound,
+	"task with the given id already exists":                     codes.AlreadyExists,
+	"username already exists":                                   codes.AlreadyExists,
+	"user is already an admin":                                  codes.FailedPrecondition,
+	"invalid credentials":                                       codes.Unauthenticated,
+	"password login is disabled":                                codes.PermissionDenied,
+	"an invitation is required to register":                     codes.PermissionDenied,
+	"invalid or expired invitation":                             codes.PermissionDenied,
+	"email does not match the invitation":                       codes.PermissionDenied,
+	"email address is not verified":                             codes.PermissionDenied,
+	"email already exists":                                      codes.AlreadyExists,
+	"a valid email address is required":                         codes.InvalidArgument,
+	"invalid or expired verification token":                     codes.InvalidArgument,
+	"invalid or expired mfa token":                              codes.Unauthenticated,
+	"invalid mfa code":                                          codes.Unauthenticated,
+	"too many failed mfa attempts, try again later":             codes.ResourceExhausted,
+	"password length must be greater than 4":                    codes.InvalidArgument,
+	"search query is required":                                  codes.InvalidArgument,
+	"page must be at least 1":                                   codes.InvalidArgument,
+	"page is too large":                                         codes.InvalidArgument,
+	"limit must be between 1 and 100":                           codes.InvalidArgument,
+	"transactions are not supported by this MongoDB deployment": codes.Unavailable,
+	"database unavailable":                                      codes.Unavailable,
+}
+
+func toStatus(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return status.Error(codes.NotFound, "task not found")
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+
+	if code, ok := errorCodes[err.Error()]; ok {
+		return status.Error(code, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,212 @@
+package grpcserver
+
+import (
+	"context"
+
+	"test_task_manager/Delivery/grpcserver/pb"
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const defaultSearchLimit = 20
+
+// NewServer returns a gRPC server exposing TaskService and UserService on top of the same use
+// cases as the HTTP API.
+func NewServer(taskUseCase domain.TaskUseCase, userUseCase domain.UserUseCase, mfaUseCase domain.MFAUseCase, jwtService infrastructure.JWTService, clock domain.Clock) *grpc.Server {
+	server := grpc.NewServer(grpc.UnaryInterceptor(AuthInterceptor(jwtService, clock)))
+	pb.RegisterTaskServiceServer(server, &taskServer{taskUseCase: taskUseCase})
+	pb.RegisterUserServiceServer(server, &userServer{userUseCase: userUseCase, mfaUseCase: mfaUseCase})
+	return server
+}
+
+type taskServer struct {
+	pb.UnimplementedTaskServiceServer
+	taskUseCase domain.TaskUseCase
+}
+
+func (s *taskServer) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	tasks, err := s.taskUseCase.GetTasks(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ListTasksResponse{Tasks: toProtoTasks(tasks)}, nil
+}
+
+func (s *taskServer) GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb.Task, error) {
+	task, err := s.taskUseCase.GetTaskByID(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoTask(*task), nil
+}
+
+func (s *taskServer) CreateTask(ctx context.Context, req *pb.CreateTaskRequest) (*pb.Task, error) {
+	task, err := s.taskUseCase.CreateTask(ctx, fromProtoTask(req.GetTask()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoTask(*task), nil
+}
+
+func (s *taskServer) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*pb.Task, error) {
+	task, err := s.taskUseCase.UpdateTask(ctx, req.GetId(), fromProtoTask(req.GetTask()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoTask(*task), nil
+}
+
+func (s *taskServer) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest) (*emptypb.Empty, error) {
+	if err := s.taskUseCase.DeleteTask(ctx, req.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *taskServer) SearchTasks(ctx context.Context, req *pb.SearchTasksRequest) (*pb.SearchTasksResponse, error) {
+	page, limit := int(req.GetPage()), int(req.GetLimit())
+	if page == 0 {
+		page = 1
+	}
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+
+	result, err := s.taskUseCase.SearchTasks(ctx, req.GetQuery(), page, limit)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &pb.SearchTasksResponse{
+		Query: result.Query,
+		Page:  int32(result.Page),
+		Limit: int32(result.Limit),
+		Total: int32(result.Total),
+		Hits:  make([]*pb.SearchHit, 0, len(result.Hits)),
+	}
+	for _, hit := range result.Hits {
+		response.Hits = append(response.Hits, &pb.SearchHit{Task: toProtoTask(hit.Task), Score: hit.Score, Highlights: hit.Highlights})
+	}
+	return response, nil
+}
+
+func (s *taskServer) ListTrash(ctx context.Context, req *pb.ListTrashRequest) (*pb.ListTasksResponse, error) {
+	tasks, err := s.taskUseCase.GetTrash(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ListTasksResponse{Tasks: toProtoTasks(tasks)}, nil
+}
+
+func (s *taskServer) RestoreTask(ctx context.Context, req *pb.RestoreTaskRequest) (*pb.Task, error) {
+	task, err := s.taskUseCase.RestoreTask(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoTask(*task), nil
+}
+
+func (s *taskServer) PurgeTask(ctx context.Context, req *pb.PurgeTaskRequest) (*emptypb.Empty, error) {
+	if err := s.taskUseCase.PurgeTask(ctx, req.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+type userServer struct {
+	pb.UnimplementedUserServiceServer
+	userUseCase domain.UserUseCase
+	mfaUseCase  domain.MFAUseCase
+}
+
+func (s *userServer) Register(ctx context.Context, req *pb.RegisterRequest) (*emptypb.Empty, error) {
+	if err := s.userUseCase.CreateUser(ctx, domain.User{Username: req.GetUsername(), Password: req.GetPassword(), Email: req.GetEmail(), InviteCode: req.GetInviteCode()}); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *userServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	result, err := s.userUseCase.Login(ctx, domain.User{Username: req.GetUsername(), Password: req.GetPassword()})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.LoginResponse{Token: result.Token, MfaToken: result.MFAToken, MfaEnrollmentRequired: result.MFAEnrollmentRequired}, nil
+}
+
+func (s *userServer) VerifyMFA(ctx context.Context, req *pb.VerifyMFARequest) (*pb.LoginResponse, error) {
+	token, err := s.mfaUseCase.VerifyLogin(ctx, req.GetMfaToken(), req.GetCode())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.LoginResponse{Token: token}, nil
+}
+
+func (s *userServer) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*emptypb.Empty, error) {
+	if err := s.userUseCase.VerifyEmail(ctx, req.GetToken()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *userServer) PromoteUser(ctx context.Context, req *pb.PromoteUserRequest) (*pb.User, error) {
+	user, err := s.userUseCase.PromoteUser(ctx, req.GetUsername())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.User{Username: user.Username, Role: user.Role}, nil
+}
+
+func (s *userServer) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	users, err := s.userUseCase.GetUsers(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	response := &pb.ListUsersResponse{Users: make([]*pb.User, 0, len(users))}
+	for _, user := range users {
+		response.Users = append(response.Users, &pb.User{Username: user.Username, Role: user.Role})
+	}
+	return response, nil
+}
+
+func toProtoTask(task domain.Task) *pb.Task {
+	message := &pb.Task{
+		Id:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      task.Status,
+	}
+	if !task.DueDate.IsZero() {
+		message.DueDate = timestamppb.New(task.DueDate)
+	}
+	if task.DeletedAt != nil {
+		message.DeletedAt = timestamppb.New(*task.DeletedAt)
+	}
+	return message
+}
+
+func toProtoTasks(tasks []domain.Task) []*pb.Task {
+	messages := make([]*pb.Task, 0, len(tasks))
+	for _, task := range tasks {
+		messages = append(messages, toProtoTask(task))
+	}
+	return messages
+}
+
+// fromProtoTask ignores deleted_at: only the trash endpoints move tasks in and out of the trash.
+func fromProtoTask(message *pb.Task) domain.Task {
+	task := domain.Task{
+		ID:          message.GetId(),
+		Title:       message.GetTitle(),
+		Description: message.GetDescription(),
+		Status:      message.GetStatus(),
+	}
+	if message.GetDueDate() != nil {
+		task.DueDate = message.GetDueDate().AsTime()
+	}
+	return task
+}
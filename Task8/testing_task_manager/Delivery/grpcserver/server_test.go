@@ -0,0 +1,186 @@
+package grpcserver_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"test_task_manager/Delivery/grpcserver"
+	"test_task_manager/Delivery/grpcserver/pb"
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	mocks "test_task_manager/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type GRPCServerTestSuite struct {
+	suite.Suite
+	taskUseCase *mocks.TaskUseCase
+	userUseCase *mocks.UserUseCase
+	mfaUseCase  *mocks.MFAUseCase
+	jwtService  *mocks.JWTService
+	clock       *infrastructure.FakeClock
+	server      *grpc.Server
+	conn        *grpc.ClientConn
+	tasks       pb.TaskServiceClient
+	users       pb.UserServiceClient
+}
+
+func (suite *GRPCServerTestSuite) SetupTest() {
+	suite.taskUseCase = new(mocks.TaskUseCase)
+	suite.userUseCase = new(mocks.UserUseCase)
+	suite.mfaUseCase = new(mocks.MFAUseCase)
+	suite.jwtService = new(mocks.JWTService)
+	suite.clock = infrastructure.NewFakeClock(time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC))
+	suite.jwtService.On("ValidateToken", "userToken").Return(map[string]interface{}{"username": "user", "role": "User"}, nil).Maybe()
+	suite.jwtService.On("ValidateToken", "adminToken").Return(map[string]interface{}{"username": "admin", "role": "Admin"}, nil).Maybe()
+
+	listener := bufconn.Listen(1 << 20)
+	suite.server = grpcserver.NewServer(suite.taskUseCase, suite.userUseCase, suite.mfaUseCase, suite.jwtService, suite.clock)
+	go suite.server.Serve(listener)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	suite.Require().NoError(err)
+	suite.conn = conn
+	suite.tasks = pb.NewTaskServiceClient(conn)
+	suite.users = pb.NewUserServiceClient(conn)
+}
+
+func (suite *GRPCServerTestSuite) TearDownTest() {
+	suite.conn.Close()
+	suite.server.Stop()
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func (suite *GRPCServerTestSuite) TestListTasks() {
+	due := time.Date(2024, 8, 7, 12, 0, 0, 0, time.UTC)
+	suite.taskUseCase.On("GetTasks", mock.Anything).Return([]domain.Task{{ID: "1", Title: "Task 1", DueDate: due, Status: "Pending"}}, nil)
+
+	response, err := suite.tasks.ListTasks(withToken("userToken"), &pb.ListTasksRequest{})
+
+	suite.Require().NoError(err)
+	suite.Require().Len(response.GetTasks(), 1)
+	suite.Equal("Task 1", response.GetTasks()[0].GetTitle())
+	suite.Equal(due, response.GetTasks()[0].GetDueDate().AsTime())
+	suite.Nil(response.GetTasks()[0].GetDeletedAt())
+}
+
+func (suite *GRPCServerTestSuite) TestMissingToken() {
+	_, err := suite.tasks.ListTasks(context.Background(), &pb.ListTasksRequest{})
+
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+	suite.taskUseCase.AssertNotCalled(suite.T(), "GetTasks", mock.Anything)
+}
+
+func (suite *GRPCServerTestSuite) TestInvalidToken() {
+	suite.jwtService.On("ValidateToken", "badToken").Return(nil, errors.New("Invalid token"))
+
+	_, err := suite.tasks.ListTasks(withToken("badToken"), &pb.ListTasksRequest{})
+
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+	suite.Equal("Invalid token", status.Convert(err).Message())
+}
+
+func (suite *GRPCServerTestSuite) TestExpiredToken() {
+	expiresAt := suite.clock.Now().Add(time.Hour)
+	suite.jwtService.On("ValidateToken", "hourToken").Return(map[string]interface{}{"username": "user", "role": "User", "exp": float64(expiresAt.Unix())}, nil)
+	suite.taskUseCase.On("GetTasks", mock.Anything).Return([]domain.Task{}, nil)
+
+	_, err := suite.tasks.ListTasks(withToken("hourToken"), &pb.ListTasksRequest{})
+	suite.Require().NoError(err)
+
+	suite.clock.Advance(time.Hour + time.Second)
+	_, err = suite.tasks.ListTasks(withToken("hourToken"), &pb.ListTasksRequest{})
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func (suite *GRPCServerTestSuite) TestCreateTaskForbiddenForUser() {
+	_, err := suite.tasks.CreateTask(withToken("userToken"), &pb.CreateTaskRequest{Task: &pb.Task{Id: "1", Title: "Task 1"}})
+
+	suite.Equal(codes.PermissionDenied, status.Code(err))
+	suite.taskUseCase.AssertNotCalled(suite.T(), "CreateTask", mock.Anything, mock.Anything)
+}
+
+func (suite *GRPCServerTestSuite) TestCreateTaskAlreadyExists() {
+	suite.taskUseCase.On("CreateTask", mock.Anything, domain.Task{ID: "1", Title: "Task 1"}).Return(nil, errors.New("task with the given id already exists"))
+
+	_, err := suite.tasks.CreateTask(withToken("adminToken"), &pb.CreateTaskRequest{Task: &pb.Task{Id: "1", Title: "Task 1"}})
+
+	suite.Equal(codes.AlreadyExists, status.Code(err))
+}
+
+func (suite *GRPCServerTestSuite) TestGetTaskNotFound() {
+	suite.taskUseCase.On("GetTaskByID", mock.Anything, "missing").Return(nil, mongo.ErrNoDocuments)
+
+	_, err := suite.tasks.GetTask(withToken("userToken"), &pb.GetTaskRequest{Id: "missing"})
+
+	suite.Equal(codes.NotFound, status.Code(err))
+}
+
+func (suite *GRPCServerTestSuite) TestSearchTasksDefaults() {
+	suite.taskUseCase.On("SearchTasks", mock.Anything, "report", 1, 20).Return(&domain.SearchResult{Query: "report", Page: 1, Limit: 20}, nil)
+
+	response, err := suite.tasks.SearchTasks(withToken("userToken"), &pb.SearchTasksRequest{Query: "report"})
+
+	suite.Require().NoError(err)
+	suite.Equal(int32(20), response.GetLimit())
+}
+
+func (suite *GRPCServerTestSuite) TestLoginIsPublic() {
+	suite.userUseCase.On("Login", mock.Anything, domain.User{Username: "user", Password: "secret"}).Return(&domain.LoginResult{Token: "token"}, nil)
+
+	response, err := suite.users.Login(context.Background(), &pb.LoginRequest{Username: "user", Password: "secret"})
+
+	suite.Require().NoError(err)
+	suite.Equal("token", response.GetToken())
+}
+
+func (suite *GRPCServerTestSuite) TestLoginInvalidCredentials() {
+	suite.userUseCase.On("Login", mock.Anything, mock.Anything).Return(nil, errors.New("invalid credentials"))
+
+	_, err := suite.users.Login(context.Background(), &pb.LoginRequest{Username: "user", Password: "wrong"})
+
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func (suite *GRPCServerTestSuite) TestLoginWithMFA() {
+	suite.userUseCase.On("Login", mock.Anything, mock.Anything).Return(&domain.LoginResult{MFAToken: "mfaToken"}, nil)
+	suite.mfaUseCase.On("VerifyLogin", mock.Anything, "mfaToken", "123456").Return("token", nil)
+
+	response, err := suite.users.Login(context.Background(), &pb.LoginRequest{Username: "user", Password: "secret"})
+	suite.Require().NoError(err)
+	suite.Empty(response.GetToken())
+	suite.Equal("mfaToken", response.GetMfaToken())
+
+	response, err = suite.users.VerifyMFA(context.Background(), &pb.VerifyMFARequest{MfaToken: response.GetMfaToken(), Code: "123456"})
+	suite.Require().NoError(err)
+	suite.Equal("token", response.GetToken())
+}
+
+func (suite *GRPCServerTestSuite) TestVerifyMFAInvalidCode() {
+	suite.mfaUseCase.On("VerifyLogin", mock.Anything, "mfaToken", "000000").Return("", errors.New("invalid mfa code"))
+
+	_, err := suite.users.VerifyMFA(context.Background(), &pb.VerifyMFARequest{MfaToken: "mfaToken", Code: "000000"})
+
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func TestGRPCServer(t *testing.T) {
+	suite.Run(t, new(GRPCServerTestSuite))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"test_task_manager/Delivery/router"
 	"time"
@@ -43,12 +44,49 @@ func main() {
 		return
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(db, os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("Error: %v", err.Error())
+		}
+		return
+	}
+
+	// Several instances may start at once; the migration lock makes them apply each change once.
+	if os.Getenv("MIGRATE_ON_START") != "false" {
+		if err := runMigrate(db, []string{"up"}, os.Stdout); err != nil {
+			log.Fatalf("Error running migrations: %v", err.Error())
+		}
+	}
+
 	// Define timeout duration
 	timeout := time.Second * 10
 
+	if len(os.Args) > 1 && os.Args[1] == "create-admin" {
+		if err := runCreateAdmin(db, os.Args[2:], os.Stdin, os.Stdout, timeout); err != nil {
+			log.Fatalf("Error: %v", err.Error())
+		}
+		return
+	}
+	warnWithoutAdmin(db, timeout)
+
 	r := gin.Default()
 
-	router.Setup(timeout, db, r)
+	// The background jobs run for as long as the process does.
+	grpcServer := router.Setup(context.Background(), timeout, db, r)
+
+	grpcAddr := os.Getenv("GRPC_ADDR")
+	if grpcAddr == "" {
+		grpcAddr = ":9090"
+	}
+	listener, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		log.Fatalf("Error: %v", err.Error())
+	}
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Fatalf("Error starting gRPC server: %v", err.Error())
+		}
+	}()
 
 	if err := r.Run(":8080"); err != nil {
 		log.Fatalf("Error starting server: %v", err.Error())
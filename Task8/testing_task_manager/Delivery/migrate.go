@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	infrastructure "test_task_manager/Infrastructure"
+	repositories "test_task_manager/Repositories"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// migrationTimeout bounds a whole run, including the wait for another instance's lock.
+const migrationTimeout = time.Minute * 5
+
+func newMigrator(db *mongo.Database) *repositories.Migrator {
+	clock := infrastructure.NewSystemClock()
+	return repositories.NewMigrator(*db, "migrations", repositories.Migrations(clock), clock)
+}
+
+// runMigrate handles `migrate up`, `migrate down [steps]` and `migrate status`.
+func runMigrate(db *mongo.Database, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("usage: migrate up|down [steps]|status")
+	}
+
+	c, cancel := context.WithTimeout(context.Background(), migrationTimeout)
+	defer cancel()
+	migrator := newMigrator(db)
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(c)
+		for _, migration := range applied {
+			fmt.Fprintf(out, "applied %d: %s\n", migration.Version, migration.Description)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Fprintln(out, "database is up to date")
+		}
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+			steps = n
+		}
+		reverted, err := migrator.Down(c, steps)
+		for _, migration := range reverted {
+			fmt.Fprintf(out, "reverted %d: %s\n", migration.Version, migration.Description)
+		}
+		return err
+	case "status":
+		statuses, err := migrator.Status(c)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(out, "%4d  %-25s  %s\n", status.Version, applied, status.Description)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown migrate command %q", args[0])
+}
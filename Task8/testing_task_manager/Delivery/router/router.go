@@ -1,7 +1,15 @@
 package router
 
 import (
+	"context"
+	"expvar"
+	"log"
+	"os"
+	"strconv"
+	"strings"
 	"test_task_manager/Delivery/controllers"
+	"test_task_manager/Delivery/grpcserver"
+	domain "test_task_manager/Domain"
 	infrastructure "test_task_manager/Infrastructure"
 	repositories "test_task_manager/Repositories"
 	usecases "test_task_manager/UseCases"
@@ -9,45 +17,517 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
 )
 
-func Setup(timeout time.Duration, db *mongo.Database, gin *gin.Engine) {
-	taskRouter := gin.Group("")
-	NewTaskRouter(timeout, *db, taskRouter)
+const (
+	webhookMaxAttempts  = 6
+	webhookBaseBackoff  = time.Second * 10
+	webhookPollInterval = time.Second * 2
+	eventBusHistory     = 1000
+	trashRetention      = time.Hour * 24 * 30
+	trashPurgeInterval  = time.Hour
+	taskCacheTTL        = time.Second * 30
+)
+
+const (
+	dbReadTimeout      = time.Second * 3
+	dbWriteTimeout     = time.Second * 5
+	dbBulkTimeout      = time.Second * 10
+	dbMaxAttempts      = 3
+	dbBaseBackoff      = time.Millisecond * 100
+	dbMaxBackoff       = time.Second
+	dbBreakerThreshold = 5
+	dbBreakerCooldown  = time.Second * 10
+)
+
+// newResilience reads DB_READ_TIMEOUT, DB_WRITE_TIMEOUT, DB_BULK_TIMEOUT, DB_MAX_ATTEMPTS,
+// DB_BREAKER_THRESHOLD and DB_BREAKER_COOLDOWN. The breaker's cooldown follows clock.
+func newResilience(clock domain.Clock) *repositories.Resilience {
+	breaker := infrastructure.NewCircuitBreaker(intFromEnv("DB_BREAKER_THRESHOLD", dbBreakerThreshold), durationFromEnv("DB_BREAKER_COOLDOWN", dbBreakerCooldown), clock)
+	retry := infrastructure.RetryPolicy{
+		MaxAttempts: intFromEnv("DB_MAX_ATTEMPTS", dbMaxAttempts),
+		BaseBackoff: dbBaseBackoff,
+		MaxBackoff:  dbMaxBackoff,
+	}
+	return repositories.NewResilience(breaker, retry, repositories.OperationTimeouts{
+		Read:  durationFromEnv("DB_READ_TIMEOUT", dbReadTimeout),
+		Write: durationFromEnv("DB_WRITE_TIMEOUT", dbWriteTimeout),
+		Bulk:  durationFromEnv("DB_BULK_TIMEOUT", dbBulkTimeout),
+	})
+}
+
+// Dependencies are the stores and services the routers are built from. Setup reads them from
+// MongoDB; SetupWith takes them as given, so the same routes can run on in-memory stores.
+type Dependencies struct {
+	Tasks             domain.TaskRepository
+	Users             domain.UserRepository
+	Organizations     domain.OrganizationRepository
+	Invitations       domain.InvitationRepository
+	APIKeys           domain.APIKeyRepository
+	OIDCLogins        domain.OIDCLoginRepository
+	Webhooks          domain.WebhookRepository
+	WebhookDeliveries domain.WebhookDeliveryRepository
+	Outbox            domain.OutboxRepository
+	Transactor        domain.Transactor
+	Mailer            infrastructure.Mailer
+	HealthChecks      []domain.HealthCheck
+	// Clock and IDs stamp everything the use cases create, and Clock decides when tokens,
+	// API keys and trashed tasks expire.
+	Clock domain.Clock
+	IDs   domain.IDGenerator
+}
+
+// newMongoDependencies keeps everything in db, except tasks with TASK_STORE=memory. Every
+// repository of db shares one Resilience, so the circuit opens for all of them at once.
+func newMongoDependencies(timeout time.Duration, db mongo.Database) Dependencies {
+	clock := infrastructure.NewSystemClock()
+	resilience := newResilience(clock)
+	deps := Dependencies{
+		// Shared by every router so REST, GraphQL and gRPC see the same tasks with TASK_STORE=memory.
+		Tasks:             newTaskRepository(db, clock, resilience),
+		Users:             repositories.NewResilientUserRepository(repositories.NewUserRepository(db, "users"), resilience),
+		Organizations:     repositories.NewResilientOrganizationRepository(repositories.NewOrganizationRepository(db, "organizations"), resilience),
+		Invitations:       repositories.NewResilientInvitationRepository(repositories.NewInvitationRepository(db, "invitations"), resilience),
+		APIKeys:           repositories.NewResilientAPIKeyRepository(repositories.NewAPIKeyRepository(db, "service_accounts", "api_keys"), resilience),
+		OIDCLogins:        repositories.NewResilientOIDCLoginRepository(repositories.NewOIDCLoginRepository(db, "oidc_logins", clock), resilience),
+		Webhooks:          repositories.NewResilientWebhookRepository(repositories.NewWebhookRepository(db, "webhooks"), resilience),
+		WebhookDeliveries: repositories.NewResilientWebhookDeliveryRepository(repositories.NewWebhookDeliveryRepository(db, "webhook_deliveries", "webhook_dead_letters"), resilience),
+		Outbox:            repositories.NewResilientOutboxRepository(repositories.NewOutboxRepository(db, "outbox", clock), resilience),
+		Transactor:        repositories.NewTransactor(db),
+		Mailer:            newMailer(timeout),
+		HealthChecks:      []domain.HealthCheck{repositories.NewDatabaseHealthCheck(db, resilience)},
+		Clock:             clock,
+		IDs:               infrastructure.NewRandomIDGenerator(),
+	}
+	if os.Getenv("TASK_STORE") == "memory" {
+		// Transactions of db cannot undo the writes to the in-memory tasks.
+		deps.Transactor = repositories.NewMixedTransactor(deps.stores(), deps.Transactor)
+	}
+	return deps
+}
+
+// NewInMemoryDependencies keeps everything in process and sends email through mailer. Nothing
+// survives a restart, so it is meant for tests, which can pass a fake clock and predictable IDs.
+func NewInMemoryDependencies(clock domain.Clock, ids domain.IDGenerator, mailer infrastructure.Mailer) Dependencies {
+	deps := Dependencies{
+		Tasks:             repositories.NewInMemoryTaskRepository(clock),
+		Users:             repositories.NewInMemoryUserRepository(),
+		Organizations:     repositories.NewInMemoryOrganizationRepository(clock),
+		Invitations:       repositories.NewInMemoryInvitationRepository(),
+		APIKeys:           repositories.NewInMemoryAPIKeyRepository(),
+		OIDCLogins:        repositories.NewInMemoryOIDCLoginRepository(clock),
+		Webhooks:          repositories.NewInMemoryWebhookRepository(),
+		WebhookDeliveries: repositories.NewInMemoryWebhookDeliveryRepository(),
+		Outbox:            repositories.NewInMemoryOutboxRepository(),
+		Mailer:            mailer,
+		Clock:             clock,
+		IDs:               ids,
+	}
+	deps.Transactor = repositories.NewInMemoryTransactor(deps.stores())
+	return deps
+}
+
+// stores are the ones a unit of work writes to.
+func (deps Dependencies) stores() domain.Stores {
+	return domain.Stores{
+		Tasks:       deps.Tasks,
+		Users:       deps.Users,
+		Invitations: deps.Invitations,
+		Outbox:      deps.Outbox,
+	}
+}
+
+// taskCacheMetrics is published once as the "task_cache" expvar and shared by every Setup.
+var taskCacheMetrics = publishCacheMetrics("task_cache")
+
+func publishCacheMetrics(name string) *repositories.CacheMetrics {
+	metrics := &repositories.CacheMetrics{}
+	vars := new(expvar.Map)
+	vars.Set("hits", &metrics.Hits)
+	vars.Set("misses", &metrics.Misses)
+	vars.Set("evictions", &metrics.Evictions)
+	expvar.Publish(name, vars)
+	return metrics
+}
+
+// apiV1 prefixes the routes of the first version of the API. A /v2 group would sit next to it,
+// registered by the routers whose behaviour changes.
+const apiV1 = "/v1"
+
+// deprecated marks responses of the unversioned routes with a Deprecation header and a link to
+// the same route under successor.
+func deprecated(successor string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Deprecation", "true")
+		c.Header("Link", "<"+successor+c.Request.URL.Path+`>; rel="successor-version"`)
+		c.Next()
+	}
+}
 
-	userRouter := gin.Group("")
-	NewUserRouter(timeout, *db, userRouter)
+// Setup registers the HTTP routes on gin and returns the gRPC server, which shares the same
+// stores and event bus. The caller decides where each of them listens. The background jobs, such
+// as emptying the trash and delivering webhooks, run until ctx is done.
+func Setup(ctx context.Context, timeout time.Duration, db *mongo.Database, gin *gin.Engine) *grpc.Server {
+	return SetupWith(ctx, timeout, newMongoDependencies(timeout, *db), gin)
 }
 
-func NewTaskRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
-	tr := repositories.NewTaskRepository(db, "tasks")
+// SetupWith is Setup on the stores and services of deps.
+func SetupWith(ctx context.Context, timeout time.Duration, deps Dependencies, gin *gin.Engine) *grpc.Server {
+	// Handlers pass the gin context to use cases; this makes it expose the organization that
+	// AuthMiddleware put on the request context.
+	gin.ContextWithFallback = true
+
+	eventBus := infrastructure.NewEventBus(eventBusHistory)
+
+	// Every route is served under /v1. The same routes at the root are the ones clients used
+	// before there were versions; they keep working, marked deprecated.
+	v1 := gin.Group(apiV1)
+	unversioned := gin.Group("", deprecated(apiV1))
+
+	NewTaskRouter(ctx, timeout, deps, eventBus, v1, unversioned)
+	NewUserRouter(timeout, deps, v1, unversioned)
+	NewOrganizationRouter(timeout, deps, v1, unversioned)
+	NewInvitationRouter(timeout, deps, v1, unversioned)
+	NewServiceAccountRouter(timeout, deps, v1, unversioned)
+	if os.Getenv("OIDC_ISSUER") != "" {
+		NewOIDCRouter(timeout, deps, v1, unversioned)
+	}
+	NewWebhookRouter(ctx, timeout, deps, v1, unversioned)
+	NewGraphQLRouter(timeout, deps, eventBus, v1, unversioned)
+
+	// Probes are not part of the API and stay where orchestrators look for them.
+	healthRouter := gin.Group("")
+	NewHealthRouter(deps, healthRouter)
+
+	return NewGRPCServer(timeout, deps, eventBus)
+}
+
+// NewTaskRouter empties the trash until ctx is done.
+func NewTaskRouter(ctx context.Context, timeout time.Duration, deps Dependencies, eventBus domain.EventBus, groups ...*gin.RouterGroup) {
+	tu := usecases.NewTaskUseCase(deps.Tasks, deps.Outbox, deps.Transactor, eventBus, deps.Clock, deps.IDs, timeout)
 	tc := &controllers.TaskController{
-		TaskUseCase: usecases.NewTaskUseCase(tr, timeout),
+		TaskUseCase: tu,
+		MaxBodySize: maxBodySizeFromEnv(),
+	}
+	sc := &controllers.StreamController{
+		EventBus: eventBus,
+	}
+	hc := &controllers.HealthController{
+		Vars: map[string]expvar.Var{"task_cache": expvar.Get("task_cache")},
+	}
+
+	retention := usecases.NewTrashRetention(tu, trashRetentionFromEnv())
+	go retention.Run(ctx, trashPurgeInterval)
+
+	authMiddleware := newAuthMiddleware(timeout, deps, infrastructure.NewJWTService(deps.Clock))
+
+	for _, group := range groups {
+		group.GET("/tasks", authMiddleware.AuthMiddleware(false), tc.GetTasks)
+		group.GET("/tasks/search", authMiddleware.AuthMiddleware(false), tc.SearchTasks)
+		group.GET("/tasks/export", authMiddleware.AuthMiddleware(false), tc.ExportTasks)
+		group.GET("/tasks/trash", authMiddleware.AuthMiddleware(true), tc.GetTrash)
+		group.GET("/tasks/stream", infrastructure.TokenFromQuery(), authMiddleware.AuthMiddleware(false), sc.StreamTasks)
+		group.GET("/tasks/ws", infrastructure.TokenFromQuery(), authMiddleware.AuthMiddleware(false), sc.StreamTasksWebSocket)
+		group.GET("/tasks/:id", authMiddleware.AuthMiddleware(false), tc.GetTaskByID)
+		group.POST("/tasks", authMiddleware.AuthMiddleware(true), tc.CreateTask)
+		group.POST("/tasks/bulk", authMiddleware.AuthMiddleware(true), tc.BulkTasks)
+		group.POST("/tasks/import", authMiddleware.AuthMiddleware(true), tc.ImportTasks)
+		group.PUT("/tasks/:id", authMiddleware.AuthMiddleware(true), tc.UpdateTask)
+		group.DELETE("/tasks/:id", authMiddleware.AuthMiddleware(true), tc.DeleteTask)
+		group.POST("/tasks/:id/restore", authMiddleware.AuthMiddleware(true), tc.RestoreTask)
+		group.DELETE("/tasks/:id/purge", authMiddleware.AuthMiddleware(true), tc.PurgeTask)
+		group.GET("/reports/summary", authMiddleware.AuthMiddleware(false), tc.GetSummary)
+		group.GET("/reports/burndown", authMiddleware.AuthMiddleware(false), tc.GetBurndown)
+		// Counters such as the task cache's hits and misses.
+		group.GET("/debug/vars", authMiddleware.SessionMiddleware(true), hc.DebugVars)
+	}
+}
+
+// newTaskRepository keeps tasks in process when TASK_STORE=memory, which is handy for demos.
+// Everything else still lives in MongoDB, optionally behind a cache of TASK_CACHE_SIZE tasks.
+func newTaskRepository(db mongo.Database, clock domain.Clock, resilience *repositories.Resilience) domain.TaskRepository {
+	if os.Getenv("TASK_STORE") == "memory" {
+		return repositories.NewInMemoryTaskRepository(clock)
 	}
+	tr := repositories.NewResilientTaskRepository(repositories.NewTaskRepository(db, "tasks", clock), resilience)
+	size, ttl := taskCacheFromEnv()
+	if size == 0 {
+		return tr
+	}
+	return repositories.NewCachedTaskRepository(tr, size, ttl, clock, taskCacheMetrics)
+}
 
-	jwtService := infrastructure.NewJWTService()
+// taskCacheFromEnv reads TASK_CACHE_SIZE (0 or unset turns the cache off) and TASK_CACHE_TTL
+// (a Go duration, 30 seconds by default).
+func taskCacheFromEnv() (int, time.Duration) {
+	value := os.Getenv("TASK_CACHE_SIZE")
+	if value == "" {
+		return 0, 0
+	}
+	size, err := strconv.Atoi(value)
+	if err != nil || size < 0 {
+		log.Printf("invalid TASK_CACHE_SIZE %q, not caching tasks", value)
+		return 0, 0
+	}
 
-	authMiddleware := infrastructure.NewAuthMiddleware(jwtService)
+	return size, durationFromEnv("TASK_CACHE_TTL", taskCacheTTL)
+}
 
-	group.GET("/tasks", authMiddleware.AuthMiddleware(false), tc.GetTasks)
-	group.GET("/tasks/:id", authMiddleware.AuthMiddleware(false), tc.GetTaskByID)
-	group.POST("/tasks", authMiddleware.AuthMiddleware(true), tc.CreateTask)
-	group.PUT("/tasks/:id", authMiddleware.AuthMiddleware(true), tc.UpdateTask)
-	group.DELETE("/tasks/:id", authMiddleware.AuthMiddleware(true), tc.DeleteTask)
+// trashRetentionFromEnv reads TRASH_RETENTION (a Go duration such as "720h") and falls back to
+// 30 days when it is unset or invalid.
+func trashRetentionFromEnv() time.Duration {
+	return durationFromEnv("TRASH_RETENTION", trashRetention)
 }
 
-func NewUserRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
-	tr := repositories.NewUserRepository(db, "users")
-	jwtService := infrastructure.NewJWTService()
-	passwordService := infrastructure.NewPasswordService()
+// maxBodySizeFromEnv reads MAX_BODY_SIZE, the largest JSON body in bytes that the task, user and
+// GraphQL routes accept, and falls back to 1 MiB.
+func maxBodySizeFromEnv() int64 {
+	return int64(intFromEnv("MAX_BODY_SIZE", controllers.DefaultMaxBodySize))
+}
+
+// durationFromEnv parses the Go duration in the variable name, falling back when it is unset
+// or not positive.
+func durationFromEnv(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("invalid %s %q, using %s", name, value, fallback)
+		return fallback
+	}
+	return duration
+}
+
+// intFromEnv is durationFromEnv for positive integers.
+func intFromEnv(name string, fallback int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		log.Printf("invalid %s %q, using %d", name, value, fallback)
+		return fallback
+	}
+	return n
+}
+
+func NewUserRouter(timeout time.Duration, deps Dependencies, groups ...*gin.RouterGroup) {
+	jwtService := infrastructure.NewJWTService(deps.Clock)
 
 	tc := &controllers.UserController{
-		UserUseCase: usecases.NewUserUseCase(tr, passwordService, jwtService, timeout),
+		UserUseCase: newUserUseCase(timeout, deps, jwtService),
+		MaxBodySize: maxBodySizeFromEnv(),
+	}
+	mc := &controllers.MFAController{
+		MFAUseCase: newMFAUseCase(timeout, deps, jwtService),
 	}
 
-	authMiddleware := infrastructure.NewAuthMiddleware(jwtService)
+	authMiddleware := newAuthMiddleware(timeout, deps, jwtService)
+
+	for _, group := range groups {
+		group.POST("/register", tc.Register)
+		group.POST("/login", tc.Login)
+		group.GET("/verify-email", tc.VerifyEmail)
+		group.POST("/verify-email/resend", tc.ResendVerification)
+		group.POST("/login/mfa", mc.VerifyLogin)
+		group.POST("/promote/:username", authMiddleware.SessionMiddleware(true), tc.PromoteUser)
+		group.POST("/mfa/enroll", authMiddleware.SessionMiddleware(false), mc.Enroll)
+		group.POST("/mfa/enroll/confirm", authMiddleware.SessionMiddleware(false), mc.ConfirmEnrollment)
+		group.POST("/mfa/disable", authMiddleware.SessionMiddleware(false), mc.Disable)
+	}
+}
+
+func newUserUseCase(timeout time.Duration, deps Dependencies, jwtService infrastructure.JWTService) domain.UserUseCase {
+	unitOfWork := repositories.NewUnitOfWork(deps.Transactor, deps.stores())
+	return usecases.NewUserUseCase(deps.Users, infrastructure.NewPasswordService(), jwtService, deps.Mailer, unitOfWork, usecases.UserOptions{
+		PasswordLogin:   passwordLoginFromEnv(),
+		RequireAdminMFA: adminMFAFromEnv(),
+		InviteOnly:      inviteOnlyFromEnv(),
+		PublicURL:       publicURL(),
+	}, deps.Clock, deps.IDs, timeout)
+}
 
-	group.POST("/register", tc.Register)
-	group.POST("/login", tc.Login)
-	group.POST("/promote/:username", authMiddleware.AuthMiddleware(true), tc.PromoteUser)
+// newMailer sends through SMTP_ADDR. Without it emails are only logged, which is enough to
+// follow verification links in development.
+func newMailer(timeout time.Duration) infrastructure.Mailer {
+	if os.Getenv("SMTP_ADDR") == "" {
+		return infrastructure.NewLogMailer()
+	}
+	return infrastructure.NewSMTPMailer(infrastructure.SMTPConfig{
+		Addr:     os.Getenv("SMTP_ADDR"),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     envOr("SMTP_FROM", "no-reply@localhost"),
+	}, timeout)
+}
+
+// publicURL is where users reach the API. Links in emails point at the /v1 routes.
+func publicURL() string {
+	return envOr("PUBLIC_URL", "http://localhost:8080") + apiV1
+}
+
+// inviteOnlyFromEnv is true when REGISTRATION=invite. Registering then needs an invitation.
+func inviteOnlyFromEnv() bool {
+	return os.Getenv("REGISTRATION") == "invite"
+}
+
+// passwordLoginFromEnv is false when PASSWORD_LOGIN=false, for deployments that only use SSO.
+func passwordLoginFromEnv() bool {
+	return os.Getenv("PASSWORD_LOGIN") != "false"
+}
+
+// adminMFAFromEnv is true when MFA_REQUIRED_FOR_ADMINS=true. Admins then need two-factor
+// authentication to get a token with their Admin role.
+func adminMFAFromEnv() bool {
+	return os.Getenv("MFA_REQUIRED_FOR_ADMINS") == "true"
+}
+
+func newMFAUseCase(timeout time.Duration, deps Dependencies, jwtService infrastructure.JWTService) domain.MFAUseCase {
+	return usecases.NewMFAUseCase(deps.Users, jwtService, infrastructure.NewTOTPService(), envOr("MFA_ISSUER", "Task Manager"), deps.Clock, timeout)
+}
+
+// NewOIDCRouter serves the single sign-on login. It is only set up when OIDC_ISSUER is set.
+func NewOIDCRouter(timeout time.Duration, deps Dependencies, groups ...*gin.RouterGroup) {
+	provider := infrastructure.NewOIDCProvider(infrastructure.OIDCConfig{
+		Issuer:       os.Getenv("OIDC_ISSUER"),
+		ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+		ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+		RedirectURL:  os.Getenv("OIDC_REDIRECT_URL"),
+		Scopes:       strings.Fields(envOr("OIDC_SCOPES", "openid profile email")),
+	}, timeout)
+	claims := domain.OIDCClaimMapping{
+		UsernameClaim: envOr("OIDC_USERNAME_CLAIM", "preferred_username"),
+		RoleClaim:     os.Getenv("OIDC_ROLE_CLAIM"),
+		AdminValue:    os.Getenv("OIDC_ADMIN_VALUE"),
+	}
+
+	oc := &controllers.OIDCController{
+		OIDCUseCase: usecases.NewOIDCUseCase(provider, deps.OIDCLogins, deps.Users, infrastructure.NewJWTService(deps.Clock), deps.Outbox, deps.Transactor, claims, adminMFAFromEnv(), deps.Clock, deps.IDs, timeout),
+	}
+
+	for _, group := range groups {
+		group.GET("/auth/oidc/login", oc.Login)
+		group.GET("/auth/oidc/callback", oc.Callback)
+	}
+}
+
+func envOr(name string, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func NewOrganizationRouter(timeout time.Duration, deps Dependencies, groups ...*gin.RouterGroup) {
+	oc := &controllers.OrganizationController{
+		OrganizationUseCase: usecases.NewOrganizationUseCase(deps.Organizations, deps.Users, deps.Transactor, deps.Clock, deps.IDs, timeout),
+	}
+
+	authMiddleware := newAuthMiddleware(timeout, deps, infrastructure.NewJWTService(deps.Clock))
+
+	for _, group := range groups {
+		group.POST("/orgs", authMiddleware.SessionMiddleware(false), oc.CreateOrganization)
+		group.GET("/orgs", authMiddleware.SessionMiddleware(false), oc.GetOrganizations)
+		group.POST("/orgs/:id/members", authMiddleware.SessionMiddleware(true), oc.AddMember)
+	}
+}
+
+// NewInvitationRouter lets the Admins of an organization invite people into it.
+func NewInvitationRouter(timeout time.Duration, deps Dependencies, groups ...*gin.RouterGroup) {
+	ic := &controllers.InvitationController{
+		InvitationUseCase: usecases.NewInvitationUseCase(deps.Invitations, deps.Mailer, publicURL(), deps.Clock, deps.IDs, timeout),
+	}
+
+	authMiddleware := newAuthMiddleware(timeout, deps, infrastructure.NewJWTService(deps.Clock))
+
+	for _, group := range groups {
+		group.POST("/invitations", authMiddleware.SessionMiddleware(true), ic.CreateInvitation)
+		group.GET("/invitations", authMiddleware.SessionMiddleware(true), ic.GetInvitations)
+		group.DELETE("/invitations/:id", authMiddleware.SessionMiddleware(true), ic.RevokeInvitation)
+	}
+}
+
+// NewServiceAccountRouter lets the Admins of an organization manage its service accounts and
+// their API keys.
+func NewServiceAccountRouter(timeout time.Duration, deps Dependencies, groups ...*gin.RouterGroup) {
+	ac := &controllers.APIKeyController{
+		APIKeyUseCase: usecases.NewAPIKeyUseCase(deps.APIKeys, deps.Clock, deps.IDs, timeout),
+	}
+
+	authMiddleware := newAuthMiddleware(timeout, deps, infrastructure.NewJWTService(deps.Clock))
+
+	for _, group := range groups {
+		group.POST("/service-accounts", authMiddleware.SessionMiddleware(true), ac.CreateServiceAccount)
+		group.GET("/service-accounts", authMiddleware.SessionMiddleware(true), ac.GetServiceAccounts)
+		group.POST("/service-accounts/:id/keys", authMiddleware.SessionMiddleware(true), ac.CreateAPIKey)
+		group.GET("/service-accounts/:id/keys", authMiddleware.SessionMiddleware(true), ac.GetAPIKeys)
+		group.DELETE("/service-accounts/:id/keys/:keyId", authMiddleware.SessionMiddleware(true), ac.RevokeAPIKey)
+	}
+}
+
+// newAuthMiddleware accepts both JWTs and the API keys of service accounts.
+func newAuthMiddleware(timeout time.Duration, deps Dependencies, jwtService infrastructure.JWTService) *infrastructure.AuthMiddleware {
+	return infrastructure.NewAuthMiddleware(jwtService, usecases.NewAPIKeyUseCase(deps.APIKeys, deps.Clock, deps.IDs, timeout), deps.Clock)
+}
+
+// NewGraphQLRouter serves /graphql. Anonymous callers may only register and log in; every
+// resolver applies the same role checks as the matching REST route.
+func NewGraphQLRouter(timeout time.Duration, deps Dependencies, eventBus domain.EventBus, groups ...*gin.RouterGroup) {
+	jwtService := infrastructure.NewJWTService(deps.Clock)
+	tu := usecases.NewTaskUseCase(deps.Tasks, deps.Outbox, deps.Transactor, eventBus, deps.Clock, deps.IDs, timeout)
+	uu := newUserUseCase(timeout, deps, jwtService)
+
+	gc, err := controllers.NewGraphQLController(tu, uu, newMFAUseCase(timeout, deps, jwtService), eventBus)
+	if err != nil {
+		log.Fatalf("building GraphQL schema: %v", err)
+	}
+	gc.MaxBodySize = maxBodySizeFromEnv()
+
+	authMiddleware := newAuthMiddleware(timeout, deps, jwtService)
+
+	for _, group := range groups {
+		group.GET("/graphql", infrastructure.TokenFromQuery(), authMiddleware.OptionalAuth(), gc.ServeGraphQL)
+		group.POST("/graphql", authMiddleware.OptionalAuth(), gc.ServeGraphQL)
+	}
+}
+
+func NewGRPCServer(timeout time.Duration, deps Dependencies, eventBus domain.EventBus) *grpc.Server {
+	jwtService := infrastructure.NewJWTService(deps.Clock)
+	tu := usecases.NewTaskUseCase(deps.Tasks, deps.Outbox, deps.Transactor, eventBus, deps.Clock, deps.IDs, timeout)
+	uu := newUserUseCase(timeout, deps, jwtService)
+
+	return grpcserver.NewServer(tu, uu, newMFAUseCase(timeout, deps, jwtService), jwtService, deps.Clock)
+}
+
+// NewHealthRouter serves /readyz for load balancers and orchestrators.
+func NewHealthRouter(deps Dependencies, group *gin.RouterGroup) {
+	hc := &controllers.HealthController{
+		Checks: deps.HealthChecks,
+	}
+
+	group.GET("/readyz", hc.Ready)
+}
+
+// NewWebhookRouter delivers webhooks until ctx is done.
+func NewWebhookRouter(ctx context.Context, timeout time.Duration, deps Dependencies, groups ...*gin.RouterGroup) {
+	wc := &controllers.WebhookController{
+		WebhookUseCase: usecases.NewWebhookUseCase(deps.Webhooks, deps.WebhookDeliveries, deps.Clock, deps.IDs, timeout),
+	}
+
+	dispatcher := usecases.NewWebhookDispatcher(deps.Outbox, deps.Webhooks, deps.WebhookDeliveries, infrastructure.NewWebhookSender(timeout), deps.Clock, deps.IDs, webhookMaxAttempts, webhookBaseBackoff)
+	go dispatcher.Run(ctx, webhookPollInterval)
+
+	authMiddleware := newAuthMiddleware(timeout, deps, infrastructure.NewJWTService(deps.Clock))
+
+	for _, group := range groups {
+		group.POST("/webhooks", authMiddleware.SessionMiddleware(true), wc.CreateWebhook)
+		group.GET("/webhooks", authMiddleware.SessionMiddleware(true), wc.GetWebhooks)
+		group.GET("/webhooks/dead-letters", authMiddleware.SessionMiddleware(true), wc.GetDeadLetters)
+		group.DELETE("/webhooks/:id", authMiddleware.SessionMiddleware(true), wc.DeleteWebhook)
+		group.GET("/webhooks/:id/deliveries", authMiddleware.SessionMiddleware(true), wc.GetDeliveries)
+	}
 }
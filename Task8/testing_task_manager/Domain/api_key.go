@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// APIKeyPrefix starts every API key, which lets AuthMiddleware tell keys from JWTs in a bearer
+// header.
+const APIKeyPrefix = "tmk_"
+
+const (
+	// ScopeRead allows the routes open to the User role.
+	ScopeRead = "read"
+	// ScopeWrite also allows the Admin-only task routes of the key's organization. Users,
+	// organizations, API keys and webhooks are only administered by logged-in Admins.
+	ScopeWrite = "write"
+)
+
+// APIKeyScopes lists the scopes a key can be issued with.
+var APIKeyScopes = []string{ScopeRead, ScopeWrite}
+
+// ServiceAccount is a non-human member of one organization, such as a CI bot. It cannot log in
+// and authenticates with API keys instead.
+type ServiceAccount struct {
+	ID        string    `json:"id" bson:"id"`
+	Name      string    `json:"name" bson:"name" binding:"required"`
+	OrgID     string    `json:"org_id" bson:"org_id"`
+	CreatedBy string    `json:"created_by" bson:"created_by"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// APIKey describes an issued key. Only a hash of the key is stored; the key itself is returned
+// once, when it is created.
+type APIKey struct {
+	ID               string     `json:"id" bson:"id"`
+	ServiceAccountID string     `json:"service_account_id" bson:"service_account_id"`
+	OrgID            string     `json:"org_id" bson:"org_id"`
+	Hash             string     `json:"-" bson:"hash"`
+	Scopes           []string   `json:"scopes" bson:"scopes"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty" bson:"expires_at,omitempty"`
+	LastUsedAt       *time.Time `json:"last_used_at,omitempty" bson:"last_used_at,omitempty"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty" bson:"revoked_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at" bson:"created_at"`
+}
+
+// Role is the role the key acts with: Admin with the write scope, User otherwise.
+func (k APIKey) Role() string {
+	for _, scope := range k.Scopes {
+		if scope == ScopeWrite {
+			return "Admin"
+		}
+	}
+	return "User"
+}
+
+type APIKeyUseCase interface {
+	CreateServiceAccount(c context.Context, name string, createdBy string) (*ServiceAccount, error)
+	GetServiceAccounts(c context.Context) ([]ServiceAccount, error)
+	// CreateAPIKey also returns the key itself, which cannot be recovered later.
+	CreateAPIKey(c context.Context, serviceAccountID string, scopes []string, expiresAt *time.Time) (*APIKey, string, error)
+	GetAPIKeys(c context.Context, serviceAccountID string) ([]APIKey, error)
+	RevokeAPIKey(c context.Context, serviceAccountID string, keyID string) error
+	// Authenticate returns the key's record if key is valid, unexpired and not revoked, and
+	// records that it was used.
+	Authenticate(c context.Context, key string) (*APIKey, error)
+}
+
+// APIKeyRepository scopes every method to the organization of c, except FindAPIKey and
+// TouchAPIKey, which authentication calls before the organization is known.
+type APIKeyRepository interface {
+	CreateServiceAccount(c context.Context, account ServiceAccount) error
+	GetServiceAccounts(c context.Context) ([]ServiceAccount, error)
+	GetServiceAccountByID(c context.Context, accountID string) (*ServiceAccount, error)
+	CreateAPIKey(c context.Context, key APIKey) error
+	GetAPIKeys(c context.Context, serviceAccountID string) ([]APIKey, error)
+	RevokeAPIKey(c context.Context, serviceAccountID string, keyID string, at time.Time) error
+	FindAPIKey(c context.Context, keyID string) (*APIKey, error)
+	TouchAPIKey(c context.Context, keyID string, at time.Time) error
+}
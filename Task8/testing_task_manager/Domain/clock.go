@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// Clock tells the time. Code that stamps or compares times asks a Clock instead of calling
+// time.Now, so tests can move time forward instead of sleeping.
+type Clock interface {
+	Now() time.Time
+}
+
+// IDGenerator makes the IDs of new records.
+type IDGenerator interface {
+	NewID() string
+}
@@ -9,14 +9,113 @@ type Task struct {
 	ID          string    `json:"id"`
 	Title       string    `json:"title"`
 	Description string    `json:"description"`
-	DueDate     time.Time `json:"due_date"`
+	DueDate     time.Time `json:"due_date" bson:"due_date"`
 	Status      string    `json:"status"`
+	// Assignee is the username of whoever works on the task.
+	Assignee string `json:"assignee,omitempty" bson:"assignee,omitempty"`
+	// CreatedAt and CompletedAt are set by the repository: when the task was created, and when
+	// its status last became one of CompletedStatuses. Reports are built from them.
+	CreatedAt   *time.Time `json:"created_at,omitempty" bson:"created_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" bson:"completed_at,omitempty"`
+	// OrgID is set by the repository from the organization of the request.
+	OrgID string `json:"org_id,omitempty" bson:"org_id"`
+	// DeletedAt is set while the task is in the trash.
+	DeletedAt *time.Time `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
+}
+
+const (
+	BulkCreate = "create"
+	BulkUpdate = "update"
+	BulkDelete = "delete"
+)
+
+const (
+	BulkStatusOK         = "ok"
+	BulkStatusFailed     = "failed"
+	BulkStatusRolledBack = "rolled_back"
+)
+
+// BulkOperation is one item of a bulk request. Creates carry the full task, updates carry the
+// task ID and the fields to change, deletes only need the ID.
+type BulkOperation struct {
+	Op   string `json:"op" binding:"required"`
+	ID   string `json:"id"`
+	Task Task   `json:"task"`
+}
+
+type BulkResult struct {
+	Index  int    `json:"index"`
+	Op     string `json:"op"`
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Task   *Task  `json:"task,omitempty"`
+}
+
+// ImportRow is one record parsed from an import file. Row is 1-based and counts records, not
+// lines. Error is set when the record could not be parsed.
+type ImportRow struct {
+	Row   int
+	Task  Task
+	Error string
+}
+
+type ImportError struct {
+	Row   int    `json:"row"`
+	ID    string `json:"id,omitempty"`
+	Error string `json:"error"`
+}
+
+type ImportReport struct {
+	DryRun   bool          `json:"dry_run"`
+	Total    int           `json:"total"`
+	Valid    int           `json:"valid"`
+	Imported int           `json:"imported"`
+	Failed   int           `json:"failed"`
+	Errors   []ImportError `json:"errors"`
+}
+
+// SearchHit is a task matching a search. Highlights maps "title" and "description" to a
+// snippet with the matching words wrapped in <mark> tags; fields without a match are left out.
+type SearchHit struct {
+	Task       Task              `json:"task"`
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights,omitempty"`
+}
+
+type SearchResult struct {
+	Query string      `json:"query"`
+	Page  int         `json:"page"`
+	Limit int         `json:"limit"`
+	Total int         `json:"total"`
+	Hits  []SearchHit `json:"hits"`
 }
 
 type User struct {
 	Username string `json:"username" binding:"required"`
 	Password string `json:"password" binding:"required"`
-	Role     string `json:"role"` // "Admin" || "User"
+	Role     string `json:"role" bson:"-"` // "Admin" || "User" in the organization of the request
+	Email    string `json:"email,omitempty" bson:"email,omitempty"`
+	// EmailVerified is set once the user followed the link mailed to Email. Password logins are
+	// refused until then.
+	EmailVerified bool `json:"email_verified" bson:"email_verified"`
+	// Orgs is where the role of each organization is stored.
+	Orgs []Membership `json:"orgs,omitempty" bson:"orgs"`
+	// OIDCSubject links the user to an identity provider account after their first SSO login.
+	OIDCSubject string `json:"-" bson:"oidc_subject,omitempty"`
+	MFA         *MFA   `json:"-" bson:"mfa,omitempty"`
+	// InviteCode is only read on registration.
+	InviteCode string `json:"invite_code,omitempty" bson:"-"`
+}
+
+// OrgRole returns the user's role in orgID, or false when the user is not a member.
+func (u User) OrgRole(orgID string) (string, bool) {
+	for _, membership := range u.Orgs {
+		if membership.OrgID == orgID {
+			return membership.Role, true
+		}
+	}
+	return "", false
 }
 
 type TaskUseCase interface {
@@ -25,26 +124,84 @@ type TaskUseCase interface {
 	CreateTask(c context.Context, newTask Task) (*Task,error)
 	UpdateTask(c context.Context, taskID string, updatedTask Task) (*Task, error)
 	DeleteTask(c context.Context, taskID string) error
+	BulkWrite(c context.Context, operations []BulkOperation, atomic bool) ([]BulkResult, error)
+	ExportTasks(c context.Context, fn func(task Task) error) error
+	ImportTasks(c context.Context, rows []ImportRow, dryRun bool) (*ImportReport, error)
+	SearchTasks(c context.Context, query string, page int, limit int) (*SearchResult, error)
+	GetTrash(c context.Context) ([]Task, error)
+	RestoreTask(c context.Context, taskID string) (*Task, error)
+	PurgeTask(c context.Context, taskID string) error
+	PurgeExpiredTasks(c context.Context, retention time.Duration) (int, error)
+	// GetSummary reports where the tasks stand now, with the completion trend of the days from
+	// from to to. Zero dates stand for the last 30 days.
+	GetSummary(c context.Context, from time.Time, to time.Time) (*TaskSummary, error)
+	// GetBurndown reports the open tasks at the end of each day from from to to.
+	GetBurndown(c context.Context, from time.Time, to time.Time) (*Burndown, error)
 }
 
+// TaskRepository scopes every method to the organization of c and fails without one. Only
+// PurgeDeletedBefore also accepts a domain.WithAllOrgs context.
 type TaskRepository interface {
 	GetTasks(c context.Context) ([]Task, error)
 	GetTaskByID(c context.Context, taskID string) (*Task, error)
 	CreateTask(c context.Context, newTask Task) (*Task,error)
 	UpdateTask(c context.Context, taskID string, updatedTask Task) (*Task, error)
 	DeleteTask(c context.Context, taskID string) error
+	// BulkWrite applies operations in one round-trip and reports a result per operation.
+	// Operations that fail do not stop the others.
+	BulkWrite(c context.Context, operations []BulkOperation) ([]BulkResult, error)
+	GetTasksByIDs(c context.Context, taskIDs []string) ([]Task, error)
+	// ForEachTask calls fn for every task while reading them from a cursor, so callers can
+	// stream the collection without loading it. Iteration stops at the first error fn returns.
+	ForEachTask(c context.Context, fn func(task Task) error) error
+	// SearchTasks returns one page of tasks matching query, best match first, and the total
+	// number of matches.
+	SearchTasks(c context.Context, query string, offset int, limit int) ([]SearchHit, int, error)
+	GetDeletedTasks(c context.Context) ([]Task, error)
+	RestoreTask(c context.Context, taskID string) (*Task, error)
+	PurgeTask(c context.Context, taskID string) error
+	// PurgeDeletedBefore hard-deletes tasks trashed before cutoff and returns them.
+	PurgeDeletedBefore(c context.Context, cutoff time.Time) ([]Task, error)
+	// TaskStats counts the tasks that are not in the trash; tasks due before now are overdue.
+	TaskStats(c context.Context, now time.Time) (*TaskStats, error)
+	// TaskActivity counts the tasks created and completed per day, for the days before until.
+	// Tasks in the trash are left out.
+	TaskActivity(c context.Context, until time.Time) ([]DailyActivity, error)
 }
 
 type UserUseCase interface {
 	GetUsers(c context.Context) ([]User, error)
+	// CreateUser registers a User of the default organization, or joins the organization of
+	// user.InviteCode with the role it grants.
 	CreateUser(c context.Context, user User) error
-	Login(c context.Context, user User) (string, error)
+	// CreateAdmin makes user an Admin of the default organization, creating them if needed.
+	// It is for bootstrapping from the command line and skips the registration rules.
+	CreateAdmin(c context.Context, user User) error
+	Login(c context.Context, user User) (*LoginResult, error)
 	PromoteUser(c context.Context, username string) (*User, error)
+	// VerifyEmail marks the address in a token from a verification email as verified.
+	VerifyEmail(c context.Context, token string) error
+	// ResendVerification mails a new link to email if it belongs to an unverified user. It
+	// succeeds either way, so it does not reveal which addresses are registered.
+	ResendVerification(c context.Context, email string) error
 }
 
+// UserRepository scopes GetUsers, PromoteUser, SetRole and AddMembership to the organization
+// of c. Usernames and identity provider subjects are global, so the other methods are not.
 type UserRepository interface {
 	GetUsers(c context.Context) ([]User, error)
 	CreateUser(c context.Context, user User) error
 	FindByUsername(c context.Context, username string) (*User, error)
+	FindByEmail(c context.Context, email string) (*User, error)
+	// MarkEmailVerified verifies email only if it is still the user's address.
+	MarkEmailVerified(c context.Context, username string, email string) error
+	FindByOIDCSubject(c context.Context, subject string) (*User, error)
+	LinkOIDCSubject(c context.Context, username string, subject string) error
 	PromoteUser(c context.Context, username string) (*User, error)
+	SetRole(c context.Context, username string, role string) error
+	AddMembership(c context.Context, username string, role string) error
+	// UpdateMFA replaces the user's MFA settings with updated, or removes them when it is nil,
+	// only if they still equal current. It fails with "mfa settings changed" otherwise, so a
+	// code cannot be used twice by concurrent logins.
+	UpdateMFA(c context.Context, username string, current *MFA, updated *MFA) error
 }
\ No newline at end of file
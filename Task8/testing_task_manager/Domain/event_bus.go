@@ -0,0 +1,15 @@
+package domain
+
+// StreamEvent is an Event as seen by stream subscribers. Sequence increases by one per published
+// event and is what clients send back as Last-Event-ID to resume after a reconnect.
+type StreamEvent struct {
+	Sequence uint64 `json:"sequence"`
+	Event
+}
+
+type EventBus interface {
+	Publish(event Event)
+	// Subscribe returns the buffered events published after lastSequence followed by a channel of
+	// new ones. The channel is closed when cancel is called or the subscriber falls too far behind.
+	Subscribe(lastSequence uint64) (replay []StreamEvent, events <-chan StreamEvent, cancel func())
+}
@@ -0,0 +1,10 @@
+package domain
+
+import "context"
+
+// HealthCheck tells whether a dependency can serve requests, for the readiness endpoint.
+type HealthCheck interface {
+	// Name identifies the dependency in the readiness report.
+	Name() string
+	Check(c context.Context) error
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Invitation lets one person register into an organization with a role chosen by an Admin of
+// it. Only a hash of the code is stored; the code itself is returned once, when it is created.
+type Invitation struct {
+	ID    string `json:"id" bson:"id"`
+	OrgID string `json:"org_id" bson:"org_id"`
+	Role  string `json:"role" bson:"role"`
+	// Email, when set, is where the code was sent and the only address that can redeem it.
+	Email     string     `json:"email,omitempty" bson:"email,omitempty"`
+	Hash      string     `json:"-" bson:"hash"`
+	CreatedBy string     `json:"created_by" bson:"created_by"`
+	CreatedAt time.Time  `json:"created_at" bson:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at" bson:"expires_at"`
+	UsedBy    string     `json:"used_by,omitempty" bson:"used_by,omitempty"`
+	UsedAt    *time.Time `json:"used_at,omitempty" bson:"used_at,omitempty"`
+}
+
+// InvitationUseCase acts in the organization of the request context.
+type InvitationUseCase interface {
+	// CreateInvitation returns the invitation and its code, and mails the code when email is
+	// set. A zero ttl uses the default.
+	CreateInvitation(c context.Context, role string, email string, ttl time.Duration, createdBy string) (*Invitation, string, error)
+	GetInvitations(c context.Context) ([]Invitation, error)
+	RevokeInvitation(c context.Context, invitationID string) error
+}
+
+// InvitationRepository scopes every method except RedeemInvitation to the organization of c.
+type InvitationRepository interface {
+	CreateInvitation(c context.Context, invitation Invitation) error
+	GetInvitations(c context.Context) ([]Invitation, error)
+	DeleteInvitation(c context.Context, invitationID string) error
+	// RedeemInvitation marks the unused, unexpired invitation with hash as used by username and
+	// returns it. Of two concurrent registrations with the same code, only one succeeds.
+	RedeemInvitation(c context.Context, hash string, username string, at time.Time) (*Invitation, error)
+	// ReleaseInvitation makes the invitation with hash unused again if username redeemed it,
+	// for registrations that failed after redeeming without a transaction to roll it back.
+	ReleaseInvitation(c context.Context, hash string, username string) error
+}
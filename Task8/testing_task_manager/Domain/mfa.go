@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// MFA is a user's TOTP enrollment. It is pending until the first code is confirmed.
+type MFA struct {
+	Secret  string `bson:"secret"`
+	Enabled bool   `bson:"enabled"`
+	// RecoveryCodes holds the SHA-256 hashes of the unused recovery codes.
+	RecoveryCodes []string `bson:"recovery_codes"`
+	// LastUsedStep is the time step of the last accepted code, so a code works only once.
+	LastUsedStep   int64      `bson:"last_used_step"`
+	FailedAttempts int        `bson:"failed_attempts"`
+	LockedUntil    *time.Time `bson:"locked_until,omitempty"`
+}
+
+// LoginResult is the outcome of a password login. Users with two-factor authentication get an
+// MFAToken instead of a Token, to exchange for one with VerifyLogin.
+type LoginResult struct {
+	Token    string `json:"token,omitempty"`
+	MFAToken string `json:"mfa_token,omitempty"`
+	// MFAEnrollmentRequired is set when Admins must use two-factor authentication and the user
+	// has not enrolled. Their token only carries the User role until they do.
+	MFAEnrollmentRequired bool `json:"mfa_enrollment_required,omitempty"`
+}
+
+type MFAEnrollment struct {
+	Secret string `json:"secret"`
+	// ProvisioningURI is the otpauth:// URI authenticator apps read from a QR code.
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type MFAUseCase interface {
+	// Enroll starts an enrollment with a new secret, replacing one that was not confirmed.
+	Enroll(c context.Context, username string) (*MFAEnrollment, error)
+	// ConfirmEnrollment enables two-factor authentication once code matches the secret and
+	// returns the recovery codes. They are not stored and cannot be shown again.
+	ConfirmEnrollment(c context.Context, username string, code string) ([]string, error)
+	Disable(c context.Context, username string, code string) error
+	// VerifyLogin finishes a login that returned an MFA token. code is a TOTP code or a
+	// recovery code.
+	VerifyLogin(c context.Context, mfaToken string, code string) (string, error)
+}
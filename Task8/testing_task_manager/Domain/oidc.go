@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// OIDCLogin is a login started at the identity provider and not yet completed. It is looked up
+// by State when the provider redirects back, and can be used once.
+type OIDCLogin struct {
+	State        string    `bson:"state"`
+	Nonce        string    `bson:"nonce"`
+	CodeVerifier string    `bson:"code_verifier"`
+	ExpiresAt    time.Time `bson:"expires_at"`
+}
+
+// OIDCClaimMapping says which ID token claims identify a user and decide their role.
+type OIDCClaimMapping struct {
+	// UsernameClaim names the local user, for example "preferred_username" or "email".
+	UsernameClaim string
+	// RoleClaim holds a string or a list of strings, such as "groups". Users whose claim contains
+	// AdminValue are Admins of the default organization, everyone else is a User. Roles are left
+	// alone when RoleClaim is empty.
+	RoleClaim  string
+	AdminValue string
+}
+
+type OIDCUseCase interface {
+	// BeginLogin returns the identity provider URL to send the browser to and the state that
+	// the callback must bring back.
+	BeginLogin(c context.Context) (authURL string, state string, err error)
+	// CompleteLogin exchanges the authorization code, provisions or updates the local user and
+	// logs them in like UserUseCase.Login, two-factor authentication included.
+	CompleteLogin(c context.Context, state string, code string) (*LoginResult, error)
+}
+
+type OIDCLoginRepository interface {
+	SaveLogin(c context.Context, login OIDCLogin) error
+	// TakeLogin returns and deletes the login, so a state cannot be replayed.
+	TakeLogin(c context.Context, state string) (*OIDCLogin, error)
+}
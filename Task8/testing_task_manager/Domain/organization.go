@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultOrgID is the organization that data from before organizations existed was moved into.
+// Tokens issued without organization claims act in it.
+const DefaultOrgID = "default"
+
+type Organization struct {
+	ID        string    `json:"id" bson:"id"`
+	Name      string    `json:"name" bson:"name" binding:"required"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// Membership gives a user a role ("Admin" or "User") in one organization.
+type Membership struct {
+	OrgID string `json:"org_id" bson:"org_id"`
+	Role  string `json:"role" bson:"role"`
+}
+
+type orgKey struct{}
+
+// allOrgs is the scope of background jobs, such as trash retention and webhook delivery, that
+// work across organizations. Request handlers never use it.
+const allOrgs = "*"
+
+// WithOrg scopes ctx to one organization. Repositories of tenant-owned data read it on every
+// query, so a use case cannot reach another organization's data by forgetting a filter.
+func WithOrg(ctx context.Context, orgID string) context.Context {
+	return context.WithValue(ctx, orgKey{}, orgID)
+}
+
+// WithAllOrgs lifts the organization filter for system jobs.
+func WithAllOrgs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, orgKey{}, allOrgs)
+}
+
+// OrgFromContext returns the organization ctx is scoped to. It is false for unscoped and
+// all-organization contexts.
+func OrgFromContext(ctx context.Context) (string, bool) {
+	orgID, _ := ctx.Value(orgKey{}).(string)
+	return orgID, orgID != "" && orgID != allOrgs
+}
+
+func IsAllOrgs(ctx context.Context) bool {
+	orgID, _ := ctx.Value(orgKey{}).(string)
+	return orgID == allOrgs
+}
+
+type OrganizationUseCase interface {
+	// CreateOrganization creates an organization with username as its first Admin.
+	CreateOrganization(c context.Context, name string, username string) (*Organization, error)
+	// GetOrganizations lists the organizations username belongs to.
+	GetOrganizations(c context.Context, username string) ([]Organization, error)
+	// AddMember adds an existing user to the organization of c.
+	AddMember(c context.Context, username string, role string) error
+}
+
+type OrganizationRepository interface {
+	CreateOrganization(c context.Context, org Organization) error
+	GetOrganizationsByIDs(c context.Context, orgIDs []string) ([]Organization, error)
+}
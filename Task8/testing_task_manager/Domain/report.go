@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// CompletedStatuses are the task statuses, compared without case and surrounding spaces, that
+// mark a task as done. Every other status counts as open.
+var CompletedStatuses = []string{"completed", "complete", "done"}
+
+// IsCompleted reports whether status is one of CompletedStatuses.
+func IsCompleted(status string) bool {
+	status = strings.ToLower(strings.TrimSpace(status))
+	for _, completed := range CompletedStatuses {
+		if status == completed {
+			return true
+		}
+	}
+	return false
+}
+
+// ReportDateLayout is how the days of reports are written, in UTC.
+const ReportDateLayout = "2006-01-02"
+
+// TaskCounts is how many tasks of a report are open, completed and overdue. Overdue tasks are
+// open tasks whose due date has passed; tasks without a due date are never overdue.
+type TaskCounts struct {
+	Total     int `json:"total"`
+	Open      int `json:"open"`
+	Completed int `json:"completed"`
+	Overdue   int `json:"overdue"`
+}
+
+// Count adds one task to c.
+func (c *TaskCounts) Count(completed bool, overdue bool) {
+	c.Total++
+	if completed {
+		c.Completed++
+	} else {
+		c.Open++
+	}
+	if overdue {
+		c.Overdue++
+	}
+}
+
+// AssigneeCounts are the TaskCounts of one assignee. Assignee is empty for unassigned tasks.
+type AssigneeCounts struct {
+	Assignee string `json:"assignee"`
+	TaskCounts
+}
+
+// TaskStats is what the repository aggregates for a summary: the counts of the tasks that are
+// not in the trash, per status as stored and per assignee, busiest first.
+type TaskStats struct {
+	TaskCounts
+	ByStatus   map[string]int   `json:"by_status"`
+	ByAssignee []AssigneeCounts `json:"by_assignee"`
+}
+
+// DailyActivity is how many tasks were created and completed on Day, a ReportDateLayout date.
+// Tasks from before creation and completion times were recorded have an empty Day.
+type DailyActivity struct {
+	Day       string `json:"day"`
+	Created   int    `json:"created"`
+	Completed int    `json:"completed"`
+}
+
+// CompletionPoint is one day of the completion trend of a summary. CompletionRate is the share
+// of the tasks created by the end of the day that were completed by then.
+type CompletionPoint struct {
+	Date           string  `json:"date"`
+	Created        int     `json:"created"`
+	Completed      int     `json:"completed"`
+	CompletionRate float64 `json:"completion_rate"`
+}
+
+// TaskSummary is the answer to GET /reports/summary.
+type TaskSummary struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	TaskStats
+	From       string            `json:"from"`
+	To         string            `json:"to"`
+	Completion []CompletionPoint `json:"completion"`
+}
+
+// BurndownPoint is one day of a burndown: the tasks created and completed that day and the ones
+// still open at its end.
+type BurndownPoint struct {
+	Date      string `json:"date"`
+	Created   int    `json:"created"`
+	Completed int    `json:"completed"`
+	Remaining int    `json:"remaining"`
+}
+
+// Burndown is the answer to GET /reports/burndown.
+type Burndown struct {
+	From   string          `json:"from"`
+	To     string          `json:"to"`
+	Points []BurndownPoint `json:"points"`
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "context"
+
+// Stores are the repositories a unit of work writes through.
+type Stores struct {
+	Tasks       TaskRepository
+	Users       UserRepository
+	Invitations InvitationRepository
+	Outbox      OutboxRepository
+}
+
+// UnitOfWork runs operations that write to several repositories as one, such as creating a
+// user, redeeming their invitation and recording the event. Writes made through the stores
+// passed to fn are committed when fn returns nil and discarded when it returns an error, which
+// Do then returns.
+type UnitOfWork interface {
+	// Do falls back to running fn without a transaction when the database cannot provide one,
+	// like Transactor.WithTransaction.
+	Do(c context.Context, fn func(ctx context.Context, stores Stores) error) error
+	// DoAtomic fails instead of falling back, like Transactor.RequireTransaction.
+	DoAtomic(c context.Context, fn func(ctx context.Context, stores Stores) error) error
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	EventTaskCreated  = "task.created"
+	EventTaskUpdated  = "task.updated"
+	EventTaskDeleted  = "task.deleted"
+	EventTaskRestored = "task.restored"
+	EventTaskPurged   = "task.purged"
+	EventUserCreated  = "user.created"
+	EventUserPromoted = "user.promoted"
+)
+
+// EventTypes lists every event a webhook can subscribe to. "*" subscribes to all of them.
+var EventTypes = []string{EventTaskCreated, EventTaskUpdated, EventTaskDeleted, EventTaskRestored, EventTaskPurged, EventUserCreated, EventUserPromoted}
+
+const (
+	DeliveryPending    = "pending"
+	DeliveryDelivered  = "delivered"
+	DeliveryDeadLetter = "dead_letter"
+)
+
+type Event struct {
+	ID        string          `json:"id" bson:"id"`
+	Type      string          `json:"type" bson:"type"`
+	Data      json.RawMessage `json:"data" bson:"data"`
+	CreatedAt time.Time       `json:"created_at" bson:"created_at"`
+	// OrgID is the organization the event happened in. Only its members and webhooks see it.
+	OrgID string `json:"org_id,omitempty" bson:"org_id"`
+}
+
+type Webhook struct {
+	ID        string    `json:"id" bson:"id"`
+	URL       string    `json:"url" bson:"url" binding:"required"`
+	Events    []string  `json:"events" bson:"events" binding:"required"`
+	Secret    string    `json:"secret,omitempty" bson:"secret" binding:"required"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	OrgID     string    `json:"org_id,omitempty" bson:"org_id"`
+}
+
+type WebhookAttempt struct {
+	At         time.Time `json:"at" bson:"at"`
+	StatusCode int       `json:"status_code,omitempty" bson:"status_code"`
+	Error      string    `json:"error,omitempty" bson:"error"`
+}
+
+type WebhookDelivery struct {
+	ID            string           `json:"id" bson:"id"`
+	WebhookID     string           `json:"webhook_id" bson:"webhook_id"`
+	Event         Event            `json:"event" bson:"event"`
+	Status        string           `json:"status" bson:"status"`
+	Attempts      int              `json:"attempts" bson:"attempts"`
+	NextAttemptAt time.Time        `json:"next_attempt_at" bson:"next_attempt_at"`
+	Log           []WebhookAttempt `json:"log" bson:"log"`
+	CreatedAt     time.Time        `json:"created_at" bson:"created_at"`
+}
+
+type WebhookDeadLetter struct {
+	ID         string    `json:"id" bson:"id"`
+	DeliveryID string    `json:"delivery_id" bson:"delivery_id"`
+	WebhookID  string    `json:"webhook_id" bson:"webhook_id"`
+	Event      Event     `json:"event" bson:"event"`
+	Attempts   int       `json:"attempts" bson:"attempts"`
+	LastError  string    `json:"last_error" bson:"last_error"`
+	CreatedAt  time.Time `json:"created_at" bson:"created_at"`
+}
+
+type WebhookUseCase interface {
+	CreateWebhook(c context.Context, webhook Webhook) (*Webhook, error)
+	GetWebhooks(c context.Context) ([]Webhook, error)
+	DeleteWebhook(c context.Context, webhookID string) error
+	GetDeliveries(c context.Context, webhookID string) ([]WebhookDelivery, error)
+	GetDeadLetters(c context.Context) ([]WebhookDeadLetter, error)
+}
+
+// WebhookRepository scopes every method to the organization of c. The dispatcher reads with a
+// domain.WithAllOrgs context.
+type WebhookRepository interface {
+	CreateWebhook(c context.Context, webhook Webhook) error
+	GetWebhooks(c context.Context) ([]Webhook, error)
+	GetWebhookByID(c context.Context, webhookID string) (*Webhook, error)
+	GetWebhooksForEvent(c context.Context, eventType string) ([]Webhook, error)
+	DeleteWebhook(c context.Context, webhookID string) error
+}
+
+// WebhookDeliveryRepository scopes reads to the organization of the delivered event.
+type WebhookDeliveryRepository interface {
+	CreateDelivery(c context.Context, delivery WebhookDelivery) error
+	// UpdateDelivery also releases the claim on the delivery.
+	UpdateDelivery(c context.Context, delivery WebhookDelivery) error
+	// ClaimDueDelivery claims the pending delivery that has been due by now the longest, among
+	// those no dispatcher holds a claim on, until until. When several dispatchers ask at once,
+	// each delivery goes to only one of them. It returns nil when no delivery is due.
+	ClaimDueDelivery(c context.Context, now time.Time, until time.Time) (*WebhookDelivery, error)
+	GetDeliveriesByWebhook(c context.Context, webhookID string) ([]WebhookDelivery, error)
+	CreateDeadLetter(c context.Context, deadLetter WebhookDeadLetter) error
+	GetDeadLetters(c context.Context) ([]WebhookDeadLetter, error)
+}
+
+// OutboxRepository stores events written in the same transaction as the change that produced them,
+// so the webhook dispatcher can deliver them even if the process dies right after the write.
+type OutboxRepository interface {
+	Enqueue(c context.Context, event Event) error
+	// EnqueueMany stores the events of a batch in one write.
+	EnqueueMany(c context.Context, events []Event) error
+	// ClaimPending claims the oldest undispatched event no dispatcher holds a claim on until until,
+	// like ClaimDueDelivery. It returns nil when there is none.
+	ClaimPending(c context.Context, now time.Time, until time.Time) (*Event, error)
+	MarkDispatched(c context.Context, eventID string) error
+}
+
+// Transactor runs fn atomically. Repository calls made with the ctx passed to fn join the transaction.
+type Transactor interface {
+	// WithTransaction falls back to running fn without a transaction when the database cannot provide one.
+	WithTransaction(c context.Context, fn func(ctx context.Context) error) error
+	// RequireTransaction fails instead of falling back, for callers that promise all-or-nothing semantics.
+	RequireTransaction(c context.Context, fn func(ctx context.Context) error) error
+}
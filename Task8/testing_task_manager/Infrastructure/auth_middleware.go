@@ -2,23 +2,32 @@ package infrastructure
 
 import (
 	"strings"
-	"time"
+	domain "test_task_manager/Domain"
 
 	"github.com/gin-gonic/gin"
 )
 
 type AuthMiddleware struct {
 	jwtService JWTService
+	apiKeys    domain.APIKeyUseCase
+	clock      domain.Clock
 }
 
-func NewAuthMiddleware(jwtService JWTService) *AuthMiddleware {
+// NewAuthMiddleware accepts JWTs, and API keys too when apiKeys is not nil.
+func NewAuthMiddleware(jwtService JWTService, apiKeys domain.APIKeyUseCase, clock domain.Clock) *AuthMiddleware {
 	return &AuthMiddleware{
 		jwtService: jwtService,
+		apiKeys:    apiKeys,
+		clock:      clock,
 	}
 }
 
 func (a *AuthMiddleware) AuthMiddleware(onlyAdmin bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if key := apiKeyFromRequest(c); key != "" && a.apiKeys != nil {
+			a.authenticateAPIKey(c, key, onlyAdmin)
+			return
+		}
 
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -45,20 +54,140 @@ func (a *AuthMiddleware) AuthMiddleware(onlyAdmin bool) gin.HandlerFunc {
 		// Check for expiration if it exists in claims
 		if exp, ok := claims["exp"].(float64); ok {
 			expiration := int64(exp)
-			if time.Now().Unix() > expiration {
+			if a.clock.Now().Unix() > expiration {
 				c.JSON(401, gin.H{"error": "Token expired"})
 				c.Abort()
 				return
 			}
 		}
 		
-		role := claims["role"].(string)
+		orgID, role, ok := ResolveOrg(claims, c.GetHeader("X-Org-ID"))
+		if !ok {
+			c.JSON(403, gin.H{"error": "Not a member of this organization"})
+			c.Abort()
+			return
+		}
 		if role == "User" && onlyAdmin {
 			c.JSON(403, gin.H{"error": "User role not allowed to access this endpoint"})
 			c.Abort()
 			return
 		}
 
+		c.Set("username", claims["username"])
+		c.Set("role", role)
+		c.Set("org", orgID)
+		// Repositories read the organization from the request context, which gin falls back to
+		// when the engine has ContextWithFallback set.
+		c.Request = c.Request.WithContext(domain.WithOrg(c.Request.Context(), orgID))
+		c.Next()
+	}
+}
+
+// SessionMiddleware is AuthMiddleware for routes that administer users, organizations, API keys
+// and webhooks: it refuses API keys, whatever their scope, so those need a logged-in user.
+func (a *AuthMiddleware) SessionMiddleware(onlyAdmin bool) gin.HandlerFunc {
+	authenticate := a.AuthMiddleware(onlyAdmin)
+	return func(c *gin.Context) {
+		if apiKeyFromRequest(c) != "" {
+			c.JSON(403, gin.H{"error": "API keys cannot access this endpoint"})
+			c.Abort()
+			return
+		}
+		authenticate(c)
+	}
+}
+
+// authenticateAPIKey admits a service account. Its key acts in the organization it was issued
+// in, with the User role for the read scope and the Admin role for the write scope. The Admin
+// role only reaches the task routes, because the others use SessionMiddleware. The username of
+// the request is "service-account:" and the ID of the account.
+func (a *AuthMiddleware) authenticateAPIKey(c *gin.Context, key string, onlyAdmin bool) {
+	apiKey, err := a.apiKeys.Authenticate(c.Request.Context(), key)
+	if err != nil {
+		c.JSON(401, gin.H{"error": err.Error()})
+		c.Abort()
+		return
+	}
+
+	if requested := c.GetHeader("X-Org-ID"); requested != "" && requested != apiKey.OrgID {
+		c.JSON(403, gin.H{"error": "Not a member of this organization"})
+		c.Abort()
+		return
+	}
+	role := apiKey.Role()
+	if role == "User" && onlyAdmin {
+		c.JSON(403, gin.H{"error": "API key does not have the write scope"})
+		c.Abort()
+		return
+	}
+
+	c.Set("username", "service-account:"+apiKey.ServiceAccountID)
+	c.Set("service_account", apiKey.ServiceAccountID)
+	c.Set("api_key", apiKey.ID)
+	c.Set("role", role)
+	c.Set("org", apiKey.OrgID)
+	c.Request = c.Request.WithContext(domain.WithOrg(c.Request.Context(), apiKey.OrgID))
+	c.Next()
+}
+
+// apiKeyFromRequest returns the API key sent as X-API-Key, or as a bearer token for clients
+// that only know how to send those.
+func apiKeyFromRequest(c *gin.Context) string {
+	if key := c.GetHeader("X-API-Key"); key != "" {
+		return key
+	}
+	authParts := strings.Split(c.GetHeader("Authorization"), " ")
+	if len(authParts) == 2 && strings.ToLower(authParts[0]) == "bearer" && strings.HasPrefix(authParts[1], domain.APIKeyPrefix) {
+		return authParts[1]
+	}
+	return ""
+}
+
+// ResolveOrg picks the organization a request acts in and the caller's role there: the one
+// named by requested (the X-Org-ID header) or else the token's "org" claim. ok is false when
+// the token has no membership in it. Tokens from before organizations existed carry only a
+// "role" and act in the default organization.
+func ResolveOrg(claims map[string]interface{}, requested string) (orgID string, role string, ok bool) {
+	orgs, hasOrgs := claims["orgs"].(map[string]interface{})
+	if !hasOrgs {
+		role, _ = claims["role"].(string)
+		if role == "" || (requested != "" && requested != domain.DefaultOrgID) {
+			return "", "", false
+		}
+		return domain.DefaultOrgID, role, true
+	}
+
+	orgID = requested
+	if orgID == "" {
+		orgID, _ = claims["org"].(string)
+	}
+	role, ok = orgs[orgID].(string)
+	return orgID, role, ok && orgID != ""
+}
+
+// TokenFromQuery lets clients that cannot set headers, such as EventSource and browser
+// WebSockets, pass their token as ?access_token=. It must run before AuthMiddleware.
+func TokenFromQuery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			if token := c.Query("access_token"); token != "" {
+				c.Request.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
 		c.Next()
 	}
 }
+
+// OptionalAuth lets requests without an Authorization or X-API-Key header through anonymously
+// and checks the others like AuthMiddleware(false). Handlers must look for "role" before
+// trusting the caller.
+func (a *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
+	authenticate := a.AuthMiddleware(false)
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" && c.GetHeader("X-API-Key") == "" {
+			c.Next()
+			return
+		}
+		authenticate(c)
+	}
+}
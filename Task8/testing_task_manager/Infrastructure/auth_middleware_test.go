@@ -6,7 +6,9 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
+	domain "test_task_manager/Domain"
 	infrastructure "test_task_manager/Infrastructure"
 	mocks "test_task_manager/mocks"
 
@@ -19,6 +21,8 @@ import (
 type AuthMiddlewareTestSuite struct {
 	suite.Suite
 	jwtService     *mocks.JWTService
+	apiKeys        *mocks.APIKeyUseCase
+	clock          *infrastructure.FakeClock
 	authMiddleware *infrastructure.AuthMiddleware
 	router         *gin.Engine
 }
@@ -30,7 +34,9 @@ func (suite *AuthMiddlewareTestSuite) SetupSuite() {
 
 func (suite *AuthMiddlewareTestSuite) SetupTest() {
 	suite.jwtService = new(mocks.JWTService)
-	suite.authMiddleware = infrastructure.NewAuthMiddleware(suite.jwtService)
+	suite.apiKeys = new(mocks.APIKeyUseCase)
+	suite.clock = infrastructure.NewFakeClock(time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC))
+	suite.authMiddleware = infrastructure.NewAuthMiddleware(suite.jwtService, suite.apiKeys, suite.clock)
 }
 
 func (suite *AuthMiddlewareTestSuite) setupRouter(adminOnly bool, route string) {
@@ -81,6 +87,25 @@ func (suite *AuthMiddlewareTestSuite) TestExpiredToken() {
 	assert.JSONEq(suite.T(), `{"error":"Token is expired"}`, w.Body.String())
 }
 
+func (suite *AuthMiddlewareTestSuite) TestTokenExpiresByTheClock() {
+	suite.setupRouter(false, "/test")
+
+	expiresAt := suite.clock.Now().Add(time.Hour)
+	suite.jwtService.On("ValidateToken", "hourToken").Return(map[string]interface{}{"username": "testuser", "role": "User", "exp": float64(expiresAt.Unix())}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Authorization", "Bearer hourToken")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	suite.clock.Advance(time.Hour + time.Second)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+	assert.JSONEq(suite.T(), `{"error":"Token expired"}`, w.Body.String())
+}
+
 func (suite *AuthMiddlewareTestSuite) TestUserRoleAccessNonAdminEndpoint() {
 	suite.setupRouter(false, "/test")
 
@@ -126,6 +151,168 @@ func (suite *AuthMiddlewareTestSuite) TestAdminRoleAccessAdminOnlyEndpoint() {
 	assert.JSONEq(suite.T(), `{"message":"success"}`, w.Body.String())
 }
 
+func (suite *AuthMiddlewareTestSuite) TestTokenFromQuery() {
+	suite.router = gin.New()
+	suite.router.Use(infrastructure.TokenFromQuery(), suite.authMiddleware.AuthMiddleware(false))
+	suite.router.GET("/stream", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"username": c.GetString("username")})
+	})
+
+	suite.jwtService.On("ValidateToken", "queryToken").Return(map[string]interface{}{"username": "testuser", "role": "User"}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/stream?access_token=queryToken", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"username":"testuser"}`, w.Body.String())
+}
+
+func (suite *AuthMiddlewareTestSuite) TestOptionalAuth() {
+	suite.router = gin.New()
+	suite.router.Use(suite.authMiddleware.OptionalAuth())
+	suite.router.GET("/graphql", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"role": c.GetString("role")})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/graphql", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"role":""}`, w.Body.String())
+
+	suite.jwtService.On("ValidateToken", "badToken").Return(nil, errors.New("Invalid token"))
+
+	req = httptest.NewRequest(http.MethodGet, "/graphql", nil)
+	req.Header.Set("Authorization", "Bearer badToken")
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+}
+
+func (suite *AuthMiddlewareTestSuite) TestOrganizationHeader() {
+	suite.router = gin.New()
+	suite.router.Use(suite.authMiddleware.AuthMiddleware(true))
+	suite.router.GET("/admin", func(c *gin.Context) {
+		orgID, _ := domain.OrgFromContext(c.Request.Context())
+		c.JSON(http.StatusOK, gin.H{"org": c.GetString("org"), "context_org": orgID, "role": c.GetString("role")})
+	})
+
+	suite.jwtService.On("ValidateToken", "orgToken").Return(map[string]interface{}{
+		"username": "testuser",
+		"org":      "acme",
+		"role":     "User",
+		"orgs":     map[string]interface{}{"acme": "User", "globex": "Admin"},
+	}, nil)
+
+	send := func(orgID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+		req.Header.Set("Authorization", "Bearer orgToken")
+		if orgID != "" {
+			req.Header.Set("X-Org-ID", orgID)
+		}
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("")
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code, "the token's default organization is acme, where the user is not an admin")
+
+	w = send("globex")
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"org":"globex","context_org":"globex","role":"Admin"}`, w.Body.String())
+
+	w = send("initech")
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+	assert.JSONEq(suite.T(), `{"error":"Not a member of this organization"}`, w.Body.String())
+}
+
+func (suite *AuthMiddlewareTestSuite) TestAPIKey() {
+	suite.router = gin.New()
+	suite.router.Use(suite.authMiddleware.AuthMiddleware(true))
+	suite.router.GET("/admin", func(c *gin.Context) {
+		orgID, _ := domain.OrgFromContext(c.Request.Context())
+		c.JSON(http.StatusOK, gin.H{"service_account": c.GetString("service_account"), "username": c.GetString("username"), "org": orgID, "role": c.GetString("role")})
+	})
+
+	suite.apiKeys.On("Authenticate", mock.Anything, "tmk_write").Return(&domain.APIKey{ID: "k1", ServiceAccountID: "sa1", OrgID: "acme", Scopes: []string{domain.ScopeWrite}}, nil)
+	suite.apiKeys.On("Authenticate", mock.Anything, "tmk_read").Return(&domain.APIKey{ID: "k2", ServiceAccountID: "sa1", OrgID: "acme", Scopes: []string{domain.ScopeRead}}, nil)
+	suite.apiKeys.On("Authenticate", mock.Anything, "tmk_revoked").Return(nil, errors.New("api key has been revoked"))
+
+	send := func(header string, value string, orgID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+		req.Header.Set(header, value)
+		if orgID != "" {
+			req.Header.Set("X-Org-ID", orgID)
+		}
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("X-API-Key", "tmk_write", "")
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"service_account":"sa1","username":"service-account:sa1","org":"acme","role":"Admin"}`, w.Body.String())
+
+	w = send("Authorization", "Bearer tmk_write", "")
+	assert.Equal(suite.T(), http.StatusOK, w.Code, "keys are accepted as bearer tokens too")
+
+	w = send("X-API-Key", "tmk_read", "")
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+	assert.JSONEq(suite.T(), `{"error":"API key does not have the write scope"}`, w.Body.String())
+
+	w = send("X-API-Key", "tmk_write", "globex")
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+
+	w = send("X-API-Key", "tmk_revoked", "")
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+	assert.JSONEq(suite.T(), `{"error":"api key has been revoked"}`, w.Body.String())
+
+	suite.jwtService.AssertNotCalled(suite.T(), "ValidateToken", mock.Anything)
+}
+
+func (suite *AuthMiddlewareTestSuite) TestSessionMiddlewareRefusesAPIKeys() {
+	suite.router = gin.New()
+	suite.router.Use(suite.authMiddleware.SessionMiddleware(true))
+	suite.router.GET("/admin", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"username": c.GetString("username")})
+	})
+	suite.jwtService.On("ValidateToken", "admintoken").Return(map[string]interface{}{"username": "root", "role": "Admin"}, nil)
+
+	for _, header := range [][2]string{{"X-API-Key", "tmk_write"}, {"Authorization", "Bearer tmk_write"}} {
+		req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+		req.Header.Set(header[0], header[1])
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		assert.Equal(suite.T(), http.StatusForbidden, w.Code, header[0])
+		assert.JSONEq(suite.T(), `{"error":"API keys cannot access this endpoint"}`, w.Body.String())
+	}
+	suite.apiKeys.AssertNotCalled(suite.T(), "Authenticate", mock.Anything, mock.Anything)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+	req.Header.Set("Authorization", "Bearer admintoken")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"username":"root"}`, w.Body.String())
+}
+
+func TestResolveOrg_LegacyToken(t *testing.T) {
+	claims := map[string]interface{}{"username": "testuser", "role": "Admin"}
+
+	orgID, role, ok := infrastructure.ResolveOrg(claims, "")
+	assert.True(t, ok)
+	assert.Equal(t, domain.DefaultOrgID, orgID)
+	assert.Equal(t, "Admin", role)
+
+	_, _, ok = infrastructure.ResolveOrg(claims, "acme")
+	assert.False(t, ok)
+}
+
 func TestAuthMiddlewareTestSuite(t *testing.T) {
 	suite.Run(t, new(AuthMiddlewareTestSuite))
 }
@@ -0,0 +1,80 @@
+package infrastructure
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SystemClock is the real time.
+type SystemClock struct{}
+
+func NewSystemClock() *SystemClock {
+	return &SystemClock{}
+}
+
+func (c *SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FakeClock stands still until it is moved, for tests.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// RandomIDGenerator makes 24 hex digit IDs from crypto/rand, the shape of a MongoDB ObjectID.
+type RandomIDGenerator struct{}
+
+func NewRandomIDGenerator() *RandomIDGenerator {
+	return &RandomIDGenerator{}
+}
+
+func (g *RandomIDGenerator) NewID() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// SequentialIDGenerator makes prefix1, prefix2, ... so tests can predict IDs.
+type SequentialIDGenerator struct {
+	prefix string
+	mu     sync.Mutex
+	next   int
+}
+
+func NewSequentialIDGenerator(prefix string) *SequentialIDGenerator {
+	return &SequentialIDGenerator{prefix: prefix}
+}
+
+func (g *SequentialIDGenerator) NewID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.next++
+	return g.prefix + strconv.Itoa(g.next)
+}
@@ -0,0 +1,36 @@
+package infrastructure_test
+
+import (
+	"testing"
+	"time"
+
+	infrastructure "test_task_manager/Infrastructure"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClock_MovesOnlyWhenTold(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	clock := infrastructure.NewFakeClock(start)
+
+	assert.Equal(t, start, clock.Now())
+	clock.Advance(time.Hour)
+	assert.Equal(t, start.Add(time.Hour), clock.Now())
+	clock.Set(start)
+	assert.Equal(t, start, clock.Now())
+}
+
+func TestSequentialIDGenerator(t *testing.T) {
+	ids := infrastructure.NewSequentialIDGenerator("task")
+
+	assert.Equal(t, "task1", ids.NewID())
+	assert.Equal(t, "task2", ids.NewID())
+}
+
+func TestRandomIDGenerator(t *testing.T) {
+	ids := infrastructure.NewRandomIDGenerator()
+
+	id := ids.NewID()
+	assert.Len(t, id, 24)
+	assert.NotEqual(t, id, ids.NewID())
+}
@@ -0,0 +1,74 @@
+package infrastructure
+
+import (
+	"sync"
+	domain "test_task_manager/Domain"
+)
+
+const subscriberBuffer = 64
+
+// InMemoryEventBus fans events out to subscribers in this process and keeps the most recent
+// ones in a ring buffer so reconnecting clients can catch up.
+type InMemoryEventBus struct {
+	mu          sync.Mutex
+	sequence    uint64
+	history     []domain.StreamEvent
+	capacity    int
+	subscribers map[chan domain.StreamEvent]struct{}
+}
+
+func NewEventBus(capacity int) *InMemoryEventBus {
+	return &InMemoryEventBus{
+		capacity:    capacity,
+		subscribers: make(map[chan domain.StreamEvent]struct{}),
+	}
+}
+
+func (b *InMemoryEventBus) Publish(event domain.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.sequence++
+	streamEvent := domain.StreamEvent{Sequence: b.sequence, Event: event}
+
+	b.history = append(b.history, streamEvent)
+	if len(b.history) > b.capacity {
+		b.history = b.history[len(b.history)-b.capacity:]
+	}
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- streamEvent:
+		default:
+			// A subscriber that cannot keep up is dropped; it reconnects with Last-Event-ID.
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (b *InMemoryEventBus) Subscribe(lastSequence uint64) ([]domain.StreamEvent, <-chan domain.StreamEvent, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var replay []domain.StreamEvent
+	for _, event := range b.history {
+		if event.Sequence > lastSequence {
+			replay = append(replay, event)
+		}
+	}
+
+	ch := make(chan domain.StreamEvent, subscriberBuffer)
+	b.subscribers[ch] = struct{}{}
+
+	cancel := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return replay, ch, cancel
+}
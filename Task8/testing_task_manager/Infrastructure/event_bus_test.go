@@ -0,0 +1,62 @@
+package infrastructure_test
+
+import (
+	"testing"
+
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventBus_DeliversToSubscribers(t *testing.T) {
+	bus := infrastructure.NewEventBus(10)
+
+	replay, events, cancel := bus.Subscribe(0)
+	defer cancel()
+
+	bus.Publish(domain.Event{ID: "e1", Type: domain.EventTaskCreated})
+
+	assert.Empty(t, replay)
+	event := <-events
+	assert.Equal(t, uint64(1), event.Sequence)
+	assert.Equal(t, "e1", event.ID)
+}
+
+func TestEventBus_ReplaysAfterLastSequence(t *testing.T) {
+	bus := infrastructure.NewEventBus(10)
+	for _, id := range []string{"e1", "e2", "e3"} {
+		bus.Publish(domain.Event{ID: id})
+	}
+
+	replay, _, cancel := bus.Subscribe(1)
+	defer cancel()
+
+	assert.Len(t, replay, 2)
+	assert.Equal(t, "e2", replay[0].ID)
+	assert.Equal(t, uint64(3), replay[1].Sequence)
+}
+
+func TestEventBus_HistoryIsBounded(t *testing.T) {
+	bus := infrastructure.NewEventBus(2)
+	for _, id := range []string{"e1", "e2", "e3"} {
+		bus.Publish(domain.Event{ID: id})
+	}
+
+	replay, _, cancel := bus.Subscribe(0)
+	defer cancel()
+
+	assert.Len(t, replay, 2)
+	assert.Equal(t, "e2", replay[0].ID)
+}
+
+func TestEventBus_CancelClosesChannel(t *testing.T) {
+	bus := infrastructure.NewEventBus(10)
+
+	_, events, cancel := bus.Subscribe(0)
+	cancel()
+	cancel()
+
+	_, open := <-events
+	assert.False(t, open)
+}
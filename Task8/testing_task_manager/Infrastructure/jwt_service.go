@@ -1,34 +1,71 @@
 package infrastructure
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	domain "test_task_manager/Domain"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
 )
 
 type JWTService interface {
-	GenerateToken(username, role string) (string, error)
+	// GenerateToken lists every membership in the "orgs" claim. The first one is the organization
+	// the token acts in unless the request picks another with X-Org-ID.
+	GenerateToken(username string, memberships []domain.Membership) (string, error)
+	// ValidateToken only accepts access tokens.
 	ValidateToken(token string) (map[string]interface{}, error)
+	// GenerateMFAToken returns a short-lived token proving username passed the password step
+	// of a login. It is not accepted as an access token.
+	GenerateMFAToken(username string) (string, error)
+	ValidateMFAToken(token string) (string, error)
+	// GenerateEmailToken returns a token proving the holder received mail at email. It is put
+	// in verification links and is not accepted as an access token.
+	GenerateEmailToken(username string, email string) (string, error)
+	ValidateEmailToken(token string) (string, string, error)
 }
 
+// mfaTokenPurpose marks MFA tokens so the two kinds of token cannot be swapped.
+const mfaTokenPurpose = "mfa"
+
+// mfaTokenTTL is how long the user has to enter a code after their password.
+const mfaTokenTTL = 5 * time.Minute
+
+const emailTokenPurpose = "email"
+
+// emailTokenTTL is how long a verification link works.
+const emailTokenTTL = 24 * time.Hour
+
 type JWTServiceImpl struct {
 	SecretKey string
+	// clock stamps and checks the expiry of every token.
+	clock domain.Clock
 }
 
-func NewJWTService() *JWTServiceImpl {
+func NewJWTService(clock domain.Clock) *JWTServiceImpl {
 	return &JWTServiceImpl{
 		SecretKey: os.Getenv("JWT_SECRET"),
+		clock:     clock,
 	}
 }
 
-func (j *JWTServiceImpl) GenerateToken(username, role string) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+func (j *JWTServiceImpl) GenerateToken(username string, memberships []domain.Membership) (string, error) {
+	orgs := make(map[string]string, len(memberships))
+	for _, membership := range memberships {
+		orgs[membership.OrgID] = membership.Role
+	}
+
+	claims := jwt.MapClaims{
 		"username": username,
-		"role":     role,
-		"exp":      time.Now().Add(time.Hour * 24 * 7).Unix(),
-	})
+		"orgs":     orgs,
+		"exp":      j.clock.Now().Add(time.Hour * 24 * 7).Unix(),
+	}
+	if len(memberships) > 0 {
+		claims["org"] = memberships[0].OrgID
+		claims["role"] = memberships[0].Role
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
 	tokenString, err := token.SignedString([]byte(j.SecretKey))
 	if err != nil {
@@ -39,7 +76,67 @@ func (j *JWTServiceImpl) GenerateToken(username, role string) (string, error) {
 }
 
 func (j *JWTServiceImpl) ValidateToken(tokenString string) (map[string]interface{}, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+	claims, err := j.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := claims["purpose"]; ok {
+		return nil, errors.New("not an access token")
+	}
+	return claims, nil
+}
+
+func (j *JWTServiceImpl) GenerateMFAToken(username string) (string, error) {
+	claims := jwt.MapClaims{
+		"username": username,
+		"purpose":  mfaTokenPurpose,
+		"exp":      j.clock.Now().Add(mfaTokenTTL).Unix(),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(j.SecretKey))
+}
+
+func (j *JWTServiceImpl) ValidateMFAToken(tokenString string) (string, error) {
+	claims, err := j.parse(tokenString)
+	if err != nil {
+		return "", err
+	}
+	username, _ := claims["username"].(string)
+	if claims["purpose"] != mfaTokenPurpose || username == "" {
+		return "", errors.New("not an mfa token")
+	}
+	return username, nil
+}
+
+func (j *JWTServiceImpl) GenerateEmailToken(username string, email string) (string, error) {
+	claims := jwt.MapClaims{
+		"username": username,
+		"email":    email,
+		"purpose":  emailTokenPurpose,
+		"exp":      j.clock.Now().Add(emailTokenTTL).Unix(),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(j.SecretKey))
+}
+
+func (j *JWTServiceImpl) ValidateEmailToken(tokenString string) (string, string, error) {
+	claims, err := j.parse(tokenString)
+	if err != nil {
+		return "", "", err
+	}
+	username, _ := claims["username"].(string)
+	email, _ := claims["email"].(string)
+	if claims["purpose"] != emailTokenPurpose || username == "" || email == "" {
+		return "", "", errors.New("not an email token")
+	}
+	return username, email, nil
+}
+
+func (j *JWTServiceImpl) parse(tokenString string) (map[string]interface{}, error) {
+	// The parser would check the expiry against the real time; it is checked against the clock
+	// below instead.
+	parser := jwt.Parser{SkipClaimsValidation: true}
+	token, err := parser.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected singing method: %v", token.Header["alg"])
 		}
@@ -50,9 +147,12 @@ func (j *JWTServiceImpl) ValidateToken(tokenString string) (map[string]interface
 		return nil, err
 	}
 
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		return claims, nil
-	} else {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
 		return nil, err
 	}
+	if !claims.VerifyExpiresAt(j.clock.Now().Unix(), false) {
+		return nil, errors.New("Token is expired")
+	}
+	return claims, nil
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	domain "test_task_manager/Domain"
 	infrastructure "test_task_manager/Infrastructure"
 
 	"github.com/dgrijalva/jwt-go"
@@ -13,12 +14,12 @@ import (
 
 func TestGenerateToken_Success(t *testing.T) {
 	os.Setenv("JWT_SECRET", "testsecret")
-	jwtService := infrastructure.NewJWTService()
+	jwtService := infrastructure.NewJWTService(infrastructure.NewSystemClock())
 
 	username := "testuser"
-	role := "User"
+	memberships := []domain.Membership{{OrgID: domain.DefaultOrgID, Role: "User"}}
 
-	token, err := jwtService.GenerateToken(username, role)
+	token, err := jwtService.GenerateToken(username, memberships)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, token)
@@ -26,12 +27,12 @@ func TestGenerateToken_Success(t *testing.T) {
 
 func TestValidateToken_Success(t *testing.T) {
 	os.Setenv("JWT_SECRET", "testsecret")
-	jwtService := infrastructure.NewJWTService()
+	jwtService := infrastructure.NewJWTService(infrastructure.NewSystemClock())
 
 	username := "testuser"
-	role := "User"
+	memberships := []domain.Membership{{OrgID: "acme", Role: "Admin"}, {OrgID: domain.DefaultOrgID, Role: "User"}}
 
-	token, err := jwtService.GenerateToken(username, role)
+	token, err := jwtService.GenerateToken(username, memberships)
 	assert.NoError(t, err)
 
 	claims, err := jwtService.ValidateToken(token)
@@ -39,12 +40,14 @@ func TestValidateToken_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, claims)
 	assert.Equal(t, username, claims["username"])
-	assert.Equal(t, role, claims["role"])
+	assert.Equal(t, "acme", claims["org"])
+	assert.Equal(t, "Admin", claims["role"])
+	assert.Equal(t, map[string]interface{}{"acme": "Admin", domain.DefaultOrgID: "User"}, claims["orgs"])
 }
 
 func TestValidateToken_InvalidToken(t *testing.T) {
 	os.Setenv("JWT_SECRET", "testsecret")
-	jwtService := infrastructure.NewJWTService()
+	jwtService := infrastructure.NewJWTService(infrastructure.NewSystemClock())
 
 	invalidToken := "invalid.token.string"
 	claims, err := jwtService.ValidateToken(invalidToken)
@@ -55,7 +58,7 @@ func TestValidateToken_InvalidToken(t *testing.T) {
 
 func TestValidateToken_ExpiredToken(t *testing.T) {
 	os.Setenv("JWT_SECRET", "testsecret")
-	jwtService := infrastructure.NewJWTService()
+	jwtService := infrastructure.NewJWTService(infrastructure.NewSystemClock())
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"username": "testuser",
@@ -70,3 +73,73 @@ func TestValidateToken_ExpiredToken(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, claims)
 }
+
+func TestTokens_ExpireByTheClock(t *testing.T) {
+	os.Setenv("JWT_SECRET", "testsecret")
+	clock := infrastructure.NewFakeClock(time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC))
+	jwtService := infrastructure.NewJWTService(clock)
+
+	accessToken, _ := jwtService.GenerateToken("testuser", nil)
+	mfaToken, _ := jwtService.GenerateMFAToken("testuser")
+	emailToken, _ := jwtService.GenerateEmailToken("testuser", "test@example.com")
+
+	clock.Advance(5 * time.Minute)
+	_, err := jwtService.ValidateMFAToken(mfaToken)
+	assert.NoError(t, err, "an MFA token lasts five minutes")
+
+	clock.Advance(time.Second)
+	_, err = jwtService.ValidateMFAToken(mfaToken)
+	assert.EqualError(t, err, "Token is expired")
+
+	clock.Set(time.Date(2024, time.January, 2, 9, 0, 1, 0, time.UTC))
+	_, _, err = jwtService.ValidateEmailToken(emailToken)
+	assert.Error(t, err, "an email token lasts a day")
+	_, err = jwtService.ValidateToken(accessToken)
+	assert.NoError(t, err, "an access token lasts a week")
+
+	clock.Advance(6 * 24 * time.Hour)
+	_, err = jwtService.ValidateToken(accessToken)
+	assert.Error(t, err)
+}
+
+func TestMFAToken(t *testing.T) {
+	os.Setenv("JWT_SECRET", "testsecret")
+	jwtService := infrastructure.NewJWTService(infrastructure.NewSystemClock())
+
+	mfaToken, err := jwtService.GenerateMFAToken("testuser")
+	assert.NoError(t, err)
+
+	username, err := jwtService.ValidateMFAToken(mfaToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "testuser", username)
+
+	_, err = jwtService.ValidateToken(mfaToken)
+	assert.Error(t, err, "an MFA token is not an access token")
+
+	accessToken, _ := jwtService.GenerateToken("testuser", nil)
+	_, err = jwtService.ValidateMFAToken(accessToken)
+	assert.Error(t, err, "an access token is not an MFA token")
+}
+
+func TestEmailToken(t *testing.T) {
+	os.Setenv("JWT_SECRET", "testsecret")
+	jwtService := infrastructure.NewJWTService(infrastructure.NewSystemClock())
+
+	emailToken, err := jwtService.GenerateEmailToken("testuser", "test@example.com")
+	assert.NoError(t, err)
+
+	username, email, err := jwtService.ValidateEmailToken(emailToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "testuser", username)
+	assert.Equal(t, "test@example.com", email)
+
+	_, err = jwtService.ValidateToken(emailToken)
+	assert.Error(t, err, "an email token is not an access token")
+
+	_, err = jwtService.ValidateMFAToken(emailToken)
+	assert.Error(t, err, "an email token is not an MFA token")
+
+	mfaToken, _ := jwtService.GenerateMFAToken("testuser")
+	_, _, err = jwtService.ValidateEmailToken(mfaToken)
+	assert.Error(t, err, "an MFA token is not an email token")
+}
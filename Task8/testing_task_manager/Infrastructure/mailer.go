@@ -0,0 +1,126 @@
+package infrastructure
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(c context.Context, email Email) error
+}
+
+type SMTPConfig struct {
+	// Addr is the host:port of the SMTP server.
+	Addr string
+	// Username and Password are sent with AUTH PLAIN when Username is set. Go refuses to send
+	// them unencrypted to anything but localhost.
+	Username string
+	Password string
+	From     string
+}
+
+type SMTPMailer struct {
+	config  SMTPConfig
+	timeout time.Duration
+}
+
+func NewSMTPMailer(config SMTPConfig, timeout time.Duration) *SMTPMailer {
+	return &SMTPMailer{
+		config:  config,
+		timeout: timeout,
+	}
+}
+
+// Send delivers a plain text email. STARTTLS is used whenever the server offers it.
+func (m *SMTPMailer) Send(c context.Context, email Email) error {
+	if strings.ContainsAny(email.To+email.Subject, "\r\n") {
+		return errors.New("invalid email header")
+	}
+
+	dialer := net.Dialer{Timeout: m.timeout}
+	conn, err := dialer.DialContext(c, "tcp", m.config.Addr)
+	if err != nil {
+		return err
+	}
+	deadline := time.Now().Add(m.timeout)
+	if d, ok := c.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+
+	host, _, _ := net.SplitHostPort(m.config.Addr)
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if m.config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.config.Username, m.config.Password, host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(m.config.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(email.To); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(m.message(email)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+func (m *SMTPMailer) message(email Email) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", m.config.From)
+	fmt.Fprintf(&b, "To: %s\r\n", email.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", email.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(email.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// LogMailer writes emails to the log instead of sending them. It is used when no SMTP server is
+// configured, so that verification links can still be followed in development.
+type LogMailer struct{}
+
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(c context.Context, email Email) error {
+	log.Printf("email to %s: %s\n%s", email.To, email.Subject, email.Body)
+	return nil
+}
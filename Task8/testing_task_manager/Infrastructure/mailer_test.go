@@ -0,0 +1,104 @@
+package infrastructure_test
+
+import (
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	infrastructure "test_task_manager/Infrastructure"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer accepts one session on a local port and records the commands and the message.
+type fakeSMTPServer struct {
+	listener net.Listener
+	commands []string
+	data     string
+	done     chan struct{}
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &fakeSMTPServer{listener: listener, done: make(chan struct{})}
+	t.Cleanup(func() { listener.Close() })
+	go server.serve()
+	return server
+}
+
+func (s *fakeSMTPServer) serve() {
+	defer close(s.done)
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		s.commands = append(s.commands, line)
+		switch verb := strings.ToUpper(strings.Fields(line)[0]); verb {
+		case "EHLO":
+			text.PrintfLine("250-localhost")
+			text.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			text.PrintfLine("235 2.7.0 Authentication successful")
+		case "DATA":
+			text.PrintfLine("354 Go ahead")
+			lines, err := text.ReadDotLines()
+			if err != nil {
+				return
+			}
+			s.data = strings.Join(lines, "\n")
+			text.PrintfLine("250 OK")
+		case "QUIT":
+			text.PrintfLine("221 Bye")
+			return
+		default:
+			text.PrintfLine("250 OK")
+		}
+	}
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	mailer := infrastructure.NewSMTPMailer(infrastructure.SMTPConfig{
+		Addr:     server.listener.Addr().String(),
+		Username: "mailer",
+		Password: "secret",
+		From:     "tasks@example.com",
+	}, 2*time.Second)
+
+	err := mailer.Send(context.Background(), infrastructure.Email{
+		To:      "alice@example.com",
+		Subject: "Verify your email",
+		Body:    "Open this link:\nhttp://localhost/verify-email?token=abc",
+	})
+
+	require.NoError(t, err)
+	<-server.done
+	assert.Contains(t, server.commands, "MAIL FROM:<tasks@example.com>")
+	assert.Contains(t, server.commands, "RCPT TO:<alice@example.com>")
+	assert.True(t, strings.HasPrefix(server.commands[1], "AUTH PLAIN "))
+	assert.Contains(t, server.data, "To: alice@example.com\n")
+	assert.Contains(t, server.data, "Subject: Verify your email\n")
+	assert.True(t, strings.HasSuffix(server.data, "\nOpen this link:\nhttp://localhost/verify-email?token=abc"))
+}
+
+func TestSMTPMailerRejectsHeaderInjection(t *testing.T) {
+	mailer := infrastructure.NewSMTPMailer(infrastructure.SMTPConfig{Addr: "127.0.0.1:1", From: "tasks@example.com"}, time.Second)
+
+	err := mailer.Send(context.Background(), infrastructure.Email{To: "alice@example.com\r\nBcc: eve@example.com", Subject: "Hi"})
+
+	assert.EqualError(t, err, "invalid email header")
+}
@@ -0,0 +1,129 @@
+package infrastructure_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"testing"
+	"time"
+
+	infrastructure "test_task_manager/Infrastructure"
+	"test_task_manager/Infrastructure/oidctest"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const testVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+
+type OIDCProviderTestSuite struct {
+	suite.Suite
+	idp      *oidctest.Server
+	provider infrastructure.OIDCProvider
+	ctx      context.Context
+}
+
+func (suite *OIDCProviderTestSuite) SetupTest() {
+	suite.idp = oidctest.NewServer("task-manager")
+	suite.idp.SetUser(map[string]interface{}{"sub": "u-1", "preferred_username": "alice"})
+	suite.provider = infrastructure.NewOIDCProvider(infrastructure.OIDCConfig{
+		Issuer:      suite.idp.Issuer(),
+		ClientID:    "task-manager",
+		RedirectURL: "http://localhost:8080/auth/oidc/callback",
+		Scopes:      []string{"openid", "profile"},
+	}, 2*time.Second)
+	suite.ctx = context.Background()
+}
+
+func (suite *OIDCProviderTestSuite) TearDownTest() {
+	suite.idp.Close()
+}
+
+// authorize starts a login with the challenge for testVerifier and returns the code.
+func (suite *OIDCProviderTestSuite) authorize(nonce string) string {
+	challenge := sha256.Sum256([]byte(testVerifier))
+	authURL, err := suite.provider.AuthCodeURL(suite.ctx, "state-1", nonce, base64.RawURLEncoding.EncodeToString(challenge[:]))
+	suite.Require().NoError(err)
+
+	code, state, err := suite.idp.Authorize(authURL)
+	suite.Require().NoError(err)
+	suite.Equal("state-1", state)
+	return code
+}
+
+func (suite *OIDCProviderTestSuite) TestAuthCodeURL() {
+	authURL, err := suite.provider.AuthCodeURL(suite.ctx, "state-1", "nonce-1", "challenge")
+	suite.Require().NoError(err)
+
+	parsed, err := url.Parse(authURL)
+	suite.Require().NoError(err)
+	query := parsed.Query()
+	suite.Equal(suite.idp.Issuer()+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	suite.Equal("code", query.Get("response_type"))
+	suite.Equal("task-manager", query.Get("client_id"))
+	suite.Equal("openid profile", query.Get("scope"))
+	suite.Equal("S256", query.Get("code_challenge_method"))
+	suite.Equal("challenge", query.Get("code_challenge"))
+	suite.Equal("nonce-1", query.Get("nonce"))
+}
+
+func (suite *OIDCProviderTestSuite) TestExchange_Positive() {
+	code := suite.authorize("nonce-1")
+
+	claims, err := suite.provider.Exchange(suite.ctx, code, testVerifier, "nonce-1")
+
+	suite.Require().NoError(err)
+	suite.Equal("u-1", claims["sub"])
+	suite.Equal("alice", claims["preferred_username"])
+}
+
+func (suite *OIDCProviderTestSuite) TestExchange_Negative_WrongVerifier() {
+	code := suite.authorize("nonce-1")
+
+	_, err := suite.provider.Exchange(suite.ctx, code, "not-the-verifier-that-was-used-for-the-challenge", "nonce-1")
+
+	suite.ErrorContains(err, "code_verifier does not match the challenge")
+}
+
+func (suite *OIDCProviderTestSuite) TestExchange_Negative_CodeReused() {
+	code := suite.authorize("nonce-1")
+	_, err := suite.provider.Exchange(suite.ctx, code, testVerifier, "nonce-1")
+	suite.Require().NoError(err)
+
+	_, err = suite.provider.Exchange(suite.ctx, code, testVerifier, "nonce-1")
+
+	suite.ErrorContains(err, "invalid_grant")
+}
+
+func (suite *OIDCProviderTestSuite) TestExchange_Negative_WrongNonce() {
+	code := suite.authorize("nonce-1")
+
+	_, err := suite.provider.Exchange(suite.ctx, code, testVerifier, "nonce-2")
+
+	suite.EqualError(err, "oidc: id token nonce does not match")
+}
+
+func (suite *OIDCProviderTestSuite) TestExchange_Negative_OtherClient() {
+	other := infrastructure.NewOIDCProvider(infrastructure.OIDCConfig{
+		Issuer:      suite.idp.Issuer(),
+		ClientID:    "someone-else",
+		RedirectURL: "http://localhost:8080/auth/oidc/callback",
+	}, 2*time.Second)
+	code := suite.authorize("nonce-1")
+
+	_, err := other.Exchange(suite.ctx, code, testVerifier, "nonce-1")
+
+	suite.ErrorContains(err, "invalid_client")
+}
+
+func (suite *OIDCProviderTestSuite) TestDiscovery_Negative_Unreachable() {
+	suite.idp.Close()
+
+	_, err := suite.provider.AuthCodeURL(suite.ctx, "state-1", "nonce-1", "challenge")
+
+	suite.ErrorContains(err, "oidc: discovery")
+}
+
+func TestOIDCProviderTestSuite(t *testing.T) {
+	suite.Run(t, new(OIDCProviderTestSuite))
+}
@@ -0,0 +1,136 @@
+package infrastructure
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	domain "test_task_manager/Domain"
+	"time"
+)
+
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+// CircuitBreaker opens after threshold failures in a row and then refuses calls for cooldown.
+// After that it lets one call through; the circuit closes again if that call succeeds and
+// stays open for another cooldown if it fails. The cooldown is measured by clock.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	clock     domain.Clock
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	// probing is set while the one call allowed through a half-open circuit runs.
+	probing bool
+}
+
+func NewCircuitBreaker(threshold int, cooldown time.Duration, clock domain.Clock) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		clock:     clock,
+	}
+}
+
+// Allow reports whether a call may go ahead. Every allowed call must be followed by Success
+// or Failure, except ones that end for reasons unrelated to the dependency, which call Release.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state() {
+	case CircuitClosed:
+		return true
+	case CircuitHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	}
+	return false
+}
+
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.probing || b.failures >= b.threshold {
+		b.openedAt = b.clock.Now()
+	}
+	b.probing = false
+}
+
+// Release gives back a call that neither proved nor disproved that the dependency works, such
+// as one canceled by its caller.
+func (b *CircuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// State is CircuitClosed, CircuitOpen or CircuitHalfOpen.
+func (b *CircuitBreaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state()
+}
+
+// state must be called with mu held.
+func (b *CircuitBreaker) state() string {
+	if b.failures < b.threshold {
+		return CircuitClosed
+	}
+	if b.clock.Now().Sub(b.openedAt) < b.cooldown {
+		return CircuitOpen
+	}
+	return CircuitHalfOpen
+}
+
+// RetryPolicy retries up to MaxAttempts times in all, waiting a random time of up to
+// BaseBackoff, 2*BaseBackoff, 4*BaseBackoff, ... but never more than MaxBackoff between
+// attempts. The randomness keeps instances that failed together from retrying together.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Backoff is the wait after the given failed attempt, counting from 1.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := p.BaseBackoff
+	for i := 1; i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(backoff) + 1))
+}
+
+// Sleep waits for d or until c is done, and reports whether the whole wait passed.
+func Sleep(c context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-c.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
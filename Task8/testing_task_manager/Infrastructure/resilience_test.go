@@ -0,0 +1,80 @@
+package infrastructure_test
+
+import (
+	"testing"
+	"time"
+
+	infrastructure "test_task_manager/Infrastructure"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newClock() *infrastructure.FakeClock {
+	return infrastructure.NewFakeClock(time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC))
+}
+
+func TestCircuitBreaker_OpensAfterThresholdFailures(t *testing.T) {
+	breaker := infrastructure.NewCircuitBreaker(2, time.Minute, newClock())
+
+	assert.True(t, breaker.Allow())
+	breaker.Failure()
+	assert.Equal(t, infrastructure.CircuitClosed, breaker.State())
+
+	assert.True(t, breaker.Allow())
+	breaker.Failure()
+	assert.Equal(t, infrastructure.CircuitOpen, breaker.State())
+	assert.False(t, breaker.Allow())
+}
+
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	breaker := infrastructure.NewCircuitBreaker(2, time.Minute, newClock())
+
+	breaker.Failure()
+	breaker.Success()
+	breaker.Failure()
+
+	assert.Equal(t, infrastructure.CircuitClosed, breaker.State())
+}
+
+func TestCircuitBreaker_HalfOpenLetsOneCallThrough(t *testing.T) {
+	clock := newClock()
+	breaker := infrastructure.NewCircuitBreaker(1, time.Second*10, clock)
+	breaker.Failure()
+	clock.Advance(time.Second * 10)
+
+	assert.Equal(t, infrastructure.CircuitHalfOpen, breaker.State())
+	assert.True(t, breaker.Allow())
+	assert.False(t, breaker.Allow())
+
+	// A failed probe opens the circuit for another cooldown.
+	breaker.Failure()
+	assert.Equal(t, infrastructure.CircuitOpen, breaker.State())
+	clock.Advance(time.Second * 9)
+	assert.Equal(t, infrastructure.CircuitOpen, breaker.State())
+
+	clock.Advance(time.Second)
+	assert.True(t, breaker.Allow())
+	breaker.Success()
+	assert.Equal(t, infrastructure.CircuitClosed, breaker.State())
+}
+
+func TestCircuitBreaker_ReleaseFreesTheProbe(t *testing.T) {
+	clock := newClock()
+	breaker := infrastructure.NewCircuitBreaker(1, time.Second*10, clock)
+	breaker.Failure()
+	clock.Advance(time.Second * 10)
+
+	assert.True(t, breaker.Allow())
+	breaker.Release()
+	assert.True(t, breaker.Allow())
+}
+
+func TestRetryPolicy_BackoffIsCapped(t *testing.T) {
+	policy := infrastructure.RetryPolicy{MaxAttempts: 5, BaseBackoff: time.Millisecond * 100, MaxBackoff: time.Millisecond * 300}
+
+	for i := 0; i < 100; i++ {
+		assert.LessOrEqual(t, policy.Backoff(1), time.Millisecond*100)
+		assert.LessOrEqual(t, policy.Backoff(2), time.Millisecond*200)
+		assert.LessOrEqual(t, policy.Backoff(10), time.Millisecond*300)
+	}
+}
@@ -0,0 +1,185 @@
+package infrastructure
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SearchQuery is a parsed search string. A task matches when it contains at least one of
+// Terms (if any are given), every phrase and a word starting with every prefix. These are the
+// semantics of a MongoDB $text search, so both task backends return the same matches.
+type SearchQuery struct {
+	Terms    []string
+	Phrases  [][]string
+	Prefixes []string
+}
+
+func (q SearchQuery) Empty() bool {
+	return len(q.Terms) == 0 && len(q.Phrases) == 0 && len(q.Prefixes) == 0
+}
+
+// ParseSearchQuery understands plain words, "quoted phrases" and prefixes written as word*.
+// Everything is lowercased; punctuation separates words like it does in the indexed text.
+func ParseSearchQuery(q string) SearchQuery {
+	var query SearchQuery
+
+	parts := strings.Split(q, `"`)
+	for i, part := range parts {
+		// Odd parts sit between quotes. A trailing unmatched quote is treated as plain text.
+		if i%2 == 1 && i < len(parts)-1 {
+			if words := tokenWords(part); len(words) == 1 {
+				query.Terms = append(query.Terms, words[0])
+			} else if len(words) > 1 {
+				query.Phrases = append(query.Phrases, words)
+			}
+			continue
+		}
+
+		for _, field := range strings.Fields(part) {
+			prefix := strings.HasSuffix(field, "*")
+			words := tokenWords(strings.TrimRight(field, "*"))
+			for j, word := range words {
+				if prefix && j == len(words)-1 {
+					query.Prefixes = append(query.Prefixes, word)
+				} else {
+					query.Terms = append(query.Terms, word)
+				}
+			}
+		}
+	}
+
+	return query
+}
+
+// Token is a lowercased word of a text together with its byte offsets in the original.
+type Token struct {
+	Text  string
+	Start int
+	End   int
+}
+
+func Tokenize(text string) []Token {
+	var tokens []Token
+	start := -1
+	for i, r := range text {
+		word := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if word && start < 0 {
+			start = i
+		}
+		if !word && start >= 0 {
+			tokens = append(tokens, Token{Text: strings.ToLower(text[start:i]), Start: start, End: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, Token{Text: strings.ToLower(text[start:]), Start: start, End: len(text)})
+	}
+	return tokens
+}
+
+func tokenWords(text string) []string {
+	tokens := Tokenize(text)
+	words := make([]string, len(tokens))
+	for i, token := range tokens {
+		words[i] = token.Text
+	}
+	return words
+}
+
+const (
+	HighlightOpen  = "<mark>"
+	HighlightClose = "</mark>"
+	snippetRadius  = 60
+)
+
+// Highlight returns a snippet of text around the first match of query with every matching
+// word wrapped in <mark> tags, or "" when nothing matches. The rest of the snippet is
+// HTML-escaped so it can be rendered as-is.
+func Highlight(text string, query SearchQuery) string {
+	tokens := Tokenize(text)
+	marked := make([]bool, len(tokens))
+
+	terms := make(map[string]bool, len(query.Terms))
+	for _, term := range query.Terms {
+		terms[term] = true
+	}
+	for i, token := range tokens {
+		if terms[token.Text] {
+			marked[i] = true
+		}
+		for _, prefix := range query.Prefixes {
+			if strings.HasPrefix(token.Text, prefix) {
+				marked[i] = true
+			}
+		}
+	}
+	for _, phrase := range query.Phrases {
+		for i := 0; i+len(phrase) <= len(tokens); i++ {
+			if phraseAt(tokens, i, phrase) {
+				for j := i; j < i+len(phrase); j++ {
+					marked[j] = true
+				}
+			}
+		}
+	}
+
+	first := -1
+	for i := range marked {
+		if marked[i] {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return ""
+	}
+
+	start := snippetBoundary(text, tokens[first].Start-snippetRadius)
+	end := snippetBoundary(text, tokens[first].End+snippetRadius)
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	position := start
+	for i, token := range tokens {
+		if !marked[i] || token.Start < start || token.End > end {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[position:token.Start]))
+		b.WriteString(HighlightOpen)
+		b.WriteString(html.EscapeString(text[token.Start:token.End]))
+		b.WriteString(HighlightClose)
+		position = token.End
+	}
+	b.WriteString(html.EscapeString(text[position:end]))
+	if end < len(text) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+func phraseAt(tokens []Token, i int, phrase []string) bool {
+	for j, word := range phrase {
+		if tokens[i+j].Text != word {
+			return false
+		}
+	}
+	return true
+}
+
+// snippetBoundary clamps offset to text and moves it back to the start of a rune.
+func snippetBoundary(text string, offset int) int {
+	if offset <= 0 {
+		return 0
+	}
+	if offset >= len(text) {
+		return len(text)
+	}
+	for offset > 0 && !utf8.RuneStart(text[offset]) {
+		offset--
+	}
+	return offset
+}
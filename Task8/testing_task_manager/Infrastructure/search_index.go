@@ -0,0 +1,204 @@
+package infrastructure
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SearchIndex is an in-process inverted index. Every document has the same fields, each with
+// its own weight, and every word records its positions per field so phrases can be matched.
+type SearchIndex struct {
+	mu         sync.Mutex
+	weights    []float64
+	postings   map[string]map[string][][]int
+	documents  map[string][]string
+	vocabulary []string
+	sorted     bool
+}
+
+type SearchMatch struct {
+	ID    string
+	Score float64
+}
+
+func NewSearchIndex(weights ...float64) *SearchIndex {
+	return &SearchIndex{
+		weights:   weights,
+		postings:  make(map[string]map[string][][]int),
+		documents: make(map[string][]string),
+	}
+}
+
+// Index adds the document, replacing any earlier version with the same id. Fields are given
+// in the order of the weights passed to NewSearchIndex.
+func (s *SearchIndex) Index(id string, fields ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remove(id)
+
+	var words []string
+	for field, text := range fields {
+		if field >= len(s.weights) {
+			break
+		}
+		for position, token := range Tokenize(text) {
+			documents, ok := s.postings[token.Text]
+			if !ok {
+				documents = make(map[string][][]int)
+				s.postings[token.Text] = documents
+				s.sorted = false
+			}
+			positions, ok := documents[id]
+			if !ok {
+				positions = make([][]int, len(s.weights))
+				words = append(words, token.Text)
+			}
+			positions[field] = append(positions[field], position)
+			documents[id] = positions
+		}
+	}
+	s.documents[id] = words
+}
+
+func (s *SearchIndex) Remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(id)
+}
+
+func (s *SearchIndex) remove(id string) {
+	for _, word := range s.documents[id] {
+		delete(s.postings[word], id)
+		if len(s.postings[word]) == 0 {
+			delete(s.postings, word)
+			s.sorted = false
+		}
+	}
+	delete(s.documents, id)
+}
+
+// Search returns the matching documents ranked by a weighted tf-idf score, best first.
+func (s *SearchIndex) Search(query SearchQuery) []SearchMatch {
+	if query.Empty() {
+		return nil
+	}
+
+	// Searching takes the write lock because prefix lookups may need to re-sort the vocabulary.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sortVocabulary()
+
+	// Every word that contributes to the score, with the prefixes expanded to indexed words.
+	scoring := append([]string(nil), query.Terms...)
+	for _, phrase := range query.Phrases {
+		scoring = append(scoring, phrase...)
+	}
+
+	var candidates map[string]bool
+	if len(query.Terms) > 0 {
+		candidates = make(map[string]bool)
+		for _, term := range query.Terms {
+			for id := range s.postings[term] {
+				candidates[id] = true
+			}
+		}
+	}
+
+	for _, phrase := range query.Phrases {
+		matches := make(map[string]bool)
+		for id := range s.postings[phrase[0]] {
+			if (candidates == nil || candidates[id]) && s.containsPhrase(id, phrase) {
+				matches[id] = true
+			}
+		}
+		candidates = matches
+	}
+
+	for _, prefix := range query.Prefixes {
+		words := s.wordsWithPrefix(prefix)
+		scoring = append(scoring, words...)
+
+		matches := make(map[string]bool)
+		for _, word := range words {
+			for id := range s.postings[word] {
+				if candidates == nil || candidates[id] {
+					matches[id] = true
+				}
+			}
+		}
+		candidates = matches
+	}
+
+	total := float64(len(s.documents))
+	results := make([]SearchMatch, 0, len(candidates))
+	for id := range candidates {
+		score := 0.0
+		for _, word := range scoring {
+			positions, ok := s.postings[word][id]
+			if !ok {
+				continue
+			}
+			idf := math.Log(1 + total/float64(len(s.postings[word])))
+			for field, fieldPositions := range positions {
+				score += s.weights[field] * float64(len(fieldPositions)) * idf
+			}
+		}
+		results = append(results, SearchMatch{ID: id, Score: score})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID < results[j].ID
+	})
+	return results
+}
+
+func (s *SearchIndex) containsPhrase(id string, phrase []string) bool {
+	first := s.postings[phrase[0]][id]
+	for field, starts := range first {
+	next:
+		for _, start := range starts {
+			for offset, word := range phrase[1:] {
+				positions := s.postings[word][id]
+				if positions == nil || !containsInt(positions[field], start+offset+1) {
+					continue next
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func (s *SearchIndex) wordsWithPrefix(prefix string) []string {
+	start := sort.SearchStrings(s.vocabulary, prefix)
+	end := start
+	for end < len(s.vocabulary) && strings.HasPrefix(s.vocabulary[end], prefix) {
+		end++
+	}
+	return s.vocabulary[start:end:end]
+}
+
+// sortVocabulary rebuilds the sorted word list used for prefix lookups after words were
+// added or removed. The caller must hold the write lock.
+func (s *SearchIndex) sortVocabulary() {
+	if s.sorted {
+		return
+	}
+	s.vocabulary = make([]string, 0, len(s.postings))
+	for word := range s.postings {
+		s.vocabulary = append(s.vocabulary, word)
+	}
+	sort.Strings(s.vocabulary)
+	s.sorted = true
+}
+
+func containsInt(values []int, value int) bool {
+	i := sort.SearchInts(values, value)
+	return i < len(values) && values[i] == value
+}
@@ -0,0 +1,50 @@
+package infrastructure_test
+
+import (
+	"testing"
+
+	infrastructure "test_task_manager/Infrastructure"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	query := infrastructure.ParseSearchQuery(`Deploy "release notes" back* "solo" "unterminated`)
+
+	assert.Equal(t, []string{"deploy", "solo", "unterminated"}, query.Terms)
+	assert.Equal(t, [][]string{{"release", "notes"}}, query.Phrases)
+	assert.Equal(t, []string{"back"}, query.Prefixes)
+}
+
+func TestHighlight_EscapesAndTrims(t *testing.T) {
+	query := infrastructure.ParseSearchQuery("deadline")
+	text := "Intro text that goes on and on and on for quite a while before the <b>deadline</b> is mentioned, and then keeps going for a while longer after it too."
+
+	snippet := infrastructure.Highlight(text, query)
+
+	assert.Contains(t, snippet, "&lt;b&gt;<mark>deadline</mark>&lt;/b&gt;")
+	assert.Equal(t, "…", snippet[:len("…")])
+	assert.Equal(t, "…", snippet[len(snippet)-len("…"):])
+	assert.Empty(t, infrastructure.Highlight("nothing here", query))
+}
+
+func TestSearchIndex_PhraseRequiresAdjacentWords(t *testing.T) {
+	index := infrastructure.NewSearchIndex(3, 1)
+	index.Index("1", "notes for the release", "")
+	index.Index("2", "release notes", "")
+	index.Index("3", "", "draft the release notes today")
+
+	matches := index.Search(infrastructure.ParseSearchQuery(`"release notes"`))
+
+	assert.Len(t, matches, 2)
+	assert.Equal(t, "2", matches[0].ID)
+	assert.Equal(t, "3", matches[1].ID)
+}
+
+func TestSearchIndex_RemoveDropsDocument(t *testing.T) {
+	index := infrastructure.NewSearchIndex(1)
+	index.Index("1", "backup database")
+	index.Remove("1")
+
+	assert.Empty(t, index.Search(infrastructure.ParseSearchQuery("back*")))
+}
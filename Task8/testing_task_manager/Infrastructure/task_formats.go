@@ -0,0 +1,372 @@
+package infrastructure
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	domain "test_task_manager/Domain"
+	"time"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+	FormatICS   = "ics"
+)
+
+var csvHeader = []string{"id", "title", "description", "due_date", "status"}
+
+// ContentType returns the media type used when serving format, or "" if the format is unknown.
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatJSONL:
+		return "application/x-ndjson"
+	case FormatICS:
+		return "text/calendar; charset=utf-8"
+	}
+	return ""
+}
+
+// FormatFromContentType maps a request Content-Type back to a format name.
+func FormatFromContentType(contentType string) string {
+	mediaType := strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	switch mediaType {
+	case "text/csv":
+		return FormatCSV
+	case "application/x-ndjson", "application/jsonl", "application/x-jsonlines":
+		return FormatJSONL
+	case "text/calendar":
+		return FormatICS
+	}
+	return ""
+}
+
+// TaskEncoder writes tasks one at a time so exports never hold the whole collection in memory.
+type TaskEncoder interface {
+	Encode(task domain.Task) error
+	Close() error
+}
+
+func NewTaskEncoder(format string, w io.Writer) (TaskEncoder, error) {
+	switch format {
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(csvHeader); err != nil {
+			return nil, err
+		}
+		return &csvEncoder{writer: writer}, nil
+	case FormatJSONL:
+		return &jsonlEncoder{encoder: json.NewEncoder(w)}, nil
+	case FormatICS:
+		encoder := &icsEncoder{w: w, stamp: time.Now().UTC()}
+		if err := encoder.writeLines("BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//test_task_manager//Tasks//EN"); err != nil {
+			return nil, err
+		}
+		return encoder, nil
+	}
+	return nil, fmt.Errorf("unsupported format: %s", format)
+}
+
+type csvEncoder struct {
+	writer *csv.Writer
+}
+
+func (e *csvEncoder) Encode(task domain.Task) error {
+	dueDate := ""
+	if !task.DueDate.IsZero() {
+		dueDate = task.DueDate.Format(time.RFC3339)
+	}
+	return e.writer.Write([]string{task.ID, task.Title, task.Description, dueDate, task.Status})
+}
+
+func (e *csvEncoder) Close() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+type jsonlEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e *jsonlEncoder) Encode(task domain.Task) error {
+	return e.encoder.Encode(task)
+}
+
+func (e *jsonlEncoder) Close() error {
+	return nil
+}
+
+type icsEncoder struct {
+	w     io.Writer
+	stamp time.Time
+}
+
+// Encode writes the task as a VTODO. DUE carries the due date so calendar apps place the task on it.
+func (e *icsEncoder) Encode(task domain.Task) error {
+	lines := []string{
+		"BEGIN:VTODO",
+		"UID:" + escapeICS(task.ID),
+		"DTSTAMP:" + e.stamp.Format(icsTimeLayout),
+		"SUMMARY:" + escapeICS(task.Title),
+	}
+	if task.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeICS(task.Description))
+	}
+	if !task.DueDate.IsZero() {
+		lines = append(lines, "DUE:"+task.DueDate.UTC().Format(icsTimeLayout))
+	}
+	lines = append(lines, "STATUS:"+icsStatus(task.Status), "END:VTODO")
+	return e.writeLines(lines...)
+}
+
+func (e *icsEncoder) Close() error {
+	return e.writeLines("END:VCALENDAR")
+}
+
+func (e *icsEncoder) writeLines(lines ...string) error {
+	for _, line := range lines {
+		if _, err := io.WriteString(e.w, foldICS(line)+"\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const icsTimeLayout = "20060102T150405Z"
+
+// DecodeTasks parses an import file. Records that cannot be parsed are returned with Error set
+// instead of aborting the import, so the caller can report every bad row at once.
+func DecodeTasks(format string, r io.Reader) ([]domain.ImportRow, error) {
+	switch format {
+	case FormatCSV:
+		return decodeCSV(r)
+	case FormatJSONL:
+		return decodeJSONL(r)
+	case FormatICS:
+		return decodeICS(r)
+	}
+	return nil, fmt.Errorf("unsupported format: %s", format)
+}
+
+func decodeCSV(r io.Reader) ([]domain.ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["id"]; !ok {
+		return nil, errors.New("csv header must contain an id column")
+	}
+
+	var rows []domain.ImportRow
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rows = append(rows, domain.ImportRow{Row: row, Error: parseErr.Err.Error()})
+				continue
+			}
+			return nil, err
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		importRow := domain.ImportRow{Row: row, Task: domain.Task{
+			ID:          field("id"),
+			Title:       field("title"),
+			Description: field("description"),
+			Status:      field("status"),
+		}}
+		if dueDate := field("due_date"); dueDate != "" {
+			parsed, err := time.Parse(time.RFC3339, dueDate)
+			if err != nil {
+				importRow.Error = "due_date must be an RFC 3339 timestamp"
+			}
+			importRow.Task.DueDate = parsed
+		}
+		rows = append(rows, importRow)
+	}
+}
+
+func decodeJSONL(r io.Reader) ([]domain.ImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var rows []domain.ImportRow
+	row := 0
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		row++
+
+		importRow := domain.ImportRow{Row: row}
+		if err := json.Unmarshal([]byte(line), &importRow.Task); err != nil {
+			importRow.Error = "invalid JSON: " + err.Error()
+		}
+		rows = append(rows, importRow)
+	}
+
+	return rows, scanner.Err()
+}
+
+func decodeICS(r io.Reader) ([]domain.ImportRow, error) {
+	lines, err := unfoldICS(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []domain.ImportRow
+	var current *domain.ImportRow
+	for _, line := range lines {
+		name, params, value := splitICSLine(line)
+		switch {
+		case name == "BEGIN" && value == "VTODO":
+			current = &domain.ImportRow{Row: len(rows) + 1}
+		case name == "END" && value == "VTODO" && current != nil:
+			rows = append(rows, *current)
+			current = nil
+		case current == nil:
+			continue
+		case name == "UID":
+			current.Task.ID = unescapeICS(value)
+		case name == "SUMMARY":
+			current.Task.Title = unescapeICS(value)
+		case name == "DESCRIPTION":
+			current.Task.Description = unescapeICS(value)
+		case name == "STATUS":
+			current.Task.Status = taskStatusFromICS(value)
+		case name == "DUE":
+			due, err := parseICSTime(value, params)
+			if err != nil {
+				current.Error = "DUE must be an iCalendar DATE or DATE-TIME"
+			}
+			current.Task.DueDate = due
+		}
+	}
+
+	return rows, nil
+}
+
+func parseICSTime(value string, params string) (time.Time, error) {
+	if strings.Contains(params, "VALUE=DATE") && !strings.Contains(params, "VALUE=DATE-TIME") {
+		return time.Parse("20060102", value)
+	}
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse(icsTimeLayout, value)
+	}
+	// Floating and TZID times are read as UTC; the zone database is not consulted.
+	return time.Parse("20060102T150405", value)
+}
+
+func splitICSLine(line string) (name string, params string, value string) {
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return strings.ToUpper(line), "", ""
+	}
+	head, value := line[:colon], line[colon+1:]
+	if semicolon := strings.Index(head, ";"); semicolon >= 0 {
+		return strings.ToUpper(head[:semicolon]), strings.ToUpper(head[semicolon+1:]), value
+	}
+	return strings.ToUpper(head), "", value
+}
+
+// unfoldICS joins continuation lines (RFC 5545 section 3.1), which start with a space or tab.
+func unfoldICS(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// foldICS splits lines longer than 75 octets without breaking UTF-8 sequences.
+func foldICS(line string) string {
+	if len(line) <= 75 {
+		return line
+	}
+
+	var b strings.Builder
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+	b.WriteString(line)
+	return b.String()
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+var icsUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+
+func escapeICS(value string) string {
+	return icsEscaper.Replace(value)
+}
+
+func unescapeICS(value string) string {
+	return icsUnescaper.Replace(value)
+}
+
+func icsStatus(status string) string {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "completed", "complete", "done":
+		return "COMPLETED"
+	case "in progress", "in-progress", "in_progress":
+		return "IN-PROCESS"
+	case "cancelled", "canceled":
+		return "CANCELLED"
+	}
+	return "NEEDS-ACTION"
+}
+
+func taskStatusFromICS(status string) string {
+	switch strings.ToUpper(strings.TrimSpace(status)) {
+	case "COMPLETED":
+		return "Completed"
+	case "IN-PROCESS":
+		return "In Progress"
+	case "CANCELLED":
+		return "Cancelled"
+	}
+	return "Pending"
+}
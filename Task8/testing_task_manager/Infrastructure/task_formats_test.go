@@ -0,0 +1,94 @@
+package infrastructure_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var formatTasks = []domain.Task{
+	{ID: "1", Title: "Write report, part 1", Description: "line one\nline two; with \\ backslash", DueDate: time.Date(2024, 8, 20, 9, 30, 0, 0, time.UTC), Status: "Completed"},
+	{ID: "2", Title: strings.Repeat("long title ", 10), Status: "In Progress"},
+}
+
+func encodeTasks(t *testing.T, format string, tasks []domain.Task) string {
+	var buf bytes.Buffer
+	encoder, err := infrastructure.NewTaskEncoder(format, &buf)
+	require.NoError(t, err)
+	for _, task := range tasks {
+		require.NoError(t, encoder.Encode(task))
+	}
+	require.NoError(t, encoder.Close())
+	return buf.String()
+}
+
+func TestTaskFormats_RoundTrip(t *testing.T) {
+	for _, format := range []string{infrastructure.FormatCSV, infrastructure.FormatJSONL, infrastructure.FormatICS} {
+		t.Run(format, func(t *testing.T) {
+			encoded := encodeTasks(t, format, formatTasks)
+
+			rows, err := infrastructure.DecodeTasks(format, strings.NewReader(encoded))
+
+			require.NoError(t, err)
+			require.Len(t, rows, len(formatTasks))
+			for i, row := range rows {
+				assert.Equal(t, i+1, row.Row)
+				assert.Empty(t, row.Error)
+				assert.Equal(t, formatTasks[i].ID, row.Task.ID)
+				assert.Equal(t, strings.TrimSpace(formatTasks[i].Title), strings.TrimSpace(row.Task.Title))
+				assert.Equal(t, formatTasks[i].Status, row.Task.Status)
+				assert.True(t, formatTasks[i].DueDate.Equal(row.Task.DueDate))
+			}
+			assert.Equal(t, formatTasks[0].Description, rows[0].Task.Description)
+		})
+	}
+}
+
+func TestEncodeICS_MapsDueDateToVTODO(t *testing.T) {
+	encoded := encodeTasks(t, infrastructure.FormatICS, formatTasks[:1])
+
+	assert.True(t, strings.HasPrefix(encoded, "BEGIN:VCALENDAR\r\n"))
+	assert.Contains(t, encoded, "BEGIN:VTODO\r\n")
+	assert.Contains(t, encoded, "DUE:20240820T093000Z\r\n")
+	assert.Contains(t, encoded, "STATUS:COMPLETED\r\n")
+	assert.Contains(t, encoded, `SUMMARY:Write report\, part 1`)
+	for _, line := range strings.Split(encoded, "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+	}
+}
+
+func TestDecodeCSV_ReportsBadRows(t *testing.T) {
+	input := "id,title,due_date\n1,First,2024-08-20T09:30:00Z\n2,Second,tomorrow\n"
+
+	rows, err := infrastructure.DecodeTasks(infrastructure.FormatCSV, strings.NewReader(input))
+
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Empty(t, rows[0].Error)
+	assert.Equal(t, "due_date must be an RFC 3339 timestamp", rows[1].Error)
+}
+
+func TestDecodeCSV_RequiresIDColumn(t *testing.T) {
+	_, err := infrastructure.DecodeTasks(infrastructure.FormatCSV, strings.NewReader("title\nFirst\n"))
+
+	assert.EqualError(t, err, "csv header must contain an id column")
+}
+
+func TestDecodeICS_DateOnlyDue(t *testing.T) {
+	input := "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nUID:7\r\nSUMMARY:Pay rent\r\nDUE;VALUE=DATE:20240901\r\nEND:VTODO\r\nEND:VCALENDAR\r\n"
+
+	rows, err := infrastructure.DecodeTasks(infrastructure.FormatICS, strings.NewReader(input))
+
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "Pay rent", rows[0].Task.Title)
+	assert.Empty(t, rows[0].Task.Status)
+	assert.Equal(t, time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC), rows[0].Task.DueDate)
+}
@@ -0,0 +1,75 @@
+package infrastructure_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	infrastructure "test_task_manager/Infrastructure"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfcSecret is the SHA-1 key of the RFC 6238 test vectors, "12345678901234567890", in base32.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPGenerateCode_RFC6238(t *testing.T) {
+	totpService := infrastructure.NewTOTPService()
+
+	// The RFC lists 8 digit codes; these are their last 6 digits.
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, want := range vectors {
+		code, err := totpService.GenerateCode(rfcSecret, time.Unix(unix, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, want, code, "at %d", unix)
+	}
+}
+
+func TestTOTPValidate(t *testing.T) {
+	totpService := infrastructure.NewTOTPService()
+	now := time.Unix(1234567890, 0)
+	code, _ := totpService.GenerateCode(rfcSecret, now)
+
+	step, ok := totpService.Validate(rfcSecret, code, now)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1234567890/30), step)
+
+	_, ok = totpService.Validate(rfcSecret, code, now.Add(30*time.Second))
+	assert.True(t, ok, "one step of drift is allowed")
+
+	_, ok = totpService.Validate(rfcSecret, code, now.Add(90*time.Second))
+	assert.False(t, ok)
+
+	_, ok = totpService.Validate(rfcSecret, "12345", now)
+	assert.False(t, ok)
+}
+
+func TestTOTPGenerateSecret(t *testing.T) {
+	totpService := infrastructure.NewTOTPService()
+
+	secret, err := totpService.GenerateSecret()
+
+	assert.NoError(t, err)
+	assert.Len(t, secret, 32)
+	_, err = totpService.GenerateCode(secret, time.Now())
+	assert.NoError(t, err)
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	totpService := infrastructure.NewTOTPService()
+
+	uri, err := url.Parse(totpService.ProvisioningURI(rfcSecret, "Task Manager", "alice"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/Task Manager:alice", uri.Path)
+	assert.Equal(t, rfcSecret, uri.Query().Get("secret"))
+	assert.Equal(t, "Task Manager", uri.Query().Get("issuer"))
+	assert.Equal(t, "6", uri.Query().Get("digits"))
+}
@@ -0,0 +1,71 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const SignatureHeader = "X-Webhook-Signature"
+
+type WebhookRequest struct {
+	URL        string
+	Secret     string
+	EventID    string
+	EventType  string
+	DeliveryID string
+	Payload    []byte
+}
+
+type WebhookSender interface {
+	Send(c context.Context, request WebhookRequest) (int, error)
+}
+
+type HTTPWebhookSender struct {
+	client *http.Client
+}
+
+func NewWebhookSender(timeout time.Duration) *HTTPWebhookSender {
+	return &HTTPWebhookSender{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// SignPayload returns the value of the signature header: the hex HMAC-SHA256 of the raw body keyed by the webhook secret.
+func SignPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *HTTPWebhookSender) Send(c context.Context, request WebhookRequest) (int, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodPost, request.URL, bytes.NewReader(request.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", request.EventType)
+	req.Header.Set("X-Webhook-Event-ID", request.EventID)
+	req.Header.Set("X-Webhook-Delivery", request.DeliveryID)
+	req.Header.Set(SignatureHeader, SignPayload(request.Secret, request.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook endpoint responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
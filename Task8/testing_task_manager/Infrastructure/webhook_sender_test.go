@@ -0,0 +1,60 @@
+package infrastructure_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	infrastructure "test_task_manager/Infrastructure"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSend_SignsPayload(t *testing.T) {
+	payload := []byte(`{"type":"task.created"}`)
+
+	var signature, eventType string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(infrastructure.SignatureHeader)
+		eventType = r.Header.Get("X-Webhook-Event")
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := infrastructure.NewWebhookSender(time.Second)
+	status, err := sender.Send(context.Background(), infrastructure.WebhookRequest{
+		URL:       server.URL,
+		Secret:    "s3cret",
+		EventType: "task.created",
+		Payload:   payload,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, status)
+	assert.Equal(t, "task.created", eventType)
+	assert.Equal(t, payload, body)
+	assert.Equal(t, infrastructure.SignPayload("s3cret", payload), signature)
+}
+
+func TestSend_NonSuccessStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	sender := infrastructure.NewWebhookSender(time.Second)
+	status, err := sender.Send(context.Background(), infrastructure.WebhookRequest{URL: server.URL, Payload: []byte(`{}`)})
+
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadGateway, status)
+}
+
+func TestSignPayload(t *testing.T) {
+	// echo -n 'hello' | openssl dgst -sha256 -hmac key
+	assert.Equal(t, "sha256=9307b3b915efb5171ff14d8cb55fbcc798c6c0ef1456d66ded1a6aa723a58b7b", infrastructure.SignPayload("key", []byte("hello")))
+}
@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	domain "test_task_manager/Domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type apiKeyRepository struct {
+	database          mongo.Database
+	accountCollection string
+	keyCollection     string
+}
+
+func NewAPIKeyRepository(db mongo.Database, accountCollection string, keyCollection string) domain.APIKeyRepository {
+	return &apiKeyRepository{
+		database:          db,
+		accountCollection: accountCollection,
+		keyCollection:     keyCollection,
+	}
+}
+
+func (a *apiKeyRepository) CreateServiceAccount(c context.Context, account domain.ServiceAccount) error {
+	collection := a.database.Collection(a.accountCollection)
+
+	orgID, err := requireOrg(c)
+	if err != nil {
+		return err
+	}
+	account.OrgID = orgID
+	_, err = collection.InsertOne(c, account)
+	return err
+}
+
+func (a *apiKeyRepository) GetServiceAccounts(c context.Context) ([]domain.ServiceAccount, error) {
+	collection := a.database.Collection(a.accountCollection)
+
+	filter, err := orgFilter(c, "org_id")
+	if err != nil {
+		return nil, err
+	}
+	cur, err := collection.Find(c, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(c)
+
+	accounts := []domain.ServiceAccount{}
+	if err := cur.All(c, &accounts); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+func (a *apiKeyRepository) GetServiceAccountByID(c context.Context, accountID string) (*domain.ServiceAccount, error) {
+	collection := a.database.Collection(a.accountCollection)
+
+	filter, err := scoped(c, "org_id", bson.D{{Key: "id", Value: accountID}})
+	if err != nil {
+		return nil, err
+	}
+	var account domain.ServiceAccount
+	if err := collection.FindOne(c, filter).Decode(&account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (a *apiKeyRepository) CreateAPIKey(c context.Context, key domain.APIKey) error {
+	collection := a.database.Collection(a.keyCollection)
+
+	orgID, err := requireOrg(c)
+	if err != nil {
+		return err
+	}
+	key.OrgID = orgID
+	_, err = collection.InsertOne(c, key)
+	return err
+}
+
+func (a *apiKeyRepository) GetAPIKeys(c context.Context, serviceAccountID string) ([]domain.APIKey, error) {
+	collection := a.database.Collection(a.keyCollection)
+
+	filter, err := scoped(c, "org_id", bson.D{{Key: "service_account_id", Value: serviceAccountID}})
+	if err != nil {
+		return nil, err
+	}
+	cur, err := collection.Find(c, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(c)
+
+	keys := []domain.APIKey{}
+	if err := cur.All(c, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey keeps the key, marked revoked, so that listing still shows when it was last used.
+func (a *apiKeyRepository) RevokeAPIKey(c context.Context, serviceAccountID string, keyID string, at time.Time) error {
+	collection := a.database.Collection(a.keyCollection)
+
+	filter, err := scoped(c, "org_id", bson.D{
+		{Key: "id", Value: keyID},
+		{Key: "service_account_id", Value: serviceAccountID},
+		{Key: "revoked_at", Value: bson.D{{Key: "$exists", Value: false}}},
+	})
+	if err != nil {
+		return err
+	}
+	result, err := collection.UpdateOne(c, filter, bson.D{{Key: "$set", Value: bson.D{{Key: "revoked_at", Value: at}}}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("api key not found")
+	}
+	return nil
+}
+
+func (a *apiKeyRepository) FindAPIKey(c context.Context, keyID string) (*domain.APIKey, error) {
+	collection := a.database.Collection(a.keyCollection)
+
+	var key domain.APIKey
+	if err := collection.FindOne(c, bson.D{{Key: "id", Value: keyID}}).Decode(&key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (a *apiKeyRepository) TouchAPIKey(c context.Context, keyID string, at time.Time) error {
+	collection := a.database.Collection(a.keyCollection)
+
+	_, err := collection.UpdateOne(c, bson.D{{Key: "id", Value: keyID}}, bson.D{{Key: "$set", Value: bson.D{{Key: "last_used_at", Value: at}}}})
+	return err
+}
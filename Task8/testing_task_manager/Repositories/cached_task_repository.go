@@ -0,0 +1,255 @@
+package repositories
+
+import (
+	"container/list"
+	"context"
+	"expvar"
+	"sync"
+	domain "test_task_manager/Domain"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// CacheMetrics counts what a cache does. The counters are safe to read while it runs and can
+// be published with expvar.
+type CacheMetrics struct {
+	Hits      expvar.Int
+	Misses    expvar.Int
+	Evictions expvar.Int
+}
+
+// cachedTaskRepository keeps recently read tasks in memory in front of another TaskRepository.
+// Only GetTaskByID reads from the cache; every write evicts the tasks it touched. Each instance
+// has its own cache, so writes made through other instances show up once the entry expires.
+type cachedTaskRepository struct {
+	// Methods that are not overridden go straight to the backend.
+	domain.TaskRepository
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// order has the most recently used entry at the front.
+	order *list.List
+	size  int
+	ttl   time.Duration
+	clock domain.Clock
+	// generation changes on every eviction by a write. A load only stores its result if no
+	// write happened while it ran, so a task read before an update is not cached after it.
+	generation uint64
+
+	loads   singleflight.Group
+	metrics *CacheMetrics
+}
+
+type cacheEntry struct {
+	key       string
+	task      domain.Task
+	expiresAt time.Time
+}
+
+// NewCachedTaskRepository caches up to size tasks of backend for ttl each. Concurrent misses
+// for the same task share one read of the backend.
+func NewCachedTaskRepository(backend domain.TaskRepository, size int, ttl time.Duration, clock domain.Clock, metrics *CacheMetrics) domain.TaskRepository {
+	return &cachedTaskRepository{
+		TaskRepository: backend,
+		entries:        make(map[string]*list.Element),
+		order:          list.New(),
+		size:           size,
+		ttl:            ttl,
+		clock:          clock,
+		metrics:        metrics,
+	}
+}
+
+// cacheKey is per organization, since task IDs are only unique within one.
+func cacheKey(orgID string, taskID string) string {
+	return orgID + "\x00" + taskID
+}
+
+func (r *cachedTaskRepository) GetTaskByID(c context.Context, taskID string) (*domain.Task, error) {
+	orgID, err := requireOrg(c)
+	if err != nil {
+		return r.TaskRepository.GetTaskByID(c, taskID)
+	}
+	key := cacheKey(orgID, taskID)
+
+	if task, ok := r.get(key); ok {
+		r.metrics.Hits.Add(1)
+		return &task, nil
+	}
+	r.metrics.Misses.Add(1)
+
+	results := r.loads.DoChan(key, func() (interface{}, error) {
+		// The read is shared, so one caller going away must not fail it for the others.
+		loadCtx, cancel := detach(c)
+		defer cancel()
+
+		generation := r.currentGeneration()
+		task, err := r.TaskRepository.GetTaskByID(loadCtx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		r.put(key, *task, generation)
+		return *task, nil
+	})
+
+	select {
+	case <-c.Done():
+		return nil, c.Err()
+	case result := <-results:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		task := result.Val.(domain.Task)
+		return &task, nil
+	}
+}
+
+// detach keeps the values and deadline of c but not its cancellation.
+func detach(c context.Context) (context.Context, context.CancelFunc) {
+	detached := context.WithoutCancel(c)
+	if deadline, ok := c.Deadline(); ok {
+		return context.WithDeadline(detached, deadline)
+	}
+	return context.WithCancel(detached)
+}
+
+func (r *cachedTaskRepository) UpdateTask(c context.Context, taskID string, updatedTask domain.Task) (*domain.Task, error) {
+	defer r.evict(c, taskID)
+	return r.TaskRepository.UpdateTask(c, taskID, updatedTask)
+}
+
+func (r *cachedTaskRepository) DeleteTask(c context.Context, taskID string) error {
+	defer r.evict(c, taskID)
+	return r.TaskRepository.DeleteTask(c, taskID)
+}
+
+func (r *cachedTaskRepository) RestoreTask(c context.Context, taskID string) (*domain.Task, error) {
+	defer r.evict(c, taskID)
+	return r.TaskRepository.RestoreTask(c, taskID)
+}
+
+func (r *cachedTaskRepository) PurgeTask(c context.Context, taskID string) error {
+	defer r.evict(c, taskID)
+	return r.TaskRepository.PurgeTask(c, taskID)
+}
+
+func (r *cachedTaskRepository) PurgeDeletedBefore(c context.Context, cutoff time.Time) ([]domain.Task, error) {
+	purged, err := r.TaskRepository.PurgeDeletedBefore(c, cutoff)
+	taskIDs := make([]string, len(purged))
+	for i, task := range purged {
+		taskIDs[i] = task.ID
+	}
+	r.evict(c, taskIDs...)
+	return purged, err
+}
+
+// BulkWrite evicts every task named by an operation, including ones that failed, since a
+// failure may come after part of the write was applied.
+func (r *cachedTaskRepository) BulkWrite(c context.Context, operations []domain.BulkOperation) ([]domain.BulkResult, error) {
+	taskIDs := make([]string, 0, len(operations))
+	for _, operation := range operations {
+		taskIDs = append(taskIDs, operation.ID, operation.Task.ID)
+	}
+	defer r.evict(c, taskIDs...)
+	return r.TaskRepository.BulkWrite(c, operations)
+}
+
+func (r *cachedTaskRepository) get(key string) (domain.Task, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	element, ok := r.entries[key]
+	if !ok {
+		return domain.Task{}, false
+	}
+	entry := element.Value.(*cacheEntry)
+	if r.clock.Now().After(entry.expiresAt) {
+		r.remove(element)
+		return domain.Task{}, false
+	}
+	r.order.MoveToFront(element)
+	return entry.task, true
+}
+
+func (r *cachedTaskRepository) put(key string, task domain.Task, generation uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if generation != r.generation {
+		return
+	}
+	expiresAt := r.clock.Now().Add(r.ttl)
+	if element, ok := r.entries[key]; ok {
+		element.Value = &cacheEntry{key: key, task: task, expiresAt: expiresAt}
+		r.order.MoveToFront(element)
+		return
+	}
+	r.entries[key] = r.order.PushFront(&cacheEntry{key: key, task: task, expiresAt: expiresAt})
+	for r.order.Len() > r.size {
+		r.remove(r.order.Back())
+		r.metrics.Evictions.Add(1)
+	}
+}
+
+func (r *cachedTaskRepository) currentGeneration() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.generation
+}
+
+// evict drops the tasks of the organization of c, or of every organization for system jobs,
+// and makes later reads load them again rather than join a read that started before the write.
+func (r *cachedTaskRepository) evict(c context.Context, taskIDs ...string) {
+	if domain.IsAllOrgs(c) {
+		r.evictEverywhere(taskIDs...)
+		return
+	}
+	orgID, err := requireOrg(c)
+	if err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	r.generation++
+	for _, taskID := range taskIDs {
+		if element, ok := r.entries[cacheKey(orgID, taskID)]; ok {
+			r.remove(element)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, taskID := range taskIDs {
+		r.loads.Forget(cacheKey(orgID, taskID))
+	}
+}
+
+// evictEverywhere drops the tasks with the given IDs in every organization. Reads in progress
+// are not stored because the generation changes.
+func (r *cachedTaskRepository) evictEverywhere(taskIDs ...string) {
+	evicted := make(map[string]bool, len(taskIDs))
+	for _, taskID := range taskIDs {
+		evicted[taskID] = true
+	}
+
+	r.mu.Lock()
+	r.generation++
+	var keys []string
+	for key, element := range r.entries {
+		if evicted[element.Value.(*cacheEntry).task.ID] {
+			r.remove(element)
+			keys = append(keys, key)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, key := range keys {
+		r.loads.Forget(key)
+	}
+}
+
+// remove must be called with mu held.
+func (r *cachedTaskRepository) remove(element *list.Element) {
+	r.order.Remove(element)
+	delete(r.entries, element.Value.(*cacheEntry).key)
+}
@@ -0,0 +1,189 @@
+package repositories_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	repositories "test_task_manager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// countingTaskRepository counts reads of GetTaskByID and, when gate is set, holds them until
+// it is closed.
+type countingTaskRepository struct {
+	domain.TaskRepository
+	reads atomic.Int64
+	gate  chan struct{}
+}
+
+func (r *countingTaskRepository) GetTaskByID(c context.Context, taskID string) (*domain.Task, error) {
+	r.reads.Add(1)
+	if r.gate != nil {
+		<-r.gate
+	}
+	return r.TaskRepository.GetTaskByID(c, taskID)
+}
+
+type CachedTaskRepositorySuite struct {
+	suite.Suite
+	backend    *countingTaskRepository
+	clock      *infrastructure.FakeClock
+	metrics    *repositories.CacheMetrics
+	repository domain.TaskRepository
+	ctx        context.Context
+}
+
+func (suite *CachedTaskRepositorySuite) SetupTest() {
+	suite.clock = infrastructure.NewFakeClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	suite.backend = &countingTaskRepository{TaskRepository: repositories.NewInMemoryTaskRepository(suite.clock)}
+	suite.metrics = &repositories.CacheMetrics{}
+	suite.repository = repositories.NewCachedTaskRepository(suite.backend, 2, time.Minute, suite.clock, suite.metrics)
+	suite.ctx = domain.WithOrg(context.Background(), "org1")
+}
+
+func (suite *CachedTaskRepositorySuite) create(tasks ...domain.Task) {
+	for _, task := range tasks {
+		_, err := suite.repository.CreateTask(suite.ctx, task)
+		suite.Require().NoError(err)
+	}
+}
+
+func (suite *CachedTaskRepositorySuite) get(taskID string) *domain.Task {
+	task, err := suite.repository.GetTaskByID(suite.ctx, taskID)
+	suite.Require().NoError(err)
+	return task
+}
+
+func (suite *CachedTaskRepositorySuite) TestGetTaskByID_ServesRepeatedReadsFromCache() {
+	suite.create(domain.Task{ID: "1", Title: "Test Task"})
+
+	suite.Equal("Test Task", suite.get("1").Title)
+	suite.Equal("Test Task", suite.get("1").Title)
+
+	suite.Equal(int64(1), suite.backend.reads.Load())
+	suite.Equal(int64(1), suite.metrics.Hits.Value())
+	suite.Equal(int64(1), suite.metrics.Misses.Value())
+}
+
+func (suite *CachedTaskRepositorySuite) TestGetTaskByID_DoesNotCacheErrors() {
+	_, err := suite.repository.GetTaskByID(suite.ctx, "missing")
+	suite.Error(err)
+	_, err = suite.repository.GetTaskByID(suite.ctx, "missing")
+	suite.Error(err)
+
+	suite.Equal(int64(2), suite.backend.reads.Load())
+}
+
+func (suite *CachedTaskRepositorySuite) TestGetTaskByID_ExpiresEntries() {
+	suite.create(domain.Task{ID: "1"})
+
+	suite.get("1")
+	suite.clock.Advance(time.Minute - time.Second)
+	suite.get("1")
+	suite.Equal(int64(1), suite.backend.reads.Load())
+
+	suite.clock.Advance(2 * time.Second)
+	suite.get("1")
+	suite.Equal(int64(2), suite.backend.reads.Load())
+}
+
+func (suite *CachedTaskRepositorySuite) TestGetTaskByID_EvictsLeastRecentlyUsed() {
+	suite.create(domain.Task{ID: "1"}, domain.Task{ID: "2"}, domain.Task{ID: "3"})
+
+	suite.get("1")
+	suite.get("2")
+	suite.get("1")
+	suite.get("3") // evicts 2, which was used less recently than 1
+
+	suite.get("1")
+	suite.Equal(int64(3), suite.backend.reads.Load())
+	suite.get("2")
+	suite.Equal(int64(4), suite.backend.reads.Load())
+	suite.Equal(int64(2), suite.metrics.Evictions.Value())
+}
+
+func (suite *CachedTaskRepositorySuite) TestWrites_EvictTheTask() {
+	suite.create(domain.Task{ID: "1", Title: "Before"})
+	suite.get("1")
+
+	_, err := suite.repository.UpdateTask(suite.ctx, "1", domain.Task{Title: "After"})
+	suite.Require().NoError(err)
+	suite.Equal("After", suite.get("1").Title)
+
+	suite.Require().NoError(suite.repository.DeleteTask(suite.ctx, "1"))
+	_, err = suite.repository.GetTaskByID(suite.ctx, "1")
+	suite.Error(err)
+
+	_, err = suite.repository.RestoreTask(suite.ctx, "1")
+	suite.Require().NoError(err)
+	suite.Equal("After", suite.get("1").Title)
+
+	_, err = suite.repository.BulkWrite(suite.ctx, []domain.BulkOperation{
+		{Op: domain.BulkUpdate, ID: "1", Task: domain.Task{Title: "Bulk"}},
+	})
+	suite.Require().NoError(err)
+	suite.Equal("Bulk", suite.get("1").Title)
+}
+
+func (suite *CachedTaskRepositorySuite) TestPurgeForAllOrganizationsEvictsTheTask() {
+	suite.create(domain.Task{ID: "1", Title: "Trashed elsewhere"})
+	suite.get("1")
+	// Another instance moves the task to the trash, so this cache still has it.
+	suite.Require().NoError(suite.backend.DeleteTask(suite.ctx, "1"))
+
+	purged, err := suite.repository.PurgeDeletedBefore(domain.WithAllOrgs(context.Background()), suite.clock.Now().Add(time.Second))
+	suite.Require().NoError(err)
+	suite.Len(purged, 1)
+
+	_, err = suite.repository.GetTaskByID(suite.ctx, "1")
+	suite.Error(err)
+}
+
+func (suite *CachedTaskRepositorySuite) TestGetTaskByID_KeepsOrganizationsApart() {
+	suite.create(domain.Task{ID: "1", Title: "Org 1"})
+	suite.get("1")
+
+	_, err := suite.repository.GetTaskByID(domain.WithOrg(context.Background(), "org2"), "1")
+	suite.Error(err)
+}
+
+func (suite *CachedTaskRepositorySuite) TestGetTaskByID_SharesConcurrentMisses() {
+	suite.create(domain.Task{ID: "1"})
+	suite.backend.gate = make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := suite.repository.GetTaskByID(suite.ctx, "1")
+			suite.NoError(err)
+		}()
+	}
+	// Give every caller time to join the read before letting it finish.
+	time.Sleep(time.Millisecond * 50)
+	close(suite.backend.gate)
+	wg.Wait()
+
+	suite.Equal(int64(1), suite.backend.reads.Load())
+}
+
+func (suite *CachedTaskRepositorySuite) TestGetTaskByID_StopsWaitingWhenCanceled() {
+	suite.create(domain.Task{ID: "1"})
+	suite.backend.gate = make(chan struct{})
+	defer close(suite.backend.gate)
+
+	ctx, cancel := context.WithTimeout(suite.ctx, time.Millisecond*20)
+	defer cancel()
+	_, err := suite.repository.GetTaskByID(ctx, "1")
+	suite.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func TestCachedTaskRepositorySuite(t *testing.T) {
+	suite.Run(t, new(CachedTaskRepositorySuite))
+}
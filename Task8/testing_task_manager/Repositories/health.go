@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+type databaseHealthCheck struct {
+	database   mongo.Database
+	resilience *Resilience
+}
+
+// NewDatabaseHealthCheck fails while the circuit of resilience is open, without asking MongoDB,
+// and otherwise pings the primary within the read timeout.
+func NewDatabaseHealthCheck(db mongo.Database, resilience *Resilience) domain.HealthCheck {
+	return &databaseHealthCheck{
+		database:   db,
+		resilience: resilience,
+	}
+}
+
+func (h *databaseHealthCheck) Name() string {
+	return "database"
+}
+
+func (h *databaseHealthCheck) Check(c context.Context) error {
+	if h.resilience.Breaker().State() == infrastructure.CircuitOpen {
+		return errors.New("circuit breaker is open")
+	}
+	return h.resilience.attempt(c, readOp, func(ctx context.Context) error {
+		return h.database.Client().Ping(ctx, readpref.Primary())
+	})
+}
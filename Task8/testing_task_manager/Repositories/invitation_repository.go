@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	domain "test_task_manager/Domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type invitationRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewInvitationRepository(db mongo.Database, collection string) domain.InvitationRepository {
+	return &invitationRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (i *invitationRepository) CreateInvitation(c context.Context, invitation domain.Invitation) error {
+	collection := i.database.Collection(i.collection)
+
+	orgID, err := requireOrg(c)
+	if err != nil {
+		return err
+	}
+	invitation.OrgID = orgID
+	_, err = collection.InsertOne(c, invitation)
+	return err
+}
+
+func (i *invitationRepository) GetInvitations(c context.Context) ([]domain.Invitation, error) {
+	collection := i.database.Collection(i.collection)
+
+	filter, err := orgFilter(c, "org_id")
+	if err != nil {
+		return nil, err
+	}
+	cur, err := collection.Find(c, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(c)
+
+	invitations := []domain.Invitation{}
+	if err := cur.All(c, &invitations); err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (i *invitationRepository) DeleteInvitation(c context.Context, invitationID string) error {
+	collection := i.database.Collection(i.collection)
+
+	filter, err := scoped(c, "org_id", bson.D{{Key: "id", Value: invitationID}})
+	if err != nil {
+		return err
+	}
+	result, err := collection.DeleteOne(c, filter)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("invitation not found")
+	}
+	return nil
+}
+
+func (i *invitationRepository) RedeemInvitation(c context.Context, hash string, username string, at time.Time) (*domain.Invitation, error) {
+	collection := i.database.Collection(i.collection)
+
+	filter := bson.D{
+		{Key: "hash", Value: hash},
+		{Key: "used_at", Value: bson.D{{Key: "$exists", Value: false}}},
+		{Key: "expires_at", Value: bson.D{{Key: "$gt", Value: at}}},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "used_by", Value: username}, {Key: "used_at", Value: at}}}}
+	var invitation domain.Invitation
+	err := collection.FindOneAndUpdate(c, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&invitation)
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (i *invitationRepository) ReleaseInvitation(c context.Context, hash string, username string) error {
+	collection := i.database.Collection(i.collection)
+
+	filter := bson.D{{Key: "hash", Value: hash}, {Key: "used_by", Value: username}}
+	update := bson.D{{Key: "$unset", Value: bson.D{{Key: "used_by", Value: ""}, {Key: "used_at", Value: ""}}}}
+	_, err := collection.UpdateOne(c, filter, update)
+	return err
+}
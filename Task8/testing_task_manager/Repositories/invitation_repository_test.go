@@ -0,0 +1,94 @@
+package repositories_test
+
+import (
+	"context"
+	domain "test_task_manager/Domain"
+	repositories "test_task_manager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type InvitationRepositorySuite struct {
+	suite.Suite
+	repository domain.InvitationRepository
+	ctx        context.Context
+	cleanup    func()
+}
+
+func (suite *InvitationRepositorySuite) SetupSuite() {
+	suite.ctx = domain.WithOrg(context.Background(), "org1")
+	client, err := mongo.Connect(context.TODO(), options.Client().ApplyURI("mongodb://localhost:27017"))
+	suite.Require().NoError(err)
+
+	db := client.Database("test_db")
+	suite.repository = repositories.NewInvitationRepository(*db, "invitations")
+	suite.cleanup = func() {
+		db.Collection("invitations").Drop(context.TODO())
+	}
+}
+
+func (suite *InvitationRepositorySuite) TearDownTest() {
+	suite.cleanup()
+}
+
+func (suite *InvitationRepositorySuite) TestRedeemInvitation_OnlyOnce() {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	err := suite.repository.CreateInvitation(suite.ctx, domain.Invitation{ID: "i1", Role: "Admin", Hash: "hash", CreatedAt: now, ExpiresAt: now.Add(time.Hour)})
+	suite.Require().NoError(err)
+
+	invitation, err := suite.repository.RedeemInvitation(context.Background(), "hash", "alice", now)
+	suite.NoError(err)
+	suite.Equal("org1", invitation.OrgID)
+	suite.Equal("alice", invitation.UsedBy)
+
+	_, err = suite.repository.RedeemInvitation(context.Background(), "hash", "bob", now)
+	suite.Error(err)
+}
+
+func (suite *InvitationRepositorySuite) TestReleaseInvitation_OnlyByItsUser() {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	err := suite.repository.CreateInvitation(suite.ctx, domain.Invitation{ID: "i1", Role: "User", Hash: "hash", CreatedAt: now, ExpiresAt: now.Add(time.Hour)})
+	suite.Require().NoError(err)
+	_, err = suite.repository.RedeemInvitation(context.Background(), "hash", "alice", now)
+	suite.Require().NoError(err)
+
+	suite.NoError(suite.repository.ReleaseInvitation(context.Background(), "hash", "bob"))
+	_, err = suite.repository.RedeemInvitation(context.Background(), "hash", "bob", now)
+	suite.Error(err, "only the user who redeemed the invitation releases it")
+
+	suite.NoError(suite.repository.ReleaseInvitation(context.Background(), "hash", "alice"))
+	invitation, err := suite.repository.RedeemInvitation(context.Background(), "hash", "bob", now)
+	suite.NoError(err)
+	suite.Equal("bob", invitation.UsedBy)
+}
+
+func (suite *InvitationRepositorySuite) TestRedeemInvitation_Expired() {
+	now := time.Now().UTC()
+	err := suite.repository.CreateInvitation(suite.ctx, domain.Invitation{ID: "i1", Role: "User", Hash: "hash", CreatedAt: now, ExpiresAt: now.Add(time.Hour)})
+	suite.Require().NoError(err)
+
+	_, err = suite.repository.RedeemInvitation(context.Background(), "hash", "alice", now.Add(2*time.Hour))
+
+	suite.Error(err)
+}
+
+func (suite *InvitationRepositorySuite) TestDeleteInvitation_OtherOrganization() {
+	now := time.Now().UTC()
+	err := suite.repository.CreateInvitation(suite.ctx, domain.Invitation{ID: "i1", Role: "User", Hash: "hash", CreatedAt: now, ExpiresAt: now.Add(time.Hour)})
+	suite.Require().NoError(err)
+
+	err = suite.repository.DeleteInvitation(domain.WithOrg(context.Background(), "org2"), "i1")
+	suite.EqualError(err, "invitation not found")
+
+	invitations, err := suite.repository.GetInvitations(suite.ctx)
+	suite.NoError(err)
+	suite.Len(invitations, 1)
+}
+
+func TestInvitationRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InvitationRepositorySuite))
+}
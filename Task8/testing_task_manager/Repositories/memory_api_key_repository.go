@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	domain "test_task_manager/Domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type inMemoryAPIKeyRepository struct {
+	mu       sync.Mutex
+	accounts map[string]domain.ServiceAccount
+	keys     map[string]domain.APIKey
+}
+
+func NewInMemoryAPIKeyRepository() domain.APIKeyRepository {
+	return &inMemoryAPIKeyRepository{
+		accounts: make(map[string]domain.ServiceAccount),
+		keys:     make(map[string]domain.APIKey),
+	}
+}
+
+func (a *inMemoryAPIKeyRepository) CreateServiceAccount(c context.Context, account domain.ServiceAccount) error {
+	orgID, err := requireOrg(c)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	account.OrgID = orgID
+	a.accounts[account.ID] = account
+	return nil
+}
+
+func (a *inMemoryAPIKeyRepository) GetServiceAccounts(c context.Context) ([]domain.ServiceAccount, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	accounts := []domain.ServiceAccount{}
+	for _, account := range a.accounts {
+		visible, err := visibleIn(c, account.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		if visible {
+			accounts = append(accounts, account)
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].CreatedAt.Before(accounts[j].CreatedAt) })
+	return accounts, nil
+}
+
+func (a *inMemoryAPIKeyRepository) GetServiceAccountByID(c context.Context, accountID string) (*domain.ServiceAccount, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	account, ok := a.accounts[accountID]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	visible, err := visibleIn(c, account.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, mongo.ErrNoDocuments
+	}
+	return &account, nil
+}
+
+func (a *inMemoryAPIKeyRepository) CreateAPIKey(c context.Context, key domain.APIKey) error {
+	orgID, err := requireOrg(c)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	key.OrgID = orgID
+	a.keys[key.ID] = key
+	return nil
+}
+
+func (a *inMemoryAPIKeyRepository) GetAPIKeys(c context.Context, serviceAccountID string) ([]domain.APIKey, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	keys := []domain.APIKey{}
+	for _, key := range a.keys {
+		visible, err := visibleIn(c, key.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		if visible && key.ServiceAccountID == serviceAccountID {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].CreatedAt.After(keys[j].CreatedAt) })
+	return keys, nil
+}
+
+func (a *inMemoryAPIKeyRepository) RevokeAPIKey(c context.Context, serviceAccountID string, keyID string, at time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key, ok := a.keys[keyID]
+	if !ok || key.ServiceAccountID != serviceAccountID || key.RevokedAt != nil {
+		return errors.New("api key not found")
+	}
+	visible, err := visibleIn(c, key.OrgID)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return errors.New("api key not found")
+	}
+	key.RevokedAt = &at
+	a.keys[keyID] = key
+	return nil
+}
+
+func (a *inMemoryAPIKeyRepository) FindAPIKey(c context.Context, keyID string) (*domain.APIKey, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key, ok := a.keys[keyID]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return &key, nil
+}
+
+func (a *inMemoryAPIKeyRepository) TouchAPIKey(c context.Context, keyID string, at time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if key, ok := a.keys[keyID]; ok {
+		key.LastUsedAt = &at
+		a.keys[keyID] = key
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	domain "test_task_manager/Domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type inMemoryInvitationRepository struct {
+	mu          sync.Mutex
+	invitations map[string]domain.Invitation
+}
+
+func NewInMemoryInvitationRepository() domain.InvitationRepository {
+	return &inMemoryInvitationRepository{
+		invitations: make(map[string]domain.Invitation),
+	}
+}
+
+func (i *inMemoryInvitationRepository) CreateInvitation(c context.Context, invitation domain.Invitation) error {
+	orgID, err := requireOrg(c)
+	if err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, existing := range i.invitations {
+		if existing.ID == invitation.ID || existing.Hash == invitation.Hash {
+			return errors.New("invitation already exists")
+		}
+	}
+	invitation.OrgID = orgID
+	i.invitations[invitation.ID] = invitation
+	return nil
+}
+
+func (i *inMemoryInvitationRepository) GetInvitations(c context.Context) ([]domain.Invitation, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	invitations := []domain.Invitation{}
+	for _, invitation := range i.invitations {
+		visible, err := visibleIn(c, invitation.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		if visible {
+			invitations = append(invitations, invitation)
+		}
+	}
+	sort.Slice(invitations, func(a, b int) bool { return invitations[a].CreatedAt.After(invitations[b].CreatedAt) })
+	return invitations, nil
+}
+
+func (i *inMemoryInvitationRepository) DeleteInvitation(c context.Context, invitationID string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	invitation, ok := i.invitations[invitationID]
+	if !ok {
+		return errors.New("invitation not found")
+	}
+	visible, err := visibleIn(c, invitation.OrgID)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return errors.New("invitation not found")
+	}
+	delete(i.invitations, invitationID)
+	return nil
+}
+
+func (i *inMemoryInvitationRepository) RedeemInvitation(c context.Context, hash string, username string, at time.Time) (*domain.Invitation, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for id, invitation := range i.invitations {
+		if invitation.Hash != hash || invitation.UsedAt != nil || !invitation.ExpiresAt.After(at) {
+			continue
+		}
+		invitation.UsedBy = username
+		invitation.UsedAt = &at
+		i.invitations[id] = invitation
+		return &invitation, nil
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (i *inMemoryInvitationRepository) ReleaseInvitation(c context.Context, hash string, username string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for id, invitation := range i.invitations {
+		if invitation.Hash == hash && invitation.UsedBy == username {
+			invitation.UsedBy = ""
+			invitation.UsedAt = nil
+			i.invitations[id] = invitation
+		}
+	}
+	return nil
+}
+
+func (i *inMemoryInvitationRepository) snapshot() func() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	saved := make(map[string]domain.Invitation, len(i.invitations))
+	for id, invitation := range i.invitations {
+		saved[id] = invitation
+	}
+	return func() {
+		i.mu.Lock()
+		defer i.mu.Unlock()
+		i.invitations = saved
+	}
+}
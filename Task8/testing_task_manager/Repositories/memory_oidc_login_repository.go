@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	domain "test_task_manager/Domain"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type inMemoryOIDCLoginRepository struct {
+	mu     sync.Mutex
+	logins map[string]domain.OIDCLogin
+	clock  domain.Clock
+}
+
+func NewInMemoryOIDCLoginRepository(clock domain.Clock) domain.OIDCLoginRepository {
+	return &inMemoryOIDCLoginRepository{
+		logins: make(map[string]domain.OIDCLogin),
+		clock:  clock,
+	}
+}
+
+func (o *inMemoryOIDCLoginRepository) SaveLogin(c context.Context, login domain.OIDCLogin) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.logins[login.State] = login
+	return nil
+}
+
+func (o *inMemoryOIDCLoginRepository) TakeLogin(c context.Context, state string) (*domain.OIDCLogin, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	login, ok := o.logins[state]
+	if !ok || !login.ExpiresAt.After(o.clock.Now().UTC()) {
+		return nil, mongo.ErrNoDocuments
+	}
+	delete(o.logins, state)
+	return &login, nil
+}
@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	domain "test_task_manager/Domain"
+)
+
+type inMemoryOrganizationRepository struct {
+	mu   sync.Mutex
+	orgs map[string]domain.Organization
+}
+
+// NewInMemoryOrganizationRepository starts with the default organization, which migration 5
+// creates in MongoDB, stamped by clock.
+func NewInMemoryOrganizationRepository(clock domain.Clock) domain.OrganizationRepository {
+	return &inMemoryOrganizationRepository{
+		orgs: map[string]domain.Organization{
+			domain.DefaultOrgID: {ID: domain.DefaultOrgID, Name: "Default", CreatedAt: clock.Now().UTC()},
+		},
+	}
+}
+
+func (o *inMemoryOrganizationRepository) CreateOrganization(c context.Context, org domain.Organization) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if _, ok := o.orgs[org.ID]; ok {
+		return errors.New("organization already exists")
+	}
+	o.orgs[org.ID] = org
+	return nil
+}
+
+func (o *inMemoryOrganizationRepository) GetOrganizationsByIDs(c context.Context, orgIDs []string) ([]domain.Organization, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	orgs := []domain.Organization{}
+	for _, orgID := range orgIDs {
+		if org, ok := o.orgs[orgID]; ok {
+			orgs = append(orgs, org)
+		}
+	}
+	sort.Slice(orgs, func(i, j int) bool { return orgs[i].ID < orgs[j].ID })
+	return orgs, nil
+}
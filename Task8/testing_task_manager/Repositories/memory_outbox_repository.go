@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	domain "test_task_manager/Domain"
+	"time"
+)
+
+// inMemoryOutboxRepository keeps events in process, in the order they were enqueued.
+type inMemoryOutboxRepository struct {
+	mu         sync.Mutex
+	events     []domain.Event
+	dispatched map[string]bool
+	// claims holds when the claim on each claimed event runs out. Rolling back a transaction
+	// leaves them alone, like it leaves a claim made outside the transaction in MongoDB.
+	claims map[string]time.Time
+}
+
+func NewInMemoryOutboxRepository() domain.OutboxRepository {
+	return &inMemoryOutboxRepository{
+		dispatched: make(map[string]bool),
+		claims:     make(map[string]time.Time),
+	}
+}
+
+func (o *inMemoryOutboxRepository) Enqueue(c context.Context, event domain.Event) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, event)
+	return nil
+}
+
+func (o *inMemoryOutboxRepository) EnqueueMany(c context.Context, events []domain.Event) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, events...)
+	return nil
+}
+
+func (o *inMemoryOutboxRepository) ClaimPending(c context.Context, now time.Time, until time.Time) (*domain.Event, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var oldest *domain.Event
+	for i, event := range o.events {
+		if o.dispatched[event.ID] || o.claims[event.ID].After(now) {
+			continue
+		}
+		if oldest == nil || event.CreatedAt.Before(oldest.CreatedAt) {
+			oldest = &o.events[i]
+		}
+	}
+	if oldest == nil {
+		return nil, nil
+	}
+	o.claims[oldest.ID] = until
+	event := *oldest
+	return &event, nil
+}
+
+func (o *inMemoryOutboxRepository) MarkDispatched(c context.Context, eventID string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.dispatched[eventID] = true
+	return nil
+}
+
+func (o *inMemoryOutboxRepository) snapshot() func() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	events := append([]domain.Event(nil), o.events...)
+	dispatched := make(map[string]bool, len(o.dispatched))
+	for id := range o.dispatched {
+		dispatched[id] = true
+	}
+	return func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		o.events = events
+		o.dispatched = dispatched
+	}
+}
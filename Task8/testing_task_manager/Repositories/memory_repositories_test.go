@@ -0,0 +1,77 @@
+package repositories_test
+
+import (
+	"context"
+	domain "test_task_manager/Domain"
+	repositories "test_task_manager/Repositories"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// The in-memory repositories stand in for MongoDB in the end-to-end tests, so they have to pass
+// the MongoDB suites too.
+
+type InMemoryUserRepositorySuite struct {
+	UserRepositorySuite
+}
+
+func (suite *InMemoryUserRepositorySuite) SetupSuite() {
+	suite.ctx = domain.WithOrg(context.Background(), "org1")
+	suite.repository = repositories.NewInMemoryUserRepository()
+	suite.cleanup = func() {
+		suite.repository = repositories.NewInMemoryUserRepository()
+	}
+}
+
+func TestInMemoryUserRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InMemoryUserRepositorySuite))
+}
+
+type InMemoryInvitationRepositorySuite struct {
+	InvitationRepositorySuite
+}
+
+func (suite *InMemoryInvitationRepositorySuite) SetupSuite() {
+	suite.ctx = domain.WithOrg(context.Background(), "org1")
+	suite.repository = repositories.NewInMemoryInvitationRepository()
+	suite.cleanup = func() {
+		suite.repository = repositories.NewInMemoryInvitationRepository()
+	}
+}
+
+func TestInMemoryInvitationRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InMemoryInvitationRepositorySuite))
+}
+
+type InMemoryWebhookDeliveryRepositorySuite struct {
+	WebhookDeliveryRepositorySuite
+}
+
+func (suite *InMemoryWebhookDeliveryRepositorySuite) SetupSuite() {
+	suite.ctx = domain.WithOrg(context.Background(), "org1")
+	suite.repository = repositories.NewInMemoryWebhookDeliveryRepository()
+	suite.cleanup = func() {
+		suite.repository = repositories.NewInMemoryWebhookDeliveryRepository()
+	}
+}
+
+func TestInMemoryWebhookDeliveryRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InMemoryWebhookDeliveryRepositorySuite))
+}
+
+type InMemoryOutboxRepositorySuite struct {
+	OutboxRepositorySuite
+}
+
+func (suite *InMemoryOutboxRepositorySuite) SetupSuite() {
+	suite.ctx = domain.WithOrg(context.Background(), "org1")
+	suite.repository = repositories.NewInMemoryOutboxRepository()
+	suite.cleanup = func() {
+		suite.repository = repositories.NewInMemoryOutboxRepository()
+	}
+}
+
+func TestInMemoryOutboxRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InMemoryOutboxRepositorySuite))
+}
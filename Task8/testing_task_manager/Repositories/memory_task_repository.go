@@ -0,0 +1,515 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// inMemoryTaskRepository keeps each organization's tasks in a map and searches them through an
+// inverted index. It reports missing tasks with mongo.ErrNoDocuments so callers behave the same
+// with either backend.
+type inMemoryTaskRepository struct {
+	mu   sync.RWMutex
+	orgs map[string]*memoryOrgTasks
+	// clock stamps deleted_at, so trash retention can be tested without waiting.
+	clock domain.Clock
+}
+
+// memoryOrgTasks holds one organization's tasks. Lookups never leave it, so a task ID used by
+// two organizations refers to two different tasks.
+type memoryOrgTasks struct {
+	tasks map[string]domain.Task
+	index *infrastructure.SearchIndex
+}
+
+func NewInMemoryTaskRepository(clock domain.Clock) domain.TaskRepository {
+	return &inMemoryTaskRepository{
+		orgs:  make(map[string]*memoryOrgTasks),
+		clock: clock,
+	}
+}
+
+func newMemoryOrgTasks() *memoryOrgTasks {
+	return &memoryOrgTasks{
+		tasks: make(map[string]domain.Task),
+		index: infrastructure.NewSearchIndex(titleSearchWeight, descriptionSearchWeight),
+	}
+}
+
+// org returns the tasks of the organization of c. Readers get an empty set for an organization
+// without tasks; writers, which hold the write lock, get one that is kept.
+func (t *inMemoryTaskRepository) org(c context.Context, write bool) (*memoryOrgTasks, error) {
+	orgID, err := requireOrg(c)
+	if err != nil {
+		return nil, err
+	}
+	org, ok := t.orgs[orgID]
+	if !ok {
+		org = newMemoryOrgTasks()
+		if write {
+			t.orgs[orgID] = org
+		}
+	}
+	return org, nil
+}
+
+func (t *inMemoryTaskRepository) CreateTask(c context.Context, newTask domain.Task) (*domain.Task, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	org, err := t.org(c, true)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := org.tasks[newTask.ID]; ok {
+		return nil, errors.New("task with the given id already exists")
+	}
+	newTask.OrgID, _ = domain.OrgFromContext(c)
+	newTask.DeletedAt = nil
+	stampNewTask(&newTask, t.clock.Now().UTC())
+	org.put(newTask)
+	return &newTask, nil
+}
+
+func (t *inMemoryTaskRepository) DeleteTask(c context.Context, taskID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	org, err := t.org(c, true)
+	if err != nil {
+		return err
+	}
+	task, ok := org.live(taskID)
+	if !ok {
+		return errors.New("task not found")
+	}
+	org.trash(task, t.clock.Now().UTC())
+	return nil
+}
+
+func (t *inMemoryTaskRepository) GetDeletedTasks(c context.Context) ([]domain.Task, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	org, err := t.org(c, false)
+	if err != nil {
+		return nil, err
+	}
+	tasks := []domain.Task{}
+	for _, task := range org.tasks {
+		if task.DeletedAt != nil {
+			tasks = append(tasks, task)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].DeletedAt.After(*tasks[j].DeletedAt) })
+	return tasks, nil
+}
+
+func (t *inMemoryTaskRepository) RestoreTask(c context.Context, taskID string) (*domain.Task, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	org, err := t.org(c, true)
+	if err != nil {
+		return nil, err
+	}
+	task, ok := org.tasks[taskID]
+	if !ok || task.DeletedAt == nil {
+		return nil, errors.New("task not found in trash")
+	}
+	task.DeletedAt = nil
+	org.put(task)
+	return &task, nil
+}
+
+func (t *inMemoryTaskRepository) PurgeTask(c context.Context, taskID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	org, err := t.org(c, true)
+	if err != nil {
+		return err
+	}
+	if _, ok := org.tasks[taskID]; !ok {
+		return errors.New("task not found")
+	}
+	org.remove(taskID)
+	return nil
+}
+
+func (t *inMemoryTaskRepository) PurgeDeletedBefore(c context.Context, cutoff time.Time) ([]domain.Task, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	orgs := t.orgs
+	if !domain.IsAllOrgs(c) {
+		org, err := t.org(c, true)
+		if err != nil {
+			return nil, err
+		}
+		orgs = map[string]*memoryOrgTasks{"": org}
+	}
+
+	var purged []domain.Task
+	for _, org := range orgs {
+		for id, task := range org.tasks {
+			if task.DeletedAt != nil && task.DeletedAt.Before(cutoff) {
+				org.remove(id)
+				purged = append(purged, task)
+			}
+		}
+	}
+	sort.Slice(purged, func(i, j int) bool {
+		if purged[i].OrgID != purged[j].OrgID {
+			return purged[i].OrgID < purged[j].OrgID
+		}
+		return purged[i].ID < purged[j].ID
+	})
+	return purged, nil
+}
+
+func (t *inMemoryTaskRepository) GetTaskByID(c context.Context, taskID string) (*domain.Task, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	org, err := t.org(c, false)
+	if err != nil {
+		return nil, err
+	}
+	task, ok := org.live(taskID)
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return &task, nil
+}
+
+func (t *inMemoryTaskRepository) GetTasks(c context.Context) ([]domain.Task, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	org, err := t.org(c, false)
+	if err != nil {
+		return nil, err
+	}
+	return org.sorted(), nil
+}
+
+func (t *inMemoryTaskRepository) UpdateTask(c context.Context, taskID string, updatedTask domain.Task) (*domain.Task, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	org, err := t.org(c, true)
+	if err != nil {
+		return nil, err
+	}
+	task, ok := org.live(taskID)
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	task = applyTaskUpdate(task, updatedTask, t.clock.Now().UTC())
+	org.put(task)
+	return &task, nil
+}
+
+func (t *inMemoryTaskRepository) GetTasksByIDs(c context.Context, taskIDs []string) ([]domain.Task, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	org, err := t.org(c, false)
+	if err != nil {
+		return nil, err
+	}
+	var tasks []domain.Task
+	for _, id := range taskIDs {
+		if task, ok := org.live(id); ok {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+// ForEachTask iterates over a snapshot so fn may call back into the repository.
+func (t *inMemoryTaskRepository) ForEachTask(c context.Context, fn func(task domain.Task) error) error {
+	t.mu.RLock()
+	org, err := t.org(c, false)
+	if err != nil {
+		t.mu.RUnlock()
+		return err
+	}
+	tasks := org.sorted()
+	t.mu.RUnlock()
+
+	for _, task := range tasks {
+		if err := c.Err(); err != nil {
+			return err
+		}
+		if err := fn(task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *inMemoryTaskRepository) BulkWrite(c context.Context, operations []domain.BulkOperation) ([]domain.BulkResult, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	org, err := t.org(c, true)
+	if err != nil {
+		return nil, err
+	}
+	orgID, _ := domain.OrgFromContext(c)
+
+	now := t.clock.Now().UTC()
+	results := make([]domain.BulkResult, len(operations))
+	for i, operation := range operations {
+		id := bulkTaskID(operation)
+		result := domain.BulkResult{Index: i, Op: operation.Op, ID: id, Status: domain.BulkStatusFailed}
+		existing, exists := org.live(id)
+
+		switch operation.Op {
+		case domain.BulkCreate:
+			if _, taken := org.tasks[id]; taken {
+				result.Error = "task with the given id already exists"
+				break
+			}
+			task := operation.Task
+			task.OrgID = orgID
+			task.DeletedAt = nil
+			stampNewTask(&task, now)
+			org.put(task)
+			result.Status, result.Task = domain.BulkStatusOK, &task
+		case domain.BulkUpdate:
+			if !exists {
+				result.Error = "task not found"
+				break
+			}
+			if len(taskUpdateFields(operation.Task)) == 0 {
+				result.Error = "no fields to update"
+				break
+			}
+			task := applyTaskUpdate(existing, operation.Task, now)
+			org.put(task)
+			result.Status, result.Task = domain.BulkStatusOK, &task
+		case domain.BulkDelete:
+			if !exists {
+				result.Error = "task not found"
+				break
+			}
+			org.trash(existing, now)
+			result.Status = domain.BulkStatusOK
+		default:
+			result.Error = "unknown operation: " + operation.Op
+		}
+
+		results[i] = result
+	}
+	return results, nil
+}
+
+func (t *inMemoryTaskRepository) SearchTasks(c context.Context, query string, offset int, limit int) ([]domain.SearchHit, int, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	org, err := t.org(c, false)
+	if err != nil {
+		return nil, 0, err
+	}
+	parsed := infrastructure.ParseSearchQuery(query)
+	matches := org.index.Search(parsed)
+
+	hits := []domain.SearchHit{}
+	if offset < 0 {
+		offset = 0
+	}
+	for i := offset; i < len(matches) && len(hits) < limit; i++ {
+		task, ok := org.live(matches[i].ID)
+		if !ok {
+			continue
+		}
+		hits = append(hits, newSearchHit(task, matches[i].Score, parsed))
+	}
+	return hits, len(matches), nil
+}
+
+func (t *inMemoryTaskRepository) TaskStats(c context.Context, now time.Time) (*domain.TaskStats, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	org, err := t.org(c, false)
+	if err != nil {
+		return nil, err
+	}
+	stats := &domain.TaskStats{ByStatus: map[string]int{}, ByAssignee: []domain.AssigneeCounts{}}
+	byAssignee := map[string]*domain.AssigneeCounts{}
+	for _, task := range org.sorted() {
+		completed := domain.IsCompleted(task.Status)
+		overdue := !completed && !task.DueDate.IsZero() && task.DueDate.Before(now)
+
+		stats.ByStatus[task.Status]++
+		assignee, ok := byAssignee[task.Assignee]
+		if !ok {
+			assignee = &domain.AssigneeCounts{Assignee: task.Assignee}
+			byAssignee[task.Assignee] = assignee
+		}
+		stats.Count(completed, overdue)
+		assignee.Count(completed, overdue)
+	}
+	for _, assignee := range byAssignee {
+		stats.ByAssignee = append(stats.ByAssignee, *assignee)
+	}
+	sortAssignees(stats.ByAssignee)
+	return stats, nil
+}
+
+func (t *inMemoryTaskRepository) TaskActivity(c context.Context, until time.Time) ([]domain.DailyActivity, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	org, err := t.org(c, false)
+	if err != nil {
+		return nil, err
+	}
+	byDay := map[string]*domain.DailyActivity{}
+	count := func(at *time.Time) *domain.DailyActivity {
+		day := ""
+		if at != nil {
+			if !at.Before(until) {
+				return nil
+			}
+			day = at.UTC().Format(domain.ReportDateLayout)
+		}
+		if _, ok := byDay[day]; !ok {
+			byDay[day] = &domain.DailyActivity{Day: day}
+		}
+		return byDay[day]
+	}
+	for _, task := range org.sorted() {
+		if activity := count(task.CreatedAt); activity != nil {
+			activity.Created++
+		}
+		if !domain.IsCompleted(task.Status) {
+			continue
+		}
+		// Tasks completed before completion times were recorded fall back to their creation.
+		completedAt := task.CompletedAt
+		if completedAt == nil {
+			completedAt = task.CreatedAt
+		}
+		if activity := count(completedAt); activity != nil {
+			activity.Completed++
+		}
+	}
+
+	activity := make([]domain.DailyActivity, 0, len(byDay))
+	for _, day := range byDay {
+		activity = append(activity, *day)
+	}
+	sort.Slice(activity, func(i, j int) bool { return activity[i].Day < activity[j].Day })
+	return activity, nil
+}
+
+func (t *inMemoryTaskRepository) snapshot() func() {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	saved := make(map[string]map[string]domain.Task, len(t.orgs))
+	for orgID, org := range t.orgs {
+		tasks := make(map[string]domain.Task, len(org.tasks))
+		for id, task := range org.tasks {
+			tasks[id] = task
+		}
+		saved[orgID] = tasks
+	}
+
+	// The search index is rebuilt rather than copied.
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		t.orgs = make(map[string]*memoryOrgTasks, len(saved))
+		for orgID, tasks := range saved {
+			org := newMemoryOrgTasks()
+			for _, task := range tasks {
+				if task.DeletedAt != nil {
+					org.trash(task, *task.DeletedAt)
+				} else {
+					org.put(task)
+				}
+			}
+			t.orgs[orgID] = org
+		}
+	}
+}
+
+// put stores the task and re-indexes it. The caller must hold the write lock.
+func (o *memoryOrgTasks) put(task domain.Task) {
+	o.tasks[task.ID] = task
+	o.index.Index(task.ID, task.Title, task.Description)
+}
+
+// trash sets the tombstone and drops the task from the search index.
+func (o *memoryOrgTasks) trash(task domain.Task, deletedAt time.Time) {
+	task.DeletedAt = &deletedAt
+	o.tasks[task.ID] = task
+	o.index.Remove(task.ID)
+}
+
+func (o *memoryOrgTasks) remove(taskID string) {
+	delete(o.tasks, taskID)
+	o.index.Remove(taskID)
+}
+
+// live returns the task unless it is missing or in the trash.
+func (o *memoryOrgTasks) live(taskID string) (domain.Task, bool) {
+	task, ok := o.tasks[taskID]
+	if !ok || task.DeletedAt != nil {
+		return domain.Task{}, false
+	}
+	return task, true
+}
+
+func (o *memoryOrgTasks) sorted() []domain.Task {
+	tasks := make([]domain.Task, 0, len(o.tasks))
+	for _, task := range o.tasks {
+		if task.DeletedAt == nil {
+			tasks = append(tasks, task)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks
+}
+
+// applyTaskUpdate mirrors taskUpdate: only non-empty fields overwrite the stored task, and a
+// status change stamps or clears the completion time.
+func applyTaskUpdate(task domain.Task, update domain.Task, now time.Time) domain.Task {
+	if update.Title != "" {
+		task.Title = update.Title
+	}
+	if update.Description != "" {
+		task.Description = update.Description
+	}
+	if update.Status != "" {
+		switch {
+		case !domain.IsCompleted(update.Status):
+			task.CompletedAt = nil
+		case !domain.IsCompleted(task.Status) || task.CompletedAt == nil:
+			task.CompletedAt = &now
+		}
+		task.Status = update.Status
+	}
+	if update.Assignee != "" {
+		task.Assignee = update.Assignee
+	}
+	if !update.DueDate.IsZero() {
+		task.DueDate = update.DueDate
+	}
+	return task
+}
@@ -0,0 +1,310 @@
+package repositories_test
+
+import (
+	"context"
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	repositories "test_task_manager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type InMemoryTaskRepositorySuite struct {
+	suite.Suite
+	clock      *infrastructure.FakeClock
+	repository domain.TaskRepository
+	ctx        context.Context
+}
+
+func (suite *InMemoryTaskRepositorySuite) SetupTest() {
+	suite.clock = infrastructure.NewFakeClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	suite.repository = repositories.NewInMemoryTaskRepository(suite.clock)
+	suite.ctx = domain.WithOrg(context.Background(), "org1")
+}
+
+func (suite *InMemoryTaskRepositorySuite) create(tasks ...domain.Task) {
+	for _, task := range tasks {
+		_, err := suite.repository.CreateTask(suite.ctx, task)
+		suite.Require().NoError(err)
+	}
+}
+
+func (suite *InMemoryTaskRepositorySuite) TestCreateAndGetTask() {
+	suite.create(domain.Task{ID: "1", Title: "Test Task"})
+
+	_, err := suite.repository.CreateTask(suite.ctx, domain.Task{ID: "1"})
+	suite.EqualError(err, "task with the given id already exists")
+
+	task, err := suite.repository.GetTaskByID(suite.ctx, "1")
+	suite.NoError(err)
+	suite.Equal("Test Task", task.Title)
+
+	_, err = suite.repository.GetTaskByID(suite.ctx, "missing")
+	suite.Equal(mongo.ErrNoDocuments, err)
+}
+
+func (suite *InMemoryTaskRepositorySuite) TestUpdateTask_ReindexesSearch() {
+	suite.create(domain.Task{ID: "1", Title: "Buy milk", Status: "pending"})
+
+	task, err := suite.repository.UpdateTask(suite.ctx, "1", domain.Task{Title: "Buy bread"})
+	suite.NoError(err)
+	suite.Equal("pending", task.Status)
+
+	hits, total, err := suite.repository.SearchTasks(suite.ctx, "milk", 0, 10)
+	suite.NoError(err)
+	suite.Equal(0, total)
+	suite.Empty(hits)
+
+	hits, total, err = suite.repository.SearchTasks(suite.ctx, "bread", 0, 10)
+	suite.NoError(err)
+	suite.Equal(1, total)
+	suite.Equal("1", hits[0].Task.ID)
+}
+
+func (suite *InMemoryTaskRepositorySuite) TestSearchTasks_RanksTitleMatchesFirst() {
+	suite.create(
+		domain.Task{ID: "1", Title: "Weekly sync", Description: "Prepare the quarterly report"},
+		domain.Task{ID: "2", Title: "Quarterly report", Description: "Send it to finance"},
+		domain.Task{ID: "3", Title: "Groceries"},
+	)
+
+	hits, total, err := suite.repository.SearchTasks(suite.ctx, "report", 0, 10)
+
+	suite.NoError(err)
+	suite.Equal(2, total)
+	suite.Equal("2", hits[0].Task.ID)
+	suite.Equal("1", hits[1].Task.ID)
+	suite.Greater(hits[0].Score, hits[1].Score)
+	suite.Equal("Quarterly <mark>report</mark>", hits[0].Highlights["title"])
+	suite.Equal("Prepare the quarterly <mark>report</mark>", hits[1].Highlights["description"])
+}
+
+func (suite *InMemoryTaskRepositorySuite) TestSearchTasks_PhraseAndPrefix() {
+	suite.create(
+		domain.Task{ID: "1", Title: "Report quarterly numbers"},
+		domain.Task{ID: "2", Title: "Quarterly report"},
+		domain.Task{ID: "3", Title: "Reporting pipeline"},
+	)
+
+	hits, _, err := suite.repository.SearchTasks(suite.ctx, `"quarterly report"`, 0, 10)
+	suite.NoError(err)
+	suite.Len(hits, 1)
+	suite.Equal("2", hits[0].Task.ID)
+
+	hits, _, err = suite.repository.SearchTasks(suite.ctx, "report*", 0, 10)
+	suite.NoError(err)
+	suite.Len(hits, 3)
+	suite.Equal("3", hits[0].Task.ID, "the rarer word ranks higher")
+	suite.Equal("<mark>Reporting</mark> pipeline", hits[0].Highlights["title"])
+}
+
+func (suite *InMemoryTaskRepositorySuite) TestSearchTasks_Paginates() {
+	suite.create(
+		domain.Task{ID: "1", Title: "Task one"},
+		domain.Task{ID: "2", Title: "Task two"},
+		domain.Task{ID: "3", Title: "Task three"},
+	)
+
+	hits, total, err := suite.repository.SearchTasks(suite.ctx, "task", 2, 2)
+
+	suite.NoError(err)
+	suite.Equal(3, total)
+	suite.Len(hits, 1)
+	suite.Equal("3", hits[0].Task.ID)
+
+	hits, _, err = suite.repository.SearchTasks(suite.ctx, "task", -1, 2)
+
+	suite.NoError(err)
+	suite.Len(hits, 2, "a negative offset starts at the first hit")
+}
+
+func (suite *InMemoryTaskRepositorySuite) TestBulkWrite_ReportsPerOperationResults() {
+	suite.create(domain.Task{ID: "1", Title: "Existing", Status: "pending"})
+
+	results, err := suite.repository.BulkWrite(suite.ctx, []domain.BulkOperation{
+		{Op: domain.BulkCreate, ID: "2", Task: domain.Task{ID: "2", Title: "New"}},
+		{Op: domain.BulkUpdate, ID: "1", Task: domain.Task{Status: "completed"}},
+		{Op: domain.BulkDelete, ID: "missing"},
+	})
+
+	suite.NoError(err)
+	suite.Equal(domain.BulkStatusOK, results[0].Status)
+	suite.Equal("completed", results[1].Task.Status)
+	suite.Equal("task not found", results[2].Error)
+}
+
+func (suite *InMemoryTaskRepositorySuite) TestDeleteTask_MovesTaskToTrash() {
+	suite.create(domain.Task{ID: "1", Title: "Quarterly report"})
+
+	suite.NoError(suite.repository.DeleteTask(suite.ctx, "1"))
+
+	_, err := suite.repository.GetTaskByID(suite.ctx, "1")
+	suite.Equal(mongo.ErrNoDocuments, err)
+	_, total, _ := suite.repository.SearchTasks(suite.ctx, "report", 0, 10)
+	suite.Equal(0, total)
+
+	trash, err := suite.repository.GetDeletedTasks(suite.ctx)
+	suite.NoError(err)
+	suite.Len(trash, 1)
+
+	_, err = suite.repository.CreateTask(suite.ctx, domain.Task{ID: "1"})
+	suite.EqualError(err, "task with the given id already exists")
+
+	restored, err := suite.repository.RestoreTask(suite.ctx, "1")
+	suite.NoError(err)
+	suite.Nil(restored.DeletedAt)
+	_, total, _ = suite.repository.SearchTasks(suite.ctx, "report", 0, 10)
+	suite.Equal(1, total)
+
+	_, err = suite.repository.RestoreTask(suite.ctx, "1")
+	suite.EqualError(err, "task not found in trash")
+}
+
+func (suite *InMemoryTaskRepositorySuite) TestPurge() {
+	suite.create(domain.Task{ID: "1"}, domain.Task{ID: "2"}, domain.Task{ID: "3"})
+	suite.NoError(suite.repository.DeleteTask(suite.ctx, "1"))
+	suite.NoError(suite.repository.DeleteTask(suite.ctx, "2"))
+
+	purged, err := suite.repository.PurgeDeletedBefore(suite.ctx, suite.clock.Now().Add(-time.Hour))
+	suite.NoError(err)
+	suite.Empty(purged)
+
+	purged, err = suite.repository.PurgeDeletedBefore(suite.ctx, suite.clock.Now().Add(time.Hour))
+	suite.NoError(err)
+	suite.Len(purged, 2)
+	suite.Equal("1", purged[0].ID)
+	suite.Equal("org1", purged[0].OrgID)
+	suite.Equal("2", purged[1].ID)
+
+	suite.NoError(suite.repository.PurgeTask(suite.ctx, "3"))
+	suite.EqualError(suite.repository.PurgeTask(suite.ctx, "3"), "task not found")
+}
+
+func (suite *InMemoryTaskRepositorySuite) TestPurge_UsesTheTimeOfDeletion() {
+	suite.create(domain.Task{ID: "1"}, domain.Task{ID: "2"})
+	suite.NoError(suite.repository.DeleteTask(suite.ctx, "1"))
+	suite.clock.Advance(48 * time.Hour)
+	suite.NoError(suite.repository.DeleteTask(suite.ctx, "2"))
+
+	trash, err := suite.repository.GetDeletedTasks(suite.ctx)
+	suite.NoError(err)
+	suite.Require().Len(trash, 2)
+	suite.Equal(suite.clock.Now(), *trash[0].DeletedAt)
+	suite.Equal(suite.clock.Now().Add(-48*time.Hour), *trash[1].DeletedAt)
+
+	purged, err := suite.repository.PurgeDeletedBefore(suite.ctx, suite.clock.Now().Add(-24*time.Hour))
+	suite.NoError(err)
+	suite.Require().Len(purged, 1)
+	suite.Equal("1", purged[0].ID)
+}
+
+func (suite *InMemoryTaskRepositorySuite) TestOrganizationsAreIsolated() {
+	suite.create(domain.Task{ID: "1", Title: "Quarterly report"})
+	other := domain.WithOrg(context.Background(), "org2")
+
+	_, err := suite.repository.GetTaskByID(other, "1")
+	suite.Equal(mongo.ErrNoDocuments, err)
+	tasks, err := suite.repository.GetTasks(other)
+	suite.NoError(err)
+	suite.Empty(tasks)
+	_, total, _ := suite.repository.SearchTasks(other, "report", 0, 10)
+	suite.Equal(0, total)
+	_, err = suite.repository.UpdateTask(other, "1", domain.Task{Title: "Hijacked"})
+	suite.Error(err)
+	suite.EqualError(suite.repository.DeleteTask(other, "1"), "task not found")
+
+	created, err := suite.repository.CreateTask(other, domain.Task{ID: "1", Title: "Their own"})
+	suite.NoError(err)
+	suite.Equal("org2", created.OrgID)
+
+	task, err := suite.repository.GetTaskByID(suite.ctx, "1")
+	suite.NoError(err)
+	suite.Equal("Quarterly report", task.Title, "the other organization's update and delete did not apply")
+	suite.Equal("org1", task.OrgID)
+}
+
+func (suite *InMemoryTaskRepositorySuite) TestRequiresOrganization() {
+	_, err := suite.repository.CreateTask(context.Background(), domain.Task{ID: "1"})
+	suite.EqualError(err, "organization is required")
+
+	_, err = suite.repository.GetTasks(context.Background())
+	suite.EqualError(err, "organization is required")
+}
+
+func (suite *InMemoryTaskRepositorySuite) TestPurgeAcrossOrganizations() {
+	other := domain.WithOrg(context.Background(), "org2")
+	suite.create(domain.Task{ID: "1"})
+	_, err := suite.repository.CreateTask(other, domain.Task{ID: "1"})
+	suite.Require().NoError(err)
+	suite.NoError(suite.repository.DeleteTask(suite.ctx, "1"))
+	suite.NoError(suite.repository.DeleteTask(other, "1"))
+
+	purged, err := suite.repository.PurgeDeletedBefore(domain.WithAllOrgs(context.Background()), suite.clock.Now().Add(time.Hour))
+
+	suite.NoError(err)
+	suite.Len(purged, 2)
+	suite.Equal("org1", purged[0].OrgID)
+	suite.Equal("org2", purged[1].OrgID)
+}
+
+func (suite *InMemoryTaskRepositorySuite) TestTaskStats() {
+	yesterday := suite.clock.Now().Add(-24 * time.Hour)
+	tomorrow := suite.clock.Now().Add(24 * time.Hour)
+	suite.create(
+		domain.Task{ID: "1", Status: "Pending", Assignee: "alice", DueDate: yesterday},
+		domain.Task{ID: "2", Status: "Pending", Assignee: "alice", DueDate: tomorrow},
+		domain.Task{ID: "3", Status: "Completed", Assignee: "alice", DueDate: yesterday},
+		domain.Task{ID: "4", Status: "In Progress", Assignee: "bob"},
+		domain.Task{ID: "5", Status: "done"},
+		domain.Task{ID: "6", Status: "Pending", Assignee: "bob", DueDate: yesterday},
+	)
+	suite.Require().NoError(suite.repository.DeleteTask(suite.ctx, "6"))
+
+	stats, err := suite.repository.TaskStats(suite.ctx, suite.clock.Now())
+
+	suite.NoError(err)
+	suite.Equal(domain.TaskCounts{Total: 5, Open: 3, Completed: 2, Overdue: 1}, stats.TaskCounts)
+	suite.Equal(map[string]int{"Pending": 2, "Completed": 1, "In Progress": 1, "done": 1}, stats.ByStatus)
+	suite.Equal([]domain.AssigneeCounts{
+		{Assignee: "alice", TaskCounts: domain.TaskCounts{Total: 3, Open: 2, Completed: 1, Overdue: 1}},
+		{Assignee: "bob", TaskCounts: domain.TaskCounts{Total: 1, Open: 1}},
+		{Assignee: "", TaskCounts: domain.TaskCounts{Total: 1, Completed: 1}},
+	}, stats.ByAssignee)
+}
+
+func (suite *InMemoryTaskRepositorySuite) TestTaskActivity_FollowsCompletion() {
+	suite.create(domain.Task{ID: "1", Status: "Pending"}, domain.Task{ID: "2", Status: "Done"})
+
+	suite.clock.Advance(24 * time.Hour)
+	task, err := suite.repository.UpdateTask(suite.ctx, "1", domain.Task{Status: "Completed"})
+	suite.Require().NoError(err)
+	suite.Equal(suite.clock.Now(), *task.CompletedAt)
+	// Neither another edit nor another completed status moves the completion.
+	_, err = suite.repository.UpdateTask(suite.ctx, "2", domain.Task{Title: "Renamed", Status: "completed"})
+	suite.Require().NoError(err)
+
+	suite.clock.Advance(24 * time.Hour)
+	suite.create(domain.Task{ID: "3"}, domain.Task{ID: "4"})
+	suite.Require().NoError(suite.repository.DeleteTask(suite.ctx, "4"))
+	task, err = suite.repository.UpdateTask(suite.ctx, "1", domain.Task{Status: "Pending"})
+	suite.Require().NoError(err)
+	suite.Nil(task.CompletedAt)
+
+	activity, err := suite.repository.TaskActivity(suite.ctx, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC))
+	suite.NoError(err)
+	suite.Equal([]domain.DailyActivity{{Day: "2024-01-01", Created: 2, Completed: 1}}, activity)
+
+	activity, err = suite.repository.TaskActivity(suite.ctx, time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC))
+	suite.NoError(err)
+	suite.Equal([]domain.DailyActivity{
+		{Day: "2024-01-01", Created: 2, Completed: 1},
+		{Day: "2024-01-03", Created: 1},
+	}, activity)
+}
+
+func TestInMemoryTaskRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InMemoryTaskRepositorySuite))
+}
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	domain "test_task_manager/Domain"
+)
+
+// snapshotter is implemented by the in-memory repositories. snapshot copies the current state
+// and returns a function that puts it back.
+type snapshotter interface {
+	snapshot() (restore func())
+}
+
+// inMemoryTransactor gives the in-memory repositories the all-or-nothing behaviour of MongoDB
+// transactions. Transactions run one at a time and undo their writes when they fail. Callers
+// outside a transaction can see its writes before it ends, so it is meant for tests and demos
+// rather than for isolation.
+type inMemoryTransactor struct {
+	mu     sync.Mutex
+	stores []snapshotter
+}
+
+type inTransactionKey struct{}
+
+// NewInMemoryTransactor rolls back the stores that are in-memory repositories. Others, such as
+// mocks, are left as they are.
+func NewInMemoryTransactor(stores domain.Stores) domain.Transactor {
+	t := &inMemoryTransactor{}
+	for _, store := range []interface{}{stores.Tasks, stores.Users, stores.Invitations, stores.Outbox} {
+		if s, ok := store.(snapshotter); ok {
+			t.stores = append(t.stores, s)
+		}
+	}
+	return t
+}
+
+func (t *inMemoryTransactor) WithTransaction(c context.Context, fn func(ctx context.Context) error) error {
+	return t.RequireTransaction(c, fn)
+}
+
+func (t *inMemoryTransactor) RequireTransaction(c context.Context, fn func(ctx context.Context) error) error {
+	// A transaction started inside another one joins it.
+	if c.Value(inTransactionKey{}) != nil {
+		return fn(c)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	restores := make([]func(), len(t.stores))
+	for i, store := range t.stores {
+		restores[i] = store.snapshot()
+	}
+	if err := fn(context.WithValue(c, inTransactionKey{}, true)); err != nil {
+		for _, restore := range restores {
+			restore()
+		}
+		return err
+	}
+	return nil
+}
+
+// mixedTransactor keeps the in-memory stores consistent with the stores of another transactor,
+// for deployments that keep some of their stores in memory.
+type mixedTransactor struct {
+	memory     domain.Transactor
+	transactor domain.Transactor
+}
+
+// NewMixedTransactor runs units in transactions of transactor and rolls back the in-memory
+// stores among stores as NewInMemoryTransactor does. The in-memory writes are undone when fn
+// fails and when transactor fails to commit.
+func NewMixedTransactor(stores domain.Stores, transactor domain.Transactor) domain.Transactor {
+	return &mixedTransactor{
+		memory:     NewInMemoryTransactor(stores),
+		transactor: transactor,
+	}
+}
+
+func (t *mixedTransactor) WithTransaction(c context.Context, fn func(ctx context.Context) error) error {
+	return t.memory.RequireTransaction(c, func(ctx context.Context) error {
+		return t.transactor.WithTransaction(ctx, fn)
+	})
+}
+
+func (t *mixedTransactor) RequireTransaction(c context.Context, fn func(ctx context.Context) error) error {
+	return t.memory.RequireTransaction(c, func(ctx context.Context) error {
+		return t.transactor.RequireTransaction(ctx, fn)
+	})
+}
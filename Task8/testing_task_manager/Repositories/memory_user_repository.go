@@ -0,0 +1,289 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	domain "test_task_manager/Domain"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// inMemoryUserRepository keeps users in process, keyed by username. Like the MongoDB one, it
+// reports missing users with mongo.ErrNoDocuments and keeps usernames and emails unique.
+type inMemoryUserRepository struct {
+	mu    sync.RWMutex
+	users map[string]domain.User
+}
+
+func NewInMemoryUserRepository() domain.UserRepository {
+	return &inMemoryUserRepository{
+		users: make(map[string]domain.User),
+	}
+}
+
+func (u *inMemoryUserRepository) CreateUser(c context.Context, user domain.User) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if _, ok := u.users[user.Username]; ok {
+		return errors.New("username already exists")
+	}
+	if user.Email != "" {
+		if _, ok := u.byEmail(user.Email); ok {
+			return errors.New("email already exists")
+		}
+	}
+	user.Role = ""
+	user.InviteCode = ""
+	u.users[user.Username] = copyUser(user)
+	return nil
+}
+
+func (u *inMemoryUserRepository) FindByUsername(c context.Context, username string) (*domain.User, error) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	user, ok := u.users[username]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return withRole(c, user), nil
+}
+
+func (u *inMemoryUserRepository) FindByEmail(c context.Context, email string) (*domain.User, error) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	user, ok := u.byEmail(email)
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return withRole(c, user), nil
+}
+
+func (u *inMemoryUserRepository) MarkEmailVerified(c context.Context, username string, email string) error {
+	return u.update(username, func(user *domain.User) error {
+		if user.Email != email {
+			return errors.New("user not found")
+		}
+		user.EmailVerified = true
+		return nil
+	})
+}
+
+func (u *inMemoryUserRepository) FindByOIDCSubject(c context.Context, subject string) (*domain.User, error) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	for _, user := range u.users {
+		if subject != "" && user.OIDCSubject == subject {
+			return withRole(c, user), nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (u *inMemoryUserRepository) LinkOIDCSubject(c context.Context, username string, subject string) error {
+	return u.update(username, func(user *domain.User) error {
+		user.OIDCSubject = subject
+		return nil
+	})
+}
+
+func (u *inMemoryUserRepository) GetUsers(c context.Context) ([]domain.User, error) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	var users []domain.User
+	for _, user := range u.sorted() {
+		if !domain.IsAllOrgs(c) {
+			orgID, err := requireOrg(c)
+			if err != nil {
+				return nil, err
+			}
+			if _, member := user.OrgRole(orgID); !member {
+				continue
+			}
+		}
+		users = append(users, inOrg(c, copyUser(user)))
+	}
+	return users, nil
+}
+
+func (u *inMemoryUserRepository) PromoteUser(c context.Context, username string) (*domain.User, error) {
+	orgID, err := requireOrg(c)
+	if err != nil {
+		return nil, err
+	}
+	var promoted domain.User
+	err = u.update(username, func(user *domain.User) error {
+		if !setMembershipRole(user, orgID, "Admin") {
+			return mongo.ErrNoDocuments
+		}
+		promoted = copyUser(*user)
+		return nil
+	})
+	if err != nil {
+		if err.Error() == "user not found" {
+			return nil, mongo.ErrNoDocuments
+		}
+		return nil, err
+	}
+	promoted = inOrg(c, promoted)
+	return &promoted, nil
+}
+
+func (u *inMemoryUserRepository) SetRole(c context.Context, username string, role string) error {
+	orgID, err := requireOrg(c)
+	if err != nil {
+		return err
+	}
+	return u.update(username, func(user *domain.User) error {
+		if !setMembershipRole(user, orgID, role) {
+			return errors.New("user not found")
+		}
+		return nil
+	})
+}
+
+func (u *inMemoryUserRepository) AddMembership(c context.Context, username string, role string) error {
+	orgID, err := requireOrg(c)
+	if err != nil {
+		return err
+	}
+	return u.update(username, func(user *domain.User) error {
+		if _, member := user.OrgRole(orgID); member {
+			return errors.New("user is already a member")
+		}
+		user.Orgs = append(user.Orgs, domain.Membership{OrgID: orgID, Role: role})
+		return nil
+	})
+}
+
+func (u *inMemoryUserRepository) UpdateMFA(c context.Context, username string, current *domain.MFA, updated *domain.MFA) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	user, ok := u.users[username]
+	if !ok || !equalMFA(user.MFA, current) {
+		return errors.New("mfa settings changed")
+	}
+	user.MFA = copyMFA(updated)
+	u.users[username] = user
+	return nil
+}
+
+func (u *inMemoryUserRepository) snapshot() func() {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	saved := make(map[string]domain.User, len(u.users))
+	for username, user := range u.users {
+		saved[username] = copyUser(user)
+	}
+	return func() {
+		u.mu.Lock()
+		defer u.mu.Unlock()
+		u.users = saved
+	}
+}
+
+// update applies fn to a copy of the user and stores it if fn succeeds.
+func (u *inMemoryUserRepository) update(username string, fn func(user *domain.User) error) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	user, ok := u.users[username]
+	if !ok {
+		return errors.New("user not found")
+	}
+	user = copyUser(user)
+	if err := fn(&user); err != nil {
+		return err
+	}
+	u.users[username] = user
+	return nil
+}
+
+// byEmail must be called with mu held.
+func (u *inMemoryUserRepository) byEmail(email string) (domain.User, bool) {
+	for _, user := range u.users {
+		if user.Email != "" && user.Email == email {
+			return user, true
+		}
+	}
+	return domain.User{}, false
+}
+
+// sorted must be called with mu held.
+func (u *inMemoryUserRepository) sorted() []domain.User {
+	users := make([]domain.User, 0, len(u.users))
+	for _, user := range u.users {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Username < users[j].Username })
+	return users
+}
+
+// withRole mirrors FindByUsername on MongoDB: the user with Role set for the organization of c.
+func withRole(c context.Context, user domain.User) *domain.User {
+	user = copyUser(user)
+	if orgID, ok := domain.OrgFromContext(c); ok {
+		user.Role, _ = user.OrgRole(orgID)
+	}
+	return &user
+}
+
+func setMembershipRole(user *domain.User, orgID string, role string) bool {
+	for i := range user.Orgs {
+		if user.Orgs[i].OrgID == orgID {
+			user.Orgs[i].Role = role
+			return true
+		}
+	}
+	return false
+}
+
+// copyUser copies the slices and pointers of user, so stored users are never shared with
+// callers.
+func copyUser(user domain.User) domain.User {
+	user.Orgs = append([]domain.Membership(nil), user.Orgs...)
+	user.MFA = copyMFA(user.MFA)
+	return user
+}
+
+func copyMFA(mfa *domain.MFA) *domain.MFA {
+	if mfa == nil {
+		return nil
+	}
+	copied := *mfa
+	copied.RecoveryCodes = append([]string(nil), mfa.RecoveryCodes...)
+	if mfa.LockedUntil != nil {
+		lockedUntil := *mfa.LockedUntil
+		copied.LockedUntil = &lockedUntil
+	}
+	return &copied
+}
+
+func equalMFA(a *domain.MFA, b *domain.MFA) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if a.Secret != b.Secret || a.Enabled != b.Enabled || a.LastUsedStep != b.LastUsedStep || a.FailedAttempts != b.FailedAttempts {
+		return false
+	}
+	if (a.LockedUntil == nil) != (b.LockedUntil == nil) || (a.LockedUntil != nil && !a.LockedUntil.Equal(*b.LockedUntil)) {
+		return false
+	}
+	if len(a.RecoveryCodes) != len(b.RecoveryCodes) {
+		return false
+	}
+	for i := range a.RecoveryCodes {
+		if a.RecoveryCodes[i] != b.RecoveryCodes[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,215 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	domain "test_task_manager/Domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type inMemoryWebhookRepository struct {
+	mu       sync.Mutex
+	webhooks map[string]domain.Webhook
+}
+
+func NewInMemoryWebhookRepository() domain.WebhookRepository {
+	return &inMemoryWebhookRepository{
+		webhooks: make(map[string]domain.Webhook),
+	}
+}
+
+func (w *inMemoryWebhookRepository) CreateWebhook(c context.Context, webhook domain.Webhook) error {
+	orgID, err := requireOrg(c)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	webhook.OrgID = orgID
+	webhook.Events = append([]string(nil), webhook.Events...)
+	w.webhooks[webhook.ID] = webhook
+	return nil
+}
+
+func (w *inMemoryWebhookRepository) GetWebhooks(c context.Context) ([]domain.Webhook, error) {
+	return w.find(c, func(webhook domain.Webhook) bool { return true })
+}
+
+func (w *inMemoryWebhookRepository) GetWebhookByID(c context.Context, webhookID string) (*domain.Webhook, error) {
+	webhooks, err := w.find(c, func(webhook domain.Webhook) bool { return webhook.ID == webhookID })
+	if err != nil {
+		return nil, err
+	}
+	if len(webhooks) == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	return &webhooks[0], nil
+}
+
+func (w *inMemoryWebhookRepository) GetWebhooksForEvent(c context.Context, eventType string) ([]domain.Webhook, error) {
+	return w.find(c, func(webhook domain.Webhook) bool {
+		for _, event := range webhook.Events {
+			if event == eventType || event == "*" {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (w *inMemoryWebhookRepository) DeleteWebhook(c context.Context, webhookID string) error {
+	webhook, err := w.GetWebhookByID(c, webhookID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return errors.New("webhook not found")
+		}
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.webhooks, webhook.ID)
+	return nil
+}
+
+func (w *inMemoryWebhookRepository) find(c context.Context, match func(webhook domain.Webhook) bool) ([]domain.Webhook, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var webhooks []domain.Webhook
+	for _, webhook := range w.webhooks {
+		visible, err := visibleIn(c, webhook.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		if visible && match(webhook) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt) })
+	return webhooks, nil
+}
+
+type inMemoryWebhookDeliveryRepository struct {
+	mu          sync.Mutex
+	deliveries  map[string]domain.WebhookDelivery
+	deadLetters []domain.WebhookDeadLetter
+	// claims holds when the claim on each claimed delivery runs out.
+	claims map[string]time.Time
+}
+
+func NewInMemoryWebhookDeliveryRepository() domain.WebhookDeliveryRepository {
+	return &inMemoryWebhookDeliveryRepository{
+		deliveries: make(map[string]domain.WebhookDelivery),
+		claims:     make(map[string]time.Time),
+	}
+}
+
+func (w *inMemoryWebhookDeliveryRepository) CreateDelivery(c context.Context, delivery domain.WebhookDelivery) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delivery.Log = append([]domain.WebhookAttempt(nil), delivery.Log...)
+	w.deliveries[delivery.ID] = delivery
+	return nil
+}
+
+func (w *inMemoryWebhookDeliveryRepository) UpdateDelivery(c context.Context, delivery domain.WebhookDelivery) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	existing, ok := w.deliveries[delivery.ID]
+	if !ok {
+		return nil
+	}
+	visible, err := visibleIn(c, existing.Event.OrgID)
+	if err != nil || !visible {
+		return err
+	}
+	delivery.Log = append([]domain.WebhookAttempt(nil), delivery.Log...)
+	w.deliveries[delivery.ID] = delivery
+	delete(w.claims, delivery.ID)
+	return nil
+}
+
+func (w *inMemoryWebhookDeliveryRepository) ClaimDueDelivery(c context.Context, now time.Time, until time.Time) (*domain.WebhookDelivery, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var due *domain.WebhookDelivery
+	for _, delivery := range w.deliveries {
+		visible, err := visibleIn(c, delivery.Event.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		if !visible || delivery.Status != domain.DeliveryPending || delivery.NextAttemptAt.After(now) || w.claims[delivery.ID].After(now) {
+			continue
+		}
+		if due == nil || delivery.NextAttemptAt.Before(due.NextAttemptAt) {
+			delivery := delivery
+			due = &delivery
+		}
+	}
+	if due == nil {
+		return nil, nil
+	}
+	w.claims[due.ID] = until
+	due.Log = append([]domain.WebhookAttempt(nil), due.Log...)
+	return due, nil
+}
+
+func (w *inMemoryWebhookDeliveryRepository) GetDeliveriesByWebhook(c context.Context, webhookID string) ([]domain.WebhookDelivery, error) {
+	deliveries, err := w.find(c, func(delivery domain.WebhookDelivery) bool { return delivery.WebhookID == webhookID })
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt) })
+	return deliveries, nil
+}
+
+func (w *inMemoryWebhookDeliveryRepository) CreateDeadLetter(c context.Context, deadLetter domain.WebhookDeadLetter) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.deadLetters = append(w.deadLetters, deadLetter)
+	return nil
+}
+
+func (w *inMemoryWebhookDeliveryRepository) GetDeadLetters(c context.Context) ([]domain.WebhookDeadLetter, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var deadLetters []domain.WebhookDeadLetter
+	for _, deadLetter := range w.deadLetters {
+		visible, err := visibleIn(c, deadLetter.Event.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		if visible {
+			deadLetters = append(deadLetters, deadLetter)
+		}
+	}
+	sort.SliceStable(deadLetters, func(i, j int) bool { return deadLetters[i].CreatedAt.After(deadLetters[j].CreatedAt) })
+	return deadLetters, nil
+}
+
+func (w *inMemoryWebhookDeliveryRepository) find(c context.Context, match func(delivery domain.WebhookDelivery) bool) ([]domain.WebhookDelivery, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var deliveries []domain.WebhookDelivery
+	for _, delivery := range w.deliveries {
+		visible, err := visibleIn(c, delivery.Event.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		if visible && match(delivery) {
+			delivery.Log = append([]domain.WebhookAttempt(nil), delivery.Log...)
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,291 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	domain "test_task_manager/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Migrations lists every schema change in version order. Versions are never reused or
+// reordered once released; a change to an applied migration goes into a new one. Data the
+// migrations create is stamped by clock.
+func Migrations(clock domain.Clock) []Migration {
+	return []Migration{
+		{
+			Version:     1,
+			Description: "create lookup indexes",
+			Up: func(c context.Context, db mongo.Database) error {
+				indexes := map[string][]mongo.IndexModel{
+					"tasks":    {uniqueIndex("id")},
+					"users":    {uniqueIndex("username")},
+					"webhooks": {uniqueIndex("id")},
+					"outbox": {
+						uniqueIndex("id"),
+						{Keys: bson.D{{Key: "dispatched", Value: 1}, {Key: "created_at", Value: 1}}, Options: options.Index().SetName("dispatched_created_at")},
+					},
+					"webhook_deliveries": {
+						{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}, Options: options.Index().SetName("status_next_attempt_at")},
+						{Keys: bson.D{{Key: "webhook_id", Value: 1}, {Key: "created_at", Value: -1}}, Options: options.Index().SetName("webhook_id_created_at")},
+					},
+				}
+				for collection, models := range indexes {
+					if _, err := db.Collection(collection).Indexes().CreateMany(c, models); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			Down: func(c context.Context, db mongo.Database) error {
+				indexes := map[string][]string{
+					"tasks":              {"id_unique"},
+					"users":              {"username_unique"},
+					"webhooks":           {"id_unique"},
+					"outbox":             {"id_unique", "dispatched_created_at"},
+					"webhook_deliveries": {"status_next_attempt_at", "webhook_id_created_at"},
+				}
+				for collection, names := range indexes {
+					for _, name := range names {
+						if err := dropIndex(c, db.Collection(collection), name); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			},
+		},
+		{
+			Version:     2,
+			Description: "create task text index",
+			Up: func(c context.Context, db mongo.Database) error {
+				_, err := db.Collection("tasks").Indexes().CreateOne(c, mongo.IndexModel{
+					Keys: bson.D{{Key: "title", Value: "text"}, {Key: "description", Value: "text"}},
+					Options: options.Index().SetName("task_text").SetWeights(bson.D{
+						{Key: "title", Value: titleSearchWeight},
+						{Key: "description", Value: descriptionSearchWeight},
+					}),
+				})
+				return err
+			},
+			Down: func(c context.Context, db mongo.Database) error {
+				return dropIndex(c, db.Collection("tasks"), "task_text")
+			},
+		},
+		{
+			// Tasks used to be stored without a bson tag on DueDate, so the driver wrote "duedate"
+			// while updates wrote "due_date" and were never read back.
+			Version:     3,
+			Description: "rename tasks.duedate to due_date",
+			Up: func(c context.Context, db mongo.Database) error {
+				return renameField(c, db.Collection("tasks"), "duedate", "due_date")
+			},
+			Down: func(c context.Context, db mongo.Database) error {
+				return renameField(c, db.Collection("tasks"), "due_date", "duedate")
+			},
+		},
+		{
+			// Filling in defaults cannot be told apart from values set on purpose, so this one
+			// is not reverted.
+			Version:     4,
+			Description: "backfill task status and user role",
+			Up: func(c context.Context, db mongo.Database) error {
+				if err := backfill(c, db.Collection("tasks"), "status", "Pending"); err != nil {
+					return err
+				}
+				return backfill(c, db.Collection("users"), "role", "User")
+			},
+		},
+		{
+			// Moves everything that existed before organizations into the default one. Users keep
+			// their role as a membership of it. Task IDs become unique per organization. Merging
+			// organizations back together could clash on those IDs, so this one is not reverted.
+			Version:     5,
+			Description: "move existing data into the default organization",
+			Up: func(c context.Context, db mongo.Database) error {
+				organizations := db.Collection("organizations")
+				if _, err := organizations.Indexes().CreateOne(c, uniqueIndex("id")); err != nil {
+					return err
+				}
+				_, err := organizations.UpdateOne(c,
+					bson.D{{Key: "id", Value: domain.DefaultOrgID}},
+					bson.D{{Key: "$setOnInsert", Value: bson.D{{Key: "name", Value: "Default"}, {Key: "created_at", Value: clock.Now().UTC()}}}},
+					options.Update().SetUpsert(true),
+				)
+				if err != nil {
+					return err
+				}
+
+				fields := map[string]string{
+					"tasks":                "org_id",
+					"webhooks":             "org_id",
+					"outbox":               "org_id",
+					"webhook_deliveries":   "event.org_id",
+					"webhook_dead_letters": "event.org_id",
+				}
+				for collection, field := range fields {
+					if err := backfill(c, db.Collection(collection), field, domain.DefaultOrgID); err != nil {
+						return err
+					}
+				}
+
+				users := db.Collection("users")
+				_, err = users.UpdateMany(c, bson.D{{Key: "orgs", Value: bson.D{{Key: "$exists", Value: false}}}}, mongo.Pipeline{
+					{{Key: "$set", Value: bson.D{{Key: "orgs", Value: bson.A{bson.D{
+						{Key: "org_id", Value: domain.DefaultOrgID},
+						{Key: "role", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$role", "User"}}}},
+					}}}}}},
+					{{Key: "$unset", Value: "role"}},
+				})
+				if err != nil {
+					return err
+				}
+				_, err = users.Indexes().CreateOne(c, mongo.IndexModel{Keys: bson.D{{Key: "orgs.org_id", Value: 1}}, Options: options.Index().SetName("orgs_org_id")})
+				if err != nil {
+					return err
+				}
+
+				tasks := db.Collection("tasks")
+				if err := dropIndex(c, tasks, "id_unique"); err != nil {
+					return err
+				}
+				_, err = tasks.Indexes().CreateOne(c, mongo.IndexModel{
+					Keys:    bson.D{{Key: "org_id", Value: 1}, {Key: "id", Value: 1}},
+					Options: options.Index().SetName("org_id_id_unique").SetUnique(true),
+				})
+				return err
+			},
+		},
+		{
+			Version:     6,
+			Description: "create service account and api key indexes",
+			Up: func(c context.Context, db mongo.Database) error {
+				if _, err := db.Collection("service_accounts").Indexes().CreateOne(c, uniqueIndex("id")); err != nil {
+					return err
+				}
+				_, err := db.Collection("api_keys").Indexes().CreateMany(c, []mongo.IndexModel{
+					uniqueIndex("id"),
+					{Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "service_account_id", Value: 1}}, Options: options.Index().SetName("org_id_service_account_id")},
+				})
+				return err
+			},
+			Down: func(c context.Context, db mongo.Database) error {
+				if err := dropIndex(c, db.Collection("service_accounts"), "id_unique"); err != nil {
+					return err
+				}
+				for _, name := range []string{"id_unique", "org_id_service_account_id"} {
+					if err := dropIndex(c, db.Collection("api_keys"), name); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			Version:     7,
+			Description: "create single sign-on indexes",
+			Up: func(c context.Context, db mongo.Database) error {
+				_, err := db.Collection("oidc_logins").Indexes().CreateMany(c, []mongo.IndexModel{
+					uniqueIndex("state"),
+					{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetName("expires_at_ttl").SetExpireAfterSeconds(0)},
+				})
+				if err != nil {
+					return err
+				}
+				_, err = db.Collection("users").Indexes().CreateOne(c, mongo.IndexModel{
+					Keys: bson.D{{Key: "oidc_subject", Value: 1}},
+					Options: options.Index().SetName("oidc_subject_unique").SetUnique(true).
+						SetPartialFilterExpression(bson.D{{Key: "oidc_subject", Value: bson.D{{Key: "$exists", Value: true}}}}),
+				})
+				return err
+			},
+			Down: func(c context.Context, db mongo.Database) error {
+				for _, name := range []string{"state_unique", "expires_at_ttl"} {
+					if err := dropIndex(c, db.Collection("oidc_logins"), name); err != nil {
+						return err
+					}
+				}
+				return dropIndex(c, db.Collection("users"), "oidc_subject_unique")
+			},
+		},
+		{
+			Version:     8,
+			Description: "create invitation indexes",
+			Up: func(c context.Context, db mongo.Database) error {
+				_, err := db.Collection("invitations").Indexes().CreateMany(c, []mongo.IndexModel{
+					uniqueIndex("id"),
+					uniqueIndex("hash"),
+					{Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "created_at", Value: -1}}, Options: options.Index().SetName("org_id_created_at")},
+				})
+				return err
+			},
+			Down: func(c context.Context, db mongo.Database) error {
+				for _, name := range []string{"id_unique", "hash_unique", "org_id_created_at"} {
+					if err := dropIndex(c, db.Collection("invitations"), name); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			// Users from before email verification have no address to verify, so they are marked
+			// verified to keep them able to log in. That cannot be told apart from a real
+			// verification afterwards, so this one is not reverted.
+			Version:     9,
+			Description: "add email verification to users",
+			Up: func(c context.Context, db mongo.Database) error {
+				users := db.Collection("users")
+				filter := bson.D{{Key: "email_verified", Value: bson.D{{Key: "$exists", Value: false}}}}
+				update := bson.D{{Key: "$set", Value: bson.D{{Key: "email_verified", Value: true}}}}
+				if _, err := users.UpdateMany(c, filter, update); err != nil {
+					return err
+				}
+				_, err := users.Indexes().CreateOne(c, mongo.IndexModel{
+					Keys: bson.D{{Key: "email", Value: 1}},
+					Options: options.Index().SetName("email_unique").SetUnique(true).
+						SetPartialFilterExpression(bson.D{{Key: "email", Value: bson.D{{Key: "$exists", Value: true}}}}),
+				})
+				return err
+			},
+		},
+	}
+}
+
+func uniqueIndex(field string) mongo.IndexModel {
+	return mongo.IndexModel{
+		Keys:    bson.D{{Key: field, Value: 1}},
+		Options: options.Index().SetName(field + "_unique").SetUnique(true),
+	}
+}
+
+// dropIndex ignores a missing index or collection so that a half-applied Down can be rerun.
+func dropIndex(c context.Context, collection *mongo.Collection, name string) error {
+	_, err := collection.Indexes().DropOne(c, name)
+	var commandErr mongo.CommandError
+	if errors.As(err, &commandErr) && (commandErr.Name == "IndexNotFound" || commandErr.Name == "NamespaceNotFound") {
+		return nil
+	}
+	return err
+}
+
+// renameField only touches documents that still have the old field and not the new one.
+func renameField(c context.Context, collection *mongo.Collection, from string, to string) error {
+	filter := bson.D{
+		{Key: from, Value: bson.D{{Key: "$exists", Value: true}}},
+		{Key: to, Value: bson.D{{Key: "$exists", Value: false}}},
+	}
+	update := bson.D{{Key: "$rename", Value: bson.D{{Key: from, Value: to}}}}
+	_, err := collection.UpdateMany(c, filter, update)
+	return err
+}
+
+// backfill sets field to value on every document where it is missing, null or empty.
+func backfill(c context.Context, collection *mongo.Collection, field string, value string) error {
+	filter := bson.D{{Key: field, Value: bson.D{{Key: "$in", Value: bson.A{nil, ""}}}}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: field, Value: value}}}}
+	_, err := collection.UpdateMany(c, filter, update)
+	return err
+}
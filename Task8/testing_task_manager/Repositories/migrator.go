@@ -0,0 +1,243 @@
+package repositories
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	domain "test_task_manager/Domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Migration is one versioned change to the database. Down undoes Up and may be nil when the
+// change cannot be reversed.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(c context.Context, db mongo.Database) error
+	Down        func(c context.Context, db mongo.Database) error
+}
+
+type MigrationStatus struct {
+	Version     int        `json:"version"`
+	Description string     `json:"description"`
+	AppliedAt   *time.Time `json:"applied_at,omitempty"`
+}
+
+// migrationRecord is stored for every applied migration, keyed by version. The same
+// collection holds the lock document, whose _id is the string "lock".
+type migrationRecord struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+const migrationLockID = "lock"
+
+// Migrator applies migrations in version order. Every run holds a lock document with an
+// expiry, so instances starting together take turns and a crashed holder cannot block the
+// others for longer than lockTTL.
+type Migrator struct {
+	database   mongo.Database
+	collection string
+	migrations []Migration
+	owner      string
+	lockTTL    time.Duration
+	lockRetry  time.Duration
+	// clock stamps applied migrations and times the lock.
+	clock domain.Clock
+}
+
+func NewMigrator(database mongo.Database, collection string, migrations []Migration, clock domain.Clock) *Migrator {
+	sorted := append([]Migration(nil), migrations...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	return &Migrator{
+		database:   database,
+		collection: collection,
+		migrations: sorted,
+		owner:      migrationOwner(),
+		lockTTL:    time.Minute * 10,
+		lockRetry:  time.Second,
+		clock:      clock,
+	}
+}
+
+// Up applies every migration that has not been applied yet and returns them.
+func (m *Migrator) Up(c context.Context) ([]MigrationStatus, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+
+	var applied []MigrationStatus
+	err := m.withLock(c, func(c context.Context) error {
+		records, err := m.records(c)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := records[migration.Version]; ok {
+				continue
+			}
+			if err := migration.Up(c, m.database); err != nil {
+				return fmt.Errorf("migration %d (%s): %w", migration.Version, migration.Description, err)
+			}
+
+			record := migrationRecord{Version: migration.Version, Description: migration.Description, AppliedAt: m.clock.Now().UTC()}
+			if _, err := m.database.Collection(m.collection).InsertOne(c, record); err != nil {
+				return err
+			}
+			applied = append(applied, MigrationStatus{Version: record.Version, Description: record.Description, AppliedAt: &record.AppliedAt})
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the last steps applied migrations, newest first, and returns them.
+func (m *Migrator) Down(c context.Context, steps int) ([]MigrationStatus, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+
+	var reverted []MigrationStatus
+	err := m.withLock(c, func(c context.Context) error {
+		records, err := m.records(c)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := records[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == nil {
+				return fmt.Errorf("migration %d (%s) cannot be reverted", migration.Version, migration.Description)
+			}
+			if err := migration.Down(c, m.database); err != nil {
+				return fmt.Errorf("reverting migration %d (%s): %w", migration.Version, migration.Description, err)
+			}
+
+			if _, err := m.database.Collection(m.collection).DeleteOne(c, bson.D{{Key: "_id", Value: migration.Version}}); err != nil {
+				return err
+			}
+			reverted = append(reverted, MigrationStatus{Version: migration.Version, Description: migration.Description})
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status lists every known migration with the time it was applied, if it was. Applied
+// migrations this build does not know about are included as well.
+func (m *Migrator) Status(c context.Context) ([]MigrationStatus, error) {
+	records, err := m.records(c)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Description: migration.Description}
+		if record, ok := records[migration.Version]; ok {
+			status.AppliedAt = &record.AppliedAt
+			delete(records, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, record := range records {
+		statuses = append(statuses, MigrationStatus{Version: record.Version, Description: record.Description, AppliedAt: &record.AppliedAt})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+func (m *Migrator) validate() error {
+	for i, migration := range m.migrations {
+		if migration.Version <= 0 {
+			return fmt.Errorf("migration version must be positive, got %d", migration.Version)
+		}
+		if migration.Up == nil {
+			return fmt.Errorf("migration %d has no Up function", migration.Version)
+		}
+		if i > 0 && m.migrations[i-1].Version == migration.Version {
+			return fmt.Errorf("duplicate migration version %d", migration.Version)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) records(c context.Context) (map[int]migrationRecord, error) {
+	filter := bson.D{{Key: "_id", Value: bson.D{{Key: "$type", Value: "number"}}}}
+	cur, err := m.database.Collection(m.collection).Find(c, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(c)
+
+	records := make(map[int]migrationRecord)
+	for cur.Next(c) {
+		var record migrationRecord
+		if err := cur.Decode(&record); err != nil {
+			return nil, err
+		}
+		records[record.Version] = record
+	}
+	return records, cur.Err()
+}
+
+// withLock waits for the lock until c is done, runs fn and releases the lock.
+func (m *Migrator) withLock(c context.Context, fn func(c context.Context) error) error {
+	collection := m.database.Collection(m.collection)
+
+	for {
+		now := m.clock.Now().UTC()
+		// Matches a lock that expired or that this migrator already holds. When another
+		// instance holds a live lock nothing matches, the upsert collides on _id and we wait.
+		filter := bson.D{
+			{Key: "_id", Value: migrationLockID},
+			{Key: "$or", Value: bson.A{
+				bson.D{{Key: "expires_at", Value: bson.D{{Key: "$lt", Value: now}}}},
+				bson.D{{Key: "owner", Value: m.owner}},
+			}},
+		}
+		update := bson.D{{Key: "$set", Value: bson.D{
+			{Key: "owner", Value: m.owner},
+			{Key: "expires_at", Value: now.Add(m.lockTTL)},
+		}}}
+
+		_, err := collection.UpdateOne(c, filter, update, options.Update().SetUpsert(true))
+		if err == nil {
+			break
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+
+		select {
+		case <-c.Done():
+			return errors.New("timed out waiting for the migration lock")
+		case <-time.After(m.lockRetry):
+		}
+	}
+
+	defer collection.DeleteOne(context.Background(), bson.D{{Key: "_id", Value: migrationLockID}, {Key: "owner", Value: m.owner}})
+	return fn(c)
+}
+
+func migrationOwner() string {
+	host, _ := os.Hostname()
+	b := make([]byte, 6)
+	rand.Read(b)
+	return fmt.Sprintf("%s/%d/%s", host, os.Getpid(), hex.EncodeToString(b))
+}
@@ -0,0 +1,187 @@
+package repositories_test
+
+import (
+	"context"
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	repositories "test_task_manager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MigratorSuite struct {
+	suite.Suite
+	database *mongo.Database
+	clock    *infrastructure.FakeClock
+	ran      []string
+}
+
+func (suite *MigratorSuite) SetupSuite() {
+	clientOptions := options.Client().ApplyURI("mongodb://localhost:27017")
+	client, err := mongo.Connect(context.TODO(), clientOptions)
+	suite.Require().NoError(err)
+
+	suite.database = client.Database("test_db")
+}
+
+func (suite *MigratorSuite) SetupTest() {
+	suite.clock = infrastructure.NewFakeClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	suite.ran = nil
+}
+
+func (suite *MigratorSuite) TearDownTest() {
+	suite.database.Collection("migrations").Drop(context.TODO())
+	suite.database.Collection("tasks").Drop(context.TODO())
+	suite.database.Collection("users").Drop(context.TODO())
+	suite.database.Collection("organizations").Drop(context.TODO())
+}
+
+// migration records its Up and Down calls in suite.ran.
+func (suite *MigratorSuite) migration(version int, reversible bool) repositories.Migration {
+	name := string(rune('0' + version))
+	migration := repositories.Migration{
+		Version:     version,
+		Description: "migration " + name,
+		Up: func(c context.Context, db mongo.Database) error {
+			suite.ran = append(suite.ran, "up "+name)
+			return nil
+		},
+	}
+	if reversible {
+		migration.Down = func(c context.Context, db mongo.Database) error {
+			suite.ran = append(suite.ran, "down "+name)
+			return nil
+		}
+	}
+	return migration
+}
+
+func (suite *MigratorSuite) TestUpAppliesPendingInOrder() {
+	migrator := repositories.NewMigrator(*suite.database, "migrations", []repositories.Migration{
+		suite.migration(2, true), suite.migration(1, true),
+	}, suite.clock)
+
+	applied, err := migrator.Up(context.TODO())
+	suite.Require().NoError(err)
+	suite.Len(applied, 2)
+	suite.Equal([]string{"up 1", "up 2"}, suite.ran)
+
+	// A second run, like a second instance starting, has nothing left to do.
+	applied, err = migrator.Up(context.TODO())
+	suite.NoError(err)
+	suite.Empty(applied)
+	suite.Equal([]string{"up 1", "up 2"}, suite.ran)
+
+	statuses, err := migrator.Status(context.TODO())
+	suite.Require().NoError(err)
+	suite.Len(statuses, 2)
+	suite.Require().NotNil(statuses[0].AppliedAt)
+	suite.True(suite.clock.Now().Equal(*statuses[0].AppliedAt), "applied migrations are stamped by the clock")
+	suite.NotNil(statuses[1].AppliedAt)
+}
+
+func (suite *MigratorSuite) TestDownRevertsNewestFirst() {
+	migrator := repositories.NewMigrator(*suite.database, "migrations", []repositories.Migration{
+		suite.migration(1, true), suite.migration(2, true), suite.migration(3, true),
+	}, suite.clock)
+	_, err := migrator.Up(context.TODO())
+	suite.Require().NoError(err)
+
+	reverted, err := migrator.Down(context.TODO(), 2)
+
+	suite.Require().NoError(err)
+	suite.Len(reverted, 2)
+	suite.Equal([]string{"up 1", "up 2", "up 3", "down 3", "down 2"}, suite.ran)
+
+	statuses, err := migrator.Status(context.TODO())
+	suite.Require().NoError(err)
+	suite.NotNil(statuses[0].AppliedAt)
+	suite.Nil(statuses[1].AppliedAt)
+	suite.Nil(statuses[2].AppliedAt)
+}
+
+func (suite *MigratorSuite) TestDownIrreversible() {
+	migrator := repositories.NewMigrator(*suite.database, "migrations", []repositories.Migration{
+		suite.migration(1, false),
+	}, suite.clock)
+	_, err := migrator.Up(context.TODO())
+	suite.Require().NoError(err)
+
+	_, err = migrator.Down(context.TODO(), 1)
+
+	suite.EqualError(err, "migration 1 (migration 1) cannot be reverted")
+}
+
+func (suite *MigratorSuite) TestDuplicateVersion() {
+	migrator := repositories.NewMigrator(*suite.database, "migrations", []repositories.Migration{
+		suite.migration(1, true), suite.migration(1, true),
+	}, suite.clock)
+
+	_, err := migrator.Up(context.TODO())
+
+	suite.EqualError(err, "duplicate migration version 1")
+	suite.Empty(suite.ran)
+}
+
+func (suite *MigratorSuite) TestWaitsForLockHeldByAnotherInstance() {
+	_, err := suite.database.Collection("migrations").InsertOne(context.TODO(), bson.D{
+		{Key: "_id", Value: "lock"},
+		{Key: "owner", Value: "other"},
+		{Key: "expires_at", Value: suite.clock.Now().Add(time.Minute)},
+	})
+	suite.Require().NoError(err)
+	migrator := repositories.NewMigrator(*suite.database, "migrations", []repositories.Migration{
+		suite.migration(1, true),
+	}, suite.clock)
+
+	c, cancel := context.WithTimeout(context.TODO(), time.Millisecond*200)
+	defer cancel()
+	_, err = migrator.Up(c)
+
+	suite.EqualError(err, "timed out waiting for the migration lock")
+	suite.Empty(suite.ran)
+}
+
+func (suite *MigratorSuite) TestRenameDueDate() {
+	due := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+	_, err := suite.database.Collection("tasks").InsertOne(context.TODO(), bson.D{
+		{Key: "id", Value: "1"},
+		{Key: "title", Value: "Task 1"},
+		{Key: "duedate", Value: due},
+	})
+	suite.Require().NoError(err)
+
+	_, err = repositories.NewMigrator(*suite.database, "migrations", repositories.Migrations(suite.clock), suite.clock).Up(context.TODO())
+	suite.Require().NoError(err)
+
+	task, err := repositories.NewTaskRepository(*suite.database, "tasks", infrastructure.NewSystemClock()).GetTaskByID(domain.WithOrg(context.TODO(), domain.DefaultOrgID), "1")
+	suite.Require().NoError(err)
+	suite.True(due.Equal(task.DueDate))
+	suite.Equal("Pending", task.Status)
+	suite.Equal(domain.DefaultOrgID, task.OrgID)
+}
+
+func (suite *MigratorSuite) TestMoveUsersIntoDefaultOrganization() {
+	_, err := suite.database.Collection("users").InsertOne(context.TODO(), bson.D{
+		{Key: "username", Value: "admin"},
+		{Key: "password", Value: "hash"},
+		{Key: "role", Value: "Admin"},
+	})
+	suite.Require().NoError(err)
+
+	_, err = repositories.NewMigrator(*suite.database, "migrations", repositories.Migrations(suite.clock), suite.clock).Up(context.TODO())
+	suite.Require().NoError(err)
+
+	user, err := repositories.NewUserRepository(*suite.database, "users").FindByUsername(context.TODO(), "admin")
+	suite.Require().NoError(err)
+	suite.Equal([]domain.Membership{{OrgID: domain.DefaultOrgID, Role: "Admin"}}, user.Orgs)
+}
+
+func TestMigratorSuite(t *testing.T) {
+	suite.Run(t, new(MigratorSuite))
+}
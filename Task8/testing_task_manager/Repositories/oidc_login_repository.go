@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"context"
+	domain "test_task_manager/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type oidcLoginRepository struct {
+	database   mongo.Database
+	collection string
+	// clock decides which logins have expired, like the in-memory repository.
+	clock domain.Clock
+}
+
+// NewOIDCLoginRepository keeps logins in progress in MongoDB, so the identity provider may
+// redirect back to any instance. A TTL index removes abandoned ones.
+func NewOIDCLoginRepository(db mongo.Database, collection string, clock domain.Clock) domain.OIDCLoginRepository {
+	return &oidcLoginRepository{
+		database:   db,
+		collection: collection,
+		clock:      clock,
+	}
+}
+
+func (o *oidcLoginRepository) SaveLogin(c context.Context, login domain.OIDCLogin) error {
+	collection := o.database.Collection(o.collection)
+
+	_, err := collection.InsertOne(c, login)
+	return err
+}
+
+func (o *oidcLoginRepository) TakeLogin(c context.Context, state string) (*domain.OIDCLogin, error) {
+	collection := o.database.Collection(o.collection)
+
+	// The TTL monitor only runs once a minute, so expiry is checked here as well.
+	filter := bson.D{
+		{Key: "state", Value: state},
+		{Key: "expires_at", Value: bson.D{{Key: "$gt", Value: o.clock.Now().UTC()}}},
+	}
+	var login domain.OIDCLogin
+	if err := collection.FindOneAndDelete(c, filter).Decode(&login); err != nil {
+		return nil, err
+	}
+	return &login, nil
+}
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	domain "test_task_manager/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type organizationRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewOrganizationRepository(db mongo.Database, collection string) domain.OrganizationRepository {
+	return &organizationRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (o *organizationRepository) CreateOrganization(c context.Context, org domain.Organization) error {
+	collection := o.database.Collection(o.collection)
+
+	_, err := collection.InsertOne(c, org)
+	return err
+}
+
+func (o *organizationRepository) GetOrganizationsByIDs(c context.Context, orgIDs []string) ([]domain.Organization, error) {
+	collection := o.database.Collection(o.collection)
+
+	cur, err := collection.Find(c, bson.D{{Key: "id", Value: bson.D{{Key: "$in", Value: orgIDs}}}})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(c)
+
+	orgs := []domain.Organization{}
+	if err := cur.All(c, &orgs); err != nil {
+		return nil, err
+	}
+	return orgs, nil
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	domain "test_task_manager/Domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type outboxRepository struct {
+	database   mongo.Database
+	collection string
+	// clock stamps dispatched events.
+	clock domain.Clock
+}
+
+type outboxDocument struct {
+	domain.Event `bson:",inline"`
+	Dispatched   bool       `bson:"dispatched"`
+	DispatchedAt *time.Time `bson:"dispatched_at,omitempty"`
+	ClaimedUntil *time.Time `bson:"claimed_until,omitempty"`
+}
+
+func NewOutboxRepository(db mongo.Database, collection string, clock domain.Clock) domain.OutboxRepository {
+	return &outboxRepository{
+		database:   db,
+		collection: collection,
+		clock:      clock,
+	}
+}
+
+func (o *outboxRepository) Enqueue(c context.Context, event domain.Event) error {
+	collection := o.database.Collection(o.collection)
+
+	_, err := collection.InsertOne(c, outboxDocument{Event: event})
+	return err
+}
+
+func (o *outboxRepository) EnqueueMany(c context.Context, events []domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	collection := o.database.Collection(o.collection)
+
+	docs := make([]interface{}, len(events))
+	for i, event := range events {
+		docs[i] = outboxDocument{Event: event}
+	}
+	_, err := collection.InsertMany(c, docs)
+	return err
+}
+
+func (o *outboxRepository) ClaimPending(c context.Context, now time.Time, until time.Time) (*domain.Event, error) {
+	collection := o.database.Collection(o.collection)
+
+	filter := bson.D{
+		{Key: "dispatched", Value: false},
+		{Key: "claimed_until", Value: bson.D{{Key: "$not", Value: bson.D{{Key: "$gt", Value: now}}}}},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "claimed_until", Value: until}}}}
+	opts := options.FindOneAndUpdate().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	var doc outboxDocument
+	err := collection.FindOneAndUpdate(c, filter, update, opts).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &doc.Event, nil
+}
+
+func (o *outboxRepository) MarkDispatched(c context.Context, eventID string) error {
+	collection := o.database.Collection(o.collection)
+
+	filter := bson.D{{Key: "id", Value: eventID}}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "dispatched", Value: true},
+		{Key: "dispatched_at", Value: o.clock.Now()},
+	}}}
+
+	_, err := collection.UpdateOne(c, filter, update)
+	return err
+}
@@ -0,0 +1,63 @@
+package repositories_test
+
+import (
+	"context"
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	repositories "test_task_manager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type OutboxRepositorySuite struct {
+	suite.Suite
+	repository domain.OutboxRepository
+	ctx        context.Context
+	cleanup    func()
+}
+
+func (suite *OutboxRepositorySuite) SetupSuite() {
+	suite.ctx = domain.WithOrg(context.Background(), "org1")
+	client, err := mongo.Connect(context.TODO(), options.Client().ApplyURI("mongodb://localhost:27017"))
+	suite.Require().NoError(err)
+
+	db := client.Database("test_db")
+	suite.repository = repositories.NewOutboxRepository(*db, "outbox", infrastructure.NewSystemClock())
+	suite.cleanup = func() {
+		db.Collection("outbox").Drop(context.TODO())
+	}
+}
+
+func (suite *OutboxRepositorySuite) TearDownTest() {
+	suite.cleanup()
+}
+
+func (suite *OutboxRepositorySuite) TestClaimPending_OldestUnclaimedFirst() {
+	now := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	suite.Require().NoError(suite.repository.Enqueue(suite.ctx, domain.Event{ID: "e2", OrgID: "org1", CreatedAt: now.Add(time.Second)}))
+	suite.Require().NoError(suite.repository.Enqueue(suite.ctx, domain.Event{ID: "e1", OrgID: "org1", CreatedAt: now}))
+
+	first, err := suite.repository.ClaimPending(suite.ctx, now, now.Add(time.Minute))
+	suite.Require().NoError(err)
+	second, err := suite.repository.ClaimPending(suite.ctx, now, now.Add(time.Minute))
+	suite.Require().NoError(err)
+	none, err := suite.repository.ClaimPending(suite.ctx, now, now.Add(time.Minute))
+	suite.Require().NoError(err)
+
+	suite.Equal("e1", first.ID)
+	suite.Equal("e2", second.ID)
+	suite.Nil(none)
+
+	suite.Require().NoError(suite.repository.MarkDispatched(suite.ctx, "e1"))
+	again, err := suite.repository.ClaimPending(suite.ctx, now.Add(time.Minute), now.Add(time.Minute*2))
+	suite.Require().NoError(err)
+	suite.Equal("e2", again.ID, "only the event that was not dispatched is claimed again once its claim runs out")
+}
+
+func TestOutboxRepositorySuite(t *testing.T) {
+	suite.Run(t, new(OutboxRepositorySuite))
+}
@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"log"
+	infrastructure "test_task_manager/Infrastructure"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// OperationTimeouts bounds each call to MongoDB. Read is for finds and lists, Write for
+// single-document writes and Bulk for writes that touch many documents. A zero timeout leaves
+// the call bounded by the caller's context only.
+type OperationTimeouts struct {
+	Read  time.Duration
+	Write time.Duration
+	Bulk  time.Duration
+}
+
+// Resilience is shared by the repositories that talk to one MongoDB deployment, so they open
+// and close the circuit together.
+type Resilience struct {
+	breaker  *infrastructure.CircuitBreaker
+	retry    infrastructure.RetryPolicy
+	timeouts OperationTimeouts
+}
+
+func NewResilience(breaker *infrastructure.CircuitBreaker, retry infrastructure.RetryPolicy, timeouts OperationTimeouts) *Resilience {
+	return &Resilience{
+		breaker:  breaker,
+		retry:    retry,
+		timeouts: timeouts,
+	}
+}
+
+// Breaker is the circuit breaker, for readiness checks.
+func (r *Resilience) Breaker() *infrastructure.CircuitBreaker {
+	return r.breaker
+}
+
+type operationKind int
+
+const (
+	// readOp is retried and bounded by the read timeout.
+	readOp operationKind = iota
+	// updateOp is a write that can be applied twice with the same result, so it is retried.
+	updateOp
+	// writeOp is a write that would fail or repeat itself if retried after it was applied.
+	writeOp
+	// bulkOp touches many documents and is not retried.
+	bulkOp
+	// streamOp hands documents to the caller as they arrive, so it has no timeout of its own and
+	// is not retried.
+	streamOp
+)
+
+func (r *Resilience) timeout(kind operationKind) time.Duration {
+	switch kind {
+	case readOp:
+		return r.timeouts.Read
+	case updateOp, writeOp:
+		return r.timeouts.Write
+	case bulkOp:
+		return r.timeouts.Bulk
+	}
+	return 0
+}
+
+func (r *Resilience) attempts(c context.Context, kind operationKind) int {
+	// Inside a transaction the driver retries the whole transaction on transient errors.
+	if mongo.SessionFromContext(c) != nil {
+		return 1
+	}
+	if kind == readOp || kind == updateOp {
+		return max(r.retry.MaxAttempts, 1)
+	}
+	return 1
+}
+
+// do runs fn through the circuit breaker, with the timeout of kind and retries of transient
+// errors. Only failures to reach MongoDB count against the circuit; once their retries are used
+// up, or while the circuit is open, it fails with "database unavailable" instead of the driver's
+// error. Other errors, such as mongo.ErrNoDocuments or a write conflict, are returned as they are.
+func (r *Resilience) do(c context.Context, kind operationKind, fn func(ctx context.Context) error) error {
+	attempts := r.attempts(c, kind)
+	for attempt := 1; ; attempt++ {
+		if !r.breaker.Allow() {
+			return errors.New("database unavailable")
+		}
+
+		err := r.attempt(c, kind, fn)
+		switch {
+		case c.Err() != nil:
+			// The caller gave up; that says nothing about the database.
+			r.breaker.Release()
+			return err
+		case !transient(err):
+			r.breaker.Success()
+			return err
+		}
+		reachable := !unreachable(err)
+		if reachable {
+			// A write conflict or an aborted transaction is an answer from a working server; a few
+			// concurrent updates of the same task must not open the circuit for every caller.
+			r.breaker.Release()
+		} else {
+			r.breaker.Failure()
+		}
+
+		if mongo.SessionFromContext(c) != nil {
+			// The driver needs the original error to decide whether to retry the transaction.
+			return err
+		}
+		if attempt >= attempts {
+			log.Printf("database operation failed after %d attempts: %v", attempt, err)
+			if reachable {
+				return err
+			}
+			return errors.New("database unavailable")
+		}
+		if !infrastructure.Sleep(c, r.retry.Backoff(attempt)) {
+			return c.Err()
+		}
+	}
+}
+
+func (r *Resilience) attempt(c context.Context, kind operationKind, fn func(ctx context.Context) error) error {
+	timeout := r.timeout(kind)
+	if timeout <= 0 {
+		return fn(c)
+	}
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+	return fn(ctx)
+}
+
+// writeConflict is the code MongoDB answers with when two writes touch the same document at once.
+const writeConflict = 112
+
+// transient reports whether err is a failure to reach MongoDB or one it expects to go away,
+// rather than an answer to the query.
+func transient(err error) bool {
+	if err == nil {
+		return false
+	}
+	if unreachable(err) {
+		return true
+	}
+	var labeled mongo.LabeledError
+	if errors.As(err, &labeled) && (labeled.HasErrorLabel("RetryableWriteError") || labeled.HasErrorLabel("TransientTransactionError")) {
+		return true
+	}
+	var server mongo.ServerError
+	return errors.As(err, &server) && server.HasErrorCode(writeConflict)
+}
+
+// unreachable reports whether err means MongoDB could not be reached at all. Only these errors
+// count against the circuit.
+func unreachable(err error) bool {
+	return mongo.IsNetworkError(err) || mongo.IsTimeout(err) || errors.Is(err, mongo.ErrClientDisconnected)
+}
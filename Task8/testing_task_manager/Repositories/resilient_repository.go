@@ -0,0 +1,540 @@
+package repositories
+
+import (
+	"context"
+	domain "test_task_manager/Domain"
+	"time"
+)
+
+// resilientTaskRepository runs every call of a MongoDB task repository through a Resilience.
+type resilientTaskRepository struct {
+	backend    domain.TaskRepository
+	resilience *Resilience
+}
+
+func NewResilientTaskRepository(backend domain.TaskRepository, resilience *Resilience) domain.TaskRepository {
+	return &resilientTaskRepository{
+		backend:    backend,
+		resilience: resilience,
+	}
+}
+
+func (r *resilientTaskRepository) GetTasks(c context.Context) (tasks []domain.Task, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		tasks, err = r.backend.GetTasks(ctx)
+		return err
+	})
+	return tasks, err
+}
+
+func (r *resilientTaskRepository) GetTaskByID(c context.Context, taskID string) (task *domain.Task, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		task, err = r.backend.GetTaskByID(ctx, taskID)
+		return err
+	})
+	return task, err
+}
+
+func (r *resilientTaskRepository) CreateTask(c context.Context, newTask domain.Task) (task *domain.Task, err error) {
+	err = r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		task, err = r.backend.CreateTask(ctx, newTask)
+		return err
+	})
+	return task, err
+}
+
+func (r *resilientTaskRepository) UpdateTask(c context.Context, taskID string, updatedTask domain.Task) (task *domain.Task, err error) {
+	err = r.resilience.do(c, updateOp, func(ctx context.Context) error {
+		task, err = r.backend.UpdateTask(ctx, taskID, updatedTask)
+		return err
+	})
+	return task, err
+}
+
+func (r *resilientTaskRepository) DeleteTask(c context.Context, taskID string) error {
+	return r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		return r.backend.DeleteTask(ctx, taskID)
+	})
+}
+
+func (r *resilientTaskRepository) BulkWrite(c context.Context, operations []domain.BulkOperation) (results []domain.BulkResult, err error) {
+	err = r.resilience.do(c, bulkOp, func(ctx context.Context) error {
+		results, err = r.backend.BulkWrite(ctx, operations)
+		return err
+	})
+	return results, err
+}
+
+func (r *resilientTaskRepository) GetTasksByIDs(c context.Context, taskIDs []string) (tasks []domain.Task, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		tasks, err = r.backend.GetTasksByIDs(ctx, taskIDs)
+		return err
+	})
+	return tasks, err
+}
+
+func (r *resilientTaskRepository) ForEachTask(c context.Context, fn func(task domain.Task) error) error {
+	return r.resilience.do(c, streamOp, func(ctx context.Context) error {
+		return r.backend.ForEachTask(ctx, fn)
+	})
+}
+
+func (r *resilientTaskRepository) SearchTasks(c context.Context, query string, offset int, limit int) (hits []domain.SearchHit, total int, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		hits, total, err = r.backend.SearchTasks(ctx, query, offset, limit)
+		return err
+	})
+	return hits, total, err
+}
+
+func (r *resilientTaskRepository) GetDeletedTasks(c context.Context) (tasks []domain.Task, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		tasks, err = r.backend.GetDeletedTasks(ctx)
+		return err
+	})
+	return tasks, err
+}
+
+func (r *resilientTaskRepository) RestoreTask(c context.Context, taskID string) (task *domain.Task, err error) {
+	err = r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		task, err = r.backend.RestoreTask(ctx, taskID)
+		return err
+	})
+	return task, err
+}
+
+func (r *resilientTaskRepository) PurgeTask(c context.Context, taskID string) error {
+	return r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		return r.backend.PurgeTask(ctx, taskID)
+	})
+}
+
+func (r *resilientTaskRepository) PurgeDeletedBefore(c context.Context, cutoff time.Time) (tasks []domain.Task, err error) {
+	err = r.resilience.do(c, bulkOp, func(ctx context.Context) error {
+		tasks, err = r.backend.PurgeDeletedBefore(ctx, cutoff)
+		return err
+	})
+	return tasks, err
+}
+
+func (r *resilientTaskRepository) TaskStats(c context.Context, now time.Time) (stats *domain.TaskStats, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		stats, err = r.backend.TaskStats(ctx, now)
+		return err
+	})
+	return stats, err
+}
+
+func (r *resilientTaskRepository) TaskActivity(c context.Context, until time.Time) (activity []domain.DailyActivity, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		activity, err = r.backend.TaskActivity(ctx, until)
+		return err
+	})
+	return activity, err
+}
+
+// resilientUserRepository runs every call of a MongoDB user repository through a Resilience.
+type resilientUserRepository struct {
+	backend    domain.UserRepository
+	resilience *Resilience
+}
+
+func NewResilientUserRepository(backend domain.UserRepository, resilience *Resilience) domain.UserRepository {
+	return &resilientUserRepository{
+		backend:    backend,
+		resilience: resilience,
+	}
+}
+
+func (r *resilientUserRepository) GetUsers(c context.Context) (users []domain.User, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		users, err = r.backend.GetUsers(ctx)
+		return err
+	})
+	return users, err
+}
+
+func (r *resilientUserRepository) CreateUser(c context.Context, user domain.User) error {
+	return r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		return r.backend.CreateUser(ctx, user)
+	})
+}
+
+func (r *resilientUserRepository) FindByUsername(c context.Context, username string) (user *domain.User, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		user, err = r.backend.FindByUsername(ctx, username)
+		return err
+	})
+	return user, err
+}
+
+func (r *resilientUserRepository) FindByEmail(c context.Context, email string) (user *domain.User, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		user, err = r.backend.FindByEmail(ctx, email)
+		return err
+	})
+	return user, err
+}
+
+func (r *resilientUserRepository) MarkEmailVerified(c context.Context, username string, email string) error {
+	return r.resilience.do(c, updateOp, func(ctx context.Context) error {
+		return r.backend.MarkEmailVerified(ctx, username, email)
+	})
+}
+
+func (r *resilientUserRepository) FindByOIDCSubject(c context.Context, subject string) (user *domain.User, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		user, err = r.backend.FindByOIDCSubject(ctx, subject)
+		return err
+	})
+	return user, err
+}
+
+func (r *resilientUserRepository) LinkOIDCSubject(c context.Context, username string, subject string) error {
+	return r.resilience.do(c, updateOp, func(ctx context.Context) error {
+		return r.backend.LinkOIDCSubject(ctx, username, subject)
+	})
+}
+
+func (r *resilientUserRepository) PromoteUser(c context.Context, username string) (user *domain.User, err error) {
+	err = r.resilience.do(c, updateOp, func(ctx context.Context) error {
+		user, err = r.backend.PromoteUser(ctx, username)
+		return err
+	})
+	return user, err
+}
+
+func (r *resilientUserRepository) SetRole(c context.Context, username string, role string) error {
+	return r.resilience.do(c, updateOp, func(ctx context.Context) error {
+		return r.backend.SetRole(ctx, username, role)
+	})
+}
+
+func (r *resilientUserRepository) AddMembership(c context.Context, username string, role string) error {
+	return r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		return r.backend.AddMembership(ctx, username, role)
+	})
+}
+
+func (r *resilientUserRepository) UpdateMFA(c context.Context, username string, current *domain.MFA, updated *domain.MFA) error {
+	return r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		return r.backend.UpdateMFA(ctx, username, current, updated)
+	})
+}
+
+// resilientWebhookRepository runs every call of a MongoDB webhook repository through a Resilience.
+type resilientWebhookRepository struct {
+	backend    domain.WebhookRepository
+	resilience *Resilience
+}
+
+func NewResilientWebhookRepository(backend domain.WebhookRepository, resilience *Resilience) domain.WebhookRepository {
+	return &resilientWebhookRepository{
+		backend:    backend,
+		resilience: resilience,
+	}
+}
+
+func (r *resilientWebhookRepository) CreateWebhook(c context.Context, webhook domain.Webhook) error {
+	return r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		return r.backend.CreateWebhook(ctx, webhook)
+	})
+}
+
+func (r *resilientWebhookRepository) GetWebhooks(c context.Context) (webhooks []domain.Webhook, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		webhooks, err = r.backend.GetWebhooks(ctx)
+		return err
+	})
+	return webhooks, err
+}
+
+func (r *resilientWebhookRepository) GetWebhookByID(c context.Context, webhookID string) (webhook *domain.Webhook, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		webhook, err = r.backend.GetWebhookByID(ctx, webhookID)
+		return err
+	})
+	return webhook, err
+}
+
+func (r *resilientWebhookRepository) GetWebhooksForEvent(c context.Context, eventType string) (webhooks []domain.Webhook, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		webhooks, err = r.backend.GetWebhooksForEvent(ctx, eventType)
+		return err
+	})
+	return webhooks, err
+}
+
+func (r *resilientWebhookRepository) DeleteWebhook(c context.Context, webhookID string) error {
+	return r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		return r.backend.DeleteWebhook(ctx, webhookID)
+	})
+}
+
+// resilientWebhookDeliveryRepository runs every call of a MongoDB webhook delivery repository
+// through a Resilience.
+type resilientWebhookDeliveryRepository struct {
+	backend    domain.WebhookDeliveryRepository
+	resilience *Resilience
+}
+
+func NewResilientWebhookDeliveryRepository(backend domain.WebhookDeliveryRepository, resilience *Resilience) domain.WebhookDeliveryRepository {
+	return &resilientWebhookDeliveryRepository{
+		backend:    backend,
+		resilience: resilience,
+	}
+}
+
+func (r *resilientWebhookDeliveryRepository) CreateDelivery(c context.Context, delivery domain.WebhookDelivery) error {
+	return r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		return r.backend.CreateDelivery(ctx, delivery)
+	})
+}
+
+func (r *resilientWebhookDeliveryRepository) UpdateDelivery(c context.Context, delivery domain.WebhookDelivery) error {
+	return r.resilience.do(c, updateOp, func(ctx context.Context) error {
+		return r.backend.UpdateDelivery(ctx, delivery)
+	})
+}
+
+func (r *resilientWebhookDeliveryRepository) ClaimDueDelivery(c context.Context, now time.Time, until time.Time) (delivery *domain.WebhookDelivery, err error) {
+	err = r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		delivery, err = r.backend.ClaimDueDelivery(ctx, now, until)
+		return err
+	})
+	return delivery, err
+}
+
+func (r *resilientWebhookDeliveryRepository) GetDeliveriesByWebhook(c context.Context, webhookID string) (deliveries []domain.WebhookDelivery, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		deliveries, err = r.backend.GetDeliveriesByWebhook(ctx, webhookID)
+		return err
+	})
+	return deliveries, err
+}
+
+func (r *resilientWebhookDeliveryRepository) CreateDeadLetter(c context.Context, deadLetter domain.WebhookDeadLetter) error {
+	return r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		return r.backend.CreateDeadLetter(ctx, deadLetter)
+	})
+}
+
+func (r *resilientWebhookDeliveryRepository) GetDeadLetters(c context.Context) (deadLetters []domain.WebhookDeadLetter, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		deadLetters, err = r.backend.GetDeadLetters(ctx)
+		return err
+	})
+	return deadLetters, err
+}
+
+// resilientOutboxRepository runs every call of a MongoDB outbox repository through a Resilience.
+type resilientOutboxRepository struct {
+	backend    domain.OutboxRepository
+	resilience *Resilience
+}
+
+func NewResilientOutboxRepository(backend domain.OutboxRepository, resilience *Resilience) domain.OutboxRepository {
+	return &resilientOutboxRepository{
+		backend:    backend,
+		resilience: resilience,
+	}
+}
+
+func (r *resilientOutboxRepository) Enqueue(c context.Context, event domain.Event) error {
+	return r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		return r.backend.Enqueue(ctx, event)
+	})
+}
+
+func (r *resilientOutboxRepository) EnqueueMany(c context.Context, events []domain.Event) error {
+	return r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		return r.backend.EnqueueMany(ctx, events)
+	})
+}
+
+func (r *resilientOutboxRepository) ClaimPending(c context.Context, now time.Time, until time.Time) (event *domain.Event, err error) {
+	err = r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		event, err = r.backend.ClaimPending(ctx, now, until)
+		return err
+	})
+	return event, err
+}
+
+func (r *resilientOutboxRepository) MarkDispatched(c context.Context, eventID string) error {
+	return r.resilience.do(c, updateOp, func(ctx context.Context) error {
+		return r.backend.MarkDispatched(ctx, eventID)
+	})
+}
+
+// resilientOrganizationRepository runs every call of a MongoDB organization repository through a
+// Resilience.
+type resilientOrganizationRepository struct {
+	backend    domain.OrganizationRepository
+	resilience *Resilience
+}
+
+func NewResilientOrganizationRepository(backend domain.OrganizationRepository, resilience *Resilience) domain.OrganizationRepository {
+	return &resilientOrganizationRepository{
+		backend:    backend,
+		resilience: resilience,
+	}
+}
+
+func (r *resilientOrganizationRepository) CreateOrganization(c context.Context, org domain.Organization) error {
+	return r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		return r.backend.CreateOrganization(ctx, org)
+	})
+}
+
+func (r *resilientOrganizationRepository) GetOrganizationsByIDs(c context.Context, orgIDs []string) (orgs []domain.Organization, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		orgs, err = r.backend.GetOrganizationsByIDs(ctx, orgIDs)
+		return err
+	})
+	return orgs, err
+}
+
+// resilientAPIKeyRepository runs every call of a MongoDB API key repository through a Resilience.
+type resilientAPIKeyRepository struct {
+	backend    domain.APIKeyRepository
+	resilience *Resilience
+}
+
+func NewResilientAPIKeyRepository(backend domain.APIKeyRepository, resilience *Resilience) domain.APIKeyRepository {
+	return &resilientAPIKeyRepository{
+		backend:    backend,
+		resilience: resilience,
+	}
+}
+
+func (r *resilientAPIKeyRepository) CreateServiceAccount(c context.Context, account domain.ServiceAccount) error {
+	return r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		return r.backend.CreateServiceAccount(ctx, account)
+	})
+}
+
+func (r *resilientAPIKeyRepository) GetServiceAccounts(c context.Context) (accounts []domain.ServiceAccount, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		accounts, err = r.backend.GetServiceAccounts(ctx)
+		return err
+	})
+	return accounts, err
+}
+
+func (r *resilientAPIKeyRepository) GetServiceAccountByID(c context.Context, accountID string) (account *domain.ServiceAccount, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		account, err = r.backend.GetServiceAccountByID(ctx, accountID)
+		return err
+	})
+	return account, err
+}
+
+func (r *resilientAPIKeyRepository) CreateAPIKey(c context.Context, key domain.APIKey) error {
+	return r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		return r.backend.CreateAPIKey(ctx, key)
+	})
+}
+
+func (r *resilientAPIKeyRepository) GetAPIKeys(c context.Context, serviceAccountID string) (keys []domain.APIKey, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		keys, err = r.backend.GetAPIKeys(ctx, serviceAccountID)
+		return err
+	})
+	return keys, err
+}
+
+func (r *resilientAPIKeyRepository) RevokeAPIKey(c context.Context, serviceAccountID string, keyID string, at time.Time) error {
+	return r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		return r.backend.RevokeAPIKey(ctx, serviceAccountID, keyID, at)
+	})
+}
+
+func (r *resilientAPIKeyRepository) FindAPIKey(c context.Context, keyID string) (key *domain.APIKey, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		key, err = r.backend.FindAPIKey(ctx, keyID)
+		return err
+	})
+	return key, err
+}
+
+func (r *resilientAPIKeyRepository) TouchAPIKey(c context.Context, keyID string, at time.Time) error {
+	return r.resilience.do(c, updateOp, func(ctx context.Context) error {
+		return r.backend.TouchAPIKey(ctx, keyID, at)
+	})
+}
+
+// resilientInvitationRepository runs every call of a MongoDB invitation repository through a
+// Resilience.
+type resilientInvitationRepository struct {
+	backend    domain.InvitationRepository
+	resilience *Resilience
+}
+
+func NewResilientInvitationRepository(backend domain.InvitationRepository, resilience *Resilience) domain.InvitationRepository {
+	return &resilientInvitationRepository{
+		backend:    backend,
+		resilience: resilience,
+	}
+}
+
+func (r *resilientInvitationRepository) CreateInvitation(c context.Context, invitation domain.Invitation) error {
+	return r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		return r.backend.CreateInvitation(ctx, invitation)
+	})
+}
+
+func (r *resilientInvitationRepository) GetInvitations(c context.Context) (invitations []domain.Invitation, err error) {
+	err = r.resilience.do(c, readOp, func(ctx context.Context) error {
+		invitations, err = r.backend.GetInvitations(ctx)
+		return err
+	})
+	return invitations, err
+}
+
+func (r *resilientInvitationRepository) DeleteInvitation(c context.Context, invitationID string) error {
+	return r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		return r.backend.DeleteInvitation(ctx, invitationID)
+	})
+}
+
+func (r *resilientInvitationRepository) RedeemInvitation(c context.Context, hash string, username string, at time.Time) (invitation *domain.Invitation, err error) {
+	err = r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		invitation, err = r.backend.RedeemInvitation(ctx, hash, username, at)
+		return err
+	})
+	return invitation, err
+}
+
+func (r *resilientInvitationRepository) ReleaseInvitation(c context.Context, hash string, username string) error {
+	return r.resilience.do(c, updateOp, func(ctx context.Context) error {
+		return r.backend.ReleaseInvitation(ctx, hash, username)
+	})
+}
+
+// resilientOIDCLoginRepository runs every call of a MongoDB OIDC login repository through a
+// Resilience.
+type resilientOIDCLoginRepository struct {
+	backend    domain.OIDCLoginRepository
+	resilience *Resilience
+}
+
+func NewResilientOIDCLoginRepository(backend domain.OIDCLoginRepository, resilience *Resilience) domain.OIDCLoginRepository {
+	return &resilientOIDCLoginRepository{
+		backend:    backend,
+		resilience: resilience,
+	}
+}
+
+func (r *resilientOIDCLoginRepository) SaveLogin(c context.Context, login domain.OIDCLogin) error {
+	return r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		return r.backend.SaveLogin(ctx, login)
+	})
+}
+
+func (r *resilientOIDCLoginRepository) TakeLogin(c context.Context, state string) (login *domain.OIDCLogin, err error) {
+	err = r.resilience.do(c, writeOp, func(ctx context.Context) error {
+		login, err = r.backend.TakeLogin(ctx, state)
+		return err
+	})
+	return login, err
+}
@@ -0,0 +1,160 @@
+package repositories_test
+
+import (
+	"context"
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	repositories "test_task_manager/Repositories"
+	mocks "test_task_manager/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// networkError is what the driver returns when it loses the connection to the server.
+var networkError = mongo.CommandError{Message: "connection reset", Labels: []string{"NetworkError"}}
+
+// writeConflict is what the server answers when two writes touch the same document at once.
+var writeConflict = mongo.CommandError{Code: 112, Name: "WriteConflict", Message: "write conflict", Labels: []string{"TransientTransactionError"}}
+
+type ResilientRepositorySuite struct {
+	suite.Suite
+	backend    *mocks.TaskRepository
+	breaker    *infrastructure.CircuitBreaker
+	resilience *repositories.Resilience
+	repository domain.TaskRepository
+	ctx        context.Context
+}
+
+func (suite *ResilientRepositorySuite) SetupTest() {
+	suite.backend = new(mocks.TaskRepository)
+	suite.breaker = infrastructure.NewCircuitBreaker(3, time.Minute, infrastructure.NewSystemClock())
+	suite.resilience = repositories.NewResilience(suite.breaker, infrastructure.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond, MaxBackoff: time.Millisecond}, repositories.OperationTimeouts{Read: time.Millisecond * 50, Write: time.Millisecond * 50})
+	suite.repository = repositories.NewResilientTaskRepository(suite.backend, suite.resilience)
+	suite.ctx = domain.WithOrg(context.Background(), "org1")
+}
+
+func (suite *ResilientRepositorySuite) TestReadsAreRetried() {
+	suite.backend.On("GetTaskByID", mock.Anything, "1").Return(nil, networkError).Once()
+	suite.backend.On("GetTaskByID", mock.Anything, "1").Return(&domain.Task{ID: "1"}, nil).Once()
+
+	task, err := suite.repository.GetTaskByID(suite.ctx, "1")
+
+	suite.NoError(err)
+	suite.Equal("1", task.ID)
+	suite.Equal(infrastructure.CircuitClosed, suite.breaker.State())
+}
+
+func (suite *ResilientRepositorySuite) TestRetriesAreBounded() {
+	suite.backend.On("GetTasks", mock.Anything).Return(nil, networkError).Times(3)
+
+	_, err := suite.repository.GetTasks(suite.ctx)
+
+	suite.EqualError(err, "database unavailable")
+	suite.backend.AssertNumberOfCalls(suite.T(), "GetTasks", 3)
+}
+
+func (suite *ResilientRepositorySuite) TestInsertsAreNotRetried() {
+	suite.backend.On("CreateTask", mock.Anything, domain.Task{ID: "1"}).Return(nil, networkError).Once()
+
+	_, err := suite.repository.CreateTask(suite.ctx, domain.Task{ID: "1"})
+
+	suite.EqualError(err, "database unavailable")
+	suite.backend.AssertNumberOfCalls(suite.T(), "CreateTask", 1)
+}
+
+func (suite *ResilientRepositorySuite) TestOtherErrorsAreReturnedAsTheyAre() {
+	suite.backend.On("GetTaskByID", mock.Anything, "1").Return(nil, mongo.ErrNoDocuments).Once()
+
+	_, err := suite.repository.GetTaskByID(suite.ctx, "1")
+
+	suite.Equal(mongo.ErrNoDocuments, err)
+	suite.backend.AssertNumberOfCalls(suite.T(), "GetTaskByID", 1)
+}
+
+func (suite *ResilientRepositorySuite) TestOpenCircuitFailsFast() {
+	suite.backend.On("GetTasks", mock.Anything).Return(nil, networkError).Times(3)
+	suite.repository.GetTasks(suite.ctx)
+	suite.Equal(infrastructure.CircuitOpen, suite.breaker.State())
+
+	_, err := suite.repository.GetTaskByID(suite.ctx, "1")
+
+	suite.EqualError(err, "database unavailable")
+	suite.backend.AssertNotCalled(suite.T(), "GetTaskByID", mock.Anything, mock.Anything)
+}
+
+func (suite *ResilientRepositorySuite) TestEachAttemptHasItsOwnTimeout() {
+	suite.backend.On("GetTaskByID", mock.Anything, "1").Return(nil, context.DeadlineExceeded).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Once()
+	suite.backend.On("GetTaskByID", mock.Anything, "1").Return(&domain.Task{ID: "1"}, nil).Once()
+
+	task, err := suite.repository.GetTaskByID(suite.ctx, "1")
+
+	suite.NoError(err)
+	suite.Equal("1", task.ID)
+}
+
+func (suite *ResilientRepositorySuite) TestCanceledCallsDoNotCountAsFailures() {
+	ctx, cancel := context.WithCancel(suite.ctx)
+	suite.backend.On("GetTasks", mock.Anything).Return(nil, context.Canceled).Run(func(args mock.Arguments) {
+		cancel()
+	})
+
+	for i := 0; i < 5; i++ {
+		_, err := suite.repository.GetTasks(ctx)
+		suite.ErrorIs(err, context.Canceled)
+	}
+	suite.Equal(infrastructure.CircuitClosed, suite.breaker.State())
+}
+
+func (suite *ResilientRepositorySuite) TestWriteConflictsDoNotOpenTheCircuit() {
+	suite.backend.On("UpdateTask", mock.Anything, "1", mock.Anything).Return(nil, writeConflict)
+
+	for i := 0; i < 5; i++ {
+		_, err := suite.repository.UpdateTask(suite.ctx, "1", domain.Task{Title: "Updated"})
+		suite.Equal(writeConflict, err, "a conflict is an answer, not an outage")
+	}
+	suite.Equal(infrastructure.CircuitClosed, suite.breaker.State())
+	suite.backend.AssertNumberOfCalls(suite.T(), "UpdateTask", 15)
+}
+
+func (suite *ResilientRepositorySuite) TestEveryRepositorySharesTheCircuit() {
+	apiKeys := new(mocks.APIKeyRepository)
+	apiKeys.On("FindAPIKey", mock.Anything, "k1").Return(nil, networkError).Times(3)
+	invitations := new(mocks.InvitationRepository)
+	outbox := new(mocks.OutboxRepository)
+
+	_, err := repositories.NewResilientAPIKeyRepository(apiKeys, suite.resilience).FindAPIKey(suite.ctx, "k1")
+	suite.EqualError(err, "database unavailable")
+	apiKeys.AssertNumberOfCalls(suite.T(), "FindAPIKey", 3)
+	suite.Equal(infrastructure.CircuitOpen, suite.breaker.State())
+
+	_, err = repositories.NewResilientInvitationRepository(invitations, suite.resilience).RedeemInvitation(suite.ctx, "hash", "bob", time.Now())
+	suite.EqualError(err, "database unavailable")
+	err = repositories.NewResilientOutboxRepository(outbox, suite.resilience).Enqueue(suite.ctx, domain.Event{ID: "e1"})
+	suite.EqualError(err, "database unavailable")
+	invitations.AssertNotCalled(suite.T(), "RedeemInvitation", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	outbox.AssertNotCalled(suite.T(), "Enqueue", mock.Anything, mock.Anything)
+}
+
+func (suite *ResilientRepositorySuite) TestRedeemingAnInvitationIsNotRetried() {
+	invitations := new(mocks.InvitationRepository)
+	invitations.On("RedeemInvitation", mock.Anything, "hash", "bob", mock.Anything).Return(nil, networkError).Once()
+	invitations.On("ReleaseInvitation", mock.Anything, "hash", "bob").Return(networkError).Once()
+	invitations.On("ReleaseInvitation", mock.Anything, "hash", "bob").Return(nil).Once()
+	repository := repositories.NewResilientInvitationRepository(invitations, suite.resilience)
+
+	_, err := repository.RedeemInvitation(suite.ctx, "hash", "bob", time.Now())
+	suite.EqualError(err, "database unavailable")
+	invitations.AssertNumberOfCalls(suite.T(), "RedeemInvitation", 1)
+
+	suite.NoError(repository.ReleaseInvitation(suite.ctx, "hash", "bob"), "releasing twice does no harm, so it is retried")
+}
+
+func TestResilientRepositorySuite(t *testing.T) {
+	suite.Run(t, new(ResilientRepositorySuite))
+}
@@ -3,9 +3,16 @@ package repositories
 import (
 	"context"
 	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
 	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -13,24 +20,44 @@ import (
 type taskRepository struct {
 	database   mongo.Database
 	collection string
+	// clock stamps creation, completion and deletion times.
+	clock domain.Clock
+
+	textIndexMu    sync.Mutex
+	textIndexReady bool
 }
 
-func NewTaskRepository(db mongo.Database, collection string) domain.TaskRepository {
+func NewTaskRepository(db mongo.Database, collection string, clock domain.Clock) domain.TaskRepository {
 	return &taskRepository{
 		database:   db,
 		collection: collection,
+		clock:      clock,
 	}
 }
 
+// notDeleted matches tasks that are not in the trash. Every read except the trash ones uses it.
+var notDeleted = bson.E{Key: "deleted_at", Value: bson.D{{Key: "$exists", Value: false}}}
+
 func (t *taskRepository) CreateTask(c context.Context, newTask domain.Task) (*domain.Task, error) {
-	id := newTask.ID
-	_, err := t.GetTaskByID(c, id)
-	if err == nil {
-		return nil, errors.New("task with the given id already exists")
+	collection := t.database.Collection(t.collection)
+
+	orgID, err := requireOrg(c)
+	if err != nil {
+		return nil, err
 	}
 
-	collection := t.database.Collection(t.collection)
+	// Trashed tasks keep their ID until they are purged, so they are included in this check.
+	count, err := collection.CountDocuments(c, bson.D{{Key: "id", Value: newTask.ID}, {Key: "org_id", Value: orgID}})
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, errors.New("task with the given id already exists")
+	}
 
+	newTask.OrgID = orgID
+	newTask.DeletedAt = nil
+	stampNewTask(&newTask, t.clock.Now().UTC())
 	_, err = collection.InsertOne(c, newTask)
 
 	if err != nil {
@@ -39,24 +66,135 @@ func (t *taskRepository) CreateTask(c context.Context, newTask domain.Task) (*do
 	return &newTask, nil
 }
 
+// DeleteTask moves the task to the trash by setting its deleted_at tombstone. A task that does
+// not exist or is already in the trash is not found.
 func (t *taskRepository) DeleteTask(c context.Context, taskID string) error {
 	collection := t.database.Collection(t.collection)
 
-	filter := bson.D{{Key: "id", Value: taskID}}
-	_, err := collection.DeleteOne(c, filter)
+	filter, err := scoped(c, "org_id", bson.D{{Key: "id", Value: taskID}, notDeleted})
 	if err != nil {
 		return err
 	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "deleted_at", Value: t.clock.Now().UTC()}}}}
+	result, err := collection.UpdateOne(c, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("task not found")
+	}
 	return nil
 }
 
+func (t *taskRepository) GetDeletedTasks(c context.Context) ([]domain.Task, error) {
+	collection := t.database.Collection(t.collection)
+
+	filter, err := scoped(c, "org_id", bson.D{{Key: "deleted_at", Value: bson.D{{Key: "$exists", Value: true}}}})
+	if err != nil {
+		return nil, err
+	}
+	cur, err := collection.Find(c, filter, options.Find().SetSort(bson.D{{Key: "deleted_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(c)
+
+	tasks := []domain.Task{}
+	if err := cur.All(c, &tasks); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (t *taskRepository) RestoreTask(c context.Context, taskID string) (*domain.Task, error) {
+	collection := t.database.Collection(t.collection)
+
+	filter, err := scoped(c, "org_id", bson.D{{Key: "id", Value: taskID}, {Key: "deleted_at", Value: bson.D{{Key: "$exists", Value: true}}}})
+	if err != nil {
+		return nil, err
+	}
+	update := bson.D{{Key: "$unset", Value: bson.D{{Key: "deleted_at", Value: ""}}}}
+
+	var task domain.Task
+	err = collection.FindOneAndUpdate(c, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&task)
+	if err == mongo.ErrNoDocuments {
+		return nil, errors.New("task not found in trash")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+// PurgeTask removes the task for good, whether or not it is in the trash.
+func (t *taskRepository) PurgeTask(c context.Context, taskID string) error {
+	collection := t.database.Collection(t.collection)
+
+	filter, err := scoped(c, "org_id", bson.D{{Key: "id", Value: taskID}})
+	if err != nil {
+		return err
+	}
+	result, err := collection.DeleteOne(c, filter)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("task not found")
+	}
+	return nil
+}
+
+func (t *taskRepository) PurgeDeletedBefore(c context.Context, cutoff time.Time) ([]domain.Task, error) {
+	collection := t.database.Collection(t.collection)
+
+	filter, err := scoped(c, "org_id", bson.D{{Key: "deleted_at", Value: bson.D{{Key: "$lt", Value: cutoff}}}})
+	if err != nil {
+		return nil, err
+	}
+	cur, err := collection.Find(c, filter, options.Find().SetProjection(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(c)
+
+	var expired []struct {
+		ObjectID interface{} `bson:"_id"`
+	}
+	if err := cur.All(c, &expired); err != nil {
+		return nil, err
+	}
+
+	// Each task is deleted on its own, with the cutoff checked again, so a task restored or
+	// purged in the meantime is neither deleted nor reported.
+	var purged []domain.Task
+	projection := options.FindOneAndDelete().SetProjection(bson.D{{Key: "id", Value: 1}, {Key: "org_id", Value: 1}})
+	for _, task := range expired {
+		deleteFilter := bson.D{{Key: "_id", Value: task.ObjectID}, {Key: "deleted_at", Value: bson.D{{Key: "$lt", Value: cutoff}}}}
+		var deleted domain.Task
+		err := collection.FindOneAndDelete(c, deleteFilter, projection).Decode(&deleted)
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+		if err != nil {
+			return purged, err
+		}
+		purged = append(purged, deleted)
+	}
+	return purged, nil
+}
+
 func (t *taskRepository) GetTaskByID(c context.Context, taskID string) (*domain.Task, error) {
 	collection := t.database.Collection(t.collection)
 
 	var task domain.Task
 
-	filter := bson.D{{Key: "id", Value: taskID}}
-	err := collection.FindOne(c, filter).Decode(&task)
+	filter, err := scoped(c, "org_id", bson.D{{Key: "id", Value: taskID}, notDeleted})
+	if err != nil {
+		return nil, err
+	}
+	err = collection.FindOne(c, filter).Decode(&task)
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +207,11 @@ func (t *taskRepository) GetTasks(c context.Context) ([]domain.Task, error) {
 
 	var tasks []domain.Task
 
-	cur, err := collection.Find(c, bson.D{{}})
+	filter, err := scoped(c, "org_id", bson.D{notDeleted})
+	if err != nil {
+		return nil, err
+	}
+	cur, err := collection.Find(c, filter)
 
 	if err != nil {
 		return nil, err
@@ -95,7 +237,215 @@ func (t *taskRepository) GetTasks(c context.Context) ([]domain.Task, error) {
 func (t *taskRepository) UpdateTask(c context.Context, taskID string, updatedTask domain.Task) (*domain.Task, error) {
 	collection := t.database.Collection(t.collection)
 
-	filter := bson.D{{Key: "id", Value: taskID}}
+	filter, err := scoped(c, "org_id", bson.D{{Key: "id", Value: taskID}, notDeleted})
+	if err != nil {
+		return nil, err
+	}
+	update := taskUpdate(updatedTask, t.clock.Now().UTC())
+
+	result := collection.FindOneAndUpdate(c, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After))
+	if result.Err() != nil {
+		return nil, result.Err()
+	}
+
+	var taskAfterUpdate domain.Task
+	err = result.Decode(&taskAfterUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &taskAfterUpdate, nil
+}
+
+func (t *taskRepository) GetTasksByIDs(c context.Context, taskIDs []string) ([]domain.Task, error) {
+	collection := t.database.Collection(t.collection)
+
+	filter, err := scoped(c, "org_id", bson.D{{Key: "id", Value: bson.D{{Key: "$in", Value: taskIDs}}}, notDeleted})
+	if err != nil {
+		return nil, err
+	}
+	cur, err := collection.Find(c, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(c)
+
+	var tasks []domain.Task
+	if err := cur.All(c, &tasks); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (t *taskRepository) ForEachTask(c context.Context, fn func(task domain.Task) error) error {
+	collection := t.database.Collection(t.collection)
+
+	filter, err := scoped(c, "org_id", bson.D{notDeleted})
+	if err != nil {
+		return err
+	}
+	cur, err := collection.Find(c, filter, options.Find().SetSort(bson.D{{Key: "id", Value: 1}}))
+	if err != nil {
+		return err
+	}
+	defer cur.Close(c)
+
+	for cur.Next(c) {
+		var task domain.Task
+		if err := cur.Decode(&task); err != nil {
+			return err
+		}
+		if err := fn(task); err != nil {
+			return err
+		}
+	}
+
+	return cur.Err()
+}
+
+func (t *taskRepository) BulkWrite(c context.Context, operations []domain.BulkOperation) ([]domain.BulkResult, error) {
+	collection := t.database.Collection(t.collection)
+
+	orgID, err := requireOrg(c)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(operations))
+	for _, operation := range operations {
+		ids = append(ids, bulkTaskID(operation))
+	}
+
+	// Trashed tasks are looked up too: they cannot be updated or deleted again, but their
+	// IDs are still taken for creates.
+	cur, err := collection.Find(c, bson.D{{Key: "id", Value: bson.D{{Key: "$in", Value: ids}}}, {Key: "org_id", Value: orgID}})
+	if err != nil {
+		return nil, err
+	}
+	var existingTasks []domain.Task
+	if err := cur.All(c, &existingTasks); err != nil {
+		return nil, err
+	}
+	exists := make(map[string]bool, len(existingTasks))
+	taken := make(map[string]bool, len(existingTasks))
+	for _, task := range existingTasks {
+		exists[task.ID] = task.DeletedAt == nil
+		taken[task.ID] = true
+	}
+
+	// Checking existence up front gives every operation its own error. A bulk write only
+	// reports aggregate match counts, so an update of a missing task would otherwise pass silently.
+	now := t.clock.Now().UTC()
+	results := make([]domain.BulkResult, len(operations))
+	var models []mongo.WriteModel
+	var modelIndex []int
+	for i, operation := range operations {
+		id := bulkTaskID(operation)
+		results[i] = domain.BulkResult{Index: i, Op: operation.Op, ID: id, Status: domain.BulkStatusOK}
+		filter := bson.D{{Key: "id", Value: id}, {Key: "org_id", Value: orgID}, notDeleted}
+
+		var model mongo.WriteModel
+		switch operation.Op {
+		case domain.BulkCreate:
+			if taken[id] {
+				results[i].Error = "task with the given id already exists"
+				break
+			}
+			task := operation.Task
+			task.OrgID = orgID
+			task.DeletedAt = nil
+			stampNewTask(&task, now)
+			model = mongo.NewInsertOneModel().SetDocument(task)
+		case domain.BulkUpdate:
+			fields := taskUpdateFields(operation.Task)
+			if !exists[id] {
+				results[i].Error = "task not found"
+				break
+			}
+			if len(fields) == 0 {
+				results[i].Error = "no fields to update"
+				break
+			}
+			model = mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(taskUpdate(operation.Task, now))
+		case domain.BulkDelete:
+			if !exists[id] {
+				results[i].Error = "task not found"
+				break
+			}
+			tombstone := bson.D{{Key: "$set", Value: bson.D{{Key: "deleted_at", Value: now}}}}
+			model = mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(tombstone)
+		default:
+			results[i].Error = "unknown operation: " + operation.Op
+		}
+
+		if model == nil {
+			results[i].Status = domain.BulkStatusFailed
+			continue
+		}
+		models = append(models, model)
+		modelIndex = append(modelIndex, i)
+	}
+
+	if len(models) > 0 {
+		_, err := collection.BulkWrite(c, models, options.BulkWrite().SetOrdered(false))
+
+		var bulkErr mongo.BulkWriteException
+		if errors.As(err, &bulkErr) {
+			for _, writeErr := range bulkErr.WriteErrors {
+				i := modelIndex[writeErr.Index]
+				results[i].Status = domain.BulkStatusFailed
+				results[i].Error = writeErr.Message
+			}
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	var written []string
+	for _, result := range results {
+		if result.Status == domain.BulkStatusOK && result.Op != domain.BulkDelete {
+			written = append(written, result.ID)
+		}
+	}
+	if len(written) == 0 {
+		return results, nil
+	}
+
+	tasks, err := t.GetTasksByIDs(c, written)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]domain.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+	for i := range results {
+		if task, ok := byID[results[i].ID]; ok && results[i].Status == domain.BulkStatusOK && results[i].Op != domain.BulkDelete {
+			results[i].Task = &task
+		}
+	}
+
+	return results, nil
+}
+
+func bulkTaskID(operation domain.BulkOperation) string {
+	if operation.Op == domain.BulkCreate && operation.ID == "" {
+		return operation.Task.ID
+	}
+	return operation.ID
+}
+
+// stampNewTask sets the times the repository owns on a task about to be created.
+func stampNewTask(task *domain.Task, now time.Time) {
+	task.CreatedAt = &now
+	task.CompletedAt = nil
+	if domain.IsCompleted(task.Status) {
+		task.CompletedAt = &now
+	}
+}
+
+func taskUpdateFields(updatedTask domain.Task) bson.D {
 	updateFields := bson.D{}
 
 	if updatedTask.Title != "" {
@@ -110,19 +460,327 @@ func (t *taskRepository) UpdateTask(c context.Context, taskID string, updatedTas
 	if !updatedTask.DueDate.IsZero() {
 		updateFields = append(updateFields, bson.E{Key: "due_date", Value: updatedTask.DueDate})
 	}
+	if updatedTask.Assignee != "" {
+		updateFields = append(updateFields, bson.E{Key: "assignee", Value: updatedTask.Assignee})
+	}
 
-	update := bson.D{{Key: "$set", Value: updateFields}}
+	return updateFields
+}
 
-	result := collection.FindOneAndUpdate(context.TODO(), filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After))
-	if result.Err() != nil {
-		return nil, result.Err()
+// taskUpdate sets the fields of taskUpdateFields. A new status also stamps completed_at when the
+// task becomes completed, or removes it when it no longer is. That depends on the stored status,
+// so the update is then a pipeline, which needs MongoDB 4.2.
+func taskUpdate(updatedTask domain.Task, now time.Time) interface{} {
+	fields := taskUpdateFields(updatedTask)
+	if updatedTask.Status == "" {
+		return bson.D{{Key: "$set", Value: fields}}
 	}
 
-	var taskAfterUpdate domain.Task
-	err := result.Decode(&taskAfterUpdate)
+	completion := bson.D{{Key: "$unset", Value: "completed_at"}}
+	if domain.IsCompleted(updatedTask.Status) {
+		completion = bson.D{{Key: "$set", Value: bson.D{{Key: "completed_at", Value: bson.D{{Key: "$cond", Value: bson.A{
+			completedStatus,
+			bson.D{{Key: "$ifNull", Value: bson.A{"$completed_at", now}}},
+			now,
+		}}}}}}}
+	}
+	// In a pipeline strings starting with $ are field paths, so the new values are literals.
+	literals := bson.D{}
+	for _, field := range fields {
+		literals = append(literals, bson.E{Key: field.Key, Value: bson.D{{Key: "$literal", Value: field.Value}}})
+	}
+	// completion runs first, while status still holds the stored one.
+	return mongo.Pipeline{completion, {{Key: "$set", Value: literals}}}
+}
+
+// completedStatus is domain.IsCompleted as an aggregation expression on the stored status.
+var completedStatus = bson.D{{Key: "$in", Value: bson.A{
+	bson.D{{Key: "$toLower", Value: bson.D{{Key: "$trim", Value: bson.D{{Key: "input", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$status", ""}}}}}}}}},
+	domain.CompletedStatuses,
+}}}
+
+// TaskStats counts per status and per assignee in one aggregation. The totals are the sums of
+// the assignees' counts.
+func (t *taskRepository) TaskStats(c context.Context, now time.Time) (*domain.TaskStats, error) {
+	collection := t.database.Collection(t.collection)
+
+	filter, err := scoped(c, "org_id", bson.D{notDeleted})
 	if err != nil {
 		return nil, err
 	}
+	overdue := bson.D{{Key: "$and", Value: bson.A{
+		bson.D{{Key: "$not", Value: bson.A{completedStatus}}},
+		bson.D{{Key: "$gt", Value: bson.A{"$due_date", time.Time{}}}},
+		bson.D{{Key: "$lt", Value: bson.A{"$due_date", now}}},
+	}}}
+	countIf := func(condition bson.D) bson.D {
+		return bson.D{{Key: "$sum", Value: bson.D{{Key: "$cond", Value: bson.A{condition, 1, 0}}}}}
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$facet", Value: bson.D{
+			{Key: "by_status", Value: bson.A{
+				bson.D{{Key: "$group", Value: bson.D{
+					{Key: "_id", Value: "$status"},
+					{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+				}}},
+			}},
+			{Key: "by_assignee", Value: bson.A{
+				bson.D{{Key: "$group", Value: bson.D{
+					{Key: "_id", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$assignee", ""}}}},
+					{Key: "total", Value: bson.D{{Key: "$sum", Value: 1}}},
+					{Key: "completed", Value: countIf(completedStatus)},
+					{Key: "overdue", Value: countIf(overdue)},
+				}}},
+			}},
+		}}},
+	}
 
-	return &taskAfterUpdate, nil
+	cur, err := collection.Aggregate(c, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(c)
+
+	var results []struct {
+		ByStatus []struct {
+			Status string `bson:"_id"`
+			Count  int    `bson:"count"`
+		} `bson:"by_status"`
+		ByAssignee []struct {
+			Assignee  string `bson:"_id"`
+			Total     int    `bson:"total"`
+			Completed int    `bson:"completed"`
+			Overdue   int    `bson:"overdue"`
+		} `bson:"by_assignee"`
+	}
+	if err := cur.All(c, &results); err != nil {
+		return nil, err
+	}
+
+	stats := &domain.TaskStats{ByStatus: map[string]int{}, ByAssignee: []domain.AssigneeCounts{}}
+	if len(results) == 0 {
+		return stats, nil
+	}
+	for _, status := range results[0].ByStatus {
+		stats.ByStatus[status.Status] += status.Count
+	}
+	for _, group := range results[0].ByAssignee {
+		counts := domain.TaskCounts{Total: group.Total, Open: group.Total - group.Completed, Completed: group.Completed, Overdue: group.Overdue}
+		stats.Total += counts.Total
+		stats.Open += counts.Open
+		stats.Completed += counts.Completed
+		stats.Overdue += counts.Overdue
+		stats.ByAssignee = append(stats.ByAssignee, domain.AssigneeCounts{Assignee: group.Assignee, TaskCounts: counts})
+	}
+	sortAssignees(stats.ByAssignee)
+	return stats, nil
+}
+
+// TaskActivity groups creations and completions by UTC day in one aggregation. Tasks without
+// created_at, and completed tasks without either time, land on the empty day.
+func (t *taskRepository) TaskActivity(c context.Context, until time.Time) ([]domain.DailyActivity, error) {
+	collection := t.database.Collection(t.collection)
+
+	filter, err := scoped(c, "org_id", bson.D{notDeleted})
+	if err != nil {
+		return nil, err
+	}
+	// A missing time sorts before every date, so it passes the $lt and has a null day.
+	perDay := func(at interface{}) bson.A {
+		return bson.A{
+			bson.D{{Key: "$match", Value: bson.D{{Key: "$expr", Value: bson.D{{Key: "$lt", Value: bson.A{at, until}}}}}}},
+			bson.D{{Key: "$group", Value: bson.D{
+				{Key: "_id", Value: bson.D{{Key: "$dateToString", Value: bson.D{{Key: "format", Value: "%Y-%m-%d"}, {Key: "date", Value: at}}}}},
+				{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			}}},
+		}
+	}
+	completed := append(bson.A{bson.D{{Key: "$match", Value: bson.D{{Key: "$expr", Value: completedStatus}}}}},
+		perDay(bson.D{{Key: "$ifNull", Value: bson.A{"$completed_at", "$created_at"}}})...)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$facet", Value: bson.D{
+			{Key: "created", Value: perDay("$created_at")},
+			{Key: "completed", Value: completed},
+		}}},
+	}
+
+	cur, err := collection.Aggregate(c, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(c)
+
+	type dayCount struct {
+		Day   string `bson:"_id"`
+		Count int    `bson:"count"`
+	}
+	var results []struct {
+		Created   []dayCount `bson:"created"`
+		Completed []dayCount `bson:"completed"`
+	}
+	if err := cur.All(c, &results); err != nil {
+		return nil, err
+	}
+
+	byDay := map[string]*domain.DailyActivity{}
+	day := func(name string) *domain.DailyActivity {
+		if _, ok := byDay[name]; !ok {
+			byDay[name] = &domain.DailyActivity{Day: name}
+		}
+		return byDay[name]
+	}
+	for _, result := range results {
+		for _, created := range result.Created {
+			day(created.Day).Created += created.Count
+		}
+		for _, completed := range result.Completed {
+			day(completed.Day).Completed += completed.Count
+		}
+	}
+
+	activity := make([]domain.DailyActivity, 0, len(byDay))
+	for _, day := range byDay {
+		activity = append(activity, *day)
+	}
+	sort.Slice(activity, func(i, j int) bool { return activity[i].Day < activity[j].Day })
+	return activity, nil
+}
+
+// sortAssignees puts the assignees with the most open tasks first, in both task backends.
+func sortAssignees(assignees []domain.AssigneeCounts) {
+	sort.Slice(assignees, func(i, j int) bool {
+		if assignees[i].Open != assignees[j].Open {
+			return assignees[i].Open > assignees[j].Open
+		}
+		return assignees[i].Assignee < assignees[j].Assignee
+	})
+}
+
+// Title matches rank above description matches in both task backends.
+const (
+	titleSearchWeight       = 3
+	descriptionSearchWeight = 1
+)
+
+// SearchTasks runs a $text search for the plain words and phrases of query. Prefixes are not
+// supported by text indexes, so each one adds a regular expression on title and description.
+// A query made only of prefixes has no text score and is returned in ID order.
+func (t *taskRepository) SearchTasks(c context.Context, query string, offset int, limit int) ([]domain.SearchHit, int, error) {
+	parsed := infrastructure.ParseSearchQuery(query)
+	if parsed.Empty() {
+		return []domain.SearchHit{}, 0, nil
+	}
+	if err := t.ensureTextIndex(c); err != nil {
+		return nil, 0, err
+	}
+
+	org, err := orgFilter(c, "org_id")
+	if err != nil {
+		return nil, 0, err
+	}
+	collection := t.database.Collection(t.collection)
+
+	filters := bson.A{bson.D{notDeleted}, org}
+	search := textSearch(parsed)
+	if search != "" {
+		filters = append(filters, bson.D{{Key: "$text", Value: bson.D{{Key: "$search", Value: search}}}})
+	}
+	for _, prefix := range parsed.Prefixes {
+		pattern := primitive.Regex{Pattern: `(^|[^\p{L}\p{N}])` + regexp.QuoteMeta(prefix), Options: "i"}
+		filters = append(filters, bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "title", Value: pattern}},
+			bson.D{{Key: "description", Value: pattern}},
+		}}})
+	}
+	filter := bson.D{{Key: "$and", Value: filters}}
+
+	total, err := collection.CountDocuments(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+	opts := options.Find().SetSkip(int64(offset)).SetLimit(int64(limit))
+	if search != "" {
+		score := bson.D{{Key: "$meta", Value: "textScore"}}
+		opts.SetProjection(bson.D{{Key: "score", Value: score}})
+		opts.SetSort(bson.D{{Key: "score", Value: score}, {Key: "id", Value: 1}})
+	} else {
+		opts.SetSort(bson.D{{Key: "id", Value: 1}})
+	}
+
+	cur, err := collection.Find(c, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cur.Close(c)
+
+	hits := []domain.SearchHit{}
+	for cur.Next(c) {
+		var document struct {
+			domain.Task `bson:",inline"`
+			Score       float64 `bson:"score"`
+		}
+		if err := cur.Decode(&document); err != nil {
+			return nil, 0, err
+		}
+		hits = append(hits, newSearchHit(document.Task, document.Score, parsed))
+	}
+	if err := cur.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return hits, int(total), nil
+}
+
+// ensureTextIndex creates the text index the first time a search runs. Migration 2 normally
+// creates it at startup; this covers a collection that was dropped and recreated since. Creating
+// an index that already exists with the same options is a no-op, so concurrent processes can race
+// here safely.
+func (t *taskRepository) ensureTextIndex(c context.Context) error {
+	t.textIndexMu.Lock()
+	defer t.textIndexMu.Unlock()
+
+	if t.textIndexReady {
+		return nil
+	}
+
+	_, err := t.database.Collection(t.collection).Indexes().CreateOne(c, mongo.IndexModel{
+		Keys: bson.D{{Key: "title", Value: "text"}, {Key: "description", Value: "text"}},
+		Options: options.Index().SetName("task_text").SetWeights(bson.D{
+			{Key: "title", Value: titleSearchWeight},
+			{Key: "description", Value: descriptionSearchWeight},
+		}),
+	})
+	if err != nil {
+		return err
+	}
+
+	t.textIndexReady = true
+	return nil
+}
+
+func textSearch(query infrastructure.SearchQuery) string {
+	parts := append([]string(nil), query.Terms...)
+	for _, phrase := range query.Phrases {
+		parts = append(parts, `"`+strings.Join(phrase, " ")+`"`)
+	}
+	return strings.Join(parts, " ")
+}
+
+func newSearchHit(task domain.Task, score float64, query infrastructure.SearchQuery) domain.SearchHit {
+	hit := domain.SearchHit{Task: task, Score: score}
+	for field, text := range map[string]string{"title": task.Title, "description": task.Description} {
+		if snippet := infrastructure.Highlight(text, query); snippet != "" {
+			if hit.Highlights == nil {
+				hit.Highlights = make(map[string]string)
+			}
+			hit.Highlights[field] = snippet
+		}
+	}
+	return hit
 }
@@ -3,6 +3,7 @@ package repositories_test
 import (
 	"context"
 	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
 	repositories "test_task_manager/Repositories"
 	"testing"
 	"time"
@@ -15,11 +16,14 @@ import (
 type TaskRepositorySuite struct {
 	suite.Suite
 	repository domain.TaskRepository
+	clock      *infrastructure.FakeClock
 	database   *mongo.Database
+	ctx        context.Context
 	cleanup    func()
 }
 
 func (suite *TaskRepositorySuite) SetupSuite() {
+	suite.ctx = domain.WithOrg(context.Background(), "org1")
 	clientOptions := options.Client().ApplyURI("mongodb://localhost:27017")
 	client, err := mongo.Connect(context.TODO(), clientOptions)
 	suite.Require().NoError(err)
@@ -27,7 +31,8 @@ func (suite *TaskRepositorySuite) SetupSuite() {
 	db := client.Database("test_db")
 	suite.database = db
 
-	repository := repositories.NewTaskRepository(*db, "tasks")
+	suite.clock = infrastructure.NewFakeClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	repository := repositories.NewTaskRepository(*db, "tasks", suite.clock)
 	suite.repository = repository
 
 	suite.cleanup = func() {
@@ -48,7 +53,7 @@ func (suite *TaskRepositorySuite) TestCreateTask() {
 		DueDate:     time.Now().Add(24 * time.Hour),
 	}
 
-	createdTask, err := suite.repository.CreateTask(context.TODO(), newTask)
+	createdTask, err := suite.repository.CreateTask(suite.ctx, newTask)
 
 	suite.NoError(err)
 	suite.Equal(newTask.ID, createdTask.ID) //checking if the task is created successfully
@@ -64,11 +69,11 @@ func (suite *TaskRepositorySuite) TestCreateTask_DuplicateID() {
 		DueDate:     time.Now().Add(24 * time.Hour),
 	}
 
-	_, err := suite.repository.CreateTask(context.TODO(), newTask)
+	_, err := suite.repository.CreateTask(suite.ctx, newTask)
 	suite.NoError(err)
 
 	// Try to create the same task again, expecting an error
-	_, err = suite.repository.CreateTask(context.TODO(), newTask)
+	_, err = suite.repository.CreateTask(suite.ctx, newTask)
 	suite.Error(err)
 	suite.EqualError(err, "task with the given id already exists")
 }
@@ -81,16 +86,16 @@ func (suite *TaskRepositorySuite) TestGetTaskByID_Positive() {
 		Status:      "pending",
 		DueDate:     time.Now().Add(24 * time.Hour),
 	}
-	_, err := suite.repository.CreateTask(context.TODO(), newTask)
+	_, err := suite.repository.CreateTask(suite.ctx, newTask)
 	suite.NoError(err)
 
-	task, err := suite.repository.GetTaskByID(context.TODO(), newTask.ID)
+	task, err := suite.repository.GetTaskByID(suite.ctx, newTask.ID)
 	suite.NoError(err)
 	suite.Equal(newTask.ID, task.ID)
 }
 
 func (suite *TaskRepositorySuite) TestGetTaskByID_NonExistent() {
-	_, err := suite.repository.GetTaskByID(context.TODO(), "non-existent-id")
+	_, err := suite.repository.GetTaskByID(suite.ctx, "non-existent-id")
 	suite.Error(err)
 	suite.EqualError(err, mongo.ErrNoDocuments.Error())
 }
@@ -103,13 +108,13 @@ func (suite *TaskRepositorySuite) TestUpdateTask_Positive() {
 		Status:      "pending",
 		DueDate:     time.Now().Add(24 * time.Hour),
 	}
-	_, err := suite.repository.CreateTask(context.TODO(), newTask)
+	_, err := suite.repository.CreateTask(suite.ctx, newTask)
 	suite.NoError(err)
 
 	updatedTask := domain.Task{
 		Title: "Updated Task",
 	}
-	task, err := suite.repository.UpdateTask(context.TODO(), newTask.ID, updatedTask)
+	task, err := suite.repository.UpdateTask(suite.ctx, newTask.ID, updatedTask)
 	suite.NoError(err)
 	suite.Equal(updatedTask.Title, task.Title)
 }
@@ -122,18 +127,186 @@ func (suite *TaskRepositorySuite) TestDeleteTask_Positive() {
 		Status:      "pending",
 		DueDate:     time.Now().Add(24 * time.Hour),
 	}
-	_, err := suite.repository.CreateTask(context.TODO(), newTask)
+	_, err := suite.repository.CreateTask(suite.ctx, newTask)
 	suite.NoError(err)
 
-	err = suite.repository.DeleteTask(context.TODO(), newTask.ID)
+	err = suite.repository.DeleteTask(suite.ctx, newTask.ID)
 	suite.NoError(err)
 
 	// Verify that the task no longer exists
-	_, err = suite.repository.GetTaskByID(context.TODO(), newTask.ID)
+	_, err = suite.repository.GetTaskByID(suite.ctx, newTask.ID)
 	suite.Error(err)
 	suite.EqualError(err, mongo.ErrNoDocuments.Error())
 }
 
+func (suite *TaskRepositorySuite) TestBulkWrite_ReportsPerOperationResults() {
+	existing := domain.Task{ID: "1", Title: "Existing", Status: "pending"}
+	_, err := suite.repository.CreateTask(suite.ctx, existing)
+	suite.NoError(err)
+
+	results, err := suite.repository.BulkWrite(suite.ctx, []domain.BulkOperation{
+		{Op: domain.BulkCreate, ID: "2", Task: domain.Task{ID: "2", Title: "New"}},
+		{Op: domain.BulkUpdate, ID: "1", Task: domain.Task{Status: "completed"}},
+		{Op: domain.BulkDelete, ID: "missing"},
+	})
+
+	suite.NoError(err)
+	suite.Equal(domain.BulkStatusOK, results[0].Status)
+	suite.Equal(domain.BulkStatusOK, results[1].Status)
+	suite.Equal("completed", results[1].Task.Status)
+	suite.Equal(domain.BulkStatusFailed, results[2].Status)
+	suite.Equal("task not found", results[2].Error)
+}
+
+func (suite *TaskRepositorySuite) TestForEachTask_VisitsEveryTask() {
+	for _, id := range []string{"2", "1"} {
+		_, err := suite.repository.CreateTask(suite.ctx, domain.Task{ID: id, Title: "Task " + id})
+		suite.NoError(err)
+	}
+
+	var visited []string
+	err := suite.repository.ForEachTask(suite.ctx, func(task domain.Task) error {
+		visited = append(visited, task.ID)
+		return nil
+	})
+
+	suite.NoError(err)
+	suite.Equal([]string{"1", "2"}, visited)
+}
+
+func (suite *TaskRepositorySuite) TestSearchTasks_UsesTextIndex() {
+	for _, task := range []domain.Task{
+		{ID: "1", Title: "Weekly sync", Description: "Prepare the quarterly report"},
+		{ID: "2", Title: "Quarterly report", Description: "Send it to finance"},
+		{ID: "3", Title: "Reporting pipeline"},
+	} {
+		_, err := suite.repository.CreateTask(suite.ctx, task)
+		suite.NoError(err)
+	}
+
+	hits, total, err := suite.repository.SearchTasks(suite.ctx, "report", 0, 10)
+	suite.NoError(err)
+	suite.Equal(2, total)
+	suite.Equal("2", hits[0].Task.ID)
+	suite.Equal("Quarterly <mark>report</mark>", hits[0].Highlights["title"])
+
+	hits, total, err = suite.repository.SearchTasks(suite.ctx, "quarterly report*", 0, 1)
+	suite.NoError(err)
+	suite.Equal(2, total)
+	suite.Len(hits, 1)
+}
+
+func (suite *TaskRepositorySuite) TestDeleteTask_MovesTaskToTrash() {
+	_, err := suite.repository.CreateTask(suite.ctx, domain.Task{ID: "1", Title: "Test Task"})
+	suite.NoError(err)
+
+	suite.NoError(suite.repository.DeleteTask(suite.ctx, "1"))
+
+	trash, err := suite.repository.GetDeletedTasks(suite.ctx)
+	suite.NoError(err)
+	suite.Len(trash, 1)
+	suite.Require().NotNil(trash[0].DeletedAt)
+	suite.True(suite.clock.Now().Equal(*trash[0].DeletedAt))
+
+	_, err = suite.repository.CreateTask(suite.ctx, domain.Task{ID: "1"})
+	suite.EqualError(err, "task with the given id already exists")
+	suite.EqualError(suite.repository.DeleteTask(suite.ctx, "1"), "task not found", "the task is already in the trash")
+	suite.EqualError(suite.repository.DeleteTask(suite.ctx, "2"), "task not found")
+
+	restored, err := suite.repository.RestoreTask(suite.ctx, "1")
+	suite.NoError(err)
+	suite.Nil(restored.DeletedAt)
+
+	_, err = suite.repository.GetTaskByID(suite.ctx, "1")
+	suite.NoError(err)
+}
+
+func (suite *TaskRepositorySuite) TestPurgeDeletedBefore_KeepsRecentTombstones() {
+	for _, id := range []string{"1", "2"} {
+		_, err := suite.repository.CreateTask(suite.ctx, domain.Task{ID: id})
+		suite.NoError(err)
+		suite.NoError(suite.repository.DeleteTask(suite.ctx, id))
+	}
+
+	purged, err := suite.repository.PurgeDeletedBefore(suite.ctx, suite.clock.Now().Add(-time.Hour))
+	suite.NoError(err)
+	suite.Empty(purged)
+
+	_, err = suite.repository.RestoreTask(suite.ctx, "2")
+	suite.Require().NoError(err)
+
+	purged, err = suite.repository.PurgeDeletedBefore(suite.ctx, suite.clock.Now().Add(time.Hour))
+	suite.NoError(err)
+	suite.Require().Len(purged, 1, "the restored task is neither deleted nor reported")
+	suite.Equal("1", purged[0].ID)
+	suite.Equal("org1", purged[0].OrgID)
+	_, err = suite.repository.GetTaskByID(suite.ctx, "2")
+	suite.NoError(err)
+}
+
+func (suite *TaskRepositorySuite) TestOrganizationsAreIsolated() {
+	other := domain.WithOrg(context.Background(), "org2")
+	_, err := suite.repository.CreateTask(suite.ctx, domain.Task{ID: "1", Title: "Quarterly report"})
+	suite.Require().NoError(err)
+
+	_, err = suite.repository.GetTaskByID(other, "1")
+	suite.Equal(mongo.ErrNoDocuments, err)
+	_, err = suite.repository.UpdateTask(other, "1", domain.Task{Title: "Hijacked"})
+	suite.Error(err)
+
+	created, err := suite.repository.CreateTask(other, domain.Task{ID: "1", Title: "Their own"})
+	suite.NoError(err)
+	suite.Equal("org2", created.OrgID)
+
+	task, err := suite.repository.GetTaskByID(suite.ctx, "1")
+	suite.NoError(err)
+	suite.Equal("Quarterly report", task.Title)
+}
+
+func (suite *TaskRepositorySuite) TestTaskStats_AggregatesLikeTheInMemoryRepository() {
+	now := time.Now()
+	for _, task := range []domain.Task{
+		{ID: "1", Status: "Pending", Assignee: "alice", DueDate: now.Add(-24 * time.Hour)},
+		{ID: "2", Status: "Pending", Assignee: "alice", DueDate: now.Add(24 * time.Hour)},
+		{ID: "3", Status: "Completed", Assignee: "alice", DueDate: now.Add(-24 * time.Hour)},
+		{ID: "4", Status: "In Progress", Assignee: "bob"},
+		{ID: "5", Status: " Done "},
+	} {
+		_, err := suite.repository.CreateTask(suite.ctx, task)
+		suite.Require().NoError(err)
+	}
+
+	stats, err := suite.repository.TaskStats(suite.ctx, now)
+
+	suite.NoError(err)
+	suite.Equal(domain.TaskCounts{Total: 5, Open: 3, Completed: 2, Overdue: 1}, stats.TaskCounts)
+	suite.Equal(map[string]int{"Pending": 2, "Completed": 1, "In Progress": 1, " Done ": 1}, stats.ByStatus)
+	suite.Equal([]domain.AssigneeCounts{
+		{Assignee: "alice", TaskCounts: domain.TaskCounts{Total: 3, Open: 2, Completed: 1, Overdue: 1}},
+		{Assignee: "bob", TaskCounts: domain.TaskCounts{Total: 1, Open: 1}},
+		{Assignee: "", TaskCounts: domain.TaskCounts{Total: 1, Completed: 1}},
+	}, stats.ByAssignee)
+}
+
+func (suite *TaskRepositorySuite) TestTaskActivity_FollowsCompletion() {
+	for _, task := range []domain.Task{{ID: "1", Title: "$cost", Status: "Pending"}, {ID: "2", Status: "Done"}} {
+		_, err := suite.repository.CreateTask(suite.ctx, task)
+		suite.Require().NoError(err)
+	}
+
+	task, err := suite.repository.UpdateTask(suite.ctx, "1", domain.Task{Status: "Completed", Description: "$title"})
+	suite.Require().NoError(err)
+	suite.Require().NotNil(task.CompletedAt)
+	suite.True(suite.clock.Now().Equal(*task.CompletedAt))
+	suite.Equal("$title", task.Description, "values are not read as field paths")
+	task, err = suite.repository.UpdateTask(suite.ctx, "2", domain.Task{Status: "Pending"})
+	suite.Require().NoError(err)
+	suite.Nil(task.CompletedAt)
+
+	activity, err := suite.repository.TaskActivity(suite.ctx, suite.clock.Now().Add(time.Hour))
+	suite.NoError(err)
+	suite.Equal([]domain.DailyActivity{{Day: "2024-01-01", Created: 2, Completed: 1}}, activity)
+}
 
 func TestTaskRepositorySuite(t *testing.T) {
 	suite.Run(t, new(TaskRepositorySuite))
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	domain "test_task_manager/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// orgFilter returns the condition that keeps a query inside the organization of c. Every
+// query on tenant-owned data includes it, so a context without an organization fails instead
+// of reading across tenants. System jobs pass a domain.WithAllOrgs context and get no condition.
+func orgFilter(c context.Context, field string) (bson.D, error) {
+	if domain.IsAllOrgs(c) {
+		return bson.D{}, nil
+	}
+	orgID, err := requireOrg(c)
+	if err != nil {
+		return nil, err
+	}
+	return bson.D{{Key: field, Value: orgID}}, nil
+}
+
+// requireOrg returns the organization new documents belong to.
+func requireOrg(c context.Context) (string, error) {
+	orgID, ok := domain.OrgFromContext(c)
+	if !ok {
+		return "", errors.New("organization is required")
+	}
+	return orgID, nil
+}
+
+// scoped appends the organization condition to filter.
+func scoped(c context.Context, field string, filter bson.D) (bson.D, error) {
+	org, err := orgFilter(c, field)
+	if err != nil {
+		return nil, err
+	}
+	return append(append(bson.D{}, filter...), org...), nil
+}
+
+// visibleIn is orgFilter for the in-memory repositories: it reports whether a document of
+// orgID may be read with c.
+func visibleIn(c context.Context, orgID string) (bool, error) {
+	if domain.IsAllOrgs(c) {
+		return true, nil
+	}
+	current, err := requireOrg(c)
+	if err != nil {
+		return false, err
+	}
+	return orgID == current, nil
+}
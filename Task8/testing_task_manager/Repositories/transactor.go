@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync/atomic"
+	domain "test_task_manager/Domain"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// illegalOperation is returned by a standalone mongod for any command carrying a transaction number.
+const illegalOperation = 20
+
+type transactor struct {
+	client      *mongo.Client
+	unsupported atomic.Bool
+}
+
+func NewTransactor(db mongo.Database) domain.Transactor {
+	return &transactor{
+		client: db.Client(),
+	}
+}
+
+var errTransactionsUnsupported = errors.New("transactions are not supported by this MongoDB deployment")
+
+func (t *transactor) WithTransaction(c context.Context, fn func(ctx context.Context) error) error {
+	err := t.RequireTransaction(c, fn)
+
+	// Transactions need a replica set. On a standalone server the first write inside fn is
+	// rejected before anything is stored, so it is safe to run fn again without a session.
+	if err == errTransactionsUnsupported {
+		return fn(c)
+	}
+	return err
+}
+
+func (t *transactor) RequireTransaction(c context.Context, fn func(ctx context.Context) error) error {
+	if t.unsupported.Load() {
+		return errTransactionsUnsupported
+	}
+
+	session, err := t.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(c)
+
+	_, err = session.WithTransaction(c, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == illegalOperation {
+		if t.unsupported.CompareAndSwap(false, true) {
+			log.Println("MongoDB deployment does not support transactions, falling back to non-transactional writes")
+		}
+		return errTransactionsUnsupported
+	}
+
+	return err
+}
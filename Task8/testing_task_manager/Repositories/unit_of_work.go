@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	domain "test_task_manager/Domain"
+)
+
+type unitOfWork struct {
+	transactor domain.Transactor
+	stores     domain.Stores
+}
+
+// NewUnitOfWork runs units in transactions of transactor. The stores must join its
+// transactions through the context, as the MongoDB repositories do with NewTransactor and the
+// in-memory ones with NewInMemoryTransactor.
+func NewUnitOfWork(transactor domain.Transactor, stores domain.Stores) domain.UnitOfWork {
+	return &unitOfWork{
+		transactor: transactor,
+		stores:     stores,
+	}
+}
+
+func (u *unitOfWork) Do(c context.Context, fn func(ctx context.Context, stores domain.Stores) error) error {
+	return u.transactor.WithTransaction(c, func(ctx context.Context) error {
+		return fn(ctx, u.stores)
+	})
+}
+
+func (u *unitOfWork) DoAtomic(c context.Context, fn func(ctx context.Context, stores domain.Stores) error) error {
+	return u.transactor.RequireTransaction(c, func(ctx context.Context) error {
+		return fn(ctx, u.stores)
+	})
+}
@@ -0,0 +1,145 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	repositories "test_task_manager/Repositories"
+	mocks "test_task_manager/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type InMemoryUnitOfWorkSuite struct {
+	suite.Suite
+	stores     domain.Stores
+	unitOfWork domain.UnitOfWork
+	ctx        context.Context
+}
+
+func (suite *InMemoryUnitOfWorkSuite) SetupTest() {
+	suite.stores = domain.Stores{
+		Tasks:  repositories.NewInMemoryTaskRepository(infrastructure.NewSystemClock()),
+		Outbox: repositories.NewInMemoryOutboxRepository(),
+	}
+	suite.unitOfWork = repositories.NewUnitOfWork(repositories.NewInMemoryTransactor(suite.stores), suite.stores)
+	suite.ctx = domain.WithOrg(context.Background(), "org1")
+}
+
+// createWithEvent writes a task and its event, then fails with err if it is set.
+func (suite *InMemoryUnitOfWorkSuite) createWithEvent(ctx context.Context, stores domain.Stores, taskID string, err error) error {
+	if _, err := stores.Tasks.CreateTask(ctx, domain.Task{ID: taskID, Title: "Quarterly report"}); err != nil {
+		return err
+	}
+	if err := stores.Outbox.Enqueue(ctx, domain.Event{ID: "event-" + taskID, Type: domain.EventTaskCreated}); err != nil {
+		return err
+	}
+	return err
+}
+
+// pendingEvents claims every event waiting in the outbox.
+func (suite *InMemoryUnitOfWorkSuite) pendingEvents() []domain.Event {
+	now := time.Now()
+	var events []domain.Event
+	for {
+		event, err := suite.stores.Outbox.ClaimPending(suite.ctx, now, now.Add(time.Minute))
+		suite.Require().NoError(err)
+		if event == nil {
+			return events
+		}
+		events = append(events, *event)
+	}
+}
+
+func (suite *InMemoryUnitOfWorkSuite) TestDo_CommitsEveryStore() {
+	err := suite.unitOfWork.Do(suite.ctx, func(ctx context.Context, stores domain.Stores) error {
+		return suite.createWithEvent(ctx, stores, "1", nil)
+	})
+	suite.Require().NoError(err)
+
+	_, err = suite.stores.Tasks.GetTaskByID(suite.ctx, "1")
+	suite.NoError(err)
+	suite.Len(suite.pendingEvents(), 1)
+}
+
+func (suite *InMemoryUnitOfWorkSuite) TestDo_RollsBackEveryStore() {
+	err := suite.unitOfWork.Do(suite.ctx, func(ctx context.Context, stores domain.Stores) error {
+		return suite.createWithEvent(ctx, stores, "1", errors.New("boom"))
+	})
+	suite.EqualError(err, "boom")
+
+	_, err = suite.stores.Tasks.GetTaskByID(suite.ctx, "1")
+	suite.Equal(mongo.ErrNoDocuments, err)
+	_, total, err := suite.stores.Tasks.SearchTasks(suite.ctx, "quarterly", 0, 10)
+	suite.NoError(err)
+	suite.Zero(total)
+	suite.Empty(suite.pendingEvents())
+}
+
+func (suite *InMemoryUnitOfWorkSuite) TestDo_KeepsEarlierCommits() {
+	suite.Require().NoError(suite.unitOfWork.Do(suite.ctx, func(ctx context.Context, stores domain.Stores) error {
+		return suite.createWithEvent(ctx, stores, "1", nil)
+	}))
+
+	err := suite.unitOfWork.DoAtomic(suite.ctx, func(ctx context.Context, stores domain.Stores) error {
+		if err := stores.Tasks.DeleteTask(ctx, "1"); err != nil {
+			return err
+		}
+		return suite.createWithEvent(ctx, stores, "2", errors.New("boom"))
+	})
+	suite.EqualError(err, "boom")
+
+	task, err := suite.stores.Tasks.GetTaskByID(suite.ctx, "1")
+	suite.Require().NoError(err)
+	suite.Nil(task.DeletedAt)
+	_, total, err := suite.stores.Tasks.SearchTasks(suite.ctx, "quarterly", 0, 10)
+	suite.NoError(err)
+	suite.Equal(1, total)
+	suite.Len(suite.pendingEvents(), 1)
+}
+
+func (suite *InMemoryUnitOfWorkSuite) TestDo_NestedUnitsJoinTheOuterOne() {
+	err := suite.unitOfWork.Do(suite.ctx, func(ctx context.Context, stores domain.Stores) error {
+		err := suite.unitOfWork.Do(ctx, func(ctx context.Context, stores domain.Stores) error {
+			return suite.createWithEvent(ctx, stores, "1", nil)
+		})
+		if err != nil {
+			return err
+		}
+		return errors.New("boom")
+	})
+	suite.EqualError(err, "boom")
+
+	_, err = suite.stores.Tasks.GetTaskByID(suite.ctx, "1")
+	suite.Equal(mongo.ErrNoDocuments, err)
+}
+
+func (suite *InMemoryUnitOfWorkSuite) TestMixedTransactor_RollsBackWhenTheCommitFails() {
+	transactor := new(mocks.Transactor)
+	transactor.EXPECT().RequireTransaction(mock.Anything, mock.Anything).RunAndReturn(func(c context.Context, fn func(context.Context) error) error {
+		if err := fn(c); err != nil {
+			return err
+		}
+		return errors.New("commit failed")
+	})
+	stores := domain.Stores{Tasks: suite.stores.Tasks}
+	unitOfWork := repositories.NewUnitOfWork(repositories.NewMixedTransactor(stores, transactor), stores)
+
+	err := unitOfWork.DoAtomic(suite.ctx, func(ctx context.Context, stores domain.Stores) error {
+		_, err := stores.Tasks.CreateTask(ctx, domain.Task{ID: "1", Title: "Quarterly report"})
+		return err
+	})
+	suite.EqualError(err, "commit failed")
+
+	_, err = suite.stores.Tasks.GetTaskByID(suite.ctx, "1")
+	suite.Equal(mongo.ErrNoDocuments, err)
+}
+
+func TestInMemoryUnitOfWorkSuite(t *testing.T) {
+	suite.Run(t, new(InMemoryUnitOfWorkSuite))
+}
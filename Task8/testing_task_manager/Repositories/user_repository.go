@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	domain "test_task_manager/Domain"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -24,7 +25,7 @@ func NewUserRepository(database mongo.Database, collection string) domain.UserRe
 func (u *userRepository) CreateUser(c context.Context, user domain.User) error {
 	collection := u.database.Collection(u.collection)
 
-	_, err := collection.InsertOne(context.TODO(), user)
+	_, err := collection.InsertOne(c, user)
 	if err != nil {
 		return err
 	}
@@ -42,15 +43,79 @@ func (u *userRepository) FindByUsername(c context.Context, username string) (*do
 		return nil, err
 	}
 
+	if orgID, ok := domain.OrgFromContext(c); ok {
+		user.Role, _ = user.OrgRole(orgID)
+	}
+	return &user, nil
+}
+
+func (u *userRepository) FindByEmail(c context.Context, email string) (*domain.User, error) {
+	collection := u.database.Collection(u.collection)
+
+	var user domain.User
+	if err := collection.FindOne(c, bson.D{{Key: "email", Value: email}}).Decode(&user); err != nil {
+		return nil, err
+	}
+
+	if orgID, ok := domain.OrgFromContext(c); ok {
+		user.Role, _ = user.OrgRole(orgID)
+	}
 	return &user, nil
 }
 
+func (u *userRepository) MarkEmailVerified(c context.Context, username string, email string) error {
+	collection := u.database.Collection(u.collection)
+
+	filter := bson.D{{Key: "username", Value: username}, {Key: "email", Value: email}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "email_verified", Value: true}}}}
+	result, err := collection.UpdateOne(c, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+func (u *userRepository) FindByOIDCSubject(c context.Context, subject string) (*domain.User, error) {
+	collection := u.database.Collection(u.collection)
+
+	var user domain.User
+	if err := collection.FindOne(c, bson.D{{Key: "oidc_subject", Value: subject}}).Decode(&user); err != nil {
+		return nil, err
+	}
+
+	if orgID, ok := domain.OrgFromContext(c); ok {
+		user.Role, _ = user.OrgRole(orgID)
+	}
+	return &user, nil
+}
+
+func (u *userRepository) LinkOIDCSubject(c context.Context, username string, subject string) error {
+	collection := u.database.Collection(u.collection)
+
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "oidc_subject", Value: subject}}}}
+	result, err := collection.UpdateOne(c, bson.D{{Key: "username", Value: username}}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
 func (u *userRepository) GetUsers(c context.Context) ([]domain.User, error) {
 	collection := u.database.Collection(u.collection)
 
 	var users []domain.User
 
-	cur, err := collection.Find(c, bson.D{{}})
+	filter, err := orgFilter(c, "orgs.org_id")
+	if err != nil {
+		return nil, err
+	}
+	cur, err := collection.Find(c, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -59,7 +124,7 @@ func (u *userRepository) GetUsers(c context.Context) ([]domain.User, error) {
 		if err := cur.Decode(&user); err != nil {
 			return nil, err
 		}
-		users = append(users, user)
+		users = append(users, inOrg(c, user))
 	}
 
 	if err = cur.Err(); err != nil {
@@ -73,23 +138,110 @@ func (u *userRepository) GetUsers(c context.Context) ([]domain.User, error) {
 func (u *userRepository) PromoteUser(c context.Context, username string) (*domain.User, error) {
 	collection := u.database.Collection(u.collection)
 
-	filter := bson.D{{Key: "username", Value: username}}
+	orgID, err := requireOrg(c)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{{Key: "username", Value: username}, {Key: "orgs.org_id", Value: orgID}}
 
+	// The positional operator updates the membership the filter matched.
 	update := bson.D{
 		{Key: "$set", Value: bson.D{
-			{Key: "role", Value: "Admin"},
+			{Key: "orgs.$.role", Value: "Admin"},
 		}}}
 
-	result := collection.FindOneAndUpdate(context.TODO(), filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After))
+	result := collection.FindOneAndUpdate(c, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After))
 	if result.Err() != nil {
 		return nil, result.Err()
 	}
 
 	var updatedUser domain.User
-	err := result.Decode(&updatedUser)
+	err = result.Decode(&updatedUser)
 	if err != nil {
 		return nil, err
 	}
 
+	updatedUser = inOrg(c, updatedUser)
 	return &updatedUser, nil
 }
+
+func (u *userRepository) SetRole(c context.Context, username string, role string) error {
+	collection := u.database.Collection(u.collection)
+
+	orgID, err := requireOrg(c)
+	if err != nil {
+		return err
+	}
+	filter := bson.D{{Key: "username", Value: username}, {Key: "orgs.org_id", Value: orgID}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "orgs.$.role", Value: role}}}}
+	result, err := collection.UpdateOne(c, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+func (u *userRepository) AddMembership(c context.Context, username string, role string) error {
+	collection := u.database.Collection(u.collection)
+
+	orgID, err := requireOrg(c)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.D{{Key: "username", Value: username}, {Key: "orgs.org_id", Value: bson.D{{Key: "$ne", Value: orgID}}}}
+	update := bson.D{{Key: "$push", Value: bson.D{{Key: "orgs", Value: domain.Membership{OrgID: orgID, Role: role}}}}}
+	result, err := collection.UpdateOne(c, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount > 0 {
+		return nil
+	}
+
+	count, err := collection.CountDocuments(c, bson.D{{Key: "username", Value: username}})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.New("user not found")
+	}
+	return errors.New("user is already a member")
+}
+
+// inOrg shows the user as a member of the organization of c: Role is the role there and the
+// other memberships are left out, since they belong to other tenants.
+func inOrg(c context.Context, user domain.User) domain.User {
+	orgID, ok := domain.OrgFromContext(c)
+	if !ok {
+		return user
+	}
+	user.Role, _ = user.OrgRole(orgID)
+	user.Orgs = nil
+	return user
+}
+
+func (u *userRepository) UpdateMFA(c context.Context, username string, current *domain.MFA, updated *domain.MFA) error {
+	collection := u.database.Collection(u.collection)
+
+	// current and updated are whole subdocuments, so comparing them covers every field.
+	filter := bson.D{{Key: "username", Value: username}, {Key: "mfa", Value: bson.D{{Key: "$exists", Value: false}}}}
+	if current != nil {
+		filter = bson.D{{Key: "username", Value: username}, {Key: "mfa", Value: current}}
+	}
+	update := bson.D{{Key: "$unset", Value: bson.D{{Key: "mfa", Value: ""}}}}
+	if updated != nil {
+		update = bson.D{{Key: "$set", Value: bson.D{{Key: "mfa", Value: updated}}}}
+	}
+	result, err := collection.UpdateOne(c, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("mfa settings changed")
+	}
+	return nil
+}
@@ -15,10 +15,12 @@ type UserRepositorySuite struct {
 	suite.Suite
 	repository domain.UserRepository
 	database   *mongo.Database
+	ctx        context.Context
 	cleanup    func()
 }
 
 func (suite *UserRepositorySuite) SetupSuite() {
+	suite.ctx = domain.WithOrg(context.Background(), "org1")
 	clientOptions := options.Client().ApplyURI("mongodb://localhost:27017")
 	client, err := mongo.Connect(context.TODO(), clientOptions)
 	suite.Require().NoError(err)
@@ -44,18 +46,18 @@ func (suite *UserRepositorySuite) TestCreateUser() {
 	newUser := domain.User{
 		Username: "test_user",
 		Password: "hashedpassword",
-		Role:     "User",
+		Orgs:     []domain.Membership{{OrgID: "org1", Role: "User"}},
 	}
 
-	err := suite.repository.CreateUser(context.TODO(), newUser)
+	err := suite.repository.CreateUser(suite.ctx, newUser)
 
 	suite.NoError(err)
 
 	// Verify that the user was inserted by fetching it back
-	retrievedUser, err := suite.repository.FindByUsername(context.TODO(), newUser.Username)
+	retrievedUser, err := suite.repository.FindByUsername(suite.ctx, newUser.Username)
 	suite.NoError(err)
 	suite.Equal(newUser.Username, retrievedUser.Username)
-	suite.Equal(newUser.Role, retrievedUser.Role)
+	suite.Equal("User", retrievedUser.Role)
 }
 
 func (suite *UserRepositorySuite) TestFindByUsername() {
@@ -63,21 +65,21 @@ func (suite *UserRepositorySuite) TestFindByUsername() {
 	newUser := domain.User{
 		Username: "search_user",
 		Password: "search_password",
-		Role:     "User",
+		Orgs:     []domain.Membership{{OrgID: "org1", Role: "User"}},
 	}
 
-	err := suite.repository.CreateUser(context.TODO(), newUser)
+	err := suite.repository.CreateUser(suite.ctx, newUser)
 	suite.Require().NoError(err)
 
-	foundUser, err := suite.repository.FindByUsername(context.TODO(), newUser.Username)
+	foundUser, err := suite.repository.FindByUsername(suite.ctx, newUser.Username)
 
 	suite.NoError(err)
 	suite.Equal(newUser.Username, foundUser.Username)
-	suite.Equal(newUser.Role, foundUser.Role)
+	suite.Equal("User", foundUser.Role)
 }
 
 func (suite *UserRepositorySuite) TestFindByUsernameNotFound() {
-	foundUser, err := suite.repository.FindByUsername(context.TODO(), "non_existent_user")
+	foundUser, err := suite.repository.FindByUsername(suite.ctx, "non_existent_user")
 
 	// Assert that an error occurred and no user was found
 	suite.Error(err)
@@ -89,20 +91,20 @@ func (suite *UserRepositorySuite) TestGetUsers() {
 		{
 			Username: "user1",
 			Password: "password1",
-			Role:     "User",
+			Orgs:     []domain.Membership{{OrgID: "org1", Role: "User"}},
 		},
 		{
 			Username: "user2",
 			Password: "password2",
-			Role:     "User",
+			Orgs:     []domain.Membership{{OrgID: "org1", Role: "User"}},
 		},
 	}
 	for _, user := range users {
-		err := suite.repository.CreateUser(context.TODO(), user)
+		err := suite.repository.CreateUser(suite.ctx, user)
 		suite.Require().NoError(err)
 	}
 
-	retrievedUsers, err := suite.repository.GetUsers(context.TODO())
+	retrievedUsers, err := suite.repository.GetUsers(suite.ctx)
 
 	suite.NoError(err)
 	suite.Equal(len(users), len(retrievedUsers))
@@ -113,17 +115,78 @@ func (suite *UserRepositorySuite) TestPromoteUser() {
 	newUser := domain.User{
 		Username: "promote_user",
 		Password: "promote_password",
-		Role:     "User",
+		Orgs:     []domain.Membership{{OrgID: "org1", Role: "User"}},
 	}
-	err := suite.repository.CreateUser(context.TODO(), newUser)
+	err := suite.repository.CreateUser(suite.ctx, newUser)
 	suite.Require().NoError(err)
 
-	updatedUser, err := suite.repository.PromoteUser(context.TODO(), newUser.Username)
+	updatedUser, err := suite.repository.PromoteUser(suite.ctx, newUser.Username)
 
 	suite.NoError(err)
 	suite.Equal("Admin", updatedUser.Role)
 }
 
+func (suite *UserRepositorySuite) TestGetUsers_OnlyMembersOfTheOrganization() {
+	suite.Require().NoError(suite.repository.CreateUser(suite.ctx, domain.User{Username: "member", Orgs: []domain.Membership{{OrgID: "org1", Role: "User"}}}))
+	suite.Require().NoError(suite.repository.CreateUser(suite.ctx, domain.User{Username: "outsider", Orgs: []domain.Membership{{OrgID: "org2", Role: "Admin"}}}))
+
+	users, err := suite.repository.GetUsers(suite.ctx)
+
+	suite.NoError(err)
+	suite.Len(users, 1)
+	suite.Equal("member", users[0].Username)
+	suite.Nil(users[0].Orgs)
+
+	_, err = suite.repository.PromoteUser(suite.ctx, "outsider")
+	suite.Error(err)
+}
+
+func (suite *UserRepositorySuite) TestAddMembership() {
+	suite.Require().NoError(suite.repository.CreateUser(suite.ctx, domain.User{Username: "joiner", Orgs: []domain.Membership{{OrgID: "org2", Role: "Admin"}}}))
+
+	suite.NoError(suite.repository.AddMembership(suite.ctx, "joiner", "User"))
+	suite.EqualError(suite.repository.AddMembership(suite.ctx, "joiner", "User"), "user is already a member")
+	suite.EqualError(suite.repository.AddMembership(suite.ctx, "missing", "User"), "user not found")
+
+	user, err := suite.repository.FindByUsername(suite.ctx, "joiner")
+	suite.NoError(err)
+	suite.Equal("User", user.Role)
+	suite.Len(user.Orgs, 2)
+}
+
+func (suite *UserRepositorySuite) TestUpdateMFA() {
+	suite.Require().NoError(suite.repository.CreateUser(suite.ctx, domain.User{Username: "alice", Orgs: []domain.Membership{{OrgID: "org1", Role: "User"}}}))
+	pending := &domain.MFA{Secret: "SECRET"}
+	enabled := &domain.MFA{Secret: "SECRET", Enabled: true, RecoveryCodes: []string{"hash"}, LastUsedStep: 10}
+
+	suite.NoError(suite.repository.UpdateMFA(suite.ctx, "alice", nil, pending))
+	suite.NoError(suite.repository.UpdateMFA(suite.ctx, "alice", pending, enabled))
+	// A second update from the same starting point loses, like a replayed code.
+	suite.EqualError(suite.repository.UpdateMFA(suite.ctx, "alice", pending, enabled), "mfa settings changed")
+
+	user, err := suite.repository.FindByUsername(suite.ctx, "alice")
+	suite.Require().NoError(err)
+	suite.Equal(enabled, user.MFA)
+
+	suite.NoError(suite.repository.UpdateMFA(suite.ctx, "alice", user.MFA, nil))
+	user, err = suite.repository.FindByUsername(suite.ctx, "alice")
+	suite.Require().NoError(err)
+	suite.Nil(user.MFA)
+}
+
+func (suite *UserRepositorySuite) TestMarkEmailVerified() {
+	suite.Require().NoError(suite.repository.CreateUser(suite.ctx, domain.User{Username: "alice", Email: "alice@example.com", Orgs: []domain.Membership{{OrgID: "org1", Role: "User"}}}))
+
+	// A link sent to an address the user no longer has does not verify the new one.
+	suite.EqualError(suite.repository.MarkEmailVerified(suite.ctx, "alice", "old@example.com"), "user not found")
+	suite.NoError(suite.repository.MarkEmailVerified(suite.ctx, "alice", "alice@example.com"))
+
+	user, err := suite.repository.FindByEmail(suite.ctx, "alice@example.com")
+	suite.Require().NoError(err)
+	suite.Equal("alice", user.Username)
+	suite.True(user.EmailVerified)
+}
+
 func TestUserRepositorySuite(t *testing.T) {
 	suite.Run(t, new(UserRepositorySuite))
 }
@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	domain "test_task_manager/Domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type webhookDeliveryRepository struct {
+	database             mongo.Database
+	collection           string
+	deadLetterCollection string
+}
+
+func NewWebhookDeliveryRepository(db mongo.Database, collection string, deadLetterCollection string) domain.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		database:             db,
+		collection:           collection,
+		deadLetterCollection: deadLetterCollection,
+	}
+}
+
+func (w *webhookDeliveryRepository) CreateDelivery(c context.Context, delivery domain.WebhookDelivery) error {
+	collection := w.database.Collection(w.collection)
+
+	_, err := collection.InsertOne(c, delivery)
+	return err
+}
+
+func (w *webhookDeliveryRepository) UpdateDelivery(c context.Context, delivery domain.WebhookDelivery) error {
+	collection := w.database.Collection(w.collection)
+
+	filter, err := scoped(c, "event.org_id", bson.D{{Key: "id", Value: delivery.ID}})
+	if err != nil {
+		return err
+	}
+	_, err = collection.ReplaceOne(c, filter, delivery)
+	return err
+}
+
+// ClaimDueDelivery sets claimed_until, which UpdateDelivery drops again by replacing the document.
+func (w *webhookDeliveryRepository) ClaimDueDelivery(c context.Context, now time.Time, until time.Time) (*domain.WebhookDelivery, error) {
+	collection := w.database.Collection(w.collection)
+
+	filter := bson.D{
+		{Key: "status", Value: domain.DeliveryPending},
+		{Key: "next_attempt_at", Value: bson.D{{Key: "$lte", Value: now}}},
+		{Key: "claimed_until", Value: bson.D{{Key: "$not", Value: bson.D{{Key: "$gt", Value: now}}}}},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "claimed_until", Value: until}}}}
+	opts := options.FindOneAndUpdate().SetSort(bson.D{{Key: "next_attempt_at", Value: 1}})
+
+	var delivery domain.WebhookDelivery
+	err := collection.FindOneAndUpdate(c, filter, update, opts).Decode(&delivery)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (w *webhookDeliveryRepository) GetDeliveriesByWebhook(c context.Context, webhookID string) ([]domain.WebhookDelivery, error) {
+	filter := bson.D{{Key: "webhook_id", Value: webhookID}}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	return w.findDeliveries(c, filter, opts)
+}
+
+func (w *webhookDeliveryRepository) CreateDeadLetter(c context.Context, deadLetter domain.WebhookDeadLetter) error {
+	collection := w.database.Collection(w.deadLetterCollection)
+
+	_, err := collection.InsertOne(c, deadLetter)
+	return err
+}
+
+func (w *webhookDeliveryRepository) GetDeadLetters(c context.Context) ([]domain.WebhookDeadLetter, error) {
+	collection := w.database.Collection(w.deadLetterCollection)
+
+	filter, err := orgFilter(c, "event.org_id")
+	if err != nil {
+		return nil, err
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cur, err := collection.Find(c, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(c)
+
+	var deadLetters []domain.WebhookDeadLetter
+	for cur.Next(c) {
+		var deadLetter domain.WebhookDeadLetter
+		if err := cur.Decode(&deadLetter); err != nil {
+			return nil, err
+		}
+		deadLetters = append(deadLetters, deadLetter)
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	return deadLetters, nil
+}
+
+func (w *webhookDeliveryRepository) findDeliveries(c context.Context, filter bson.D, opts *options.FindOptions) ([]domain.WebhookDelivery, error) {
+	collection := w.database.Collection(w.collection)
+
+	filter, err := scoped(c, "event.org_id", filter)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := collection.Find(c, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(c)
+
+	var deliveries []domain.WebhookDelivery
+	for cur.Next(c) {
+		var delivery domain.WebhookDelivery
+		if err := cur.Decode(&delivery); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
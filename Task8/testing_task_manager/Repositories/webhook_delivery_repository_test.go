@@ -0,0 +1,92 @@
+package repositories_test
+
+import (
+	"context"
+	"sync"
+	domain "test_task_manager/Domain"
+	repositories "test_task_manager/Repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WebhookDeliveryRepositorySuite struct {
+	suite.Suite
+	repository domain.WebhookDeliveryRepository
+	ctx        context.Context
+	cleanup    func()
+}
+
+func (suite *WebhookDeliveryRepositorySuite) SetupSuite() {
+	suite.ctx = domain.WithOrg(context.Background(), "org1")
+	client, err := mongo.Connect(context.TODO(), options.Client().ApplyURI("mongodb://localhost:27017"))
+	suite.Require().NoError(err)
+
+	db := client.Database("test_db")
+	suite.repository = repositories.NewWebhookDeliveryRepository(*db, "webhook_deliveries", "webhook_dead_letters")
+	suite.cleanup = func() {
+		db.Collection("webhook_deliveries").Drop(context.TODO())
+		db.Collection("webhook_dead_letters").Drop(context.TODO())
+	}
+}
+
+func (suite *WebhookDeliveryRepositorySuite) TearDownTest() {
+	suite.cleanup()
+}
+
+func (suite *WebhookDeliveryRepositorySuite) TestClaimDueDelivery_GoesToOneDispatcher() {
+	now := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	suite.Require().NoError(suite.repository.CreateDelivery(suite.ctx, domain.WebhookDelivery{ID: "d1", Event: domain.Event{OrgID: "org1"}, Status: domain.DeliveryPending, NextAttemptAt: now}))
+	suite.Require().NoError(suite.repository.CreateDelivery(suite.ctx, domain.WebhookDelivery{ID: "d2", Event: domain.Event{OrgID: "org1"}, Status: domain.DeliveryPending, NextAttemptAt: now.Add(time.Hour)}))
+	all := domain.WithAllOrgs(context.Background())
+
+	var mu sync.Mutex
+	var claimed []string
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			delivery, err := suite.repository.ClaimDueDelivery(all, now, now.Add(time.Minute))
+			suite.NoError(err)
+			if delivery != nil {
+				mu.Lock()
+				claimed = append(claimed, delivery.ID)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	suite.Equal([]string{"d1"}, claimed, "d2 is not due yet")
+}
+
+func (suite *WebhookDeliveryRepositorySuite) TestClaimDueDelivery_AgainOnceReleasedOrExpired() {
+	now := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	suite.Require().NoError(suite.repository.CreateDelivery(suite.ctx, domain.WebhookDelivery{ID: "d1", Event: domain.Event{OrgID: "org1"}, Status: domain.DeliveryPending, NextAttemptAt: now}))
+
+	delivery, err := suite.repository.ClaimDueDelivery(suite.ctx, now, now.Add(time.Minute))
+	suite.Require().NoError(err)
+	suite.Require().NotNil(delivery)
+	delivery, err = suite.repository.ClaimDueDelivery(suite.ctx, now.Add(time.Second*59), now.Add(time.Minute*2))
+	suite.NoError(err)
+	suite.Nil(delivery, "the claim still holds")
+
+	delivery, err = suite.repository.ClaimDueDelivery(suite.ctx, now.Add(time.Minute), now.Add(time.Minute*2))
+	suite.NoError(err)
+	suite.Require().NotNil(delivery, "a dispatcher that died leaves the delivery to the others")
+
+	delivery.Attempts = 1
+	suite.Require().NoError(suite.repository.UpdateDelivery(suite.ctx, *delivery))
+	delivery, err = suite.repository.ClaimDueDelivery(suite.ctx, now.Add(time.Minute), now.Add(time.Minute*2))
+	suite.NoError(err)
+	suite.Require().NotNil(delivery, "updating the delivery releases the claim")
+	suite.Equal(1, delivery.Attempts)
+}
+
+func TestWebhookDeliveryRepositorySuite(t *testing.T) {
+	suite.Run(t, new(WebhookDeliveryRepositorySuite))
+}
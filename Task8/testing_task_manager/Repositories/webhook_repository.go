@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	domain "test_task_manager/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type webhookRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewWebhookRepository(db mongo.Database, collection string) domain.WebhookRepository {
+	return &webhookRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (w *webhookRepository) CreateWebhook(c context.Context, webhook domain.Webhook) error {
+	collection := w.database.Collection(w.collection)
+
+	orgID, err := requireOrg(c)
+	if err != nil {
+		return err
+	}
+	webhook.OrgID = orgID
+	_, err = collection.InsertOne(c, webhook)
+	return err
+}
+
+func (w *webhookRepository) GetWebhooks(c context.Context) ([]domain.Webhook, error) {
+	return w.find(c, bson.D{})
+}
+
+func (w *webhookRepository) GetWebhookByID(c context.Context, webhookID string) (*domain.Webhook, error) {
+	collection := w.database.Collection(w.collection)
+
+	var webhook domain.Webhook
+	filter, err := scoped(c, "org_id", bson.D{{Key: "id", Value: webhookID}})
+	if err != nil {
+		return nil, err
+	}
+	if err := collection.FindOne(c, filter).Decode(&webhook); err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (w *webhookRepository) GetWebhooksForEvent(c context.Context, eventType string) ([]domain.Webhook, error) {
+	filter := bson.D{{Key: "events", Value: bson.D{{Key: "$in", Value: bson.A{eventType, "*"}}}}}
+	return w.find(c, filter)
+}
+
+func (w *webhookRepository) DeleteWebhook(c context.Context, webhookID string) error {
+	collection := w.database.Collection(w.collection)
+
+	filter, err := scoped(c, "org_id", bson.D{{Key: "id", Value: webhookID}})
+	if err != nil {
+		return err
+	}
+	result, err := collection.DeleteOne(c, filter)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("webhook not found")
+	}
+	return nil
+}
+
+func (w *webhookRepository) find(c context.Context, filter bson.D) ([]domain.Webhook, error) {
+	collection := w.database.Collection(w.collection)
+
+	filter, err := scoped(c, "org_id", filter)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := collection.Find(c, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(c)
+
+	var webhooks []domain.Webhook
+	for cur.Next(c) {
+		var webhook domain.Webhook
+		if err := cur.Decode(&webhook); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
@@ -0,0 +1,151 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	usecases "test_task_manager/UseCases"
+	mocks "test_task_manager/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type APIKeyUseCaseSuite struct {
+	suite.Suite
+	apiKeyRepository *mocks.APIKeyRepository
+	clock            *infrastructure.FakeClock
+	apiKeyUseCase    domain.APIKeyUseCase
+	ctx              context.Context
+}
+
+func (suite *APIKeyUseCaseSuite) SetupTest() {
+	suite.apiKeyRepository = new(mocks.APIKeyRepository)
+	suite.clock = infrastructure.NewFakeClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	suite.apiKeyUseCase = usecases.NewAPIKeyUseCase(suite.apiKeyRepository, suite.clock, infrastructure.NewSequentialIDGenerator("key"), 2*time.Second)
+	suite.ctx = domain.WithOrg(context.Background(), "acme")
+}
+
+// issue creates a key through the use case and returns it with the record that was stored.
+func (suite *APIKeyUseCaseSuite) issue(scopes ...string) (string, *domain.APIKey) {
+	suite.apiKeyRepository.On("GetServiceAccountByID", mock.Anything, "sa1").Return(&domain.ServiceAccount{ID: "sa1", OrgID: "acme"}, nil).Once()
+	var stored domain.APIKey
+	suite.apiKeyRepository.On("CreateAPIKey", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(domain.APIKey)
+	}).Return(nil).Once()
+
+	_, key, err := suite.apiKeyUseCase.CreateAPIKey(suite.ctx, "sa1", scopes, nil)
+	suite.Require().NoError(err)
+	return key, &stored
+}
+
+func (suite *APIKeyUseCaseSuite) TestCreateAPIKey_StoresOnlyTheHash() {
+	expiresAt := suite.clock.Now().Add(time.Hour)
+	suite.apiKeyRepository.On("GetServiceAccountByID", mock.Anything, "sa1").Return(&domain.ServiceAccount{ID: "sa1", OrgID: "acme"}, nil)
+	suite.apiKeyRepository.On("CreateAPIKey", mock.Anything, mock.Anything).Return(nil)
+
+	apiKey, key, err := suite.apiKeyUseCase.CreateAPIKey(suite.ctx, "sa1", []string{domain.ScopeRead}, &expiresAt)
+
+	suite.NoError(err)
+	suite.True(strings.HasPrefix(key, domain.APIKeyPrefix+apiKey.ID+"_"))
+	suite.NotEmpty(apiKey.Hash)
+	suite.NotContains(apiKey.Hash, key)
+	suite.Equal("sa1", apiKey.ServiceAccountID)
+	suite.Equal("acme", apiKey.OrgID)
+	suite.apiKeyRepository.AssertCalled(suite.T(), "CreateAPIKey", mock.Anything, mock.MatchedBy(func(stored domain.APIKey) bool {
+		return stored.Hash == apiKey.Hash && !strings.Contains(stored.Hash, key)
+	}))
+}
+
+func (suite *APIKeyUseCaseSuite) TestCreateAPIKey_Negative_Validation() {
+	past := suite.clock.Now().Add(-time.Hour)
+
+	_, _, err := suite.apiKeyUseCase.CreateAPIKey(suite.ctx, "sa1", nil, nil)
+	suite.EqualError(err, "api key must have at least one scope")
+
+	_, _, err = suite.apiKeyUseCase.CreateAPIKey(suite.ctx, "sa1", []string{"delete"}, nil)
+	suite.EqualError(err, "unknown scope: delete")
+
+	_, _, err = suite.apiKeyUseCase.CreateAPIKey(suite.ctx, "sa1", []string{domain.ScopeRead}, &past)
+	suite.EqualError(err, "api key expiry must be in the future")
+
+	suite.apiKeyRepository.On("GetServiceAccountByID", mock.Anything, "other").Return(nil, errors.New("mongo: no documents in result"))
+	_, _, err = suite.apiKeyUseCase.CreateAPIKey(suite.ctx, "other", []string{domain.ScopeRead}, nil)
+	suite.EqualError(err, "service account not found")
+}
+
+func (suite *APIKeyUseCaseSuite) TestAuthenticate_Positive() {
+	key, stored := suite.issue(domain.ScopeWrite)
+	suite.apiKeyRepository.On("FindAPIKey", mock.Anything, stored.ID).Return(stored, nil)
+	suite.apiKeyRepository.On("TouchAPIKey", mock.Anything, stored.ID, mock.Anything).Return(nil).Once()
+
+	apiKey, err := suite.apiKeyUseCase.Authenticate(context.Background(), key)
+
+	suite.NoError(err)
+	suite.Equal("Admin", apiKey.Role())
+	suite.NotNil(apiKey.LastUsedAt)
+	suite.apiKeyRepository.AssertExpectations(suite.T())
+}
+
+func (suite *APIKeyUseCaseSuite) TestAuthenticate_RecentlyUsedKeyIsNotTouched() {
+	key, stored := suite.issue(domain.ScopeRead)
+	lastUsed := suite.clock.Now().UTC().Add(-time.Second)
+	stored.LastUsedAt = &lastUsed
+	suite.apiKeyRepository.On("FindAPIKey", mock.Anything, stored.ID).Return(stored, nil)
+
+	_, err := suite.apiKeyUseCase.Authenticate(context.Background(), key)
+
+	suite.NoError(err)
+	suite.apiKeyRepository.AssertNotCalled(suite.T(), "TouchAPIKey", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *APIKeyUseCaseSuite) TestAuthenticate_Negative_WrongSecret() {
+	key, stored := suite.issue(domain.ScopeRead)
+	suite.apiKeyRepository.On("FindAPIKey", mock.Anything, stored.ID).Return(stored, nil)
+
+	_, err := suite.apiKeyUseCase.Authenticate(context.Background(), key[:len(key)-1]+"x")
+	suite.EqualError(err, "invalid api key")
+
+	_, err = suite.apiKeyUseCase.Authenticate(context.Background(), "not-a-key")
+	suite.EqualError(err, "invalid api key")
+}
+
+func (suite *APIKeyUseCaseSuite) TestAuthenticate_Negative_RevokedOrExpired() {
+	key, stored := suite.issue(domain.ScopeRead)
+	suite.apiKeyRepository.On("FindAPIKey", mock.Anything, stored.ID).Return(stored, nil)
+
+	revokedAt := suite.clock.Now().UTC()
+	stored.RevokedAt = &revokedAt
+	_, err := suite.apiKeyUseCase.Authenticate(context.Background(), key)
+	suite.EqualError(err, "api key has been revoked")
+
+	expiredAt := suite.clock.Now().UTC().Add(-time.Minute)
+	stored.RevokedAt = nil
+	stored.ExpiresAt = &expiredAt
+	_, err = suite.apiKeyUseCase.Authenticate(context.Background(), key)
+	suite.EqualError(err, "api key has expired")
+}
+
+func (suite *APIKeyUseCaseSuite) TestAuthenticate_KeyExpiresByTheClock() {
+	expiresAt := suite.clock.Now().Add(time.Hour)
+	key, stored := suite.issue(domain.ScopeRead)
+	stored.ExpiresAt = &expiresAt
+	suite.apiKeyRepository.On("FindAPIKey", mock.Anything, stored.ID).Return(stored, nil)
+	suite.apiKeyRepository.On("TouchAPIKey", mock.Anything, stored.ID, suite.clock.Now()).Return(nil).Once()
+
+	_, err := suite.apiKeyUseCase.Authenticate(context.Background(), key)
+	suite.NoError(err)
+
+	suite.clock.Advance(time.Hour + time.Second)
+	_, err = suite.apiKeyUseCase.Authenticate(context.Background(), key)
+	suite.EqualError(err, "api key has expired")
+}
+
+func TestAPIKeyUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyUseCaseSuite))
+}
@@ -0,0 +1,165 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	domain "test_task_manager/Domain"
+	"time"
+)
+
+// apiKeyTouchInterval limits how often authentication writes last_used_at, so a busy bot does
+// not turn every request into a write.
+const apiKeyTouchInterval = time.Minute
+
+type apiKeyUseCase struct {
+	apiKeyRepository domain.APIKeyRepository
+	clock            domain.Clock
+	ids              domain.IDGenerator
+	contextTimeout   time.Duration
+}
+
+func NewAPIKeyUseCase(apiKeyRepository domain.APIKeyRepository, clock domain.Clock, ids domain.IDGenerator, timeout time.Duration) domain.APIKeyUseCase {
+	return &apiKeyUseCase{
+		apiKeyRepository: apiKeyRepository,
+		clock:            clock,
+		ids:              ids,
+		contextTimeout:   timeout,
+	}
+}
+
+func (a *apiKeyUseCase) CreateServiceAccount(c context.Context, name string, createdBy string) (*domain.ServiceAccount, error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("service account name is required")
+	}
+
+	orgID, _ := domain.OrgFromContext(ctx)
+	account := domain.ServiceAccount{ID: a.ids.NewID(), Name: name, OrgID: orgID, CreatedBy: createdBy, CreatedAt: a.clock.Now().UTC()}
+	if err := a.apiKeyRepository.CreateServiceAccount(ctx, account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (a *apiKeyUseCase) GetServiceAccounts(c context.Context) ([]domain.ServiceAccount, error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+	return a.apiKeyRepository.GetServiceAccounts(ctx)
+}
+
+func (a *apiKeyUseCase) CreateAPIKey(c context.Context, serviceAccountID string, scopes []string, expiresAt *time.Time) (*domain.APIKey, string, error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	if len(scopes) == 0 {
+		return nil, "", errors.New("api key must have at least one scope")
+	}
+	for _, scope := range scopes {
+		if !isKnownScope(scope) {
+			return nil, "", errors.New("unknown scope: " + scope)
+		}
+	}
+	now := a.clock.Now().UTC()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, "", errors.New("api key expiry must be in the future")
+	}
+
+	account, err := a.apiKeyRepository.GetServiceAccountByID(ctx, serviceAccountID)
+	if err != nil {
+		return nil, "", errors.New("service account not found")
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+	key := domain.APIKey{
+		ID:               a.ids.NewID(),
+		ServiceAccountID: account.ID,
+		OrgID:            account.OrgID,
+		Scopes:           scopes,
+		ExpiresAt:        expiresAt,
+		CreatedAt:        now,
+	}
+	plaintext := domain.APIKeyPrefix + key.ID + "_" + hex.EncodeToString(secret)
+	key.Hash = hashAPIKey(plaintext)
+
+	if err := a.apiKeyRepository.CreateAPIKey(ctx, key); err != nil {
+		return nil, "", err
+	}
+	return &key, plaintext, nil
+}
+
+func (a *apiKeyUseCase) GetAPIKeys(c context.Context, serviceAccountID string) ([]domain.APIKey, error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	if _, err := a.apiKeyRepository.GetServiceAccountByID(ctx, serviceAccountID); err != nil {
+		return nil, errors.New("service account not found")
+	}
+	return a.apiKeyRepository.GetAPIKeys(ctx, serviceAccountID)
+}
+
+func (a *apiKeyUseCase) RevokeAPIKey(c context.Context, serviceAccountID string, keyID string) error {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+	return a.apiKeyRepository.RevokeAPIKey(ctx, serviceAccountID, keyID, a.clock.Now().UTC())
+}
+
+func (a *apiKeyUseCase) Authenticate(c context.Context, key string) (*domain.APIKey, error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	// A key is tmk_<id>_<secret>. The id finds the record, the hash of the whole key proves it.
+	keyID, _, ok := strings.Cut(strings.TrimPrefix(key, domain.APIKeyPrefix), "_")
+	if !ok || !strings.HasPrefix(key, domain.APIKeyPrefix) {
+		return nil, errors.New("invalid api key")
+	}
+	apiKey, err := a.apiKeyRepository.FindAPIKey(ctx, keyID)
+	if err != nil || subtle.ConstantTimeCompare([]byte(apiKey.Hash), []byte(hashAPIKey(key))) != 1 {
+		return nil, errors.New("invalid api key")
+	}
+
+	now := a.clock.Now().UTC()
+	if apiKey.RevokedAt != nil {
+		return nil, errors.New("api key has been revoked")
+	}
+	if apiKey.ExpiresAt != nil && !apiKey.ExpiresAt.After(now) {
+		return nil, errors.New("api key has expired")
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyTouchInterval {
+		// Failing to record the use is not a reason to turn the caller away.
+		if err := a.apiKeyRepository.TouchAPIKey(ctx, apiKey.ID, now); err != nil {
+			log.Printf("recording use of api key %s: %v", apiKey.ID, err)
+		} else {
+			apiKey.LastUsedAt = &now
+		}
+	}
+	return apiKey, nil
+}
+
+// hashAPIKey uses a plain SHA-256: keys carry 256 random bits, so unlike passwords they need no
+// slow hash, and authentication runs on every request.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func isKnownScope(scope string) bool {
+	for _, known := range domain.APIKeyScopes {
+		if known == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	infrastructure "test_task_manager/Infrastructure"
+	"time"
+)
+
+// normalizeEmail lowercases a bare address such as "alice@example.com". Display names and
+// lists are refused, since the address ends up in a mail header.
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email || address.Name != "" {
+		return "", errors.New("a valid email address is required")
+	}
+	return email, nil
+}
+
+func verificationEmail(publicURL string, to string, token string) infrastructure.Email {
+	link := strings.TrimRight(publicURL, "/") + "/verify-email?token=" + url.QueryEscape(token)
+	return infrastructure.Email{
+		To:      to,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Open this link within 24 hours to verify your email address and start logging in:\n\n%s\n\n"+
+			"If you did not register, you can ignore this email.\n", link),
+	}
+}
+
+func invitationEmail(publicURL string, to string, orgID string, role string, code string, expiresAt time.Time) infrastructure.Email {
+	return infrastructure.Email{
+		To:      to,
+		Subject: "You are invited to the Task Manager",
+		Body: fmt.Sprintf("You are invited to join the %s organization as a %s.\n\n"+
+			"Register at %s/register with this email address and the invitation code below before %s:\n\n%s\n",
+			orgID, role, strings.TrimRight(publicURL, "/"), expiresAt.Format(time.RFC1123), code),
+	}
+}
@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	domain "test_task_manager/Domain"
+)
+
+// newEvent stamps the event with the organization of ctx, which decides who may see it.
+func newEvent(ctx context.Context, clock domain.Clock, ids domain.IDGenerator, eventType string, data interface{}) (domain.Event, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return domain.Event{}, err
+	}
+
+	orgID, _ := domain.OrgFromContext(ctx)
+	return domain.Event{
+		ID:        ids.NewID(),
+		Type:      eventType,
+		Data:      payload,
+		CreatedAt: clock.Now().UTC(),
+		OrgID:     orgID,
+	}, nil
+}
+
+// userEventData is what user events expose; the password hash never leaves the service.
+func userEventData(user domain.User) map[string]string {
+	return map[string]string{"username": user.Username, "role": user.Role}
+}
+
+// enqueueEvent records the event in outbox, which should be the one of the unit of work making
+// the change so both are committed together.
+func enqueueEvent(ctx context.Context, outbox domain.OutboxRepository, clock domain.Clock, ids domain.IDGenerator, eventType string, data interface{}) error {
+	event, err := newEvent(ctx, clock, ids, eventType, data)
+	if err != nil {
+		return err
+	}
+	return outbox.Enqueue(ctx, event)
+}
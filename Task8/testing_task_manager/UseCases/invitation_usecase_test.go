@@ -0,0 +1,92 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	usecases "test_task_manager/UseCases"
+	mocks "test_task_manager/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type InvitationUseCaseSuite struct {
+	suite.Suite
+	invitationRepository *mocks.InvitationRepository
+	mailer               *mocks.Mailer
+	invitationUseCase    domain.InvitationUseCase
+	ctx                  context.Context
+}
+
+func (suite *InvitationUseCaseSuite) SetupTest() {
+	suite.invitationRepository = new(mocks.InvitationRepository)
+	suite.mailer = new(mocks.Mailer)
+	suite.invitationUseCase = usecases.NewInvitationUseCase(suite.invitationRepository, suite.mailer, "https://tasks.example.com", infrastructure.NewSystemClock(), infrastructure.NewRandomIDGenerator(), 2*time.Second)
+	suite.ctx = domain.WithOrg(context.Background(), "acme")
+}
+
+func (suite *InvitationUseCaseSuite) TestCreateInvitation_Defaults() {
+	suite.invitationRepository.On("CreateInvitation", mock.Anything, mock.Anything).Return(nil)
+
+	invitation, code, err := suite.invitationUseCase.CreateInvitation(suite.ctx, "", "", 0, "alice")
+
+	suite.NoError(err)
+	suite.NotEmpty(code)
+	suite.Equal("User", invitation.Role)
+	suite.Equal("acme", invitation.OrgID)
+	suite.Equal("alice", invitation.CreatedBy)
+	suite.Equal(7*24*time.Hour, invitation.ExpiresAt.Sub(invitation.CreatedAt))
+	suite.invitationRepository.AssertCalled(suite.T(), "CreateInvitation", mock.Anything, mock.MatchedBy(func(stored domain.Invitation) bool {
+		return stored.Hash != "" && stored.Hash != code
+	}))
+}
+
+func (suite *InvitationUseCaseSuite) TestCreateInvitation_Negative() {
+	_, _, err := suite.invitationUseCase.CreateInvitation(suite.ctx, "Owner", "", 0, "alice")
+	suite.EqualError(err, "role must be Admin or User")
+
+	_, _, err = suite.invitationUseCase.CreateInvitation(suite.ctx, "Admin", "", 31*24*time.Hour, "alice")
+	suite.EqualError(err, "invitation must expire within 30 days")
+
+	_, _, err = suite.invitationUseCase.CreateInvitation(suite.ctx, "User", "Bob <bob@example.com>", 0, "alice")
+	suite.EqualError(err, "a valid email address is required")
+
+	suite.invitationRepository.AssertNotCalled(suite.T(), "CreateInvitation", mock.Anything, mock.Anything)
+}
+
+func (suite *InvitationUseCaseSuite) TestCreateInvitation_MailsTheCode() {
+	suite.invitationRepository.On("CreateInvitation", mock.Anything, mock.Anything).Return(nil)
+	var sent infrastructure.Email
+	suite.mailer.On("Send", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		sent = args.Get(1).(infrastructure.Email)
+	}).Return(nil)
+
+	invitation, code, err := suite.invitationUseCase.CreateInvitation(suite.ctx, "User", " Bob@Example.com", 0, "alice")
+
+	suite.NoError(err)
+	suite.Equal("bob@example.com", invitation.Email)
+	suite.Equal("bob@example.com", sent.To)
+	suite.True(strings.Contains(sent.Body, code))
+	suite.True(strings.Contains(sent.Body, "https://tasks.example.com/register"))
+}
+
+func (suite *InvitationUseCaseSuite) TestCreateInvitation_Negative_MailFails() {
+	suite.invitationRepository.On("CreateInvitation", mock.Anything, mock.Anything).Return(nil)
+	suite.invitationRepository.On("DeleteInvitation", mock.Anything, mock.Anything).Return(nil)
+	suite.mailer.On("Send", mock.Anything, mock.Anything).Return(errors.New("connection refused"))
+
+	_, _, err := suite.invitationUseCase.CreateInvitation(suite.ctx, "User", "bob@example.com", 0, "alice")
+
+	suite.EqualError(err, "the invitation email could not be sent")
+	suite.invitationRepository.AssertCalled(suite.T(), "DeleteInvitation", mock.Anything, mock.Anything)
+}
+
+func TestInvitationUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(InvitationUseCaseSuite))
+}
@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	"time"
+)
+
+const (
+	defaultInvitationTTL = 7 * 24 * time.Hour
+	maxInvitationTTL     = 30 * 24 * time.Hour
+)
+
+type invitationUseCase struct {
+	invitationRepository domain.InvitationRepository
+	mailer               infrastructure.Mailer
+	publicURL            string
+	clock                domain.Clock
+	ids                  domain.IDGenerator
+	contextTimeout       time.Duration
+}
+
+func NewInvitationUseCase(invitationRepository domain.InvitationRepository, mailer infrastructure.Mailer, publicURL string, clock domain.Clock, ids domain.IDGenerator, timeout time.Duration) domain.InvitationUseCase {
+	return &invitationUseCase{
+		invitationRepository: invitationRepository,
+		mailer:               mailer,
+		publicURL:            publicURL,
+		clock:                clock,
+		ids:                  ids,
+		contextTimeout:       timeout,
+	}
+}
+
+func (i *invitationUseCase) CreateInvitation(c context.Context, role string, email string, ttl time.Duration, createdBy string) (*domain.Invitation, string, error) {
+	ctx, cancel := context.WithTimeout(c, i.contextTimeout)
+	defer cancel()
+
+	if role == "" {
+		role = "User"
+	}
+	if role != "User" && role != "Admin" {
+		return nil, "", errors.New("role must be Admin or User")
+	}
+	if ttl == 0 {
+		ttl = defaultInvitationTTL
+	}
+	if ttl < 0 || ttl > maxInvitationTTL {
+		return nil, "", errors.New("invitation must expire within 30 days")
+	}
+	if email != "" {
+		var err error
+		if email, err = normalizeEmail(email); err != nil {
+			return nil, "", err
+		}
+	}
+
+	orgID, _ := domain.OrgFromContext(ctx)
+	now := i.clock.Now().UTC()
+	code := randomToken()
+	invitation := domain.Invitation{
+		ID:        i.ids.NewID(),
+		OrgID:     orgID,
+		Role:      role,
+		Email:     email,
+		Hash:      hashInvitationCode(code),
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := i.invitationRepository.CreateInvitation(ctx, invitation); err != nil {
+		return nil, "", err
+	}
+	if email != "" {
+		// An invitation nobody was told about would only linger, so it is taken back.
+		if err := i.mailer.Send(ctx, invitationEmail(i.publicURL, email, orgID, role, code, invitation.ExpiresAt)); err != nil {
+			if deleteErr := i.invitationRepository.DeleteInvitation(ctx, invitation.ID); deleteErr != nil {
+				return nil, "", deleteErr
+			}
+			return nil, "", errors.New("the invitation email could not be sent")
+		}
+	}
+	return &invitation, code, nil
+}
+
+func (i *invitationUseCase) GetInvitations(c context.Context) ([]domain.Invitation, error) {
+	ctx, cancel := context.WithTimeout(c, i.contextTimeout)
+	defer cancel()
+	return i.invitationRepository.GetInvitations(ctx)
+}
+
+func (i *invitationUseCase) RevokeInvitation(c context.Context, invitationID string) error {
+	ctx, cancel := context.WithTimeout(c, i.contextTimeout)
+	defer cancel()
+	return i.invitationRepository.DeleteInvitation(ctx, invitationID)
+}
+
+// hashInvitationCode is what the repository stores and looks codes up by. Codes are random
+// enough that a plain SHA-256 is safe, like for API keys.
+func hashInvitationCode(code string) string {
+	return hashAPIKey(code)
+}
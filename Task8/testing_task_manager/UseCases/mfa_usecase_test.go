@@ -0,0 +1,187 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	usecases "test_task_manager/UseCases"
+	mocks "test_task_manager/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MFAUseCaseSuite struct {
+	suite.Suite
+	userRepository *mocks.UserRepository
+	jwtService     *mocks.JWTService
+	totpService    infrastructure.TOTPService
+	clock          *infrastructure.FakeClock
+	mfaUseCase     domain.MFAUseCase
+	user           domain.User
+}
+
+func (suite *MFAUseCaseSuite) SetupTest() {
+	suite.userRepository = new(mocks.UserRepository)
+	suite.jwtService = new(mocks.JWTService)
+	suite.totpService = infrastructure.NewTOTPService()
+	suite.clock = infrastructure.NewFakeClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	suite.mfaUseCase = usecases.NewMFAUseCase(suite.userRepository, suite.jwtService, suite.totpService, "Task Manager", suite.clock, 2*time.Second)
+	suite.user = domain.User{Username: "alice", Orgs: []domain.Membership{{OrgID: domain.DefaultOrgID, Role: "Admin"}}}
+
+	// The repository keeps suite.user and compares the MFA settings like the Mongo filter does.
+	suite.userRepository.EXPECT().FindByUsername(mock.Anything, "alice").RunAndReturn(func(context.Context, string) (*domain.User, error) {
+		user := suite.user
+		if user.MFA != nil {
+			mfa := *user.MFA
+			user.MFA = &mfa
+		}
+		return &user, nil
+	}).Maybe()
+	suite.userRepository.EXPECT().UpdateMFA(mock.Anything, "alice", mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, _ string, current *domain.MFA, updated *domain.MFA) error {
+		if !reflect.DeepEqual(current, suite.user.MFA) {
+			return errors.New("mfa settings changed")
+		}
+		suite.user.MFA = updated
+		return nil
+	}).Maybe()
+	suite.jwtService.On("ValidateMFAToken", "mfaToken").Return("alice", nil).Maybe()
+}
+
+// enroll enables two-factor authentication for alice and returns the recovery codes.
+func (suite *MFAUseCaseSuite) enroll() []string {
+	enrollment, err := suite.mfaUseCase.Enroll(context.Background(), "alice")
+	suite.Require().NoError(err)
+	code, _ := suite.totpService.GenerateCode(enrollment.Secret, suite.clock.Now())
+	recoveryCodes, err := suite.mfaUseCase.ConfirmEnrollment(context.Background(), "alice", code)
+	suite.Require().NoError(err)
+	return recoveryCodes
+}
+
+// nextCode moves the clock on to the next time step and returns its code, so the replay
+// protection accepts it.
+func (suite *MFAUseCaseSuite) nextCode() string {
+	suite.clock.Advance(30 * time.Second)
+	code, _ := suite.totpService.GenerateCode(suite.user.MFA.Secret, suite.clock.Now())
+	return code
+}
+
+func (suite *MFAUseCaseSuite) TestEnroll() {
+	enrollment, err := suite.mfaUseCase.Enroll(context.Background(), "alice")
+
+	suite.NoError(err)
+	suite.NotEmpty(enrollment.Secret)
+	suite.True(strings.HasPrefix(enrollment.ProvisioningURI, "otpauth://totp/Task%20Manager:alice?"))
+	suite.Equal(&domain.MFA{Secret: enrollment.Secret}, suite.user.MFA)
+}
+
+func (suite *MFAUseCaseSuite) TestConfirmEnrollment() {
+	recoveryCodes := suite.enroll()
+
+	suite.Len(recoveryCodes, 10)
+	suite.True(suite.user.MFA.Enabled)
+	suite.Len(suite.user.MFA.RecoveryCodes, 10)
+	suite.NotContains(suite.user.MFA.RecoveryCodes, recoveryCodes[0], "only hashes are stored")
+
+	_, err := suite.mfaUseCase.Enroll(context.Background(), "alice")
+	suite.EqualError(err, "mfa is already enabled")
+}
+
+func (suite *MFAUseCaseSuite) TestConfirmEnrollment_Negative_WrongCode() {
+	_, err := suite.mfaUseCase.Enroll(context.Background(), "alice")
+	suite.Require().NoError(err)
+
+	_, err = suite.mfaUseCase.ConfirmEnrollment(context.Background(), "alice", "000000")
+
+	suite.EqualError(err, "invalid mfa code")
+	suite.False(suite.user.MFA.Enabled)
+}
+
+func (suite *MFAUseCaseSuite) TestVerifyLogin() {
+	suite.enroll()
+	suite.jwtService.On("GenerateToken", "alice", suite.user.Orgs).Return("token", nil)
+
+	token, err := suite.mfaUseCase.VerifyLogin(context.Background(), "mfaToken", suite.nextCode())
+
+	suite.NoError(err)
+	suite.Equal("token", token)
+}
+
+func (suite *MFAUseCaseSuite) TestVerifyLogin_Negative_CodeReplayed() {
+	suite.enroll()
+	suite.jwtService.On("GenerateToken", "alice", suite.user.Orgs).Return("token", nil)
+	code := suite.nextCode()
+	_, err := suite.mfaUseCase.VerifyLogin(context.Background(), "mfaToken", code)
+	suite.Require().NoError(err)
+
+	_, err = suite.mfaUseCase.VerifyLogin(context.Background(), "mfaToken", code)
+
+	suite.EqualError(err, "invalid mfa code")
+}
+
+func (suite *MFAUseCaseSuite) TestVerifyLogin_RecoveryCodeWorksOnce() {
+	recoveryCodes := suite.enroll()
+	suite.jwtService.On("GenerateToken", "alice", suite.user.Orgs).Return("token", nil)
+
+	_, err := suite.mfaUseCase.VerifyLogin(context.Background(), "mfaToken", strings.ToUpper(recoveryCodes[3]))
+	suite.NoError(err)
+	suite.Len(suite.user.MFA.RecoveryCodes, 9)
+
+	_, err = suite.mfaUseCase.VerifyLogin(context.Background(), "mfaToken", recoveryCodes[3])
+	suite.EqualError(err, "invalid mfa code")
+}
+
+func (suite *MFAUseCaseSuite) TestVerifyLogin_Negative_LockedAfterFailedAttempts() {
+	suite.enroll()
+
+	for i := 0; i < 5; i++ {
+		_, err := suite.mfaUseCase.VerifyLogin(context.Background(), "mfaToken", "000000")
+		suite.EqualError(err, "invalid mfa code")
+	}
+	_, err := suite.mfaUseCase.VerifyLogin(context.Background(), "mfaToken", suite.nextCode())
+
+	suite.EqualError(err, "too many failed mfa attempts, try again later")
+	suite.jwtService.AssertNotCalled(suite.T(), "GenerateToken", mock.Anything, mock.Anything)
+}
+
+func (suite *MFAUseCaseSuite) TestVerifyLogin_LockoutEnds() {
+	suite.enroll()
+	suite.jwtService.On("GenerateToken", "alice", suite.user.Orgs).Return("token", nil)
+
+	for i := 0; i < 5; i++ {
+		_, err := suite.mfaUseCase.VerifyLogin(context.Background(), "mfaToken", "000000")
+		suite.EqualError(err, "invalid mfa code")
+	}
+	suite.clock.Advance(5 * time.Minute)
+	token, err := suite.mfaUseCase.VerifyLogin(context.Background(), "mfaToken", suite.nextCode())
+
+	suite.NoError(err)
+	suite.Equal("token", token)
+}
+
+func (suite *MFAUseCaseSuite) TestVerifyLogin_Negative_InvalidToken() {
+	suite.jwtService.On("ValidateMFAToken", "forged").Return("", errors.New("signature is invalid"))
+
+	_, err := suite.mfaUseCase.VerifyLogin(context.Background(), "forged", "123456")
+
+	suite.EqualError(err, "invalid or expired mfa token")
+}
+
+func (suite *MFAUseCaseSuite) TestDisable() {
+	recoveryCodes := suite.enroll()
+
+	err := suite.mfaUseCase.Disable(context.Background(), "alice", recoveryCodes[0])
+
+	suite.NoError(err)
+	suite.Nil(suite.user.MFA)
+}
+
+func TestMFAUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(MFAUseCaseSuite))
+}
@@ -0,0 +1,208 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	"time"
+)
+
+const (
+	recoveryCodeCount = 10
+	// After mfaMaxFailedAttempts wrong codes in a row, codes are refused for mfaLockout, which
+	// keeps guessing a 6 digit code out of reach.
+	mfaMaxFailedAttempts = 5
+	mfaLockout           = 5 * time.Minute
+)
+
+type mfaUseCase struct {
+	userRepository domain.UserRepository
+	jwtService     infrastructure.JWTService
+	totpService    infrastructure.TOTPService
+	issuer         string
+	clock          domain.Clock
+	contextTimeout time.Duration
+}
+
+// NewMFAUseCase names the service issuer in authenticator apps.
+func NewMFAUseCase(userRepository domain.UserRepository, jwtService infrastructure.JWTService, totpService infrastructure.TOTPService, issuer string, clock domain.Clock, timeout time.Duration) domain.MFAUseCase {
+	return &mfaUseCase{
+		userRepository: userRepository,
+		jwtService:     jwtService,
+		totpService:    totpService,
+		issuer:         issuer,
+		clock:          clock,
+		contextTimeout: timeout,
+	}
+}
+
+func (m *mfaUseCase) Enroll(c context.Context, username string) (*domain.MFAEnrollment, error) {
+	ctx, cancel := context.WithTimeout(c, m.contextTimeout)
+	defer cancel()
+
+	user, err := m.userRepository.FindByUsername(ctx, username)
+	if err != nil || user.Username == "" {
+		return nil, errors.New("user not found")
+	}
+	if user.MFA != nil && user.MFA.Enabled {
+		return nil, errors.New("mfa is already enabled")
+	}
+
+	secret, err := m.totpService.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := m.userRepository.UpdateMFA(ctx, username, user.MFA, &domain.MFA{Secret: secret}); err != nil {
+		return nil, err
+	}
+	return &domain.MFAEnrollment{
+		Secret:          secret,
+		ProvisioningURI: m.totpService.ProvisioningURI(secret, m.issuer, username),
+	}, nil
+}
+
+func (m *mfaUseCase) ConfirmEnrollment(c context.Context, username string, code string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(c, m.contextTimeout)
+	defer cancel()
+
+	user, err := m.userRepository.FindByUsername(ctx, username)
+	if err != nil || user.Username == "" {
+		return nil, errors.New("user not found")
+	}
+	if user.MFA == nil {
+		return nil, errors.New("mfa enrollment has not been started")
+	}
+	if user.MFA.Enabled {
+		return nil, errors.New("mfa is already enabled")
+	}
+	step, ok := m.totpService.Validate(user.MFA.Secret, code, m.clock.Now())
+	if !ok {
+		return nil, errors.New("invalid mfa code")
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		codes[i] = newRecoveryCode()
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+	enabled := &domain.MFA{
+		Secret:        user.MFA.Secret,
+		Enabled:       true,
+		RecoveryCodes: hashes,
+		LastUsedStep:  step,
+	}
+	if err := m.userRepository.UpdateMFA(ctx, username, user.MFA, enabled); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (m *mfaUseCase) Disable(c context.Context, username string, code string) error {
+	ctx, cancel := context.WithTimeout(c, m.contextTimeout)
+	defer cancel()
+
+	user, err := m.userRepository.FindByUsername(ctx, username)
+	if err != nil || user.Username == "" {
+		return errors.New("user not found")
+	}
+	if user.MFA == nil || !user.MFA.Enabled {
+		return errors.New("mfa is not enabled")
+	}
+	stored, err := m.verify(ctx, user, code)
+	if err != nil {
+		return err
+	}
+	return m.userRepository.UpdateMFA(ctx, username, stored, nil)
+}
+
+func (m *mfaUseCase) VerifyLogin(c context.Context, mfaToken string, code string) (string, error) {
+	ctx, cancel := context.WithTimeout(c, m.contextTimeout)
+	defer cancel()
+
+	username, err := m.jwtService.ValidateMFAToken(mfaToken)
+	if err != nil {
+		return "", errors.New("invalid or expired mfa token")
+	}
+	user, err := m.userRepository.FindByUsername(ctx, username)
+	if err != nil || user.Username == "" || user.MFA == nil || !user.MFA.Enabled {
+		return "", errors.New("invalid or expired mfa token")
+	}
+	if _, err := m.verify(ctx, user, code); err != nil {
+		return "", err
+	}
+	return m.jwtService.GenerateToken(user.Username, user.Orgs)
+}
+
+// verify accepts a TOTP code newer than the last one used, or an unused recovery code, and
+// records it so it cannot be used again. Wrong codes count towards a lockout. It returns the
+// settings as stored afterwards.
+func (m *mfaUseCase) verify(ctx context.Context, user *domain.User, code string) (*domain.MFA, error) {
+	current := user.MFA
+	now := m.clock.Now().UTC()
+	if current.LockedUntil != nil && now.Before(*current.LockedUntil) {
+		return nil, errors.New("too many failed mfa attempts, try again later")
+	}
+
+	updated := *current
+	updated.LockedUntil = nil
+	updated.FailedAttempts = 0
+
+	valid := false
+	if step, ok := m.totpService.Validate(current.Secret, strings.TrimSpace(code), now); ok && step > current.LastUsedStep {
+		updated.LastUsedStep = step
+		valid = true
+	} else if index := indexOf(current.RecoveryCodes, hashRecoveryCode(code)); index >= 0 {
+		updated.RecoveryCodes = append(append([]string{}, current.RecoveryCodes[:index]...), current.RecoveryCodes[index+1:]...)
+		valid = true
+	} else {
+		updated.FailedAttempts = current.FailedAttempts + 1
+		if updated.FailedAttempts >= mfaMaxFailedAttempts {
+			lockedUntil := now.Add(mfaLockout)
+			updated.LockedUntil = &lockedUntil
+			updated.FailedAttempts = 0
+		}
+	}
+
+	// A concurrent login that used the same code changes the settings first, and this one
+	// fails. That is what makes a code single-use.
+	if err := m.userRepository.UpdateMFA(ctx, user.Username, current, &updated); err != nil {
+		if err.Error() == "mfa settings changed" {
+			return nil, errors.New("invalid mfa code")
+		}
+		return nil, err
+	}
+	if !valid {
+		return nil, errors.New("invalid mfa code")
+	}
+	return &updated, nil
+}
+
+// newRecoveryCode returns 40 random bits as two groups of five hex digits, like 3f9a2-0c81d.
+func newRecoveryCode() string {
+	b := make([]byte, 5)
+	rand.Read(b)
+	code := hex.EncodeToString(b)
+	return code[:5] + "-" + code[5:]
+}
+
+// hashRecoveryCode ignores case, spaces and dashes, which people get wrong when typing codes.
+func hashRecoveryCode(code string) string {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,235 @@
+package usecases_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	usecases "test_task_manager/UseCases"
+	mocks "test_task_manager/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type OIDCUseCaseSuite struct {
+	suite.Suite
+	provider        *mocks.OIDCProvider
+	loginRepository *mocks.OIDCLoginRepository
+	userRepository  *mocks.UserRepository
+	jwtService      *mocks.JWTService
+	outbox          *mocks.OutboxRepository
+	transactor      *mocks.Transactor
+	oidcUseCase     domain.OIDCUseCase
+	login           domain.OIDCLogin
+}
+
+func (suite *OIDCUseCaseSuite) SetupTest() {
+	suite.provider = new(mocks.OIDCProvider)
+	suite.loginRepository = new(mocks.OIDCLoginRepository)
+	suite.userRepository = new(mocks.UserRepository)
+	suite.jwtService = new(mocks.JWTService)
+	suite.outbox = new(mocks.OutboxRepository)
+	suite.transactor = new(mocks.Transactor)
+
+	suite.transactor.EXPECT().WithTransaction(mock.Anything, mock.Anything).RunAndReturn(func(c context.Context, fn func(context.Context) error) error {
+		return fn(c)
+	}).Maybe()
+
+	suite.oidcUseCase = suite.newUseCase(false)
+
+	suite.login = domain.OIDCLogin{State: "state-1", Nonce: "nonce-1", CodeVerifier: "verifier-1", ExpiresAt: time.Now().Add(time.Minute)}
+}
+
+func (suite *OIDCUseCaseSuite) newUseCase(requireAdminMFA bool) domain.OIDCUseCase {
+	return usecases.NewOIDCUseCase(suite.provider, suite.loginRepository, suite.userRepository, suite.jwtService, suite.outbox, suite.transactor, domain.OIDCClaimMapping{
+		UsernameClaim: "preferred_username",
+		RoleClaim:     "groups",
+		AdminValue:    "task-admins",
+	}, requireAdminMFA, infrastructure.NewSystemClock(), infrastructure.NewRandomIDGenerator(), 2*time.Second)
+}
+
+// exchange sets up a login for state-1 whose code exchange returns claims.
+func (suite *OIDCUseCaseSuite) exchange(claims map[string]interface{}) {
+	suite.loginRepository.On("TakeLogin", mock.Anything, "state-1").Return(&suite.login, nil)
+	suite.provider.On("Exchange", mock.Anything, "code-1", "verifier-1", "nonce-1").Return(claims, nil)
+}
+
+func (suite *OIDCUseCaseSuite) TestBeginLogin() {
+	var challenge string
+	suite.provider.On("AuthCodeURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		challenge = args.String(3)
+	}).Return("https://idp.example.com/authorize?state=x", nil)
+	var saved domain.OIDCLogin
+	suite.loginRepository.On("SaveLogin", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(domain.OIDCLogin)
+	}).Return(nil)
+
+	authURL, state, err := suite.oidcUseCase.BeginLogin(context.Background())
+
+	suite.NoError(err)
+	suite.Equal("https://idp.example.com/authorize?state=x", authURL)
+	suite.Equal(saved.State, state)
+	suite.NotEqual(saved.State, saved.Nonce)
+	suite.Len(saved.CodeVerifier, 43)
+	suite.True(saved.ExpiresAt.After(time.Now()))
+	sum := sha256.Sum256([]byte(saved.CodeVerifier))
+	suite.Equal(base64.RawURLEncoding.EncodeToString(sum[:]), challenge)
+	suite.provider.AssertCalled(suite.T(), "AuthCodeURL", mock.Anything, saved.State, saved.Nonce, challenge)
+}
+
+func (suite *OIDCUseCaseSuite) TestCompleteLogin_ProvisionsNewUser() {
+	suite.exchange(map[string]interface{}{"sub": "u-1", "preferred_username": "alice", "groups": []interface{}{"task-admins"}, "email": "Alice@Example.com", "email_verified": true})
+	suite.userRepository.On("FindByOIDCSubject", mock.Anything, "u-1").Return(nil, errors.New("user not found"))
+	suite.userRepository.On("FindByEmail", mock.Anything, "alice@example.com").Return(nil, errors.New("mongo: no documents in result"))
+	suite.userRepository.On("FindByUsername", mock.Anything, "alice").Return(nil, errors.New("mongo: no documents in result"))
+	suite.userRepository.On("CreateUser", mock.Anything, mock.Anything).Return(nil)
+	suite.outbox.On("Enqueue", mock.Anything, mock.Anything).Return(nil)
+	admin := []domain.Membership{{OrgID: domain.DefaultOrgID, Role: "Admin"}}
+	suite.jwtService.On("GenerateToken", "alice", admin).Return("token", nil)
+
+	result, err := suite.oidcUseCase.CompleteLogin(context.Background(), "state-1", "code-1")
+
+	suite.NoError(err)
+	suite.Equal(&domain.LoginResult{Token: "token"}, result)
+	suite.userRepository.AssertCalled(suite.T(), "CreateUser", mock.Anything, mock.MatchedBy(func(user domain.User) bool {
+		return user.Username == "alice" && user.Password == "" && user.OIDCSubject == "u-1" && user.Role == "Admin" &&
+			user.Email == "alice@example.com" && user.EmailVerified
+	}))
+	suite.outbox.AssertCalled(suite.T(), "Enqueue", mock.Anything, mock.MatchedBy(func(event domain.Event) bool {
+		return event.Type == domain.EventUserCreated && event.OrgID == domain.DefaultOrgID
+	}))
+}
+
+func (suite *OIDCUseCaseSuite) TestCompleteLogin_LinksUserByVerifiedEmail() {
+	suite.exchange(map[string]interface{}{"sub": "u-1", "preferred_username": "alice.smith", "groups": []interface{}{"staff"}, "email": "alice@example.com", "email_verified": true})
+	orgs := []domain.Membership{{OrgID: domain.DefaultOrgID, Role: "User"}}
+	suite.userRepository.On("FindByOIDCSubject", mock.Anything, "u-1").Return(nil, errors.New("user not found"))
+	suite.userRepository.On("FindByEmail", mock.Anything, "alice@example.com").Return(&domain.User{Username: "alice", Email: "alice@example.com", EmailVerified: true, Orgs: orgs}, nil)
+	suite.userRepository.On("LinkOIDCSubject", mock.Anything, "alice", "u-1").Return(nil)
+	suite.jwtService.On("GenerateToken", "alice", orgs).Return("token", nil)
+
+	result, err := suite.oidcUseCase.CompleteLogin(context.Background(), "state-1", "code-1")
+
+	suite.NoError(err)
+	suite.Equal(&domain.LoginResult{Token: "token"}, result)
+	suite.userRepository.AssertNotCalled(suite.T(), "CreateUser", mock.Anything, mock.Anything)
+	suite.userRepository.AssertNotCalled(suite.T(), "SetRole", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *OIDCUseCaseSuite) TestCompleteLogin_MFAEnrolledUserGetsChallenge() {
+	suite.exchange(map[string]interface{}{"sub": "u-1", "preferred_username": "alice", "groups": []interface{}{"staff"}})
+	orgs := []domain.Membership{{OrgID: domain.DefaultOrgID, Role: "User"}}
+	suite.userRepository.On("FindByOIDCSubject", mock.Anything, "u-1").Return(&domain.User{Username: "alice", OIDCSubject: "u-1", Role: "User", Orgs: orgs, MFA: &domain.MFA{Enabled: true}}, nil)
+	suite.jwtService.On("GenerateMFAToken", "alice").Return("mfa-token", nil)
+
+	result, err := suite.oidcUseCase.CompleteLogin(context.Background(), "state-1", "code-1")
+
+	suite.NoError(err)
+	suite.Equal(&domain.LoginResult{MFAToken: "mfa-token"}, result)
+	suite.jwtService.AssertNotCalled(suite.T(), "GenerateToken", mock.Anything, mock.Anything)
+}
+
+func (suite *OIDCUseCaseSuite) TestCompleteLogin_UnenrolledAdminIsDowngraded() {
+	suite.oidcUseCase = suite.newUseCase(true)
+	suite.exchange(map[string]interface{}{"sub": "u-1", "preferred_username": "alice", "groups": []interface{}{"task-admins"}})
+	admin := []domain.Membership{{OrgID: domain.DefaultOrgID, Role: "Admin"}}
+	suite.userRepository.On("FindByOIDCSubject", mock.Anything, "u-1").Return(&domain.User{Username: "alice", OIDCSubject: "u-1", Role: "Admin", Orgs: admin}, nil)
+	suite.jwtService.On("GenerateToken", "alice", []domain.Membership{{OrgID: domain.DefaultOrgID, Role: "User"}}).Return("token", nil)
+
+	result, err := suite.oidcUseCase.CompleteLogin(context.Background(), "state-1", "code-1")
+
+	suite.NoError(err)
+	suite.Equal(&domain.LoginResult{Token: "token", MFAEnrollmentRequired: true}, result)
+}
+
+func (suite *OIDCUseCaseSuite) TestCompleteLogin_Negative_SameUsernameOtherSubject() {
+	// The provider account is named like the local Admin but is someone else.
+	suite.exchange(map[string]interface{}{"sub": "attacker", "preferred_username": "root", "groups": []interface{}{"task-admins"}, "email": "root@evil.example", "email_verified": true})
+	suite.userRepository.On("FindByOIDCSubject", mock.Anything, "attacker").Return(nil, errors.New("user not found"))
+	suite.userRepository.On("FindByEmail", mock.Anything, "root@evil.example").Return(nil, errors.New("mongo: no documents in result"))
+	suite.userRepository.On("FindByUsername", mock.Anything, "root").Return(&domain.User{Username: "root", EmailVerified: true, Orgs: []domain.Membership{{OrgID: domain.DefaultOrgID, Role: "Admin"}}}, nil)
+
+	_, err := suite.oidcUseCase.CompleteLogin(context.Background(), "state-1", "code-1")
+
+	suite.EqualError(err, "username belongs to another account")
+	suite.userRepository.AssertNotCalled(suite.T(), "LinkOIDCSubject", mock.Anything, mock.Anything, mock.Anything)
+	suite.jwtService.AssertNotCalled(suite.T(), "GenerateToken", mock.Anything, mock.Anything)
+}
+
+func (suite *OIDCUseCaseSuite) TestCompleteLogin_Negative_EmailOfLinkedUser() {
+	suite.exchange(map[string]interface{}{"sub": "u-2", "preferred_username": "alice2", "email": "alice@example.com", "email_verified": true})
+	suite.userRepository.On("FindByOIDCSubject", mock.Anything, "u-2").Return(nil, errors.New("user not found"))
+	suite.userRepository.On("FindByEmail", mock.Anything, "alice@example.com").Return(&domain.User{Username: "alice", Email: "alice@example.com", EmailVerified: true, OIDCSubject: "u-1"}, nil)
+	suite.userRepository.On("FindByUsername", mock.Anything, "alice2").Return(nil, errors.New("mongo: no documents in result"))
+	suite.userRepository.On("CreateUser", mock.Anything, mock.Anything).Return(errors.New("email already exists"))
+
+	_, err := suite.oidcUseCase.CompleteLogin(context.Background(), "state-1", "code-1")
+
+	suite.EqualError(err, "email already exists")
+	suite.userRepository.AssertNotCalled(suite.T(), "LinkOIDCSubject", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *OIDCUseCaseSuite) TestCompleteLogin_SyncsRole() {
+	suite.exchange(map[string]interface{}{"sub": "u-1", "preferred_username": "alice", "groups": "staff"})
+	suite.userRepository.On("FindByOIDCSubject", mock.Anything, "u-1").Return(&domain.User{
+		Username: "alice",
+		Orgs:     []domain.Membership{{OrgID: domain.DefaultOrgID, Role: "Admin"}, {OrgID: "globex", Role: "Admin"}},
+	}, nil)
+	suite.userRepository.On("SetRole", mock.MatchedBy(func(c context.Context) bool {
+		orgID, _ := domain.OrgFromContext(c)
+		return orgID == domain.DefaultOrgID
+	}), "alice", "User").Return(nil)
+	suite.jwtService.On("GenerateToken", "alice", []domain.Membership{{OrgID: domain.DefaultOrgID, Role: "User"}, {OrgID: "globex", Role: "Admin"}}).Return("token", nil)
+
+	_, err := suite.oidcUseCase.CompleteLogin(context.Background(), "state-1", "code-1")
+
+	suite.NoError(err)
+	suite.userRepository.AssertExpectations(suite.T())
+	suite.jwtService.AssertExpectations(suite.T())
+}
+
+func (suite *OIDCUseCaseSuite) TestCompleteLogin_Negative_InvalidState() {
+	suite.loginRepository.On("TakeLogin", mock.Anything, "forged").Return(nil, errors.New("mongo: no documents in result"))
+
+	_, err := suite.oidcUseCase.CompleteLogin(context.Background(), "forged", "code-1")
+
+	suite.EqualError(err, "invalid login state")
+	suite.provider.AssertNotCalled(suite.T(), "Exchange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *OIDCUseCaseSuite) TestCompleteLogin_Negative_ExchangeFails() {
+	suite.loginRepository.On("TakeLogin", mock.Anything, "state-1").Return(&suite.login, nil)
+	suite.provider.On("Exchange", mock.Anything, "code-1", "verifier-1", "nonce-1").Return(nil, errors.New("oidc: id token nonce does not match"))
+
+	_, err := suite.oidcUseCase.CompleteLogin(context.Background(), "state-1", "code-1")
+
+	suite.EqualError(err, "oidc: id token nonce does not match")
+	suite.userRepository.AssertNotCalled(suite.T(), "FindByOIDCSubject", mock.Anything, mock.Anything)
+}
+
+func (suite *OIDCUseCaseSuite) TestCompleteLogin_Negative_NoSubject() {
+	suite.exchange(map[string]interface{}{"preferred_username": "alice"})
+
+	_, err := suite.oidcUseCase.CompleteLogin(context.Background(), "state-1", "code-1")
+
+	suite.EqualError(err, "identity provider did not return a subject")
+	suite.userRepository.AssertNotCalled(suite.T(), "FindByOIDCSubject", mock.Anything, mock.Anything)
+}
+
+func (suite *OIDCUseCaseSuite) TestCompleteLogin_Negative_NoUsername() {
+	suite.exchange(map[string]interface{}{"sub": "u-1"})
+
+	_, err := suite.oidcUseCase.CompleteLogin(context.Background(), "state-1", "code-1")
+
+	suite.EqualError(err, "identity provider did not return a username")
+}
+
+func TestOIDCUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(OIDCUseCaseSuite))
+}
@@ -0,0 +1,224 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	"time"
+)
+
+// oidcLoginTTL is how long the user has to finish logging in at the identity provider.
+const oidcLoginTTL = 10 * time.Minute
+
+type oidcUseCase struct {
+	provider        infrastructure.OIDCProvider
+	loginRepository domain.OIDCLoginRepository
+	userRepository  domain.UserRepository
+	jwtService      infrastructure.JWTService
+	outbox          domain.OutboxRepository
+	transactor      domain.Transactor
+	claims          domain.OIDCClaimMapping
+	requireAdminMFA bool
+	clock           domain.Clock
+	ids             domain.IDGenerator
+	contextTimeout  time.Duration
+}
+
+func NewOIDCUseCase(provider infrastructure.OIDCProvider, loginRepository domain.OIDCLoginRepository, userRepository domain.UserRepository, jwtService infrastructure.JWTService, outbox domain.OutboxRepository, transactor domain.Transactor, claims domain.OIDCClaimMapping, requireAdminMFA bool, clock domain.Clock, ids domain.IDGenerator, timeout time.Duration) domain.OIDCUseCase {
+	return &oidcUseCase{
+		provider:        provider,
+		loginRepository: loginRepository,
+		userRepository:  userRepository,
+		jwtService:      jwtService,
+		outbox:          outbox,
+		transactor:      transactor,
+		claims:          claims,
+		requireAdminMFA: requireAdminMFA,
+		clock:           clock,
+		ids:             ids,
+		contextTimeout:  timeout,
+	}
+}
+
+func (o *oidcUseCase) BeginLogin(c context.Context) (string, string, error) {
+	ctx, cancel := context.WithTimeout(c, o.contextTimeout)
+	defer cancel()
+
+	login := domain.OIDCLogin{
+		State:        randomToken(),
+		Nonce:        randomToken(),
+		CodeVerifier: randomToken(),
+		ExpiresAt:    o.clock.Now().UTC().Add(oidcLoginTTL),
+	}
+	challenge := sha256.Sum256([]byte(login.CodeVerifier))
+
+	authURL, err := o.provider.AuthCodeURL(ctx, login.State, login.Nonce, base64.RawURLEncoding.EncodeToString(challenge[:]))
+	if err != nil {
+		return "", "", err
+	}
+	if err := o.loginRepository.SaveLogin(ctx, login); err != nil {
+		return "", "", err
+	}
+	return authURL, login.State, nil
+}
+
+func (o *oidcUseCase) CompleteLogin(c context.Context, state string, code string) (*domain.LoginResult, error) {
+	ctx, cancel := context.WithTimeout(c, o.contextTimeout)
+	defer cancel()
+
+	login, err := o.loginRepository.TakeLogin(ctx, state)
+	if err != nil {
+		return nil, errors.New("invalid login state")
+	}
+
+	claims, err := o.provider.Exchange(ctx, code, login.CodeVerifier, login.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, errors.New("identity provider did not return a subject")
+	}
+	username, _ := claims[o.claims.UsernameClaim].(string)
+	if username == "" {
+		return nil, errors.New("identity provider did not return a username")
+	}
+
+	// SSO users land in the default organization, like registration.
+	ctx = domain.WithOrg(ctx, domain.DefaultOrgID)
+	user, err := o.provision(ctx, subject, username, claims)
+	if err != nil {
+		return nil, err
+	}
+	// The provider vouches for the password, not for the second factor.
+	return issueLogin(o.jwtService, user, o.requireAdminMFA)
+}
+
+// provision finds the local user for subject, or links or creates one on their first SSO login.
+// The role in the default organization follows the role claim on every login.
+func (o *oidcUseCase) provision(ctx context.Context, subject string, username string, claims map[string]interface{}) (*domain.User, error) {
+	role, syncRole := o.role(claims)
+
+	user, err := o.userRepository.FindByOIDCSubject(ctx, subject)
+	if err != nil || user.Username == "" {
+		user, err = o.linkOrCreate(ctx, subject, username, role, verifiedEmail(claims))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !syncRole {
+		return user, nil
+	}
+	current, member := user.OrgRole(domain.DefaultOrgID)
+	switch {
+	case !member:
+		if err := o.userRepository.AddMembership(ctx, user.Username, role); err != nil {
+			return nil, err
+		}
+		user.Orgs = append([]domain.Membership{{OrgID: domain.DefaultOrgID, Role: role}}, user.Orgs...)
+	case current != role:
+		if err := o.userRepository.SetRole(ctx, user.Username, role); err != nil {
+			return nil, err
+		}
+		for i := range user.Orgs {
+			if user.Orgs[i].OrgID == domain.DefaultOrgID {
+				user.Orgs[i].Role = role
+			}
+		}
+	}
+	return user, nil
+}
+
+// linkOrCreate links subject to the local user whose verified email the provider verified too,
+// or creates a user. Usernames are never enough to link: whoever controls a provider account
+// with the name of a local user, an Admin included, would take that user over.
+func (o *oidcUseCase) linkOrCreate(ctx context.Context, subject string, username string, role string, email string) (*domain.User, error) {
+	if email != "" {
+		user, err := o.userRepository.FindByEmail(ctx, email)
+		if err == nil && user.Username != "" && user.EmailVerified && user.OIDCSubject == "" {
+			if err := o.userRepository.LinkOIDCSubject(ctx, user.Username, subject); err != nil {
+				return nil, err
+			}
+			user.OIDCSubject = subject
+			return user, nil
+		}
+	}
+
+	if user, err := o.userRepository.FindByUsername(ctx, username); err == nil && user.Username != "" {
+		return nil, errors.New("username belongs to another account")
+	}
+	return o.createUser(ctx, subject, username, role, email)
+}
+
+// verifiedEmail returns the email claim if the provider says it verified it.
+func verifiedEmail(claims map[string]interface{}) string {
+	if verified, _ := claims["email_verified"].(bool); !verified {
+		return ""
+	}
+	email, _ := claims["email"].(string)
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return ""
+	}
+	return email
+}
+
+// createUser provisions a user without a password, so they can only log in through SSO.
+func (o *oidcUseCase) createUser(ctx context.Context, subject string, username string, role string, email string) (*domain.User, error) {
+	user := domain.User{
+		Username:      username,
+		Role:          role,
+		Orgs:          []domain.Membership{{OrgID: domain.DefaultOrgID, Role: role}},
+		OIDCSubject:   subject,
+		Email:         email,
+		EmailVerified: email != "",
+	}
+	err := o.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := o.userRepository.CreateUser(ctx, user); err != nil {
+			return err
+		}
+		event, err := newEvent(ctx, o.clock, o.ids, domain.EventUserCreated, userEventData(user))
+		if err != nil {
+			return err
+		}
+		return o.outbox.Enqueue(ctx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// role maps the role claim to a role. It is false when no role claim is configured, in which
+// case existing users keep their role and new ones are Users.
+func (o *oidcUseCase) role(claims map[string]interface{}) (string, bool) {
+	if o.claims.RoleClaim == "" {
+		return "User", false
+	}
+	var values []interface{}
+	switch value := claims[o.claims.RoleClaim].(type) {
+	case string:
+		values = []interface{}{value}
+	case []interface{}:
+		values = value
+	}
+	for _, value := range values {
+		if value == o.claims.AdminValue {
+			return "Admin", true
+		}
+	}
+	return "User", true
+}
+
+// randomToken returns 256 random bits, base64url encoded. As a PKCE verifier it is 43 characters,
+// the shortest RFC 7636 allows.
+func randomToken() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,80 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	domain "test_task_manager/Domain"
+	"time"
+)
+
+type organizationUseCase struct {
+	organizationRepository domain.OrganizationRepository
+	userRepository         domain.UserRepository
+	transactor             domain.Transactor
+	clock                  domain.Clock
+	ids                    domain.IDGenerator
+	contextTimeout         time.Duration
+}
+
+func NewOrganizationUseCase(organizationRepository domain.OrganizationRepository, userRepository domain.UserRepository, transactor domain.Transactor, clock domain.Clock, ids domain.IDGenerator, timeout time.Duration) domain.OrganizationUseCase {
+	return &organizationUseCase{
+		organizationRepository: organizationRepository,
+		userRepository:         userRepository,
+		transactor:             transactor,
+		clock:                  clock,
+		ids:                    ids,
+		contextTimeout:         timeout,
+	}
+}
+
+func (o *organizationUseCase) CreateOrganization(c context.Context, name string, username string) (*domain.Organization, error) {
+	ctx, cancel := context.WithTimeout(c, o.contextTimeout)
+	defer cancel()
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("organization name is required")
+	}
+
+	org := domain.Organization{ID: o.ids.NewID(), Name: name, CreatedAt: o.clock.Now().UTC()}
+	err := o.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := o.organizationRepository.CreateOrganization(ctx, org); err != nil {
+			return err
+		}
+		return o.userRepository.AddMembership(domain.WithOrg(ctx, org.ID), username, "Admin")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+func (o *organizationUseCase) GetOrganizations(c context.Context, username string) ([]domain.Organization, error) {
+	ctx, cancel := context.WithTimeout(c, o.contextTimeout)
+	defer cancel()
+
+	user, err := o.userRepository.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	orgIDs := make([]string, len(user.Orgs))
+	for i, membership := range user.Orgs {
+		orgIDs[i] = membership.OrgID
+	}
+	return o.organizationRepository.GetOrganizationsByIDs(ctx, orgIDs)
+}
+
+func (o *organizationUseCase) AddMember(c context.Context, username string, role string) error {
+	ctx, cancel := context.WithTimeout(c, o.contextTimeout)
+	defer cancel()
+
+	if role == "" {
+		role = "User"
+	}
+	if role != "User" && role != "Admin" {
+		return errors.New("role must be Admin or User")
+	}
+	return o.userRepository.AddMembership(ctx, username, role)
+}
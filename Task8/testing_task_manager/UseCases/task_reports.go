@@ -0,0 +1,128 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	domain "test_task_manager/Domain"
+	"time"
+)
+
+const (
+	defaultReportDays = 30
+	maxReportDays     = 366
+)
+
+func (t *taskUseCase) GetSummary(c context.Context, from time.Time, to time.Time) (*domain.TaskSummary, error) {
+	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
+	defer cancel()
+
+	now := t.clock.Now().UTC()
+	from, to, err := t.reportRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := t.taskRepository.TaskStats(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	activity, err := t.taskRepository.TaskActivity(ctx, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &domain.TaskSummary{
+		GeneratedAt: now,
+		TaskStats:   *stats,
+		From:        from.Format(domain.ReportDateLayout),
+		To:          to.Format(domain.ReportDateLayout),
+		Completion:  []domain.CompletionPoint{},
+	}
+	eachDay(activity, from, to, func(date string, day domain.DailyActivity, created int, completed int) {
+		rate := 0.0
+		if created > 0 {
+			rate = math.Round(float64(completed)/float64(created)*1000) / 1000
+		}
+		summary.Completion = append(summary.Completion, domain.CompletionPoint{Date: date, Created: day.Created, Completed: day.Completed, CompletionRate: rate})
+	})
+	return summary, nil
+}
+
+func (t *taskUseCase) GetBurndown(c context.Context, from time.Time, to time.Time) (*domain.Burndown, error) {
+	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
+	defer cancel()
+
+	from, to, err := t.reportRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	activity, err := t.taskRepository.TaskActivity(ctx, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	burndown := &domain.Burndown{
+		From:   from.Format(domain.ReportDateLayout),
+		To:     to.Format(domain.ReportDateLayout),
+		Points: []domain.BurndownPoint{},
+	}
+	eachDay(activity, from, to, func(date string, day domain.DailyActivity, created int, completed int) {
+		burndown.Points = append(burndown.Points, domain.BurndownPoint{Date: date, Created: day.Created, Completed: day.Completed, Remaining: created - completed})
+	})
+	return burndown, nil
+}
+
+// reportRange truncates from and to to UTC days and fills in the last defaultReportDays days
+// for the ones that are zero.
+func (t *taskUseCase) reportRange(from time.Time, to time.Time) (time.Time, time.Time, error) {
+	if to.IsZero() {
+		to = t.clock.Now()
+	}
+	to = utcDay(to)
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -(defaultReportDays - 1))
+	}
+	from = utcDay(from)
+
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, errors.New("from must not be after to")
+	}
+	if to.Sub(from) >= maxReportDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("a report must not span more than %d days", maxReportDays)
+	}
+	return from, to, nil
+}
+
+func utcDay(at time.Time) time.Time {
+	year, month, day := at.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// eachDay calls fn for every day from from to to with the activity of that day and the number
+// of tasks created and completed by its end. Activity before from, and of tasks whose day is not
+// known, only counts towards those totals.
+func eachDay(activity []domain.DailyActivity, from time.Time, to time.Time, fn func(date string, day domain.DailyActivity, created int, completed int)) {
+	first := from.Format(domain.ReportDateLayout)
+	byDay := make(map[string]domain.DailyActivity, len(activity))
+	created, completed := 0, 0
+	for _, day := range activity {
+		if day.Day < first {
+			created += day.Created
+			completed += day.Completed
+			continue
+		}
+		byDay[day.Day] = day
+	}
+
+	for at := from; !at.After(to); at = at.AddDate(0, 0, 1) {
+		date := at.Format(domain.ReportDateLayout)
+		day := byDay[date]
+		day.Day = date
+		created += day.Created
+		completed += day.Completed
+		fn(date, day, created, completed)
+	}
+}
@@ -0,0 +1,62 @@
+package usecases_test
+
+import (
+	"context"
+	domain "test_task_manager/Domain"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func date(value string) time.Time {
+	day, _ := time.Parse(domain.ReportDateLayout, value)
+	return day
+}
+
+func (suite *TaskUseCaseSuite) TestGetSummary_DefaultsToTheLast30Days() {
+	stats := &domain.TaskStats{TaskCounts: domain.TaskCounts{Total: 3, Open: 1, Completed: 2}, ByStatus: map[string]int{"Pending": 1, "Completed": 2}}
+	suite.taskRepository.On("TaskStats", mock.Anything, suite.clock.Now()).Return(stats, nil)
+	suite.taskRepository.On("TaskActivity", mock.Anything, date("2024-01-02")).Return([]domain.DailyActivity{
+		{Day: "", Created: 1, Completed: 1},
+		{Day: "2023-11-30", Created: 1},
+		{Day: "2023-12-03", Created: 1, Completed: 1},
+	}, nil)
+
+	summary, err := suite.taskUseCase.GetSummary(context.Background(), time.Time{}, time.Time{})
+
+	suite.NoError(err)
+	suite.Equal(suite.clock.Now(), summary.GeneratedAt)
+	suite.Equal(stats.TaskCounts, summary.TaskCounts)
+	suite.Equal("2023-12-03", summary.From)
+	suite.Equal("2024-01-01", summary.To)
+	suite.Len(summary.Completion, 30)
+	suite.Equal(domain.CompletionPoint{Date: "2023-12-03", Created: 1, Completed: 1, CompletionRate: 0.667}, summary.Completion[0])
+	suite.Equal(domain.CompletionPoint{Date: "2024-01-01", CompletionRate: 0.667}, summary.Completion[29])
+}
+
+func (suite *TaskUseCaseSuite) TestGetBurndown() {
+	suite.taskRepository.On("TaskActivity", mock.Anything, date("2024-01-04")).Return([]domain.DailyActivity{
+		{Day: "2023-12-31", Created: 2},
+		{Day: "2024-01-01", Created: 3, Completed: 1},
+		{Day: "2024-01-03", Completed: 2},
+	}, nil)
+
+	burndown, err := suite.taskUseCase.GetBurndown(context.Background(), date("2024-01-01"), date("2024-01-03"))
+
+	suite.NoError(err)
+	suite.Equal(&domain.Burndown{From: "2024-01-01", To: "2024-01-03", Points: []domain.BurndownPoint{
+		{Date: "2024-01-01", Created: 3, Completed: 1, Remaining: 4},
+		{Date: "2024-01-02", Remaining: 4},
+		{Date: "2024-01-03", Completed: 2, Remaining: 2},
+	}}, burndown)
+}
+
+func (suite *TaskUseCaseSuite) TestGetBurndown_InvalidRange() {
+	_, err := suite.taskUseCase.GetBurndown(context.Background(), date("2024-01-02"), date("2024-01-01"))
+	suite.EqualError(err, "from must not be after to")
+
+	_, err = suite.taskUseCase.GetBurndown(context.Background(), date("2023-01-01"), date("2024-01-02"))
+	suite.EqualError(err, "a report must not span more than 366 days")
+
+	suite.taskRepository.AssertNotCalled(suite.T(), "TaskActivity", mock.Anything, mock.Anything)
+}
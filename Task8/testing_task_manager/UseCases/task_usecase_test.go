@@ -3,9 +3,12 @@ package usecases_test
 import (
 	"context"
 	"errors"
+	"math"
 	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
 	usecases "test_task_manager/UseCases"
 	mocks "test_task_manager/mocks"
+	"strconv"
 	"testing"
 	"time"
 
@@ -16,14 +19,27 @@ import (
 type TaskUseCaseSuite struct {
 	suite.Suite
 	taskRepository *mocks.TaskRepository
+	outbox         *mocks.OutboxRepository
+	transactor     *mocks.Transactor
+	eventBus       *mocks.EventBus
+	clock          *infrastructure.FakeClock
 	taskUseCase    domain.TaskUseCase
 }
 
 func (suite *TaskUseCaseSuite) SetupTest() {
 	// Create a new mock TaskRepository
 	suite.taskRepository = new(mocks.TaskRepository)
+	suite.outbox = new(mocks.OutboxRepository)
+	suite.transactor = new(mocks.Transactor)
+	suite.eventBus = new(mocks.EventBus)
 
-	suite.taskUseCase = usecases.NewTaskUseCase(suite.taskRepository, 2*time.Second)
+	// Run transactional callbacks inline so repository expectations apply as usual
+	suite.transactor.EXPECT().WithTransaction(mock.Anything, mock.Anything).RunAndReturn(func(c context.Context, fn func(context.Context) error) error {
+		return fn(c)
+	}).Maybe()
+
+	suite.clock = infrastructure.NewFakeClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	suite.taskUseCase = usecases.NewTaskUseCase(suite.taskRepository, suite.outbox, suite.transactor, suite.eventBus, suite.clock, infrastructure.NewSequentialIDGenerator("event"), 2*time.Second)
 }
 
 func (suite *TaskUseCaseSuite) TestCreateTask_Positive() {
@@ -36,6 +52,12 @@ func (suite *TaskUseCaseSuite) TestCreateTask_Positive() {
 	}
 
 	suite.taskRepository.On("CreateTask", mock.Anything, task).Return(&task, nil)
+	suite.outbox.On("Enqueue", mock.Anything, mock.MatchedBy(func(event domain.Event) bool {
+		return event.Type == domain.EventTaskCreated
+	})).Return(nil)
+	suite.eventBus.On("Publish", mock.MatchedBy(func(event domain.Event) bool {
+		return event.Type == domain.EventTaskCreated
+	})).Return()
 
 	createdTask, err := suite.taskUseCase.CreateTask(context.Background(), task)
 
@@ -43,6 +65,20 @@ func (suite *TaskUseCaseSuite) TestCreateTask_Positive() {
 	suite.NotNil(createdTask)
 	suite.Equal(task.ID, createdTask.ID)
 	suite.taskRepository.AssertExpectations(suite.T())
+	suite.outbox.AssertExpectations(suite.T())
+}
+
+func (suite *TaskUseCaseSuite) TestCreateTask_OutboxFailure() {
+	task := domain.Task{ID: "1", Title: "Test Task"}
+
+	suite.taskRepository.On("CreateTask", mock.Anything, task).Return(&task, nil)
+	suite.outbox.On("Enqueue", mock.Anything, mock.Anything).Return(errors.New("outbox unavailable"))
+
+	createdTask, err := suite.taskUseCase.CreateTask(context.Background(), task)
+
+	suite.EqualError(err, "outbox unavailable")
+	suite.Nil(createdTask)
+	suite.eventBus.AssertNotCalled(suite.T(), "Publish", mock.Anything)
 }
 
 func (suite *TaskUseCaseSuite) TestCreateTask_Negative() {
@@ -67,11 +103,18 @@ func (suite *TaskUseCaseSuite) TestDeleteTask_Positive() {
 	taskID := "1"
 
 	suite.taskRepository.On("DeleteTask", mock.Anything, taskID).Return(nil)
+	suite.outbox.On("Enqueue", mock.Anything, mock.MatchedBy(func(event domain.Event) bool {
+		return event.Type == domain.EventTaskDeleted
+	})).Return(nil)
+	suite.eventBus.On("Publish", mock.MatchedBy(func(event domain.Event) bool {
+		return event.Type == domain.EventTaskDeleted
+	})).Return()
 
 	err := suite.taskUseCase.DeleteTask(context.Background(), taskID)
 
 	suite.NoError(err)                              
 	suite.taskRepository.AssertExpectations(suite.T())
+	suite.eventBus.AssertExpectations(suite.T())
 }
 
 func (suite *TaskUseCaseSuite) TestDeleteTask_Negative() {
@@ -168,6 +211,12 @@ func (suite *TaskUseCaseSuite) TestUpdateTask_Positive() {
 	}
 
 	suite.taskRepository.On("UpdateTask", mock.Anything, taskID, updatedTask).Return(&updatedTask, nil)
+	suite.outbox.On("Enqueue", mock.Anything, mock.MatchedBy(func(event domain.Event) bool {
+		return event.Type == domain.EventTaskUpdated
+	})).Return(nil)
+	suite.eventBus.On("Publish", mock.MatchedBy(func(event domain.Event) bool {
+		return event.Type == domain.EventTaskUpdated
+	})).Return()
 
 	result, err := suite.taskUseCase.UpdateTask(context.Background(), taskID, updatedTask)
 
@@ -197,6 +246,259 @@ func (suite *TaskUseCaseSuite) TestUpdateTask_Negative() {
 	suite.taskRepository.AssertExpectations(suite.T())
 }
 
+func (suite *TaskUseCaseSuite) TestBulkWrite_BestEffort_PartialSuccess() {
+	operations := []domain.BulkOperation{
+		{Op: domain.BulkCreate, ID: "1", Task: domain.Task{ID: "1", Title: "New"}},
+		{Op: domain.BulkDelete, ID: "2"},
+		{Op: domain.BulkDelete, ID: "1"},
+	}
+	created := domain.Task{ID: "1", Title: "New"}
+
+	suite.taskRepository.On("BulkWrite", mock.Anything, operations[:2]).Return([]domain.BulkResult{
+		{Index: 0, Op: domain.BulkCreate, ID: "1", Status: domain.BulkStatusOK, Task: &created},
+		{Index: 1, Op: domain.BulkDelete, ID: "2", Status: domain.BulkStatusFailed, Error: "task not found"},
+	}, nil)
+	suite.outbox.On("EnqueueMany", mock.Anything, mock.MatchedBy(func(events []domain.Event) bool {
+		return len(events) == 1 && events[0].Type == domain.EventTaskCreated
+	})).Return(nil).Once()
+	suite.eventBus.On("Publish", mock.Anything).Return().Once()
+
+	results, err := suite.taskUseCase.BulkWrite(context.Background(), operations, false)
+
+	suite.NoError(err)
+	suite.Len(results, 3)
+	suite.Equal(domain.BulkStatusOK, results[0].Status)
+	suite.Equal("task not found", results[1].Error)
+	suite.Equal(domain.BulkStatusFailed, results[2].Status)
+	suite.Equal("task appears more than once in the batch", results[2].Error)
+	suite.Equal(1, results[1].Index)
+	suite.outbox.AssertExpectations(suite.T())
+	suite.eventBus.AssertExpectations(suite.T())
+	suite.transactor.AssertNumberOfCalls(suite.T(), "WithTransaction", 1)
+}
+
+func (suite *TaskUseCaseSuite) TestBulkWrite_BestEffort_FullBatchIsOneWrite() {
+	operations := make([]domain.BulkOperation, 1000)
+	written := make([]domain.BulkResult, 1000)
+	for i := range operations {
+		id := strconv.Itoa(i)
+		operations[i] = domain.BulkOperation{Op: domain.BulkDelete, ID: id}
+		written[i] = domain.BulkResult{Index: i, Op: domain.BulkDelete, ID: id, Status: domain.BulkStatusOK}
+	}
+	suite.taskRepository.On("BulkWrite", mock.Anything, operations).Return(written, nil).Once()
+	suite.outbox.On("EnqueueMany", mock.Anything, mock.MatchedBy(func(events []domain.Event) bool {
+		return len(events) == 1000
+	})).Return(nil).Once()
+	suite.eventBus.On("Publish", mock.Anything).Return().Times(1000)
+
+	results, err := suite.taskUseCase.BulkWrite(context.Background(), operations, false)
+
+	suite.NoError(err)
+	suite.Equal(written, results)
+	suite.transactor.AssertNumberOfCalls(suite.T(), "WithTransaction", 1)
+	suite.taskRepository.AssertExpectations(suite.T())
+	suite.outbox.AssertExpectations(suite.T())
+}
+
+func (suite *TaskUseCaseSuite) TestBulkWrite_BestEffort_FailsWithItsEvents() {
+	operations := []domain.BulkOperation{{Op: domain.BulkDelete, ID: "1"}}
+	suite.taskRepository.On("BulkWrite", mock.Anything, operations).Return([]domain.BulkResult{
+		{Index: 0, Op: domain.BulkDelete, ID: "1", Status: domain.BulkStatusOK},
+	}, nil)
+	suite.outbox.On("EnqueueMany", mock.Anything, mock.Anything).Return(errors.New("database unavailable"))
+
+	results, err := suite.taskUseCase.BulkWrite(context.Background(), operations, false)
+
+	suite.EqualError(err, "database unavailable", "the unit of work rolls the batch back with its events")
+	suite.Nil(results)
+	suite.eventBus.AssertNotCalled(suite.T(), "Publish", mock.Anything)
+}
+
+func (suite *TaskUseCaseSuite) TestBulkWrite_Atomic_Success() {
+	operations := []domain.BulkOperation{
+		{Op: domain.BulkUpdate, ID: "1", Task: domain.Task{Status: "done"}},
+		{Op: domain.BulkDelete, ID: "2"},
+	}
+	updated := domain.Task{ID: "1", Status: "done"}
+
+	suite.transactor.EXPECT().RequireTransaction(mock.Anything, mock.Anything).RunAndReturn(func(c context.Context, fn func(context.Context) error) error {
+		return fn(c)
+	})
+	suite.taskRepository.On("BulkWrite", mock.Anything, operations).Return([]domain.BulkResult{
+		{Index: 0, Op: domain.BulkUpdate, ID: "1", Status: domain.BulkStatusOK, Task: &updated},
+		{Index: 1, Op: domain.BulkDelete, ID: "2", Status: domain.BulkStatusOK},
+	}, nil)
+	suite.outbox.On("EnqueueMany", mock.Anything, mock.MatchedBy(func(events []domain.Event) bool { return len(events) == 2 })).Return(nil).Once()
+	suite.eventBus.On("Publish", mock.Anything).Return().Twice()
+
+	results, err := suite.taskUseCase.BulkWrite(context.Background(), operations, true)
+
+	suite.NoError(err)
+	suite.Equal(domain.BulkStatusOK, results[0].Status)
+	suite.Equal(domain.BulkStatusOK, results[1].Status)
+	suite.outbox.AssertExpectations(suite.T())
+	suite.eventBus.AssertExpectations(suite.T())
+}
+
+func (suite *TaskUseCaseSuite) TestBulkWrite_Atomic_RollsBackOnFailure() {
+	operations := []domain.BulkOperation{
+		{Op: domain.BulkCreate, Task: domain.Task{ID: "1"}},
+		{Op: domain.BulkUpdate, ID: "2", Task: domain.Task{Title: "x"}},
+	}
+
+	suite.transactor.EXPECT().RequireTransaction(mock.Anything, mock.Anything).RunAndReturn(func(c context.Context, fn func(context.Context) error) error {
+		return fn(c)
+	})
+	suite.taskRepository.On("BulkWrite", mock.Anything, mock.Anything).Return([]domain.BulkResult{
+		{Index: 0, Op: domain.BulkCreate, ID: "1", Status: domain.BulkStatusOK, Task: &domain.Task{ID: "1"}},
+		{Index: 1, Op: domain.BulkUpdate, ID: "2", Status: domain.BulkStatusFailed, Error: "task not found"},
+	}, nil)
+
+	results, err := suite.taskUseCase.BulkWrite(context.Background(), operations, true)
+
+	suite.EqualError(err, "bulk operation rolled back")
+	suite.Equal(domain.BulkStatusRolledBack, results[0].Status)
+	suite.Nil(results[0].Task)
+	suite.Equal(domain.BulkStatusFailed, results[1].Status)
+	suite.outbox.AssertNotCalled(suite.T(), "EnqueueMany", mock.Anything, mock.Anything)
+	suite.eventBus.AssertNotCalled(suite.T(), "Publish", mock.Anything)
+}
+
+func (suite *TaskUseCaseSuite) TestBulkWrite_Atomic_InvalidOperationSkipsDatabase() {
+	operations := []domain.BulkOperation{
+		{Op: domain.BulkDelete, ID: "1"},
+		{Op: "archive", ID: "2"},
+	}
+
+	results, err := suite.taskUseCase.BulkWrite(context.Background(), operations, true)
+
+	suite.EqualError(err, "bulk operation rolled back")
+	suite.Equal(domain.BulkStatusRolledBack, results[0].Status)
+	suite.Equal("unknown operation: archive", results[1].Error)
+	suite.taskRepository.AssertNotCalled(suite.T(), "BulkWrite", mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUseCaseSuite) TestBulkWrite_Empty() {
+	results, err := suite.taskUseCase.BulkWrite(context.Background(), nil, false)
+
+	suite.Nil(results)
+	suite.EqualError(err, "bulk request must contain at least one operation")
+}
+
+func (suite *TaskUseCaseSuite) TestImportTasks_ReportsRowErrors() {
+	rows := []domain.ImportRow{
+		{Row: 1, Task: domain.Task{ID: "1", Title: "New"}},
+		{Row: 2, Task: domain.Task{ID: "2", Title: "Existing"}},
+		{Row: 3, Task: domain.Task{ID: "1", Title: "Duplicate"}},
+		{Row: 4, Task: domain.Task{ID: "4"}},
+		{Row: 5, Error: "invalid JSON"},
+	}
+
+	suite.taskRepository.On("GetTasksByIDs", mock.Anything, []string{"1", "2"}).Return([]domain.Task{{ID: "2"}}, nil)
+	suite.taskRepository.On("BulkWrite", mock.Anything, []domain.BulkOperation{
+		{Op: domain.BulkCreate, ID: "1", Task: domain.Task{ID: "1", Title: "New"}},
+	}).Return([]domain.BulkResult{{Index: 0, Op: domain.BulkCreate, ID: "1", Status: domain.BulkStatusOK, Task: &domain.Task{ID: "1", Title: "New"}}}, nil)
+	suite.outbox.On("EnqueueMany", mock.Anything, mock.Anything).Return(nil)
+	suite.eventBus.On("Publish", mock.Anything).Return()
+
+	report, err := suite.taskUseCase.ImportTasks(context.Background(), rows, false)
+
+	suite.NoError(err)
+	suite.Equal(5, report.Total)
+	suite.Equal(1, report.Valid)
+	suite.Equal(1, report.Imported)
+	suite.Equal(4, report.Failed)
+	suite.Equal([]domain.ImportError{
+		{Row: 2, ID: "2", Error: "task with the given id already exists"},
+		{Row: 3, ID: "1", Error: "task appears more than once in the file"},
+		{Row: 4, ID: "4", Error: "task title is required"},
+		{Row: 5, Error: "invalid JSON"},
+	}, report.Errors)
+}
+
+func (suite *TaskUseCaseSuite) TestImportTasks_DryRunDoesNotWrite() {
+	rows := []domain.ImportRow{{Row: 1, Task: domain.Task{ID: "1", Title: "New"}}}
+
+	suite.taskRepository.On("GetTasksByIDs", mock.Anything, []string{"1"}).Return(nil, nil)
+
+	report, err := suite.taskUseCase.ImportTasks(context.Background(), rows, true)
+
+	suite.NoError(err)
+	suite.True(report.DryRun)
+	suite.Equal(1, report.Valid)
+	suite.Equal(0, report.Imported)
+	suite.taskRepository.AssertNotCalled(suite.T(), "BulkWrite", mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUseCaseSuite) TestSearchTasks_ComputesOffset() {
+	hits := []domain.SearchHit{{Task: domain.Task{ID: "7"}, Score: 1.5}}
+	suite.taskRepository.On("SearchTasks", mock.Anything, "report", 20, 10).Return(hits, 21, nil)
+
+	result, err := suite.taskUseCase.SearchTasks(context.Background(), "  report ", 3, 10)
+
+	suite.NoError(err)
+	suite.Equal(&domain.SearchResult{Query: "report", Page: 3, Limit: 10, Total: 21, Hits: hits}, result)
+}
+
+func (suite *TaskUseCaseSuite) TestSearchTasks_RejectsInvalidInput() {
+	_, err := suite.taskUseCase.SearchTasks(context.Background(), " ", 1, 10)
+	suite.EqualError(err, "search query is required")
+
+	_, err = suite.taskUseCase.SearchTasks(context.Background(), "report", 0, 10)
+	suite.EqualError(err, "page must be at least 1")
+
+	_, err = suite.taskUseCase.SearchTasks(context.Background(), "report", 1, 101)
+	suite.EqualError(err, "limit must be between 1 and 100")
+
+	_, err = suite.taskUseCase.SearchTasks(context.Background(), "report", math.MaxInt, 10)
+	suite.EqualError(err, "page is too large")
+
+	suite.taskRepository.AssertNotCalled(suite.T(), "SearchTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUseCaseSuite) TestRestoreTask_PublishesEvent() {
+	task := domain.Task{ID: "1", Title: "Restored"}
+	suite.taskRepository.On("RestoreTask", mock.Anything, "1").Return(&task, nil)
+	suite.outbox.On("Enqueue", mock.Anything, mock.MatchedBy(func(event domain.Event) bool {
+		return event.Type == domain.EventTaskRestored
+	})).Return(nil)
+	suite.eventBus.On("Publish", mock.Anything).Return()
+
+	restored, err := suite.taskUseCase.RestoreTask(context.Background(), "1")
+
+	suite.NoError(err)
+	suite.Equal(&task, restored)
+	suite.outbox.AssertExpectations(suite.T())
+}
+
+func (suite *TaskUseCaseSuite) TestRestoreTask_NotInTrash() {
+	suite.taskRepository.On("RestoreTask", mock.Anything, "1").Return(nil, errors.New("task not found in trash"))
+
+	_, err := suite.taskUseCase.RestoreTask(context.Background(), "1")
+
+	suite.EqualError(err, "task not found in trash")
+	suite.outbox.AssertNotCalled(suite.T(), "Enqueue", mock.Anything, mock.Anything)
+}
+
+func (suite *TaskUseCaseSuite) TestPurgeExpiredTasks_UsesRetentionCutoff() {
+	retention := 48 * time.Hour
+	suite.taskRepository.On("PurgeDeletedBefore", mock.Anything, suite.clock.Now().Add(-retention)).Return([]domain.Task{{ID: "1", OrgID: "org1"}, {ID: "2", OrgID: "org2"}}, nil)
+	suite.outbox.On("Enqueue", mock.Anything, mock.MatchedBy(func(event domain.Event) bool {
+		return event.Type == domain.EventTaskPurged && event.OrgID == "org1"
+	})).Return(nil).Once()
+	suite.outbox.On("Enqueue", mock.Anything, mock.MatchedBy(func(event domain.Event) bool {
+		return event.Type == domain.EventTaskPurged && event.OrgID == "org2"
+	})).Return(nil).Once()
+	suite.eventBus.On("Publish", mock.Anything).Return().Times(2)
+
+	purged, err := suite.taskUseCase.PurgeExpiredTasks(context.Background(), retention)
+
+	suite.NoError(err)
+	suite.Equal(2, purged)
+	suite.outbox.AssertExpectations(suite.T())
+	suite.eventBus.AssertExpectations(suite.T())
+}
+
 func TestTaskUseCaseSuite(t *testing.T) {
 	suite.Run(t, new(TaskUseCaseSuite))
 }
@@ -2,18 +2,33 @@ package usecases
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
 	domain "test_task_manager/Domain"
 	"time"
 )
 
 type taskUseCase struct {
 	taskRepository domain.TaskRepository
+	outbox         domain.OutboxRepository
+	transactor     domain.Transactor
+	eventBus       domain.EventBus
+	clock          domain.Clock
+	ids            domain.IDGenerator
 	contextTimeout time.Duration
 }
 
-func NewTaskUseCase(taskRepository domain.TaskRepository, timeout time.Duration) domain.TaskUseCase {
+func NewTaskUseCase(taskRepository domain.TaskRepository, outbox domain.OutboxRepository, transactor domain.Transactor, eventBus domain.EventBus, clock domain.Clock, ids domain.IDGenerator, timeout time.Duration) domain.TaskUseCase {
 	return &taskUseCase{
 		taskRepository: taskRepository,
+		outbox:         outbox,
+		transactor:     transactor,
+		eventBus:       eventBus,
+		clock:          clock,
+		ids:            ids,
 		contextTimeout: timeout,
 	}
 }
@@ -21,13 +36,45 @@ func NewTaskUseCase(taskRepository domain.TaskRepository, timeout time.Duration)
 func (t *taskUseCase) CreateTask(c context.Context, newTask domain.Task) (*domain.Task, error) {
 	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
 	defer cancel()
-	return t.taskRepository.CreateTask(ctx, newTask)
+
+	var createdTask *domain.Task
+	var event domain.Event
+	err := t.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		task, err := t.taskRepository.CreateTask(ctx, newTask)
+		if err != nil {
+			return err
+		}
+		createdTask = task
+		event, err = t.enqueue(ctx, domain.EventTaskCreated, task)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.eventBus.Publish(event)
+	return createdTask, nil
 }
 
 func (t *taskUseCase) DeleteTask(c context.Context, taskID string) error {
 	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
 	defer cancel()
-	return t.taskRepository.DeleteTask(ctx, taskID)
+
+	var event domain.Event
+	err := t.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		err := t.taskRepository.DeleteTask(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		event, err = t.enqueue(ctx, domain.EventTaskDeleted, domain.Task{ID: taskID})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	t.eventBus.Publish(event)
+	return nil
 }
 
 func (t *taskUseCase) GetTaskByID(c context.Context, taskID string) (*domain.Task, error) {
@@ -45,5 +92,399 @@ func (t *taskUseCase) GetTasks(c context.Context) ([]domain.Task, error) {
 func (t *taskUseCase) UpdateTask(c context.Context, taskID string, updatedTask domain.Task) (*domain.Task, error) {
 	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
 	defer cancel()
-	return t.taskRepository.UpdateTask(ctx, taskID, updatedTask)
+
+	var task *domain.Task
+	var event domain.Event
+	err := t.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		result, err := t.taskRepository.UpdateTask(ctx, taskID, updatedTask)
+		if err != nil {
+			return err
+		}
+		task = result
+		event, err = t.enqueue(ctx, domain.EventTaskUpdated, result)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.eventBus.Publish(event)
+	return task, nil
+}
+
+func (t *taskUseCase) GetTrash(c context.Context) ([]domain.Task, error) {
+	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
+	defer cancel()
+	return t.taskRepository.GetDeletedTasks(ctx)
+}
+
+func (t *taskUseCase) RestoreTask(c context.Context, taskID string) (*domain.Task, error) {
+	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
+	defer cancel()
+
+	var task *domain.Task
+	var event domain.Event
+	err := t.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		restored, err := t.taskRepository.RestoreTask(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		task = restored
+		event, err = t.enqueue(ctx, domain.EventTaskRestored, restored)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.eventBus.Publish(event)
+	return task, nil
+}
+
+// PurgeTask deletes the task permanently, skipping the trash.
+func (t *taskUseCase) PurgeTask(c context.Context, taskID string) error {
+	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
+	defer cancel()
+
+	var event domain.Event
+	err := t.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		err := t.taskRepository.PurgeTask(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		event, err = t.enqueue(ctx, domain.EventTaskPurged, domain.Task{ID: taskID})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	t.eventBus.Publish(event)
+	return nil
+}
+
+// PurgeExpiredTasks permanently deletes tasks that have been in the trash longer than retention
+// and returns how many were purged.
+func (t *taskUseCase) PurgeExpiredTasks(c context.Context, retention time.Duration) (int, error) {
+	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
+	defer cancel()
+
+	var events []domain.Event
+	err := t.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		events = nil
+		purged, err := t.taskRepository.PurgeDeletedBefore(ctx, t.clock.Now().UTC().Add(-retention))
+		if err != nil {
+			return err
+		}
+		for _, task := range purged {
+			// Retention runs across organizations; each event belongs to the task's own.
+			event, err := t.enqueue(domain.WithOrg(ctx, task.OrgID), domain.EventTaskPurged, domain.Task{ID: task.ID})
+			if err != nil {
+				return err
+			}
+			events = append(events, event)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	t.publish(events)
+	return len(events), nil
+}
+
+// enqueue writes the event to the outbox inside the caller's transaction. The same event is
+// published on the in-process bus once the transaction has committed.
+func (t *taskUseCase) enqueue(ctx context.Context, eventType string, data interface{}) (domain.Event, error) {
+	event, err := newEvent(ctx, t.clock, t.ids, eventType, data)
+	if err != nil {
+		return domain.Event{}, err
+	}
+	return event, t.outbox.Enqueue(ctx, event)
+}
+
+const maxBulkOperations = 1000
+
+// BulkWrite applies a batch of operations. In atomic mode nothing is written unless every
+// operation succeeds. Otherwise each operation succeeds or fails on its own.
+func (t *taskUseCase) BulkWrite(c context.Context, operations []domain.BulkOperation, atomic bool) ([]domain.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
+	defer cancel()
+
+	if len(operations) == 0 {
+		return nil, errors.New("bulk request must contain at least one operation")
+	}
+	if len(operations) > maxBulkOperations {
+		return nil, fmt.Errorf("bulk request must not contain more than %d operations", maxBulkOperations)
+	}
+
+	operations = append([]domain.BulkOperation(nil), operations...)
+	results, valid := validateBulkOperations(operations)
+
+	if atomic {
+		if len(valid) != len(operations) {
+			return rollBack(results), errBulkRolledBack
+		}
+
+		var events []domain.Event
+		err := t.transactor.RequireTransaction(ctx, func(ctx context.Context) error {
+			written, err := t.taskRepository.BulkWrite(ctx, operations)
+			if err != nil {
+				return err
+			}
+			results = written
+			for _, result := range results {
+				if result.Status != domain.BulkStatusOK {
+					return errBulkRolledBack
+				}
+			}
+			events, err = t.enqueueBulk(ctx, results)
+			return err
+		})
+		if err == errBulkRolledBack {
+			return rollBack(results), err
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		t.publish(events)
+		return results, nil
+	}
+
+	if len(valid) == 0 {
+		return results, nil
+	}
+	batch := make([]domain.BulkOperation, len(valid))
+	for i, index := range valid {
+		batch[i] = operations[index]
+	}
+
+	// The batch and the events of the operations that succeeded are written in one unit of
+	// work. The repository reports missing and taken IDs per operation without writing them, so
+	// the other operations still go through. A write MongoDB itself refuses aborts the
+	// transaction instead, and the retry of the whole transaction then reports it like the others.
+	var events []domain.Event
+	err := t.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		written, err := t.taskRepository.BulkWrite(ctx, batch)
+		if err != nil {
+			return err
+		}
+		for i, result := range written {
+			result.Index = valid[i]
+			results[valid[i]] = result
+		}
+		events, err = t.enqueueBulk(ctx, written)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.publish(events)
+	return results, nil
+}
+
+var errBulkRolledBack = errors.New("bulk operation rolled back")
+
+func (t *taskUseCase) enqueueBulk(ctx context.Context, results []domain.BulkResult) ([]domain.Event, error) {
+	var events []domain.Event
+	for _, result := range results {
+		if result.Status != domain.BulkStatusOK {
+			continue
+		}
+
+		var event domain.Event
+		var err error
+		switch result.Op {
+		case domain.BulkCreate:
+			event, err = newEvent(ctx, t.clock, t.ids, domain.EventTaskCreated, result.Task)
+		case domain.BulkUpdate:
+			event, err = newEvent(ctx, t.clock, t.ids, domain.EventTaskUpdated, result.Task)
+		case domain.BulkDelete:
+			event, err = newEvent(ctx, t.clock, t.ids, domain.EventTaskDeleted, domain.Task{ID: result.ID})
+		}
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	if err := t.outbox.EnqueueMany(ctx, events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (t *taskUseCase) publish(events []domain.Event) {
+	for _, event := range events {
+		t.eventBus.Publish(event)
+	}
+}
+
+// validateBulkOperations checks what can be checked without the database and returns the
+// indexes of the operations that passed. A task may only appear once per batch because the
+// best-effort write does not guarantee ordering between operations.
+func validateBulkOperations(operations []domain.BulkOperation) ([]domain.BulkResult, []int) {
+	results := make([]domain.BulkResult, len(operations))
+	seen := make(map[string]bool, len(operations))
+	var valid []int
+
+	for i := range operations {
+		operation := &operations[i]
+		if operation.Op == domain.BulkCreate {
+			if operation.ID == "" {
+				operation.ID = operation.Task.ID
+			}
+			operation.Task.ID = operation.ID
+		}
+
+		result := domain.BulkResult{Index: i, Op: operation.Op, ID: operation.ID, Status: domain.BulkStatusFailed}
+		switch {
+		case operation.Op != domain.BulkCreate && operation.Op != domain.BulkUpdate && operation.Op != domain.BulkDelete:
+			result.Error = "unknown operation: " + operation.Op
+		case operation.ID == "":
+			result.Error = "task id is required"
+		case seen[operation.ID]:
+			result.Error = "task appears more than once in the batch"
+		default:
+			result.Status = domain.BulkStatusOK
+			valid = append(valid, i)
+		}
+
+		seen[operation.ID] = true
+		results[i] = result
+	}
+
+	return results, valid
+}
+
+// rollBack marks every operation that would have succeeded as rolled back, keeping the
+// errors of the ones that caused the rollback.
+func rollBack(results []domain.BulkResult) []domain.BulkResult {
+	for i := range results {
+		if results[i].Status == domain.BulkStatusOK {
+			results[i].Status = domain.BulkStatusRolledBack
+			results[i].Task = nil
+		}
+	}
+	return results
+}
+
+const maxSearchLimit = 100
+
+func (t *taskUseCase) SearchTasks(c context.Context, query string, page int, limit int) (*domain.SearchResult, error) {
+	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
+	defer cancel()
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New("search query is required")
+	}
+	if page < 1 {
+		return nil, errors.New("page must be at least 1")
+	}
+	if limit < 1 || limit > maxSearchLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxSearchLimit)
+	}
+	if page-1 > math.MaxInt/limit {
+		return nil, errors.New("page is too large")
+	}
+
+	hits, total, err := t.taskRepository.SearchTasks(ctx, query, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.SearchResult{Query: query, Page: page, Limit: limit, Total: total, Hits: hits}, nil
+}
+
+// ExportTasks streams every task to fn. It runs without the use case timeout because a large
+// export can legitimately take longer; it stops when the caller's context is cancelled.
+func (t *taskUseCase) ExportTasks(c context.Context, fn func(task domain.Task) error) error {
+	return t.taskRepository.ForEachTask(c, fn)
+}
+
+// ImportTasks validates the parsed rows and creates the tasks that pass. A row fails when it
+// could not be parsed, misses an ID or title, repeats an ID of the file or collides with an
+// existing task. In dry-run mode the report is produced without writing anything.
+func (t *taskUseCase) ImportTasks(c context.Context, rows []domain.ImportRow, dryRun bool) (*domain.ImportReport, error) {
+	ctx, cancel := context.WithTimeout(c, t.contextTimeout)
+	defer cancel()
+
+	report := &domain.ImportReport{DryRun: dryRun, Total: len(rows), Errors: []domain.ImportError{}}
+	fail := func(row domain.ImportRow, message string) {
+		report.Errors = append(report.Errors, domain.ImportError{Row: row.Row, ID: row.Task.ID, Error: message})
+	}
+
+	seen := make(map[string]bool, len(rows))
+	var candidates []domain.ImportRow
+	for _, row := range rows {
+		switch {
+		case row.Error != "":
+			fail(row, row.Error)
+		case row.Task.ID == "":
+			fail(row, "task id is required")
+		case row.Task.Title == "":
+			fail(row, "task title is required")
+		case seen[row.Task.ID]:
+			fail(row, "task appears more than once in the file")
+		default:
+			candidates = append(candidates, row)
+		}
+		seen[row.Task.ID] = true
+	}
+
+	var valid []domain.ImportRow
+	for start := 0; start < len(candidates); start += maxBulkOperations {
+		chunk := candidates[start:min(start+maxBulkOperations, len(candidates))]
+		ids := make([]string, len(chunk))
+		for i, row := range chunk {
+			ids[i] = row.Task.ID
+		}
+
+		existing, err := t.taskRepository.GetTasksByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		exists := make(map[string]bool, len(existing))
+		for _, task := range existing {
+			exists[task.ID] = true
+		}
+
+		for _, row := range chunk {
+			if exists[row.Task.ID] {
+				fail(row, "task with the given id already exists")
+				continue
+			}
+			valid = append(valid, row)
+		}
+	}
+	report.Valid = len(valid)
+
+	if !dryRun {
+		for start := 0; start < len(valid); start += maxBulkOperations {
+			chunk := valid[start:min(start+maxBulkOperations, len(valid))]
+			operations := make([]domain.BulkOperation, len(chunk))
+			for i, row := range chunk {
+				operations[i] = domain.BulkOperation{Op: domain.BulkCreate, ID: row.Task.ID, Task: row.Task}
+			}
+
+			results, err := t.BulkWrite(ctx, operations, false)
+			if err != nil {
+				return nil, err
+			}
+			for _, result := range results {
+				if result.Status == domain.BulkStatusOK {
+					report.Imported++
+					continue
+				}
+				fail(chunk[result.Index], result.Error)
+			}
+		}
+	}
+
+	sort.SliceStable(report.Errors, func(i, j int) bool { return report.Errors[i].Row < report.Errors[j].Row })
+	report.Failed = len(report.Errors)
+	return report, nil
 }
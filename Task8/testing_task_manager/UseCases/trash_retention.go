@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	domain "test_task_manager/Domain"
+	"time"
+)
+
+// TrashRetention periodically purges tasks that have stayed in the trash longer than retention.
+type TrashRetention struct {
+	taskUseCase domain.TaskUseCase
+	retention   time.Duration
+}
+
+func NewTrashRetention(taskUseCase domain.TaskUseCase, retention time.Duration) *TrashRetention {
+	return &TrashRetention{
+		taskUseCase: taskUseCase,
+		retention:   retention,
+	}
+}
+
+// Run purges expired tasks of every organization every interval until ctx is cancelled.
+func (r *TrashRetention) Run(ctx context.Context, interval time.Duration) {
+	ctx = domain.WithAllOrgs(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := r.taskUseCase.PurgeExpiredTasks(ctx, r.retention)
+		if err != nil {
+			log.Printf("trash retention: purging expired tasks: %v", err)
+		} else if purged > 0 {
+			log.Printf("trash retention: purged %d tasks", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -3,30 +3,49 @@ package usecases_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
 	usecases "test_task_manager/UseCases"
 	mocks "test_task_manager/mocks"
 )
 
 type UserUseCaseSuite struct {
 	suite.Suite
-	userRepository  *mocks.UserRepository
-	passwordService *mocks.PasswordService
-	jwtService      *mocks.JWTService
-	userUseCase     domain.UserUseCase
+	userRepository       *mocks.UserRepository
+	invitationRepository *mocks.InvitationRepository
+	passwordService      *mocks.PasswordService
+	jwtService           *mocks.JWTService
+	mailer               *mocks.Mailer
+	outbox               *mocks.OutboxRepository
+	unitOfWork           *mocks.UnitOfWork
+	clock                *infrastructure.FakeClock
+	ids                  domain.IDGenerator
+	userUseCase          domain.UserUseCase
 }
 
 func (suite *UserUseCaseSuite) SetupTest() {
 	suite.userRepository = new(mocks.UserRepository)
+	suite.invitationRepository = new(mocks.InvitationRepository)
 	suite.passwordService = new(mocks.PasswordService)
 	suite.jwtService = new(mocks.JWTService)
-
-	suite.userUseCase = usecases.NewUserUseCase(suite.userRepository, suite.passwordService, suite.jwtService, 2*time.Second)
+	suite.mailer = new(mocks.Mailer)
+	suite.outbox = new(mocks.OutboxRepository)
+	suite.unitOfWork = new(mocks.UnitOfWork)
+	suite.clock = infrastructure.NewFakeClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	suite.ids = infrastructure.NewSequentialIDGenerator("event")
+
+	stores := domain.Stores{Users: suite.userRepository, Invitations: suite.invitationRepository, Outbox: suite.outbox}
+	suite.unitOfWork.EXPECT().Do(mock.Anything, mock.Anything).RunAndReturn(func(c context.Context, fn func(context.Context, domain.Stores) error) error {
+		return fn(c, stores)
+	}).Maybe()
+
+	suite.userUseCase = usecases.NewUserUseCase(suite.userRepository, suite.passwordService, suite.jwtService, suite.mailer, suite.unitOfWork, usecases.UserOptions{PasswordLogin: true, PublicURL: "https://tasks.example.com"}, suite.clock, suite.ids, 2*time.Second)
 }
 
 func (suite *UserUseCaseSuite) TestGetUsers() {
@@ -48,26 +67,56 @@ func (suite *UserUseCaseSuite) TestCreateUser_Positive() {
 	user := domain.User{
 		Username: "user1",
 		Password: "password123",
+		Email:    "User1@Example.com",
 	}
 
 	hashedPassword := "hashedpassword"
 	suite.passwordService.On("Hash", user.Password).Return(hashedPassword, nil)
-	suite.userRepository.On("GetUsers", mock.Anything).Return([]domain.User{}, nil)
 	suite.userRepository.On("FindByUsername", mock.Anything, user.Username).Return(&domain.User{}, errors.New("user not found"))
+	suite.userRepository.On("FindByEmail", mock.Anything, "user1@example.com").Return(&domain.User{}, errors.New("user not found"))
 
 	// Update the expected user object with the hashed password and role
 	expectedUser := domain.User{
 		Username: "user1",
 		Password: hashedPassword,
-		Role:     "Admin",
+		Email:    "user1@example.com",
+		Role:     "User",
+		Orgs:     []domain.Membership{{OrgID: domain.DefaultOrgID, Role: "User"}},
 	}
 	suite.userRepository.On("CreateUser", mock.Anything, expectedUser).Return(nil)
+	suite.outbox.On("Enqueue", mock.Anything, mock.MatchedBy(func(event domain.Event) bool {
+		return event.ID == "event1" && event.CreatedAt.Equal(suite.clock.Now()) &&
+			event.Type == domain.EventUserCreated && event.OrgID == domain.DefaultOrgID && !strings.Contains(string(event.Data), hashedPassword)
+	})).Return(nil)
+	suite.jwtService.On("GenerateEmailToken", "user1", "user1@example.com").Return("emailToken", nil)
+	suite.mailer.On("Send", mock.Anything, mock.MatchedBy(func(email infrastructure.Email) bool {
+		return email.To == "user1@example.com" && strings.Contains(email.Body, "https://tasks.example.com/verify-email?token=emailToken")
+	})).Return(nil)
 
 	err := suite.userUseCase.CreateUser(context.Background(), user)
 
 	suite.NoError(err)
 	suite.userRepository.AssertExpectations(suite.T())
 	suite.passwordService.AssertExpectations(suite.T())
+	suite.outbox.AssertExpectations(suite.T())
+	suite.mailer.AssertExpectations(suite.T())
+}
+
+func (suite *UserUseCaseSuite) TestCreateUser_Negative_InvalidEmail() {
+	for _, email := range []string{"", "not-an-email", "Bob <bob@example.com>", "a@example.com, b@example.com"} {
+		err := suite.userUseCase.CreateUser(context.Background(), domain.User{Username: "user1", Password: "password123", Email: email})
+		suite.EqualError(err, "a valid email address is required", email)
+	}
+}
+
+func (suite *UserUseCaseSuite) TestCreateUser_Negative_EmailExists() {
+	suite.userRepository.On("FindByUsername", mock.Anything, "user1").Return(&domain.User{}, errors.New("user not found"))
+	suite.userRepository.On("FindByEmail", mock.Anything, "taken@example.com").Return(&domain.User{Username: "user2"}, nil)
+
+	err := suite.userUseCase.CreateUser(context.Background(), domain.User{Username: "user1", Password: "password123", Email: "taken@example.com"})
+
+	suite.EqualError(err, "email already exists")
+	suite.userRepository.AssertNotCalled(suite.T(), "CreateUser", mock.Anything, mock.Anything)
 }
 
 func (suite *UserUseCaseSuite) TestCreateUser_Negative_PasswordLength() {
@@ -86,11 +135,11 @@ func (suite *UserUseCaseSuite) TestCreateUser_Negative_UsernameExists() {
 	user := domain.User{
 		Username: "user1",
 		Password: "password123",
+		Email:    "user1@example.com",
 	}
 
 	hashedPassword := "hashedpassword"
 	suite.passwordService.On("Hash", user.Password).Return(hashedPassword, nil)
-	suite.userRepository.On("GetUsers", mock.Anything).Return([]domain.User{}, nil)
 	suite.userRepository.On("FindByUsername", mock.Anything, user.Username).Return(&domain.User{Username: "user1"}, nil)
 
 	err := suite.userUseCase.CreateUser(context.Background(), user)
@@ -99,6 +148,153 @@ func (suite *UserUseCaseSuite) TestCreateUser_Negative_UsernameExists() {
 	suite.EqualError(err, "username already exists")
 }
 
+func (suite *UserUseCaseSuite) TestCreateUser_Invitation() {
+	user := domain.User{
+		Username:   "user1",
+		Password:   "password123",
+		Email:      "user1@example.com",
+		InviteCode: "code",
+	}
+
+	suite.passwordService.On("Hash", user.Password).Return("hashedpassword", nil)
+	suite.userRepository.On("FindByUsername", mock.Anything, user.Username).Return(&domain.User{}, errors.New("user not found"))
+	suite.userRepository.On("FindByEmail", mock.Anything, user.Email).Return(&domain.User{}, errors.New("user not found"))
+	suite.invitationRepository.On("RedeemInvitation", mock.Anything, mock.Anything, "user1", suite.clock.Now()).Return(&domain.Invitation{OrgID: "acme", Role: "Admin", Email: "user1@example.com"}, nil)
+	// The invitation was mailed to the same address, so no verification email is needed.
+	suite.userRepository.On("CreateUser", mock.Anything, domain.User{
+		Username:      "user1",
+		Password:      "hashedpassword",
+		Email:         "user1@example.com",
+		EmailVerified: true,
+		Role:          "Admin",
+		Orgs:          []domain.Membership{{OrgID: "acme", Role: "Admin"}},
+	}).Return(nil)
+	suite.outbox.On("Enqueue", mock.Anything, mock.MatchedBy(func(event domain.Event) bool {
+		return event.Type == domain.EventUserCreated && event.OrgID == "acme"
+	})).Return(nil)
+
+	err := suite.userUseCase.CreateUser(context.Background(), user)
+
+	suite.NoError(err)
+	suite.userRepository.AssertExpectations(suite.T())
+	suite.outbox.AssertExpectations(suite.T())
+	// Only the hash of the code reaches the repository.
+	suite.invitationRepository.AssertNotCalled(suite.T(), "RedeemInvitation", mock.Anything, "code", mock.Anything, mock.Anything)
+	suite.mailer.AssertNotCalled(suite.T(), "Send", mock.Anything, mock.Anything)
+}
+
+func (suite *UserUseCaseSuite) TestCreateUser_Negative_InvitationForAnotherEmail() {
+	user := domain.User{Username: "user1", Password: "password123", Email: "user1@example.com", InviteCode: "code"}
+
+	suite.passwordService.On("Hash", user.Password).Return("hashedpassword", nil)
+	suite.userRepository.On("FindByUsername", mock.Anything, user.Username).Return(&domain.User{}, errors.New("user not found"))
+	suite.userRepository.On("FindByEmail", mock.Anything, user.Email).Return(&domain.User{}, errors.New("user not found"))
+	suite.invitationRepository.On("RedeemInvitation", mock.Anything, mock.Anything, "user1", mock.Anything).Return(&domain.Invitation{OrgID: "acme", Role: "User", Email: "bob@example.com"}, nil)
+	suite.invitationRepository.On("ReleaseInvitation", mock.Anything, mock.Anything, "user1").Return(nil)
+
+	err := suite.userUseCase.CreateUser(context.Background(), user)
+
+	suite.EqualError(err, "email does not match the invitation")
+	suite.userRepository.AssertNotCalled(suite.T(), "CreateUser", mock.Anything, mock.Anything)
+	suite.invitationRepository.AssertCalled(suite.T(), "ReleaseInvitation", mock.Anything, mock.Anything, "user1")
+}
+
+func (suite *UserUseCaseSuite) TestCreateUser_Negative_ReleasesInvitationWhenCreatingFails() {
+	user := domain.User{Username: "user1", Password: "password123", Email: "user1@example.com", InviteCode: "code"}
+
+	suite.passwordService.On("Hash", user.Password).Return("hashedpassword", nil)
+	suite.userRepository.On("FindByUsername", mock.Anything, user.Username).Return(&domain.User{}, errors.New("user not found"))
+	suite.userRepository.On("FindByEmail", mock.Anything, user.Email).Return(&domain.User{}, errors.New("user not found"))
+	var redeemed string
+	suite.invitationRepository.On("RedeemInvitation", mock.Anything, mock.Anything, "user1", mock.Anything).Run(func(args mock.Arguments) {
+		redeemed = args.String(1)
+	}).Return(&domain.Invitation{OrgID: "acme", Role: "User"}, nil)
+	suite.userRepository.On("CreateUser", mock.Anything, mock.Anything).Return(errors.New("username already exists"))
+	suite.invitationRepository.On("ReleaseInvitation", mock.Anything, mock.Anything, "user1").Return(nil)
+
+	err := suite.userUseCase.CreateUser(context.Background(), user)
+
+	suite.EqualError(err, "username already exists")
+	suite.invitationRepository.AssertCalled(suite.T(), "ReleaseInvitation", mock.Anything, redeemed, "user1")
+}
+
+func (suite *UserUseCaseSuite) TestCreateUser_Negative_InvalidInvitation() {
+	user := domain.User{
+		Username:   "user1",
+		Password:   "password123",
+		Email:      "user1@example.com",
+		InviteCode: "used",
+	}
+
+	suite.passwordService.On("Hash", user.Password).Return("hashedpassword", nil)
+	suite.userRepository.On("FindByUsername", mock.Anything, user.Username).Return(&domain.User{}, errors.New("user not found"))
+	suite.userRepository.On("FindByEmail", mock.Anything, user.Email).Return(&domain.User{}, errors.New("user not found"))
+	suite.invitationRepository.On("RedeemInvitation", mock.Anything, mock.Anything, "user1", mock.Anything).Return(nil, errors.New("mongo: no documents in result"))
+
+	err := suite.userUseCase.CreateUser(context.Background(), user)
+
+	suite.EqualError(err, "invalid or expired invitation")
+	suite.userRepository.AssertNotCalled(suite.T(), "CreateUser", mock.Anything, mock.Anything)
+}
+
+func (suite *UserUseCaseSuite) TestCreateUser_Negative_InviteOnly() {
+	userUseCase := usecases.NewUserUseCase(suite.userRepository, suite.passwordService, suite.jwtService, suite.mailer, suite.unitOfWork, usecases.UserOptions{PasswordLogin: true, InviteOnly: true, PublicURL: "https://tasks.example.com"}, suite.clock, suite.ids, 2*time.Second)
+
+	err := userUseCase.CreateUser(context.Background(), domain.User{Username: "user1", Password: "password123", Email: "user1@example.com"})
+
+	suite.EqualError(err, "an invitation is required to register")
+	suite.userRepository.AssertNotCalled(suite.T(), "CreateUser", mock.Anything, mock.Anything)
+}
+
+func (suite *UserUseCaseSuite) TestCreateAdmin_NewUser() {
+	suite.passwordService.On("Hash", "password123").Return("hashedpassword", nil)
+	suite.userRepository.On("FindByUsername", mock.Anything, "root").Return(&domain.User{}, errors.New("user not found"))
+	suite.userRepository.On("CreateUser", mock.Anything, domain.User{
+		Username:      "root",
+		Password:      "hashedpassword",
+		Role:          "Admin",
+		Orgs:          []domain.Membership{{OrgID: domain.DefaultOrgID, Role: "Admin"}},
+		EmailVerified: true,
+	}).Return(nil)
+	suite.outbox.On("Enqueue", mock.Anything, mock.MatchedBy(func(event domain.Event) bool {
+		return event.Type == domain.EventUserCreated
+	})).Return(nil)
+
+	err := suite.userUseCase.CreateAdmin(context.Background(), domain.User{Username: "root", Password: "password123"})
+
+	suite.NoError(err)
+	suite.userRepository.AssertExpectations(suite.T())
+	suite.outbox.AssertExpectations(suite.T())
+}
+
+func (suite *UserUseCaseSuite) TestCreateAdmin_ExistingUser() {
+	suite.userRepository.On("FindByUsername", mock.Anything, "user1").Return(&domain.User{
+		Username: "user1",
+		Orgs:     []domain.Membership{{OrgID: domain.DefaultOrgID, Role: "User"}},
+	}, nil)
+	suite.userRepository.On("SetRole", mock.Anything, "user1", "Admin").Return(nil)
+	suite.outbox.On("Enqueue", mock.Anything, mock.MatchedBy(func(event domain.Event) bool {
+		return event.Type == domain.EventUserPromoted
+	})).Return(nil)
+
+	err := suite.userUseCase.CreateAdmin(context.Background(), domain.User{Username: "user1"})
+
+	suite.NoError(err)
+	suite.userRepository.AssertExpectations(suite.T())
+	suite.passwordService.AssertNotCalled(suite.T(), "Hash", mock.Anything)
+}
+
+func (suite *UserUseCaseSuite) TestCreateAdmin_Negative_AlreadyAdmin() {
+	suite.userRepository.On("FindByUsername", mock.Anything, "root").Return(&domain.User{
+		Username: "root",
+		Orgs:     []domain.Membership{{OrgID: domain.DefaultOrgID, Role: "Admin"}},
+	}, nil)
+
+	err := suite.userUseCase.CreateAdmin(context.Background(), domain.User{Username: "root"})
+
+	suite.EqualError(err, "user is already an admin")
+}
+
 func (suite *UserUseCaseSuite) TestLogin_Positive() {
 	user := domain.User{
 		Username: "user1",
@@ -109,13 +305,14 @@ func (suite *UserUseCaseSuite) TestLogin_Positive() {
 	token := "validtoken"
 
 	suite.passwordService.On("CompareHashAndPassword", hashedPassword, user.Password).Return(nil)
-	suite.userRepository.On("FindByUsername", mock.Anything, user.Username).Return(&domain.User{Username: "user1", Password: hashedPassword, Role: "User"}, nil)
-	suite.jwtService.On("GenerateToken", user.Username, "User").Return(token, nil)
+	memberships := []domain.Membership{{OrgID: domain.DefaultOrgID, Role: "User"}}
+	suite.userRepository.On("FindByUsername", mock.Anything, user.Username).Return(&domain.User{Username: "user1", Password: hashedPassword, Orgs: memberships, EmailVerified: true}, nil)
+	suite.jwtService.On("GenerateToken", user.Username, memberships).Return(token, nil)
 
-	resultToken, err := suite.userUseCase.Login(context.Background(), user)
+	result, err := suite.userUseCase.Login(context.Background(), user)
 
 	suite.NoError(err)
-	suite.Equal(token, resultToken)
+	suite.Equal(&domain.LoginResult{Token: token}, result)
 	suite.userRepository.AssertExpectations(suite.T())
 	suite.passwordService.AssertExpectations(suite.T())
 	suite.jwtService.AssertExpectations(suite.T())
@@ -130,10 +327,10 @@ func (suite *UserUseCaseSuite) TestLogin_Negative_User_Not_Found() {
 
 	suite.userRepository.On("FindByUsername", mock.Anything, user.Username).Return(&domain.User{}, errors.New("user not found"))
 
-	resultToken, err := suite.userUseCase.Login(context.Background(), user)
+	result, err := suite.userUseCase.Login(context.Background(), user)
 
 	suite.Error(err)
-	suite.Empty(resultToken)
+	suite.Nil(result)
 	suite.EqualError(err, "invalid credentials")
 }
 
@@ -147,10 +344,10 @@ func (suite *UserUseCaseSuite) TestLogin_Negative_PasswordMismatch() {
 	suite.passwordService.On("CompareHashAndPassword", hashedPassword, user.Password).Return(errors.New("password mismatch"))
 	suite.userRepository.On("FindByUsername", mock.Anything, user.Username).Return(&domain.User{Username: "user1", Password: hashedPassword}, nil)
 
-	resultToken, err := suite.userUseCase.Login(context.Background(), user)
+	result, err := suite.userUseCase.Login(context.Background(), user)
 
 	suite.Error(err)
-	suite.Empty(resultToken)
+	suite.Nil(result)
 	suite.EqualError(err, "invalid credentials")
 }
 
@@ -159,18 +356,22 @@ func (suite *UserUseCaseSuite) TestPromoteUser_Positive() {
 	username := "user1"
 	user := domain.User{
 		Username: username,
-		Role:     "User",
+		Orgs:     []domain.Membership{{OrgID: "acme", Role: "User"}},
 	}
 
 	suite.userRepository.On("FindByUsername", mock.Anything, username).Return(&user, nil)
 	suite.userRepository.On("PromoteUser", mock.Anything, username).Return(&domain.User{Username: username, Role: "Admin"}, nil)
+	suite.outbox.On("Enqueue", mock.Anything, mock.MatchedBy(func(event domain.Event) bool {
+		return event.Type == domain.EventUserPromoted
+	})).Return(nil)
 
-	result, err := suite.userUseCase.PromoteUser(context.Background(), username)
+	result, err := suite.userUseCase.PromoteUser(domain.WithOrg(context.Background(), "acme"), username)
 
 	suite.NoError(err)
 	suite.NotNil(result)
 	suite.Equal("Admin", result.Role)
 	suite.userRepository.AssertExpectations(suite.T())
+	suite.outbox.AssertExpectations(suite.T())
 }
 
 func (suite *UserUseCaseSuite) TestPromoteUser_Negative_UserNotFound() {
@@ -189,18 +390,129 @@ func (suite *UserUseCaseSuite) TestPromoteUser_Negative_AlreadyAdmin() {
 	username := "user1"
 	user := domain.User{
 		Username: username,
-		Role:     "Admin",
+		Orgs:     []domain.Membership{{OrgID: "acme", Role: "Admin"}},
 	}
 
 	suite.userRepository.On("FindByUsername", mock.Anything, username).Return(&user, nil)
 
-	result, err := suite.userUseCase.PromoteUser(context.Background(), username)
+	result, err := suite.userUseCase.PromoteUser(domain.WithOrg(context.Background(), "acme"), username)
 
 	suite.Error(err)
 	suite.Nil(result)
 	suite.EqualError(err, "user is already an admin")
 }
 
+func (suite *UserUseCaseSuite) TestPromoteUser_Negative_OtherOrganization() {
+	username := "user1"
+	user := domain.User{
+		Username: username,
+		Orgs:     []domain.Membership{{OrgID: "acme", Role: "User"}},
+	}
+
+	suite.userRepository.On("FindByUsername", mock.Anything, username).Return(&user, nil)
+
+	result, err := suite.userUseCase.PromoteUser(domain.WithOrg(context.Background(), "globex"), username)
+
+	suite.Nil(result)
+	suite.EqualError(err, "user not found")
+	suite.userRepository.AssertNotCalled(suite.T(), "PromoteUser", mock.Anything, mock.Anything)
+}
+
+func (suite *UserUseCaseSuite) TestLogin_MFAEnabled() {
+	user := domain.User{Username: "user1", Password: "password123"}
+	suite.passwordService.On("CompareHashAndPassword", "hashedpassword", user.Password).Return(nil)
+	suite.userRepository.On("FindByUsername", mock.Anything, user.Username).Return(&domain.User{
+		Username: "user1",
+		Password: "hashedpassword",
+		Orgs:     []domain.Membership{{OrgID: domain.DefaultOrgID, Role: "Admin"}},
+		MFA:      &domain.MFA{Secret: "SECRET", Enabled: true},
+		EmailVerified: true,
+	}, nil)
+	suite.jwtService.On("GenerateMFAToken", "user1").Return("mfaToken", nil)
+
+	result, err := suite.userUseCase.Login(context.Background(), user)
+
+	suite.NoError(err)
+	suite.Equal(&domain.LoginResult{MFAToken: "mfaToken"}, result)
+	suite.jwtService.AssertNotCalled(suite.T(), "GenerateToken", mock.Anything, mock.Anything)
+}
+
+func (suite *UserUseCaseSuite) TestLogin_AdminWithoutMFAWhenRequired() {
+	userUseCase := usecases.NewUserUseCase(suite.userRepository, suite.passwordService, suite.jwtService, suite.mailer, suite.unitOfWork, usecases.UserOptions{PasswordLogin: true, RequireAdminMFA: true, PublicURL: "https://tasks.example.com"}, suite.clock, suite.ids, 2*time.Second)
+	user := domain.User{Username: "user1", Password: "password123"}
+	suite.passwordService.On("CompareHashAndPassword", "hashedpassword", user.Password).Return(nil)
+	suite.userRepository.On("FindByUsername", mock.Anything, user.Username).Return(&domain.User{
+		Username: "user1",
+		Password: "hashedpassword",
+		Orgs:     []domain.Membership{{OrgID: domain.DefaultOrgID, Role: "Admin"}, {OrgID: "acme", Role: "User"}},
+		EmailVerified: true,
+	}, nil)
+	suite.jwtService.On("GenerateToken", "user1", []domain.Membership{{OrgID: domain.DefaultOrgID, Role: "User"}, {OrgID: "acme", Role: "User"}}).Return("token", nil)
+
+	result, err := userUseCase.Login(context.Background(), user)
+
+	suite.NoError(err)
+	suite.Equal(&domain.LoginResult{Token: "token", MFAEnrollmentRequired: true}, result)
+}
+
+func (suite *UserUseCaseSuite) TestPasswordLoginDisabled() {
+	userUseCase := usecases.NewUserUseCase(suite.userRepository, suite.passwordService, suite.jwtService, suite.mailer, suite.unitOfWork, usecases.UserOptions{PublicURL: "https://tasks.example.com"}, suite.clock, suite.ids, 2*time.Second)
+	user := domain.User{Username: "user1", Password: "password123"}
+
+	_, err := userUseCase.Login(context.Background(), user)
+	suite.EqualError(err, "password login is disabled")
+
+	err = userUseCase.CreateUser(context.Background(), user)
+	suite.EqualError(err, "password login is disabled")
+
+	suite.userRepository.AssertNotCalled(suite.T(), "FindByUsername", mock.Anything, mock.Anything)
+}
+
+func (suite *UserUseCaseSuite) TestLogin_Negative_EmailNotVerified() {
+	user := domain.User{Username: "user1", Password: "password123"}
+	suite.passwordService.On("CompareHashAndPassword", "hashedpassword", user.Password).Return(nil)
+	suite.userRepository.On("FindByUsername", mock.Anything, user.Username).Return(&domain.User{Username: "user1", Password: "hashedpassword", Email: "user1@example.com"}, nil)
+
+	_, err := suite.userUseCase.Login(context.Background(), user)
+
+	suite.EqualError(err, "email address is not verified")
+	suite.jwtService.AssertNotCalled(suite.T(), "GenerateToken", mock.Anything, mock.Anything)
+}
+
+func (suite *UserUseCaseSuite) TestVerifyEmail() {
+	suite.jwtService.On("ValidateEmailToken", "emailToken").Return("user1", "user1@example.com", nil)
+	suite.userRepository.On("MarkEmailVerified", mock.Anything, "user1", "user1@example.com").Return(nil)
+
+	err := suite.userUseCase.VerifyEmail(context.Background(), "emailToken")
+
+	suite.NoError(err)
+	suite.userRepository.AssertExpectations(suite.T())
+}
+
+func (suite *UserUseCaseSuite) TestVerifyEmail_Negative() {
+	suite.jwtService.On("ValidateEmailToken", "expired").Return("", "", errors.New("Token is expired"))
+	// The user changed their address after the link was sent.
+	suite.jwtService.On("ValidateEmailToken", "oldAddress").Return("user1", "old@example.com", nil)
+	suite.userRepository.On("MarkEmailVerified", mock.Anything, "user1", "old@example.com").Return(errors.New("user not found"))
+
+	suite.EqualError(suite.userUseCase.VerifyEmail(context.Background(), "expired"), "invalid or expired verification token")
+	suite.EqualError(suite.userUseCase.VerifyEmail(context.Background(), "oldAddress"), "invalid or expired verification token")
+}
+
+func (suite *UserUseCaseSuite) TestResendVerification() {
+	suite.userRepository.On("FindByEmail", mock.Anything, "user1@example.com").Return(&domain.User{Username: "user1", Email: "user1@example.com"}, nil)
+	suite.userRepository.On("FindByEmail", mock.Anything, "verified@example.com").Return(&domain.User{Username: "user2", Email: "verified@example.com", EmailVerified: true}, nil)
+	suite.userRepository.On("FindByEmail", mock.Anything, "nobody@example.com").Return(nil, errors.New("mongo: no documents in result"))
+	suite.jwtService.On("GenerateEmailToken", "user1", "user1@example.com").Return("emailToken", nil)
+	suite.mailer.On("Send", mock.Anything, mock.Anything).Return(nil).Once()
+
+	suite.NoError(suite.userUseCase.ResendVerification(context.Background(), "User1@example.com"))
+	suite.NoError(suite.userUseCase.ResendVerification(context.Background(), "verified@example.com"))
+	suite.NoError(suite.userUseCase.ResendVerification(context.Background(), "nobody@example.com"))
+
+	suite.mailer.AssertNumberOfCalls(suite.T(), "Send", 1)
+}
+
 func TestUserUseCaseSuite(t *testing.T) {
 	suite.Run(t, new(UserUseCaseSuite))
 }
@@ -3,6 +3,7 @@ package usecases
 import (
 	"context"
 	"errors"
+	"log"
 	domain "test_task_manager/Domain"
 	infrastructure "test_task_manager/Infrastructure"
 	"time"
@@ -12,14 +13,46 @@ type userUseCase struct {
 	userRepository  domain.UserRepository
 	passwordService infrastructure.PasswordService
 	jwtService      infrastructure.JWTService
+	mailer          infrastructure.Mailer
+	unitOfWork      domain.UnitOfWork
+	passwordLogin   bool
+	requireAdminMFA bool
+	inviteOnly      bool
+	publicURL       string
+	clock           domain.Clock
+	ids             domain.IDGenerator
 	contextTimeout  time.Duration
 }
 
-func NewUserUseCase(userRepo domain.UserRepository, passwordService infrastructure.PasswordService, jwtService infrastructure.JWTService, timeout time.Duration) domain.UserUseCase {
+// UserOptions are the deployment's registration and login policies.
+type UserOptions struct {
+	// PasswordLogin allows registration and password login. Without it, users log in through
+	// single sign-on only.
+	PasswordLogin bool
+	// RequireAdminMFA gives Admins who have not enrolled in two-factor authentication the User
+	// role only.
+	RequireAdminMFA bool
+	// InviteOnly requires an invitation to register.
+	InviteOnly bool
+	// PublicURL is where the API is reachable, for the links in verification emails.
+	PublicURL string
+}
+
+// NewUserUseCase sends verification emails through mailer. Users are written through
+// unitOfWork, together with the invitations they redeem and their events; userRepo is only read.
+func NewUserUseCase(userRepo domain.UserRepository, passwordService infrastructure.PasswordService, jwtService infrastructure.JWTService, mailer infrastructure.Mailer, unitOfWork domain.UnitOfWork, options UserOptions, clock domain.Clock, ids domain.IDGenerator, timeout time.Duration) domain.UserUseCase {
 	return &userUseCase{
 		userRepository:  userRepo,
 		passwordService: passwordService,
 		jwtService:      jwtService,
+		mailer:          mailer,
+		unitOfWork:      unitOfWork,
+		passwordLogin:   options.PasswordLogin,
+		requireAdminMFA: options.RequireAdminMFA,
+		inviteOnly:      options.InviteOnly,
+		publicURL:       options.PublicURL,
+		clock:           clock,
+		ids:             ids,
 		contextTimeout:  timeout,
 	}
 }
@@ -34,67 +67,276 @@ func (u *userUseCase) CreateUser(ctx context.Context, user domain.User) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
+	if !u.passwordLogin {
+		return errors.New("password login is disabled")
+	}
 	if len(user.Password) < 4 {
 		return errors.New("password length must be greater than 4")
 	}
-
-	users, err := u.userRepository.GetUsers(ctx)
+	email, err := normalizeEmail(user.Email)
 	if err != nil {
 		return err
 	}
-	if len(users) == 0 {
-		user.Role = "Admin"
-	} else {
-		user.Role = "User"
+	if u.inviteOnly && user.InviteCode == "" {
+		return errors.New("an invitation is required to register")
 	}
 
+	// Registration makes a User of the default organization. Admins are created with the
+	// create-admin command, invited, or promoted.
+	ctx = domain.WithOrg(ctx, domain.DefaultOrgID)
 	existingUser, err := u.userRepository.FindByUsername(ctx, user.Username)
 	if err == nil && existingUser.Username != "" {
 		return errors.New("username already exists")
 	}
+	existingUser, err = u.userRepository.FindByEmail(ctx, email)
+	if err == nil && existingUser.Username != "" {
+		return errors.New("email already exists")
+	}
 
 	hashedPassword, err := u.passwordService.Hash(user.Password)
 	if err != nil {
 		return err
 	}
 
+	inviteHash := ""
+	if user.InviteCode != "" {
+		inviteHash = hashInvitationCode(user.InviteCode)
+	}
 	user.Password = hashedPassword
-	return u.userRepository.CreateUser(ctx, user)
+	user.Email = email
+	user.EmailVerified = false
+	user.InviteCode = ""
+	redeemed := false
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context, stores domain.Stores) error {
+		user.Role = "User"
+		user.Orgs = []domain.Membership{{OrgID: domain.DefaultOrgID, Role: user.Role}}
+		if inviteHash != "" {
+			invitation, err := stores.Invitations.RedeemInvitation(ctx, inviteHash, user.Username, u.clock.Now().UTC())
+			if err != nil {
+				return errors.New("invalid or expired invitation")
+			}
+			redeemed = true
+			if invitation.Email != "" {
+				if invitation.Email != email {
+					return errors.New("email does not match the invitation")
+				}
+				// The code was mailed to this address, which proves the user receives mail there.
+				user.EmailVerified = true
+			}
+			user.Role = invitation.Role
+			user.Orgs = []domain.Membership{{OrgID: invitation.OrgID, Role: invitation.Role}}
+			ctx = domain.WithOrg(ctx, invitation.OrgID)
+		}
+		if err := stores.Users.CreateUser(ctx, user); err != nil {
+			return err
+		}
+		return enqueueEvent(ctx, stores.Outbox, u.clock, u.ids, domain.EventUserCreated, userEventData(user))
+	})
+	if err != nil && redeemed {
+		// A transaction rolls the redemption back with the user. MongoDB without a replica set
+		// has none, so the invitation is released here for the user to try again.
+		releaseErr := u.unitOfWork.Do(ctx, func(ctx context.Context, stores domain.Stores) error {
+			return stores.Invitations.ReleaseInvitation(ctx, inviteHash, user.Username)
+		})
+		if releaseErr != nil {
+			log.Printf("releasing the invitation of %s: %v", user.Username, releaseErr)
+		}
+	}
+	if err != nil || user.EmailVerified {
+		return err
+	}
+
+	// The account exists either way; a lost email can be sent again with ResendVerification.
+	if err := u.sendVerification(ctx, user.Username, email); err != nil {
+		log.Printf("sending verification email to %s: %v", user.Username, err)
+	}
+	return nil
+}
+
+func (u *userUseCase) sendVerification(ctx context.Context, username string, email string) error {
+	token, err := u.jwtService.GenerateEmailToken(username, email)
+	if err != nil {
+		return err
+	}
+	return u.mailer.Send(ctx, verificationEmail(u.publicURL, email, token))
+}
+
+func (u *userUseCase) VerifyEmail(ctx context.Context, token string) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	username, email, err := u.jwtService.ValidateEmailToken(token)
+	if err != nil {
+		return errors.New("invalid or expired verification token")
+	}
+	if err := u.userRepository.MarkEmailVerified(ctx, username, email); err != nil {
+		if err.Error() == "user not found" {
+			return errors.New("invalid or expired verification token")
+		}
+		return err
+	}
+	return nil
+}
+
+func (u *userUseCase) ResendVerification(ctx context.Context, email string) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return err
+	}
+	user, err := u.userRepository.FindByEmail(ctx, email)
+	if err != nil || user.Username == "" || user.EmailVerified {
+		return nil
+	}
+	return u.sendVerification(ctx, user.Username, email)
+}
+
+func (u *userUseCase) CreateAdmin(ctx context.Context, user domain.User) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	ctx = domain.WithOrg(ctx, domain.DefaultOrgID)
+	existingUser, err := u.userRepository.FindByUsername(ctx, user.Username)
+	if err == nil && existingUser.Username != "" {
+		role, member := existingUser.OrgRole(domain.DefaultOrgID)
+		if role == "Admin" {
+			return errors.New("user is already an admin")
+		}
+		existingUser.Role = "Admin"
+		return u.unitOfWork.Do(ctx, func(ctx context.Context, stores domain.Stores) error {
+			if member {
+				err = stores.Users.SetRole(ctx, user.Username, "Admin")
+			} else {
+				err = stores.Users.AddMembership(ctx, user.Username, "Admin")
+			}
+			if err != nil {
+				return err
+			}
+			return enqueueEvent(ctx, stores.Outbox, u.clock, u.ids, domain.EventUserPromoted, userEventData(*existingUser))
+		})
+	}
+
+	if len(user.Password) < 4 {
+		return errors.New("password length must be greater than 4")
+	}
+	hashedPassword, err := u.passwordService.Hash(user.Password)
+	if err != nil {
+		return err
+	}
+	// Whoever runs the command vouches for the address, so it is not verified by email.
+	admin := domain.User{
+		Username:      user.Username,
+		Password:      hashedPassword,
+		Role:          "Admin",
+		Orgs:          []domain.Membership{{OrgID: domain.DefaultOrgID, Role: "Admin"}},
+		EmailVerified: true,
+	}
+	if user.Email != "" {
+		if admin.Email, err = normalizeEmail(user.Email); err != nil {
+			return err
+		}
+	}
+	return u.unitOfWork.Do(ctx, func(ctx context.Context, stores domain.Stores) error {
+		if err := stores.Users.CreateUser(ctx, admin); err != nil {
+			return err
+		}
+		return enqueueEvent(ctx, stores.Outbox, u.clock, u.ids, domain.EventUserCreated, userEventData(admin))
+	})
 }
 
-func (u *userUseCase) Login(ctx context.Context, user domain.User) (string, error) {
+func (u *userUseCase) Login(ctx context.Context, user domain.User) (*domain.LoginResult, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
+	if !u.passwordLogin {
+		return nil, errors.New("password login is disabled")
+	}
 	if len(user.Password) < 4 {
-		return "", errors.New("password length must be greater than 4")
+		return nil, errors.New("password length must be greater than 4")
 	}
 
 	existingUser, err := u.userRepository.FindByUsername(ctx, user.Username)
-	if err != nil || existingUser.Username == "" || u.passwordService.CompareHashAndPassword(existingUser.Password, user.Password) != nil {
-		return "", errors.New("invalid credentials")
+	if err != nil || existingUser.Username == "" || existingUser.Password == "" || u.passwordService.CompareHashAndPassword(existingUser.Password, user.Password) != nil {
+		return nil, errors.New("invalid credentials")
 	}
+	if !existingUser.EmailVerified {
+		return nil, errors.New("email address is not verified")
+	}
+
+	return issueLogin(u.jwtService, existingUser, u.requireAdminMFA)
+}
 
-	token, err := u.jwtService.GenerateToken(existingUser.Username, existingUser.Role)
+// issueLogin ends every way of logging in once the user is known. Users with two-factor
+// authentication get a challenge instead of a token. With requireAdminMFA, the Admin roles of
+// users without it are Users until they enroll.
+func issueLogin(jwtService infrastructure.JWTService, user *domain.User, requireAdminMFA bool) (*domain.LoginResult, error) {
+	if user.MFA != nil && user.MFA.Enabled {
+		mfaToken, err := jwtService.GenerateMFAToken(user.Username)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.LoginResult{MFAToken: mfaToken}, nil
+	}
+
+	result := &domain.LoginResult{}
+	memberships := user.Orgs
+	if requireAdminMFA {
+		memberships, result.MFAEnrollmentRequired = withoutAdminRoles(memberships)
+	}
+	token, err := jwtService.GenerateToken(user.Username, memberships)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
+	result.Token = token
+	return result, nil
+}
 
-	return token, nil
+// withoutAdminRoles turns Admin memberships into User ones and reports whether there were any.
+func withoutAdminRoles(memberships []domain.Membership) ([]domain.Membership, bool) {
+	downgraded := make([]domain.Membership, len(memberships))
+	found := false
+	for i, membership := range memberships {
+		if membership.Role == "Admin" {
+			membership.Role = "User"
+			found = true
+		}
+		downgraded[i] = membership
+	}
+	return downgraded, found
 }
 
 func (u *userUseCase) PromoteUser(ctx context.Context, username string) (*domain.User, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
+	orgID, _ := domain.OrgFromContext(ctx)
 	user, err := u.userRepository.FindByUsername(ctx, username)
 	if err != nil || user.Username == "" {
 		return nil, errors.New("user not found")
 	}
 
-	if user.Role == "Admin" {
+	// Members of other organizations are reported as missing rather than revealed.
+	role, member := user.OrgRole(orgID)
+	if !member {
+		return nil, errors.New("user not found")
+	}
+	if role == "Admin" {
 		return nil, errors.New("user is already an admin")
 	}
 
-	return u.userRepository.PromoteUser(ctx, username)
+	var promotedUser *domain.User
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context, stores domain.Stores) error {
+		result, err := stores.Users.PromoteUser(ctx, username)
+		if err != nil {
+			return err
+		}
+		promotedUser = result
+		return enqueueEvent(ctx, stores.Outbox, u.clock, u.ids, domain.EventUserPromoted, userEventData(*result))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return promotedUser, nil
 }
@@ -0,0 +1,188 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	"time"
+)
+
+const dispatchBatchSize = 100
+
+// claimLease is how long a dispatcher has to handle an event or delivery it claimed before
+// another may take it over. It outlasts any webhook request.
+const claimLease = time.Minute
+
+// WebhookDispatcher moves events from the outbox into per-webhook deliveries and sends them,
+// retrying failed attempts with exponential backoff until maxAttempts is reached.
+type WebhookDispatcher struct {
+	outbox             domain.OutboxRepository
+	webhookRepository  domain.WebhookRepository
+	deliveryRepository domain.WebhookDeliveryRepository
+	sender             infrastructure.WebhookSender
+	clock              domain.Clock
+	ids                domain.IDGenerator
+	maxAttempts        int
+	baseBackoff        time.Duration
+}
+
+func NewWebhookDispatcher(outbox domain.OutboxRepository, webhookRepository domain.WebhookRepository, deliveryRepository domain.WebhookDeliveryRepository, sender infrastructure.WebhookSender, clock domain.Clock, ids domain.IDGenerator, maxAttempts int, baseBackoff time.Duration) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		outbox:             outbox,
+		webhookRepository:  webhookRepository,
+		deliveryRepository: deliveryRepository,
+		sender:             sender,
+		clock:              clock,
+		ids:                ids,
+		maxAttempts:        maxAttempts,
+		baseBackoff:        baseBackoff,
+	}
+}
+
+// Run polls the outbox and the delivery queue every interval until ctx is cancelled. It works
+// across organizations, but each event only goes to the webhooks of its own.
+func (d *WebhookDispatcher) Run(ctx context.Context, interval time.Duration) {
+	ctx = domain.WithAllOrgs(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.ProcessOutbox(ctx); err != nil {
+			log.Printf("webhook dispatcher: processing outbox: %v", err)
+		}
+		if err := d.ProcessDeliveries(ctx); err != nil {
+			log.Printf("webhook dispatcher: processing deliveries: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessOutbox creates a pending delivery for every webhook subscribed to each undispatched event.
+// It claims each event first, so dispatchers of several replicas do not fan the same event out.
+func (d *WebhookDispatcher) ProcessOutbox(ctx context.Context) error {
+	for i := 0; i < dispatchBatchSize; i++ {
+		now := d.clock.Now().UTC()
+		event, err := d.outbox.ClaimPending(ctx, now, now.Add(claimLease))
+		if err != nil || event == nil {
+			return err
+		}
+
+		webhooks, err := d.webhookRepository.GetWebhooksForEvent(domain.WithOrg(ctx, event.OrgID), event.Type)
+		if err != nil {
+			return err
+		}
+
+		for _, webhook := range webhooks {
+			delivery := domain.WebhookDelivery{
+				ID:            d.ids.NewID(),
+				WebhookID:     webhook.ID,
+				Event:         *event,
+				Status:        domain.DeliveryPending,
+				NextAttemptAt: now,
+				CreatedAt:     now,
+			}
+			if err := d.deliveryRepository.CreateDelivery(ctx, delivery); err != nil {
+				return err
+			}
+		}
+
+		if err := d.outbox.MarkDispatched(ctx, event.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ProcessDeliveries attempts every delivery whose next attempt is due. It claims each delivery
+// before sending it, so dispatchers of several replicas do not send the same one.
+func (d *WebhookDispatcher) ProcessDeliveries(ctx context.Context) error {
+	for i := 0; i < dispatchBatchSize; i++ {
+		now := d.clock.Now().UTC()
+		delivery, err := d.deliveryRepository.ClaimDueDelivery(ctx, now, now.Add(claimLease))
+		if err != nil || delivery == nil {
+			return err
+		}
+		if err := d.attempt(ctx, *delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *WebhookDispatcher) attempt(ctx context.Context, delivery domain.WebhookDelivery) error {
+	now := d.clock.Now().UTC()
+
+	webhook, err := d.webhookRepository.GetWebhookByID(ctx, delivery.WebhookID)
+	if err != nil {
+		if err.Error() != "mongo: no documents in result" {
+			// The delivery stays claimed until the lease runs out and is tried again then.
+			return err
+		}
+		delivery.Log = append(delivery.Log, domain.WebhookAttempt{At: now, Error: "webhook no longer exists"})
+		return d.deadLetter(ctx, delivery, "webhook no longer exists")
+	}
+
+	payload, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return err
+	}
+
+	statusCode, sendErr := d.sender.Send(ctx, infrastructure.WebhookRequest{
+		URL:        webhook.URL,
+		Secret:     webhook.Secret,
+		EventID:    delivery.Event.ID,
+		EventType:  delivery.Event.Type,
+		DeliveryID: delivery.ID,
+		Payload:    payload,
+	})
+
+	delivery.Attempts++
+	attempt := domain.WebhookAttempt{At: now, StatusCode: statusCode}
+	if sendErr != nil {
+		attempt.Error = sendErr.Error()
+	}
+	delivery.Log = append(delivery.Log, attempt)
+
+	if sendErr == nil {
+		delivery.Status = domain.DeliveryDelivered
+		return d.deliveryRepository.UpdateDelivery(ctx, delivery)
+	}
+
+	if delivery.Attempts >= d.maxAttempts {
+		return d.deadLetter(ctx, delivery, sendErr.Error())
+	}
+
+	delivery.NextAttemptAt = now.Add(d.backoff(delivery.Attempts))
+	return d.deliveryRepository.UpdateDelivery(ctx, delivery)
+}
+
+// backoff doubles the wait after every failed attempt: base, 2*base, 4*base, ...
+func (d *WebhookDispatcher) backoff(attempts int) time.Duration {
+	return d.baseBackoff * time.Duration(1<<uint(attempts-1))
+}
+
+func (d *WebhookDispatcher) deadLetter(ctx context.Context, delivery domain.WebhookDelivery, reason string) error {
+	delivery.Status = domain.DeliveryDeadLetter
+	if err := d.deliveryRepository.UpdateDelivery(ctx, delivery); err != nil {
+		return err
+	}
+
+	return d.deliveryRepository.CreateDeadLetter(ctx, domain.WebhookDeadLetter{
+		ID:         d.ids.NewID(),
+		DeliveryID: delivery.ID,
+		WebhookID:  delivery.WebhookID,
+		Event:      delivery.Event,
+		Attempts:   delivery.Attempts,
+		LastError:  reason,
+		CreatedAt:  d.clock.Now().UTC(),
+	})
+}
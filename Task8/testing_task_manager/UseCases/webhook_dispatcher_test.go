@@ -0,0 +1,169 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	usecases "test_task_manager/UseCases"
+	mocks "test_task_manager/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookDispatcherSuite struct {
+	suite.Suite
+	outbox             *mocks.OutboxRepository
+	webhookRepository  *mocks.WebhookRepository
+	deliveryRepository *mocks.WebhookDeliveryRepository
+	sender             *mocks.WebhookSender
+	clock              *infrastructure.FakeClock
+	dispatcher         *usecases.WebhookDispatcher
+}
+
+func (suite *WebhookDispatcherSuite) SetupTest() {
+	suite.outbox = new(mocks.OutboxRepository)
+	suite.webhookRepository = new(mocks.WebhookRepository)
+	suite.deliveryRepository = new(mocks.WebhookDeliveryRepository)
+	suite.sender = new(mocks.WebhookSender)
+
+	suite.clock = infrastructure.NewFakeClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	suite.dispatcher = usecases.NewWebhookDispatcher(suite.outbox, suite.webhookRepository, suite.deliveryRepository, suite.sender, suite.clock, infrastructure.NewSequentialIDGenerator("delivery"), 3, time.Minute)
+}
+
+// claim makes delivery the only one due, claimed for a minute.
+func (suite *WebhookDispatcherSuite) claim(delivery domain.WebhookDelivery) {
+	suite.deliveryRepository.On("ClaimDueDelivery", mock.Anything, suite.clock.Now(), suite.clock.Now().Add(time.Minute)).Return(&delivery, nil).Once()
+	suite.deliveryRepository.On("ClaimDueDelivery", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+}
+
+func (suite *WebhookDispatcherSuite) TestProcessOutbox_FansOutToSubscribers() {
+	event := domain.Event{ID: "e1", Type: domain.EventTaskCreated}
+	webhooks := []domain.Webhook{{ID: "w1"}, {ID: "w2"}}
+
+	suite.outbox.On("ClaimPending", mock.Anything, suite.clock.Now(), suite.clock.Now().Add(time.Minute)).Return(&event, nil).Once()
+	suite.outbox.On("ClaimPending", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+	suite.webhookRepository.On("GetWebhooksForEvent", mock.Anything, domain.EventTaskCreated).Return(webhooks, nil)
+	suite.deliveryRepository.On("CreateDelivery", mock.Anything, mock.MatchedBy(func(d domain.WebhookDelivery) bool {
+		return d.Event.ID == "e1" && d.Status == domain.DeliveryPending && d.NextAttemptAt.Equal(suite.clock.Now())
+	})).Return(nil).Twice()
+	suite.outbox.On("MarkDispatched", mock.Anything, "e1").Return(nil)
+
+	err := suite.dispatcher.ProcessOutbox(context.Background())
+
+	suite.NoError(err)
+	suite.outbox.AssertExpectations(suite.T())
+	suite.deliveryRepository.AssertExpectations(suite.T())
+}
+
+func (suite *WebhookDispatcherSuite) TestProcessDeliveries_Success() {
+	delivery := domain.WebhookDelivery{ID: "d1", WebhookID: "w1", Event: domain.Event{ID: "e1", Type: domain.EventTaskCreated}, Status: domain.DeliveryPending}
+
+	suite.claim(delivery)
+	suite.webhookRepository.On("GetWebhookByID", mock.Anything, "w1").Return(&domain.Webhook{ID: "w1", URL: "https://example.com", Secret: "s3cret"}, nil)
+	suite.sender.On("Send", mock.Anything, mock.MatchedBy(func(r infrastructure.WebhookRequest) bool {
+		return r.URL == "https://example.com" && r.Secret == "s3cret" && r.DeliveryID == "d1"
+	})).Return(200, nil)
+	suite.deliveryRepository.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(d domain.WebhookDelivery) bool {
+		return d.Status == domain.DeliveryDelivered && d.Attempts == 1 && len(d.Log) == 1
+	})).Return(nil)
+
+	err := suite.dispatcher.ProcessDeliveries(context.Background())
+
+	suite.NoError(err)
+	suite.deliveryRepository.AssertExpectations(suite.T())
+}
+
+func (suite *WebhookDispatcherSuite) TestProcessDeliveries_RetriesWithBackoff() {
+	delivery := domain.WebhookDelivery{ID: "d1", WebhookID: "w1", Attempts: 1, Status: domain.DeliveryPending}
+
+	suite.claim(delivery)
+	suite.webhookRepository.On("GetWebhookByID", mock.Anything, "w1").Return(&domain.Webhook{ID: "w1"}, nil)
+	suite.sender.On("Send", mock.Anything, mock.Anything).Return(500, errors.New("webhook endpoint responded with status 500"))
+
+	suite.deliveryRepository.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(d domain.WebhookDelivery) bool {
+		// second failure waits two base intervals
+		return d.Status == domain.DeliveryPending && d.Attempts == 2 && d.NextAttemptAt.Equal(suite.clock.Now().Add(2*time.Minute))
+	})).Return(nil)
+
+	err := suite.dispatcher.ProcessDeliveries(context.Background())
+
+	suite.NoError(err)
+	suite.deliveryRepository.AssertExpectations(suite.T())
+}
+
+func (suite *WebhookDispatcherSuite) TestProcessDeliveries_DeadLettersAfterMaxAttempts() {
+	delivery := domain.WebhookDelivery{ID: "d1", WebhookID: "w1", Attempts: 2, Status: domain.DeliveryPending}
+
+	suite.claim(delivery)
+	suite.webhookRepository.On("GetWebhookByID", mock.Anything, "w1").Return(&domain.Webhook{ID: "w1"}, nil)
+	suite.sender.On("Send", mock.Anything, mock.Anything).Return(0, errors.New("connection refused"))
+	suite.deliveryRepository.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(d domain.WebhookDelivery) bool {
+		return d.Status == domain.DeliveryDeadLetter && d.Attempts == 3
+	})).Return(nil)
+	suite.deliveryRepository.On("CreateDeadLetter", mock.Anything, mock.MatchedBy(func(d domain.WebhookDeadLetter) bool {
+		return d.DeliveryID == "d1" && d.LastError == "connection refused" && d.Attempts == 3
+	})).Return(nil)
+
+	err := suite.dispatcher.ProcessDeliveries(context.Background())
+
+	suite.NoError(err)
+	suite.deliveryRepository.AssertExpectations(suite.T())
+}
+
+func (suite *WebhookDispatcherSuite) TestProcessDeliveries_DeadLettersWhenTheWebhookIsGone() {
+	delivery := domain.WebhookDelivery{ID: "d1", WebhookID: "w1", Status: domain.DeliveryPending}
+	suite.claim(delivery)
+	suite.webhookRepository.On("GetWebhookByID", mock.Anything, "w1").Return(nil, errors.New("mongo: no documents in result"))
+	suite.deliveryRepository.On("CreateDeadLetter", mock.Anything, mock.MatchedBy(func(dl domain.WebhookDeadLetter) bool {
+		return dl.LastError == "webhook no longer exists"
+	})).Return(nil)
+	suite.deliveryRepository.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(d domain.WebhookDelivery) bool {
+		return d.Status == domain.DeliveryDeadLetter
+	})).Return(nil)
+
+	err := suite.dispatcher.ProcessDeliveries(context.Background())
+
+	suite.NoError(err)
+	suite.deliveryRepository.AssertExpectations(suite.T())
+}
+
+func (suite *WebhookDispatcherSuite) TestProcessDeliveries_KeepsTheDeliveryWhileTheDatabaseIsDown() {
+	delivery := domain.WebhookDelivery{ID: "d1", WebhookID: "w1", Status: domain.DeliveryPending}
+	suite.claim(delivery)
+	suite.webhookRepository.On("GetWebhookByID", mock.Anything, "w1").Return(nil, errors.New("database unavailable"))
+
+	err := suite.dispatcher.ProcessDeliveries(context.Background())
+
+	suite.EqualError(err, "database unavailable")
+	suite.sender.AssertNotCalled(suite.T(), "Send", mock.Anything, mock.Anything)
+	suite.deliveryRepository.AssertNotCalled(suite.T(), "CreateDeadLetter", mock.Anything, mock.Anything)
+	suite.deliveryRepository.AssertNotCalled(suite.T(), "UpdateDelivery", mock.Anything, mock.Anything)
+}
+
+func (suite *WebhookDispatcherSuite) TestRun_StopsWhenTheContextIsDone() {
+	suite.outbox.On("ClaimPending", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	suite.deliveryRepository.On("ClaimDueDelivery", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		suite.dispatcher.Run(ctx, time.Hour)
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		suite.Fail("Run kept going after its context was canceled")
+	}
+}
+
+func TestWebhookDispatcherSuite(t *testing.T) {
+	suite.Run(t, new(WebhookDispatcherSuite))
+}
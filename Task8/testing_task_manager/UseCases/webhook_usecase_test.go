@@ -0,0 +1,100 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "test_task_manager/Domain"
+	infrastructure "test_task_manager/Infrastructure"
+	usecases "test_task_manager/UseCases"
+	mocks "test_task_manager/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookUseCaseSuite struct {
+	suite.Suite
+	webhookRepository  *mocks.WebhookRepository
+	deliveryRepository *mocks.WebhookDeliveryRepository
+	webhookUseCase     domain.WebhookUseCase
+}
+
+func (suite *WebhookUseCaseSuite) SetupTest() {
+	suite.webhookRepository = new(mocks.WebhookRepository)
+	suite.deliveryRepository = new(mocks.WebhookDeliveryRepository)
+
+	suite.webhookUseCase = usecases.NewWebhookUseCase(suite.webhookRepository, suite.deliveryRepository, infrastructure.NewSystemClock(), infrastructure.NewRandomIDGenerator(), 2*time.Second)
+}
+
+func (suite *WebhookUseCaseSuite) TestCreateWebhook_Positive() {
+	webhook := domain.Webhook{
+		URL:    "https://example.com/hooks",
+		Events: []string{domain.EventTaskCreated},
+		Secret: "s3cret",
+	}
+
+	suite.webhookRepository.On("CreateWebhook", mock.Anything, mock.MatchedBy(func(w domain.Webhook) bool {
+		return w.ID != "" && w.Secret == "s3cret" && w.URL == webhook.URL
+	})).Return(nil)
+
+	created, err := suite.webhookUseCase.CreateWebhook(context.Background(), webhook)
+
+	suite.NoError(err)
+	suite.NotEmpty(created.ID)
+	suite.Empty(created.Secret)
+	suite.webhookRepository.AssertExpectations(suite.T())
+}
+
+func (suite *WebhookUseCaseSuite) TestCreateWebhook_Negative_InvalidURL() {
+	webhook := domain.Webhook{URL: "ftp://example.com", Events: []string{"*"}, Secret: "s3cret"}
+
+	created, err := suite.webhookUseCase.CreateWebhook(context.Background(), webhook)
+
+	suite.Nil(created)
+	suite.EqualError(err, "webhook url must be an absolute http or https url")
+}
+
+func (suite *WebhookUseCaseSuite) TestCreateWebhook_Negative_UnknownEvent() {
+	webhook := domain.Webhook{URL: "https://example.com", Events: []string{"task.archived"}, Secret: "s3cret"}
+
+	created, err := suite.webhookUseCase.CreateWebhook(context.Background(), webhook)
+
+	suite.Nil(created)
+	suite.EqualError(err, "unknown event type: task.archived")
+}
+
+func (suite *WebhookUseCaseSuite) TestGetWebhooks_HidesSecrets() {
+	suite.webhookRepository.On("GetWebhooks", mock.Anything).Return([]domain.Webhook{{ID: "1", Secret: "s3cret"}}, nil)
+
+	webhooks, err := suite.webhookUseCase.GetWebhooks(context.Background())
+
+	suite.NoError(err)
+	suite.Len(webhooks, 1)
+	suite.Empty(webhooks[0].Secret)
+}
+
+func (suite *WebhookUseCaseSuite) TestGetDeliveries_Negative_WebhookNotFound() {
+	suite.webhookRepository.On("GetWebhookByID", mock.Anything, "1").Return(nil, errors.New("mongo: no documents in result"))
+
+	deliveries, err := suite.webhookUseCase.GetDeliveries(context.Background(), "1")
+
+	suite.Nil(deliveries)
+	suite.EqualError(err, "webhook not found")
+}
+
+func (suite *WebhookUseCaseSuite) TestGetDeliveries_Positive() {
+	suite.webhookRepository.On("GetWebhookByID", mock.Anything, "1").Return(&domain.Webhook{ID: "1"}, nil)
+	suite.deliveryRepository.On("GetDeliveriesByWebhook", mock.Anything, "1").Return([]domain.WebhookDelivery{{ID: "d1", WebhookID: "1"}}, nil)
+
+	deliveries, err := suite.webhookUseCase.GetDeliveries(context.Background(), "1")
+
+	suite.NoError(err)
+	suite.Len(deliveries, 1)
+}
+
+func TestWebhookUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(WebhookUseCaseSuite))
+}
@@ -0,0 +1,109 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	domain "test_task_manager/Domain"
+	"time"
+)
+
+type webhookUseCase struct {
+	webhookRepository  domain.WebhookRepository
+	deliveryRepository domain.WebhookDeliveryRepository
+	clock              domain.Clock
+	ids                domain.IDGenerator
+	contextTimeout     time.Duration
+}
+
+func NewWebhookUseCase(webhookRepository domain.WebhookRepository, deliveryRepository domain.WebhookDeliveryRepository, clock domain.Clock, ids domain.IDGenerator, timeout time.Duration) domain.WebhookUseCase {
+	return &webhookUseCase{
+		webhookRepository:  webhookRepository,
+		deliveryRepository: deliveryRepository,
+		clock:              clock,
+		ids:                ids,
+		contextTimeout:     timeout,
+	}
+}
+
+func (w *webhookUseCase) CreateWebhook(c context.Context, webhook domain.Webhook) (*domain.Webhook, error) {
+	ctx, cancel := context.WithTimeout(c, w.contextTimeout)
+	defer cancel()
+
+	target, err := url.Parse(webhook.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, errors.New("webhook url must be an absolute http or https url")
+	}
+
+	if len(webhook.Events) == 0 {
+		return nil, errors.New("webhook must subscribe to at least one event")
+	}
+	for _, eventType := range webhook.Events {
+		if !isKnownEventType(eventType) {
+			return nil, errors.New("unknown event type: " + eventType)
+		}
+	}
+
+	if webhook.Secret == "" {
+		return nil, errors.New("webhook secret is required")
+	}
+
+	webhook.ID = w.ids.NewID()
+	webhook.CreatedAt = w.clock.Now().UTC()
+	if err := w.webhookRepository.CreateWebhook(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	webhook.Secret = ""
+	return &webhook, nil
+}
+
+func (w *webhookUseCase) GetWebhooks(c context.Context) ([]domain.Webhook, error) {
+	ctx, cancel := context.WithTimeout(c, w.contextTimeout)
+	defer cancel()
+
+	webhooks, err := w.webhookRepository.GetWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	return webhooks, nil
+}
+
+func (w *webhookUseCase) DeleteWebhook(c context.Context, webhookID string) error {
+	ctx, cancel := context.WithTimeout(c, w.contextTimeout)
+	defer cancel()
+	return w.webhookRepository.DeleteWebhook(ctx, webhookID)
+}
+
+func (w *webhookUseCase) GetDeliveries(c context.Context, webhookID string) ([]domain.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(c, w.contextTimeout)
+	defer cancel()
+
+	if _, err := w.webhookRepository.GetWebhookByID(ctx, webhookID); err != nil {
+		return nil, errors.New("webhook not found")
+	}
+
+	return w.deliveryRepository.GetDeliveriesByWebhook(ctx, webhookID)
+}
+
+func (w *webhookUseCase) GetDeadLetters(c context.Context) ([]domain.WebhookDeadLetter, error) {
+	ctx, cancel := context.WithTimeout(c, w.contextTimeout)
+	defer cancel()
+	return w.deliveryRepository.GetDeadLetters(ctx)
+}
+
+func isKnownEventType(eventType string) bool {
+	if eventType == "*" {
+		return true
+	}
+	for _, known := range domain.EventTypes {
+		if known == eventType {
+			return true
+		}
+	}
+	return false
+}
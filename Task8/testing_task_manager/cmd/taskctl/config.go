@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// config is cached in $XDG_CONFIG_HOME/taskctl/config.json (or TASKCTL_CONFIG). Tokens are kept
+// per server so switching --server does not send one server's token to another.
+type config struct {
+	Server string            `json:"server,omitempty"`
+	Tokens map[string]string `json:"tokens,omitempty"`
+}
+
+func configPath() (string, error) {
+	if path := os.Getenv("TASKCTL_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "taskctl", "config.json"), nil
+}
+
+func loadConfig() (*config, error) {
+	cfg := &config{Tokens: map[string]string{}}
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Tokens == nil {
+		cfg.Tokens = map[string]string{}
+	}
+	return cfg, nil
+}
+
+// save writes the file readable by the owner only, since it holds bearer tokens.
+func (c *config) save() error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
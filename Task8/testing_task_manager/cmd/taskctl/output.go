@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	domain "test_task_manager/Domain"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+var outputFormats = []string{outputTable, outputJSON, outputYAML}
+
+// printTasks writes tasks in the chosen format. JSON and YAML use the API's field names.
+func printTasks(w io.Writer, format string, tasks []domain.Task) error {
+	switch format {
+	case outputTable:
+		table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(table, "ID\tTITLE\tSTATUS\tDUE")
+		for _, task := range tasks {
+			fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", task.ID, task.Title, task.Status, formatDue(task.DueDate))
+		}
+		return table.Flush()
+	case outputJSON, outputYAML:
+		return printValue(w, format, tasks)
+	}
+	return unknownOutput(format)
+}
+
+func printTask(w io.Writer, format string, task domain.Task) error {
+	if format != outputTable {
+		return printValue(w, format, task)
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(table, "ID:\t%s\n", task.ID)
+	fmt.Fprintf(table, "Title:\t%s\n", task.Title)
+	fmt.Fprintf(table, "Description:\t%s\n", task.Description)
+	fmt.Fprintf(table, "Status:\t%s\n", task.Status)
+	fmt.Fprintf(table, "Due:\t%s\n", formatDue(task.DueDate))
+	return table.Flush()
+}
+
+func printValue(w io.Writer, format string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case outputJSON:
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case outputYAML:
+		// Going through JSON keeps the json tags (due_date rather than duedate).
+		var generic interface{}
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return err
+		}
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(generic); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+	return unknownOutput(format)
+}
+
+func unknownOutput(format string) error {
+	return fmt.Errorf("unknown output %q: must be one of %s", format, strings.Join(outputFormats, ", "))
+}
+
+func formatDue(due time.Time) string {
+	if due.IsZero() {
+		return "-"
+	}
+	return due.Format(time.RFC3339)
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	client "test_task_manager/Client"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const defaultServer = "http://localhost:8080"
+
+// app holds what every command shares: the resolved server, the output format and the cached
+// tokens.
+type app struct {
+	server string
+	org    string
+	output string
+	cfg    *config
+	in     *bufio.Reader
+	out    io.Writer
+}
+
+func newRootCommand() *cobra.Command {
+	a := &app{in: bufio.NewReader(os.Stdin), out: os.Stdout}
+
+	root := &cobra.Command{
+		Use:           "taskctl",
+		Short:         "Manage tasks and users through the task manager API",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			a.out = cmd.OutOrStdout()
+			return a.init()
+		},
+	}
+	root.PersistentFlags().StringVar(&a.server, "server", "", "API base URL (default $TASKCTL_SERVER, then the last server logged in to, then "+defaultServer+")")
+	root.PersistentFlags().StringVar(&a.org, "org", os.Getenv("TASKCTL_ORG"), "organization to act in (default $TASKCTL_ORG, then the token's default organization)")
+	root.PersistentFlags().StringVarP(&a.output, "output", "o", outputTable, "output format: "+strings.Join(outputFormats, ", "))
+	root.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(outputFormats, cobra.ShellCompDirectiveNoFileComp))
+
+	root.AddCommand(newLoginCommand(a), newLogoutCommand(a), newTasksCommand(a), newUsersCommand(a))
+	return root
+}
+
+// init is safe to call more than once. Completion functions call it themselves because cobra
+// does not run PersistentPreRunE while completing.
+func (a *app) init() error {
+	if a.cfg != nil {
+		return nil
+	}
+	if !slices.Contains(outputFormats, a.output) {
+		return unknownOutput(a.output)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("reading config: %w", err)
+	}
+	a.cfg = cfg
+
+	switch {
+	case a.server != "":
+	case os.Getenv("TASKCTL_SERVER") != "":
+		a.server = os.Getenv("TASKCTL_SERVER")
+	case cfg.Server != "":
+		a.server = cfg.Server
+	default:
+		a.server = defaultServer
+	}
+	a.server = strings.TrimRight(a.server, "/")
+	return nil
+}
+
+func (a *app) client() *client.Client {
+	c := client.NewClient(a.server, a.cfg.Tokens[a.server])
+	c.OrgID = a.org
+	return c
+}
+
+func newLoginCommand(a *app) *cobra.Command {
+	var username, password, code string
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Log in and cache the token for the server",
+		Long:  "Log in and cache the token for the server. The password is read from --password, $TASKCTL_PASSWORD or the terminal, in that order. Accounts with two-factor authentication also need a code from --code or the terminal.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			if username == "" {
+				if username, err = a.prompt("Username: ", false); err != nil {
+					return err
+				}
+			}
+			if password == "" {
+				password = os.Getenv("TASKCTL_PASSWORD")
+			}
+			if password == "" {
+				if password, err = a.prompt("Password: ", true); err != nil {
+					return err
+				}
+			}
+
+			token, err := a.client().Login(cmd.Context(), username, password)
+			var mfaErr *client.MFARequiredError
+			if errors.As(err, &mfaErr) {
+				if code == "" {
+					if code, err = a.prompt("Authentication code: ", false); err != nil {
+						return err
+					}
+				}
+				token, err = a.client().VerifyMFA(cmd.Context(), mfaErr.MFAToken, code)
+			}
+			if err != nil {
+				return err
+			}
+
+			a.cfg.Server = a.server
+			a.cfg.Tokens[a.server] = token
+			if err := a.cfg.save(); err != nil {
+				return fmt.Errorf("caching token: %w", err)
+			}
+			fmt.Fprintf(a.out, "Logged in to %s as %s\n", a.server, username)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&username, "username", "u", "", "username")
+	cmd.Flags().StringVarP(&password, "password", "p", "", "password")
+	cmd.Flags().StringVar(&code, "code", "", "two-factor authentication or recovery code, prompted for when needed")
+	return cmd
+}
+
+func newLogoutCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "logout",
+		Short: "Forget the cached token for the server",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			delete(a.cfg.Tokens, a.server)
+			return a.cfg.save()
+		},
+	}
+}
+
+// prompt reads a line from the terminal, without echo when secret is set.
+func (a *app) prompt(label string, secret bool) (string, error) {
+	fmt.Fprint(os.Stderr, label)
+	if secret && term.IsTerminal(int(os.Stdin.Fd())) {
+		value, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		return string(value), err
+	}
+
+	line, err := a.in.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
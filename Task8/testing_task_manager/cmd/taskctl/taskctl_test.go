@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	domain "test_task_manager/Domain"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TaskctlTestSuite struct {
+	suite.Suite
+	server     *httptest.Server
+	configPath string
+}
+
+func (suite *TaskctlTestSuite) SetupTest() {
+	suite.configPath = filepath.Join(suite.T().TempDir(), "config.json")
+	suite.T().Setenv("TASKCTL_CONFIG", suite.configPath)
+	suite.T().Setenv("TASKCTL_SERVER", "")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/login", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"token": "jwt"}`))
+	})
+	mux.HandleFunc("GET /v1/tasks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer jwt" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": "Authorization header is required"}`))
+			return
+		}
+		json.NewEncoder(w).Encode([]domain.Task{
+			{ID: "1", Title: "Write report", Status: "Pending", DueDate: time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)},
+			{ID: "2", Title: "Review report", Status: "Completed", DueDate: time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)},
+			{ID: "3", Title: "Plan offsite", Status: "Pending"},
+		})
+	})
+	suite.server = httptest.NewServer(mux)
+}
+
+func (suite *TaskctlTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *TaskctlTestSuite) run(args ...string) (string, error) {
+	var out bytes.Buffer
+	cmd := newRootCommand()
+	cmd.SetOut(&out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func (suite *TaskctlTestSuite) TestLoginCachesTokenForServer() {
+	out, err := suite.run("login", "--server", suite.server.URL, "-u", "alice", "-p", "secret")
+	suite.Require().NoError(err)
+	suite.Contains(out, "Logged in to "+suite.server.URL+" as alice")
+
+	info, err := os.Stat(suite.configPath)
+	suite.Require().NoError(err)
+	suite.Equal(os.FileMode(0o600), info.Mode().Perm())
+
+	// The server is remembered, so later commands need neither --server nor a token.
+	out, err = suite.run("tasks", "list", "--status", "pending", "--title", "REPORT")
+	suite.Require().NoError(err)
+	suite.Contains(out, "Write report")
+	suite.NotContains(out, "Review report")
+	suite.NotContains(out, "Plan offsite")
+}
+
+func (suite *TaskctlTestSuite) TestListWithoutLogin() {
+	_, err := suite.run("tasks", "list", "--server", suite.server.URL)
+
+	suite.EqualError(err, "401 Unauthorized: Authorization header is required")
+}
+
+func (suite *TaskctlTestSuite) TestListAsYAML() {
+	_, err := suite.run("login", "--server", suite.server.URL, "-u", "alice", "-p", "secret")
+	suite.Require().NoError(err)
+
+	out, err := suite.run("tasks", "list", "-o", "yaml", "--due-before", "2024-08-15")
+
+	suite.Require().NoError(err)
+	suite.Equal("- description: \"\"\n  due_date: \"2024-08-01T00:00:00Z\"\n  id: \"1\"\n  status: Pending\n  title: Write report\n", out)
+}
+
+func (suite *TaskctlTestSuite) TestUnknownOutput() {
+	_, err := suite.run("tasks", "list", "-o", "xml")
+
+	suite.EqualError(err, `unknown output "xml": must be one of table, json, yaml`)
+}
+
+func (suite *TaskctlTestSuite) TestUpdateNeedsAField() {
+	_, err := suite.run("tasks", "update", "1", "--server", suite.server.URL)
+
+	suite.ErrorContains(err, "nothing to update")
+}
+
+func TestTaskctl(t *testing.T) {
+	suite.Run(t, new(TaskctlTestSuite))
+}
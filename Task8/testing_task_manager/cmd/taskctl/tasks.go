@@ -0,0 +1,245 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	domain "test_task_manager/Domain"
+
+	"github.com/spf13/cobra"
+)
+
+var taskStatuses = []string{"Pending", "In Progress", "Completed", "Cancelled"}
+
+// taskFilter narrows `tasks list` on the client side; the API has no filter parameters.
+type taskFilter struct {
+	status    string
+	title     string
+	dueBefore time.Time
+	dueAfter  time.Time
+}
+
+func (f taskFilter) match(task domain.Task) bool {
+	if f.status != "" && !strings.EqualFold(task.Status, f.status) {
+		return false
+	}
+	if f.title != "" && !strings.Contains(strings.ToLower(task.Title), strings.ToLower(f.title)) {
+		return false
+	}
+	if !f.dueBefore.IsZero() && (task.DueDate.IsZero() || !task.DueDate.Before(f.dueBefore)) {
+		return false
+	}
+	if !f.dueAfter.IsZero() && !task.DueDate.After(f.dueAfter) {
+		return false
+	}
+	return true
+}
+
+func filterTasks(tasks []domain.Task, filter taskFilter) []domain.Task {
+	matched := []domain.Task{}
+	for _, task := range tasks {
+		if filter.match(task) {
+			matched = append(matched, task)
+		}
+	}
+	return matched
+}
+
+// parseDue accepts an RFC 3339 timestamp or a plain date, read as midnight UTC.
+func parseDue(value string) (time.Time, error) {
+	if due, err := time.Parse(time.RFC3339, value); err == nil {
+		return due, nil
+	}
+	if due, err := time.Parse(time.DateOnly, value); err == nil {
+		return due, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q: use YYYY-MM-DD or an RFC 3339 timestamp", value)
+}
+
+func newTasksCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "tasks",
+		Aliases: []string{"task"},
+		Short:   "List, show, create, update and delete tasks",
+	}
+	cmd.AddCommand(newTasksListCommand(a), newTasksGetCommand(a), newTasksCreateCommand(a), newTasksUpdateCommand(a), newTasksDeleteCommand(a))
+	return cmd
+}
+
+func newTasksListCommand(a *app) *cobra.Command {
+	var filter taskFilter
+	var dueBefore, dueAfter string
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List tasks",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			if dueBefore != "" {
+				if filter.dueBefore, err = parseDue(dueBefore); err != nil {
+					return err
+				}
+			}
+			if dueAfter != "" {
+				if filter.dueAfter, err = parseDue(dueAfter); err != nil {
+					return err
+				}
+			}
+
+			tasks, err := a.client().GetTasks(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return printTasks(a.out, a.output, filterTasks(tasks, filter))
+		},
+	}
+	cmd.Flags().StringVar(&filter.status, "status", "", "only tasks with this status")
+	cmd.Flags().StringVar(&filter.title, "title", "", "only tasks whose title contains this text")
+	cmd.Flags().StringVar(&dueBefore, "due-before", "", "only tasks due before this date")
+	cmd.Flags().StringVar(&dueAfter, "due-after", "", "only tasks due after this date")
+	cmd.RegisterFlagCompletionFunc("status", cobra.FixedCompletions(taskStatuses, cobra.ShellCompDirectiveNoFileComp))
+	return cmd
+}
+
+func newTasksGetCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:               "get ID",
+		Short:             "Show a task",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: a.completeTaskIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			task, err := a.client().GetTask(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return printTask(a.out, a.output, *task)
+		},
+	}
+}
+
+// taskFlags are the editable fields shared by create and update.
+type taskFlags struct {
+	title       string
+	description string
+	status      string
+	due         string
+}
+
+func (f *taskFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.title, "title", "", "task title")
+	cmd.Flags().StringVar(&f.description, "description", "", "task description")
+	cmd.Flags().StringVar(&f.status, "status", "", "task status")
+	cmd.Flags().StringVar(&f.due, "due", "", "due date, YYYY-MM-DD or RFC 3339")
+	cmd.RegisterFlagCompletionFunc("status", cobra.FixedCompletions(taskStatuses, cobra.ShellCompDirectiveNoFileComp))
+}
+
+func (f *taskFlags) task(id string) (domain.Task, error) {
+	task := domain.Task{ID: id, Title: f.title, Description: f.description, Status: f.status}
+	if f.due != "" {
+		due, err := parseDue(f.due)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		task.DueDate = due
+	}
+	return task, nil
+}
+
+func newTasksCreateCommand(a *app) *cobra.Command {
+	var id string
+	var flags taskFlags
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a task (Admin)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			task, err := flags.task(id)
+			if err != nil {
+				return err
+			}
+			if task.Status == "" {
+				task.Status = "Pending"
+			}
+			created, err := a.client().CreateTask(cmd.Context(), task)
+			if err != nil {
+				return err
+			}
+			return printTask(a.out, a.output, *created)
+		},
+	}
+	cmd.Flags().StringVar(&id, "id", "", "task ID")
+	flags.register(cmd)
+	cmd.MarkFlagRequired("id")
+	cmd.MarkFlagRequired("title")
+	return cmd
+}
+
+func newTasksUpdateCommand(a *app) *cobra.Command {
+	var flags taskFlags
+
+	cmd := &cobra.Command{
+		Use:               "update ID",
+		Short:             "Change some fields of a task (Admin)",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: a.completeTaskIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("title") && !cmd.Flags().Changed("description") && !cmd.Flags().Changed("status") && !cmd.Flags().Changed("due") {
+				return errors.New("nothing to update: set at least one of --title, --description, --status or --due")
+			}
+			task, err := flags.task(args[0])
+			if err != nil {
+				return err
+			}
+			updated, err := a.client().UpdateTask(cmd.Context(), args[0], task)
+			if err != nil {
+				return err
+			}
+			return printTask(a.out, a.output, *updated)
+		},
+	}
+	flags.register(cmd)
+	return cmd
+}
+
+func newTasksDeleteCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:               "delete ID...",
+		Aliases:           []string{"rm"},
+		Short:             "Move tasks to the trash (Admin)",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: a.completeTaskIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, id := range args {
+				if err := a.client().DeleteTask(cmd.Context(), id); err != nil {
+					return fmt.Errorf("deleting %s: %w", id, err)
+				}
+				fmt.Fprintf(a.out, "Deleted %s\n", id)
+			}
+			return nil
+		},
+	}
+}
+
+// completeTaskIDs offers the IDs of existing tasks, described by their titles.
+func (a *app) completeTaskIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if err := a.init(); err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	tasks, err := a.client().GetTasks(cmd.Context())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var ids []string
+	for _, task := range tasks {
+		if strings.HasPrefix(task.ID, toComplete) {
+			ids = append(ids, task.ID+"\t"+task.Title)
+		}
+	}
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newUsersCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "users",
+		Aliases: []string{"user"},
+		Short:   "Manage users",
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "promote USERNAME",
+		Short: "Make a user an Admin (Admin)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := a.client().PromoteUser(cmd.Context(), args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(a.out, "Promoted %s to Admin\n", args[0])
+			return nil
+		},
+	})
+	return cmd
+}
@@ -0,0 +1,465 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "test_task_manager/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// APIKeyRepository is an autogenerated mock type for the APIKeyRepository type
+type APIKeyRepository struct {
+	mock.Mock
+}
+
+type APIKeyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *APIKeyRepository) EXPECT() *APIKeyRepository_Expecter {
+	return &APIKeyRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateAPIKey provides a mock function with given fields: c, key
+func (_m *APIKeyRepository) CreateAPIKey(c context.Context, key domain.APIKey) error {
+	ret := _m.Called(c, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAPIKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.APIKey) error); ok {
+		r0 = rf(c, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// APIKeyRepository_CreateAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAPIKey'
+type APIKeyRepository_CreateAPIKey_Call struct {
+	*mock.Call
+}
+
+// CreateAPIKey is a helper method to define mock.On call
+//   - c context.Context
+//   - key domain.APIKey
+func (_e *APIKeyRepository_Expecter) CreateAPIKey(c interface{}, key interface{}) *APIKeyRepository_CreateAPIKey_Call {
+	return &APIKeyRepository_CreateAPIKey_Call{Call: _e.mock.On("CreateAPIKey", c, key)}
+}
+
+func (_c *APIKeyRepository_CreateAPIKey_Call) Run(run func(c context.Context, key domain.APIKey)) *APIKeyRepository_CreateAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.APIKey))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_CreateAPIKey_Call) Return(_a0 error) *APIKeyRepository_CreateAPIKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *APIKeyRepository_CreateAPIKey_Call) RunAndReturn(run func(context.Context, domain.APIKey) error) *APIKeyRepository_CreateAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateServiceAccount provides a mock function with given fields: c, account
+func (_m *APIKeyRepository) CreateServiceAccount(c context.Context, account domain.ServiceAccount) error {
+	ret := _m.Called(c, account)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateServiceAccount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ServiceAccount) error); ok {
+		r0 = rf(c, account)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// APIKeyRepository_CreateServiceAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateServiceAccount'
+type APIKeyRepository_CreateServiceAccount_Call struct {
+	*mock.Call
+}
+
+// CreateServiceAccount is a helper method to define mock.On call
+//   - c context.Context
+//   - account domain.ServiceAccount
+func (_e *APIKeyRepository_Expecter) CreateServiceAccount(c interface{}, account interface{}) *APIKeyRepository_CreateServiceAccount_Call {
+	return &APIKeyRepository_CreateServiceAccount_Call{Call: _e.mock.On("CreateServiceAccount", c, account)}
+}
+
+func (_c *APIKeyRepository_CreateServiceAccount_Call) Run(run func(c context.Context, account domain.ServiceAccount)) *APIKeyRepository_CreateServiceAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ServiceAccount))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_CreateServiceAccount_Call) Return(_a0 error) *APIKeyRepository_CreateServiceAccount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *APIKeyRepository_CreateServiceAccount_Call) RunAndReturn(run func(context.Context, domain.ServiceAccount) error) *APIKeyRepository_CreateServiceAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindAPIKey provides a mock function with given fields: c, keyID
+func (_m *APIKeyRepository) FindAPIKey(c context.Context, keyID string) (*domain.APIKey, error) {
+	ret := _m.Called(c, keyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAPIKey")
+	}
+
+	var r0 *domain.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.APIKey, error)); ok {
+		return rf(c, keyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.APIKey); ok {
+		r0 = rf(c, keyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, keyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyRepository_FindAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindAPIKey'
+type APIKeyRepository_FindAPIKey_Call struct {
+	*mock.Call
+}
+
+// FindAPIKey is a helper method to define mock.On call
+//   - c context.Context
+//   - keyID string
+func (_e *APIKeyRepository_Expecter) FindAPIKey(c interface{}, keyID interface{}) *APIKeyRepository_FindAPIKey_Call {
+	return &APIKeyRepository_FindAPIKey_Call{Call: _e.mock.On("FindAPIKey", c, keyID)}
+}
+
+func (_c *APIKeyRepository_FindAPIKey_Call) Run(run func(c context.Context, keyID string)) *APIKeyRepository_FindAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_FindAPIKey_Call) Return(_a0 *domain.APIKey, _a1 error) *APIKeyRepository_FindAPIKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyRepository_FindAPIKey_Call) RunAndReturn(run func(context.Context, string) (*domain.APIKey, error)) *APIKeyRepository_FindAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAPIKeys provides a mock function with given fields: c, serviceAccountID
+func (_m *APIKeyRepository) GetAPIKeys(c context.Context, serviceAccountID string) ([]domain.APIKey, error) {
+	ret := _m.Called(c, serviceAccountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAPIKeys")
+	}
+
+	var r0 []domain.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.APIKey, error)); ok {
+		return rf(c, serviceAccountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.APIKey); ok {
+		r0 = rf(c, serviceAccountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, serviceAccountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyRepository_GetAPIKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAPIKeys'
+type APIKeyRepository_GetAPIKeys_Call struct {
+	*mock.Call
+}
+
+// GetAPIKeys is a helper method to define mock.On call
+//   - c context.Context
+//   - serviceAccountID string
+func (_e *APIKeyRepository_Expecter) GetAPIKeys(c interface{}, serviceAccountID interface{}) *APIKeyRepository_GetAPIKeys_Call {
+	return &APIKeyRepository_GetAPIKeys_Call{Call: _e.mock.On("GetAPIKeys", c, serviceAccountID)}
+}
+
+func (_c *APIKeyRepository_GetAPIKeys_Call) Run(run func(c context.Context, serviceAccountID string)) *APIKeyRepository_GetAPIKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_GetAPIKeys_Call) Return(_a0 []domain.APIKey, _a1 error) *APIKeyRepository_GetAPIKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyRepository_GetAPIKeys_Call) RunAndReturn(run func(context.Context, string) ([]domain.APIKey, error)) *APIKeyRepository_GetAPIKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetServiceAccountByID provides a mock function with given fields: c, accountID
+func (_m *APIKeyRepository) GetServiceAccountByID(c context.Context, accountID string) (*domain.ServiceAccount, error) {
+	ret := _m.Called(c, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetServiceAccountByID")
+	}
+
+	var r0 *domain.ServiceAccount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.ServiceAccount, error)); ok {
+		return rf(c, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.ServiceAccount); ok {
+		r0 = rf(c, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ServiceAccount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyRepository_GetServiceAccountByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServiceAccountByID'
+type APIKeyRepository_GetServiceAccountByID_Call struct {
+	*mock.Call
+}
+
+// GetServiceAccountByID is a helper method to define mock.On call
+//   - c context.Context
+//   - accountID string
+func (_e *APIKeyRepository_Expecter) GetServiceAccountByID(c interface{}, accountID interface{}) *APIKeyRepository_GetServiceAccountByID_Call {
+	return &APIKeyRepository_GetServiceAccountByID_Call{Call: _e.mock.On("GetServiceAccountByID", c, accountID)}
+}
+
+func (_c *APIKeyRepository_GetServiceAccountByID_Call) Run(run func(c context.Context, accountID string)) *APIKeyRepository_GetServiceAccountByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_GetServiceAccountByID_Call) Return(_a0 *domain.ServiceAccount, _a1 error) *APIKeyRepository_GetServiceAccountByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyRepository_GetServiceAccountByID_Call) RunAndReturn(run func(context.Context, string) (*domain.ServiceAccount, error)) *APIKeyRepository_GetServiceAccountByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetServiceAccounts provides a mock function with given fields: c
+func (_m *APIKeyRepository) GetServiceAccounts(c context.Context) ([]domain.ServiceAccount, error) {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetServiceAccounts")
+	}
+
+	var r0 []domain.ServiceAccount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.ServiceAccount, error)); ok {
+		return rf(c)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.ServiceAccount); ok {
+		r0 = rf(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ServiceAccount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyRepository_GetServiceAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServiceAccounts'
+type APIKeyRepository_GetServiceAccounts_Call struct {
+	*mock.Call
+}
+
+// GetServiceAccounts is a helper method to define mock.On call
+//   - c context.Context
+func (_e *APIKeyRepository_Expecter) GetServiceAccounts(c interface{}) *APIKeyRepository_GetServiceAccounts_Call {
+	return &APIKeyRepository_GetServiceAccounts_Call{Call: _e.mock.On("GetServiceAccounts", c)}
+}
+
+func (_c *APIKeyRepository_GetServiceAccounts_Call) Run(run func(c context.Context)) *APIKeyRepository_GetServiceAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_GetServiceAccounts_Call) Return(_a0 []domain.ServiceAccount, _a1 error) *APIKeyRepository_GetServiceAccounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyRepository_GetServiceAccounts_Call) RunAndReturn(run func(context.Context) ([]domain.ServiceAccount, error)) *APIKeyRepository_GetServiceAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeAPIKey provides a mock function with given fields: c, serviceAccountID, keyID, at
+func (_m *APIKeyRepository) RevokeAPIKey(c context.Context, serviceAccountID string, keyID string, at time.Time) error {
+	ret := _m.Called(c, serviceAccountID, keyID, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAPIKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(c, serviceAccountID, keyID, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// APIKeyRepository_RevokeAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAPIKey'
+type APIKeyRepository_RevokeAPIKey_Call struct {
+	*mock.Call
+}
+
+// RevokeAPIKey is a helper method to define mock.On call
+//   - c context.Context
+//   - serviceAccountID string
+//   - keyID string
+//   - at time.Time
+func (_e *APIKeyRepository_Expecter) RevokeAPIKey(c interface{}, serviceAccountID interface{}, keyID interface{}, at interface{}) *APIKeyRepository_RevokeAPIKey_Call {
+	return &APIKeyRepository_RevokeAPIKey_Call{Call: _e.mock.On("RevokeAPIKey", c, serviceAccountID, keyID, at)}
+}
+
+func (_c *APIKeyRepository_RevokeAPIKey_Call) Run(run func(c context.Context, serviceAccountID string, keyID string, at time.Time)) *APIKeyRepository_RevokeAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_RevokeAPIKey_Call) Return(_a0 error) *APIKeyRepository_RevokeAPIKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *APIKeyRepository_RevokeAPIKey_Call) RunAndReturn(run func(context.Context, string, string, time.Time) error) *APIKeyRepository_RevokeAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchAPIKey provides a mock function with given fields: c, keyID, at
+func (_m *APIKeyRepository) TouchAPIKey(c context.Context, keyID string, at time.Time) error {
+	ret := _m.Called(c, keyID, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchAPIKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(c, keyID, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// APIKeyRepository_TouchAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchAPIKey'
+type APIKeyRepository_TouchAPIKey_Call struct {
+	*mock.Call
+}
+
+// TouchAPIKey is a helper method to define mock.On call
+//   - c context.Context
+//   - keyID string
+//   - at time.Time
+func (_e *APIKeyRepository_Expecter) TouchAPIKey(c interface{}, keyID interface{}, at interface{}) *APIKeyRepository_TouchAPIKey_Call {
+	return &APIKeyRepository_TouchAPIKey_Call{Call: _e.mock.On("TouchAPIKey", c, keyID, at)}
+}
+
+func (_c *APIKeyRepository_TouchAPIKey_Call) Run(run func(c context.Context, keyID string, at time.Time)) *APIKeyRepository_TouchAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_TouchAPIKey_Call) Return(_a0 error) *APIKeyRepository_TouchAPIKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *APIKeyRepository_TouchAPIKey_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *APIKeyRepository_TouchAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAPIKeyRepository creates a new instance of APIKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAPIKeyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *APIKeyRepository {
+	mock := &APIKeyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
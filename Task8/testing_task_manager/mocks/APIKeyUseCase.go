@@ -0,0 +1,391 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "test_task_manager/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// APIKeyUseCase is an autogenerated mock type for the APIKeyUseCase type
+type APIKeyUseCase struct {
+	mock.Mock
+}
+
+type APIKeyUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *APIKeyUseCase) EXPECT() *APIKeyUseCase_Expecter {
+	return &APIKeyUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Authenticate provides a mock function with given fields: c, key
+func (_m *APIKeyUseCase) Authenticate(c context.Context, key string) (*domain.APIKey, error) {
+	ret := _m.Called(c, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 *domain.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.APIKey, error)); ok {
+		return rf(c, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.APIKey); ok {
+		r0 = rf(c, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyUseCase_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type APIKeyUseCase_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - c context.Context
+//   - key string
+func (_e *APIKeyUseCase_Expecter) Authenticate(c interface{}, key interface{}) *APIKeyUseCase_Authenticate_Call {
+	return &APIKeyUseCase_Authenticate_Call{Call: _e.mock.On("Authenticate", c, key)}
+}
+
+func (_c *APIKeyUseCase_Authenticate_Call) Run(run func(c context.Context, key string)) *APIKeyUseCase_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *APIKeyUseCase_Authenticate_Call) Return(_a0 *domain.APIKey, _a1 error) *APIKeyUseCase_Authenticate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyUseCase_Authenticate_Call) RunAndReturn(run func(context.Context, string) (*domain.APIKey, error)) *APIKeyUseCase_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateAPIKey provides a mock function with given fields: c, serviceAccountID, scopes, expiresAt
+func (_m *APIKeyUseCase) CreateAPIKey(c context.Context, serviceAccountID string, scopes []string, expiresAt *time.Time) (*domain.APIKey, string, error) {
+	ret := _m.Called(c, serviceAccountID, scopes, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAPIKey")
+	}
+
+	var r0 *domain.APIKey
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, *time.Time) (*domain.APIKey, string, error)); ok {
+		return rf(c, serviceAccountID, scopes, expiresAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, *time.Time) *domain.APIKey); ok {
+		r0 = rf(c, serviceAccountID, scopes, expiresAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, *time.Time) string); ok {
+		r1 = rf(c, serviceAccountID, scopes, expiresAt)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, []string, *time.Time) error); ok {
+		r2 = rf(c, serviceAccountID, scopes, expiresAt)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// APIKeyUseCase_CreateAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAPIKey'
+type APIKeyUseCase_CreateAPIKey_Call struct {
+	*mock.Call
+}
+
+// CreateAPIKey is a helper method to define mock.On call
+//   - c context.Context
+//   - serviceAccountID string
+//   - scopes []string
+//   - expiresAt *time.Time
+func (_e *APIKeyUseCase_Expecter) CreateAPIKey(c interface{}, serviceAccountID interface{}, scopes interface{}, expiresAt interface{}) *APIKeyUseCase_CreateAPIKey_Call {
+	return &APIKeyUseCase_CreateAPIKey_Call{Call: _e.mock.On("CreateAPIKey", c, serviceAccountID, scopes, expiresAt)}
+}
+
+func (_c *APIKeyUseCase_CreateAPIKey_Call) Run(run func(c context.Context, serviceAccountID string, scopes []string, expiresAt *time.Time)) *APIKeyUseCase_CreateAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string), args[3].(*time.Time))
+	})
+	return _c
+}
+
+func (_c *APIKeyUseCase_CreateAPIKey_Call) Return(_a0 *domain.APIKey, _a1 string, _a2 error) *APIKeyUseCase_CreateAPIKey_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *APIKeyUseCase_CreateAPIKey_Call) RunAndReturn(run func(context.Context, string, []string, *time.Time) (*domain.APIKey, string, error)) *APIKeyUseCase_CreateAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateServiceAccount provides a mock function with given fields: c, name, createdBy
+func (_m *APIKeyUseCase) CreateServiceAccount(c context.Context, name string, createdBy string) (*domain.ServiceAccount, error) {
+	ret := _m.Called(c, name, createdBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateServiceAccount")
+	}
+
+	var r0 *domain.ServiceAccount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.ServiceAccount, error)); ok {
+		return rf(c, name, createdBy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.ServiceAccount); ok {
+		r0 = rf(c, name, createdBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ServiceAccount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, name, createdBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyUseCase_CreateServiceAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateServiceAccount'
+type APIKeyUseCase_CreateServiceAccount_Call struct {
+	*mock.Call
+}
+
+// CreateServiceAccount is a helper method to define mock.On call
+//   - c context.Context
+//   - name string
+//   - createdBy string
+func (_e *APIKeyUseCase_Expecter) CreateServiceAccount(c interface{}, name interface{}, createdBy interface{}) *APIKeyUseCase_CreateServiceAccount_Call {
+	return &APIKeyUseCase_CreateServiceAccount_Call{Call: _e.mock.On("CreateServiceAccount", c, name, createdBy)}
+}
+
+func (_c *APIKeyUseCase_CreateServiceAccount_Call) Run(run func(c context.Context, name string, createdBy string)) *APIKeyUseCase_CreateServiceAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *APIKeyUseCase_CreateServiceAccount_Call) Return(_a0 *domain.ServiceAccount, _a1 error) *APIKeyUseCase_CreateServiceAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyUseCase_CreateServiceAccount_Call) RunAndReturn(run func(context.Context, string, string) (*domain.ServiceAccount, error)) *APIKeyUseCase_CreateServiceAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAPIKeys provides a mock function with given fields: c, serviceAccountID
+func (_m *APIKeyUseCase) GetAPIKeys(c context.Context, serviceAccountID string) ([]domain.APIKey, error) {
+	ret := _m.Called(c, serviceAccountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAPIKeys")
+	}
+
+	var r0 []domain.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.APIKey, error)); ok {
+		return rf(c, serviceAccountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.APIKey); ok {
+		r0 = rf(c, serviceAccountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, serviceAccountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyUseCase_GetAPIKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAPIKeys'
+type APIKeyUseCase_GetAPIKeys_Call struct {
+	*mock.Call
+}
+
+// GetAPIKeys is a helper method to define mock.On call
+//   - c context.Context
+//   - serviceAccountID string
+func (_e *APIKeyUseCase_Expecter) GetAPIKeys(c interface{}, serviceAccountID interface{}) *APIKeyUseCase_GetAPIKeys_Call {
+	return &APIKeyUseCase_GetAPIKeys_Call{Call: _e.mock.On("GetAPIKeys", c, serviceAccountID)}
+}
+
+func (_c *APIKeyUseCase_GetAPIKeys_Call) Run(run func(c context.Context, serviceAccountID string)) *APIKeyUseCase_GetAPIKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *APIKeyUseCase_GetAPIKeys_Call) Return(_a0 []domain.APIKey, _a1 error) *APIKeyUseCase_GetAPIKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyUseCase_GetAPIKeys_Call) RunAndReturn(run func(context.Context, string) ([]domain.APIKey, error)) *APIKeyUseCase_GetAPIKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetServiceAccounts provides a mock function with given fields: c
+func (_m *APIKeyUseCase) GetServiceAccounts(c context.Context) ([]domain.ServiceAccount, error) {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetServiceAccounts")
+	}
+
+	var r0 []domain.ServiceAccount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.ServiceAccount, error)); ok {
+		return rf(c)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.ServiceAccount); ok {
+		r0 = rf(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ServiceAccount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyUseCase_GetServiceAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServiceAccounts'
+type APIKeyUseCase_GetServiceAccounts_Call struct {
+	*mock.Call
+}
+
+// GetServiceAccounts is a helper method to define mock.On call
+//   - c context.Context
+func (_e *APIKeyUseCase_Expecter) GetServiceAccounts(c interface{}) *APIKeyUseCase_GetServiceAccounts_Call {
+	return &APIKeyUseCase_GetServiceAccounts_Call{Call: _e.mock.On("GetServiceAccounts", c)}
+}
+
+func (_c *APIKeyUseCase_GetServiceAccounts_Call) Run(run func(c context.Context)) *APIKeyUseCase_GetServiceAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *APIKeyUseCase_GetServiceAccounts_Call) Return(_a0 []domain.ServiceAccount, _a1 error) *APIKeyUseCase_GetServiceAccounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyUseCase_GetServiceAccounts_Call) RunAndReturn(run func(context.Context) ([]domain.ServiceAccount, error)) *APIKeyUseCase_GetServiceAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeAPIKey provides a mock function with given fields: c, serviceAccountID, keyID
+func (_m *APIKeyUseCase) RevokeAPIKey(c context.Context, serviceAccountID string, keyID string) error {
+	ret := _m.Called(c, serviceAccountID, keyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAPIKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, serviceAccountID, keyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// APIKeyUseCase_RevokeAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAPIKey'
+type APIKeyUseCase_RevokeAPIKey_Call struct {
+	*mock.Call
+}
+
+// RevokeAPIKey is a helper method to define mock.On call
+//   - c context.Context
+//   - serviceAccountID string
+//   - keyID string
+func (_e *APIKeyUseCase_Expecter) RevokeAPIKey(c interface{}, serviceAccountID interface{}, keyID interface{}) *APIKeyUseCase_RevokeAPIKey_Call {
+	return &APIKeyUseCase_RevokeAPIKey_Call{Call: _e.mock.On("RevokeAPIKey", c, serviceAccountID, keyID)}
+}
+
+func (_c *APIKeyUseCase_RevokeAPIKey_Call) Run(run func(c context.Context, serviceAccountID string, keyID string)) *APIKeyUseCase_RevokeAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *APIKeyUseCase_RevokeAPIKey_Call) Return(_a0 error) *APIKeyUseCase_RevokeAPIKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *APIKeyUseCase_RevokeAPIKey_Call) RunAndReturn(run func(context.Context, string, string) error) *APIKeyUseCase_RevokeAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAPIKeyUseCase creates a new instance of APIKeyUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAPIKeyUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *APIKeyUseCase {
+	mock := &APIKeyUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
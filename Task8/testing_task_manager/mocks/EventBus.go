@@ -0,0 +1,138 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "test_task_manager/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EventBus is an autogenerated mock type for the EventBus type
+type EventBus struct {
+	mock.Mock
+}
+
+type EventBus_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *EventBus) EXPECT() *EventBus_Expecter {
+	return &EventBus_Expecter{mock: &_m.Mock}
+}
+
+// Publish provides a mock function with given fields: event
+func (_m *EventBus) Publish(event domain.Event) {
+	_m.Called(event)
+}
+
+// EventBus_Publish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Publish'
+type EventBus_Publish_Call struct {
+	*mock.Call
+}
+
+// Publish is a helper method to define mock.On call
+//   - event domain.Event
+func (_e *EventBus_Expecter) Publish(event interface{}) *EventBus_Publish_Call {
+	return &EventBus_Publish_Call{Call: _e.mock.On("Publish", event)}
+}
+
+func (_c *EventBus_Publish_Call) Run(run func(event domain.Event)) *EventBus_Publish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.Event))
+	})
+	return _c
+}
+
+func (_c *EventBus_Publish_Call) Return() *EventBus_Publish_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *EventBus_Publish_Call) RunAndReturn(run func(domain.Event)) *EventBus_Publish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Subscribe provides a mock function with given fields: lastSequence
+func (_m *EventBus) Subscribe(lastSequence uint64) ([]domain.StreamEvent, <-chan domain.StreamEvent, func()) {
+	ret := _m.Called(lastSequence)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 []domain.StreamEvent
+	var r1 <-chan domain.StreamEvent
+	var r2 func()
+	if rf, ok := ret.Get(0).(func(uint64) ([]domain.StreamEvent, <-chan domain.StreamEvent, func())); ok {
+		return rf(lastSequence)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) []domain.StreamEvent); ok {
+		r0 = rf(lastSequence)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.StreamEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) <-chan domain.StreamEvent); ok {
+		r1 = rf(lastSequence)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(<-chan domain.StreamEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(uint64) func()); ok {
+		r2 = rf(lastSequence)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(func())
+		}
+	}
+
+	return r0, r1, r2
+}
+
+// EventBus_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type EventBus_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - lastSequence uint64
+func (_e *EventBus_Expecter) Subscribe(lastSequence interface{}) *EventBus_Subscribe_Call {
+	return &EventBus_Subscribe_Call{Call: _e.mock.On("Subscribe", lastSequence)}
+}
+
+func (_c *EventBus_Subscribe_Call) Run(run func(lastSequence uint64)) *EventBus_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint64))
+	})
+	return _c
+}
+
+func (_c *EventBus_Subscribe_Call) Return(replay []domain.StreamEvent, events <-chan domain.StreamEvent, cancel func()) *EventBus_Subscribe_Call {
+	_c.Call.Return(replay, events, cancel)
+	return _c
+}
+
+func (_c *EventBus_Subscribe_Call) RunAndReturn(run func(uint64) ([]domain.StreamEvent, <-chan domain.StreamEvent, func())) *EventBus_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewEventBus creates a new instance of EventBus. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventBus(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventBus {
+	mock := &EventBus{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,300 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "test_task_manager/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// InvitationRepository is an autogenerated mock type for the InvitationRepository type
+type InvitationRepository struct {
+	mock.Mock
+}
+
+type InvitationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *InvitationRepository) EXPECT() *InvitationRepository_Expecter {
+	return &InvitationRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateInvitation provides a mock function with given fields: c, invitation
+func (_m *InvitationRepository) CreateInvitation(c context.Context, invitation domain.Invitation) error {
+	ret := _m.Called(c, invitation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Invitation) error); ok {
+		r0 = rf(c, invitation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvitationRepository_CreateInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateInvitation'
+type InvitationRepository_CreateInvitation_Call struct {
+	*mock.Call
+}
+
+// CreateInvitation is a helper method to define mock.On call
+//   - c context.Context
+//   - invitation domain.Invitation
+func (_e *InvitationRepository_Expecter) CreateInvitation(c interface{}, invitation interface{}) *InvitationRepository_CreateInvitation_Call {
+	return &InvitationRepository_CreateInvitation_Call{Call: _e.mock.On("CreateInvitation", c, invitation)}
+}
+
+func (_c *InvitationRepository_CreateInvitation_Call) Run(run func(c context.Context, invitation domain.Invitation)) *InvitationRepository_CreateInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Invitation))
+	})
+	return _c
+}
+
+func (_c *InvitationRepository_CreateInvitation_Call) Return(_a0 error) *InvitationRepository_CreateInvitation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *InvitationRepository_CreateInvitation_Call) RunAndReturn(run func(context.Context, domain.Invitation) error) *InvitationRepository_CreateInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteInvitation provides a mock function with given fields: c, invitationID
+func (_m *InvitationRepository) DeleteInvitation(c context.Context, invitationID string) error {
+	ret := _m.Called(c, invitationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, invitationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvitationRepository_DeleteInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteInvitation'
+type InvitationRepository_DeleteInvitation_Call struct {
+	*mock.Call
+}
+
+// DeleteInvitation is a helper method to define mock.On call
+//   - c context.Context
+//   - invitationID string
+func (_e *InvitationRepository_Expecter) DeleteInvitation(c interface{}, invitationID interface{}) *InvitationRepository_DeleteInvitation_Call {
+	return &InvitationRepository_DeleteInvitation_Call{Call: _e.mock.On("DeleteInvitation", c, invitationID)}
+}
+
+func (_c *InvitationRepository_DeleteInvitation_Call) Run(run func(c context.Context, invitationID string)) *InvitationRepository_DeleteInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *InvitationRepository_DeleteInvitation_Call) Return(_a0 error) *InvitationRepository_DeleteInvitation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *InvitationRepository_DeleteInvitation_Call) RunAndReturn(run func(context.Context, string) error) *InvitationRepository_DeleteInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInvitations provides a mock function with given fields: c
+func (_m *InvitationRepository) GetInvitations(c context.Context) ([]domain.Invitation, error) {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvitations")
+	}
+
+	var r0 []domain.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Invitation, error)); ok {
+		return rf(c)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Invitation); ok {
+		r0 = rf(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvitationRepository_GetInvitations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInvitations'
+type InvitationRepository_GetInvitations_Call struct {
+	*mock.Call
+}
+
+// GetInvitations is a helper method to define mock.On call
+//   - c context.Context
+func (_e *InvitationRepository_Expecter) GetInvitations(c interface{}) *InvitationRepository_GetInvitations_Call {
+	return &InvitationRepository_GetInvitations_Call{Call: _e.mock.On("GetInvitations", c)}
+}
+
+func (_c *InvitationRepository_GetInvitations_Call) Run(run func(c context.Context)) *InvitationRepository_GetInvitations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *InvitationRepository_GetInvitations_Call) Return(_a0 []domain.Invitation, _a1 error) *InvitationRepository_GetInvitations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InvitationRepository_GetInvitations_Call) RunAndReturn(run func(context.Context) ([]domain.Invitation, error)) *InvitationRepository_GetInvitations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RedeemInvitation provides a mock function with given fields: c, hash, username, at
+func (_m *InvitationRepository) RedeemInvitation(c context.Context, hash string, username string, at time.Time) (*domain.Invitation, error) {
+	ret := _m.Called(c, hash, username, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RedeemInvitation")
+	}
+
+	var r0 *domain.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (*domain.Invitation, error)); ok {
+		return rf(c, hash, username, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) *domain.Invitation); ok {
+		r0 = rf(c, hash, username, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(c, hash, username, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvitationRepository_RedeemInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RedeemInvitation'
+type InvitationRepository_RedeemInvitation_Call struct {
+	*mock.Call
+}
+
+// RedeemInvitation is a helper method to define mock.On call
+//   - c context.Context
+//   - hash string
+//   - username string
+//   - at time.Time
+func (_e *InvitationRepository_Expecter) RedeemInvitation(c interface{}, hash interface{}, username interface{}, at interface{}) *InvitationRepository_RedeemInvitation_Call {
+	return &InvitationRepository_RedeemInvitation_Call{Call: _e.mock.On("RedeemInvitation", c, hash, username, at)}
+}
+
+func (_c *InvitationRepository_RedeemInvitation_Call) Run(run func(c context.Context, hash string, username string, at time.Time)) *InvitationRepository_RedeemInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *InvitationRepository_RedeemInvitation_Call) Return(_a0 *domain.Invitation, _a1 error) *InvitationRepository_RedeemInvitation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InvitationRepository_RedeemInvitation_Call) RunAndReturn(run func(context.Context, string, string, time.Time) (*domain.Invitation, error)) *InvitationRepository_RedeemInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseInvitation provides a mock function with given fields: c, hash, username
+func (_m *InvitationRepository) ReleaseInvitation(c context.Context, hash string, username string) error {
+	ret := _m.Called(c, hash, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, hash, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvitationRepository_ReleaseInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseInvitation'
+type InvitationRepository_ReleaseInvitation_Call struct {
+	*mock.Call
+}
+
+// ReleaseInvitation is a helper method to define mock.On call
+//   - c context.Context
+//   - hash string
+//   - username string
+func (_e *InvitationRepository_Expecter) ReleaseInvitation(c interface{}, hash interface{}, username interface{}) *InvitationRepository_ReleaseInvitation_Call {
+	return &InvitationRepository_ReleaseInvitation_Call{Call: _e.mock.On("ReleaseInvitation", c, hash, username)}
+}
+
+func (_c *InvitationRepository_ReleaseInvitation_Call) Run(run func(c context.Context, hash string, username string)) *InvitationRepository_ReleaseInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *InvitationRepository_ReleaseInvitation_Call) Return(_a0 error) *InvitationRepository_ReleaseInvitation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *InvitationRepository_ReleaseInvitation_Call) RunAndReturn(run func(context.Context, string, string) error) *InvitationRepository_ReleaseInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewInvitationRepository creates a new instance of InvitationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInvitationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *InvitationRepository {
+	mock := &InvitationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
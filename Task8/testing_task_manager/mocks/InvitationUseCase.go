@@ -0,0 +1,213 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "test_task_manager/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// InvitationUseCase is an autogenerated mock type for the InvitationUseCase type
+type InvitationUseCase struct {
+	mock.Mock
+}
+
+type InvitationUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *InvitationUseCase) EXPECT() *InvitationUseCase_Expecter {
+	return &InvitationUseCase_Expecter{mock: &_m.Mock}
+}
+
+// CreateInvitation provides a mock function with given fields: c, role, email, ttl, createdBy
+func (_m *InvitationUseCase) CreateInvitation(c context.Context, role string, email string, ttl time.Duration, createdBy string) (*domain.Invitation, string, error) {
+	ret := _m.Called(c, role, email, ttl, createdBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateInvitation")
+	}
+
+	var r0 *domain.Invitation
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, string) (*domain.Invitation, string, error)); ok {
+		return rf(c, role, email, ttl, createdBy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, string) *domain.Invitation); ok {
+		r0 = rf(c, role, email, ttl, createdBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration, string) string); ok {
+		r1 = rf(c, role, email, ttl, createdBy)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, time.Duration, string) error); ok {
+		r2 = rf(c, role, email, ttl, createdBy)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// InvitationUseCase_CreateInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateInvitation'
+type InvitationUseCase_CreateInvitation_Call struct {
+	*mock.Call
+}
+
+// CreateInvitation is a helper method to define mock.On call
+//   - c context.Context
+//   - role string
+//   - email string
+//   - ttl time.Duration
+//   - createdBy string
+func (_e *InvitationUseCase_Expecter) CreateInvitation(c interface{}, role interface{}, email interface{}, ttl interface{}, createdBy interface{}) *InvitationUseCase_CreateInvitation_Call {
+	return &InvitationUseCase_CreateInvitation_Call{Call: _e.mock.On("CreateInvitation", c, role, email, ttl, createdBy)}
+}
+
+func (_c *InvitationUseCase_CreateInvitation_Call) Run(run func(c context.Context, role string, email string, ttl time.Duration, createdBy string)) *InvitationUseCase_CreateInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *InvitationUseCase_CreateInvitation_Call) Return(_a0 *domain.Invitation, _a1 string, _a2 error) *InvitationUseCase_CreateInvitation_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *InvitationUseCase_CreateInvitation_Call) RunAndReturn(run func(context.Context, string, string, time.Duration, string) (*domain.Invitation, string, error)) *InvitationUseCase_CreateInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInvitations provides a mock function with given fields: c
+func (_m *InvitationUseCase) GetInvitations(c context.Context) ([]domain.Invitation, error) {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvitations")
+	}
+
+	var r0 []domain.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Invitation, error)); ok {
+		return rf(c)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Invitation); ok {
+		r0 = rf(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvitationUseCase_GetInvitations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInvitations'
+type InvitationUseCase_GetInvitations_Call struct {
+	*mock.Call
+}
+
+// GetInvitations is a helper method to define mock.On call
+//   - c context.Context
+func (_e *InvitationUseCase_Expecter) GetInvitations(c interface{}) *InvitationUseCase_GetInvitations_Call {
+	return &InvitationUseCase_GetInvitations_Call{Call: _e.mock.On("GetInvitations", c)}
+}
+
+func (_c *InvitationUseCase_GetInvitations_Call) Run(run func(c context.Context)) *InvitationUseCase_GetInvitations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *InvitationUseCase_GetInvitations_Call) Return(_a0 []domain.Invitation, _a1 error) *InvitationUseCase_GetInvitations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InvitationUseCase_GetInvitations_Call) RunAndReturn(run func(context.Context) ([]domain.Invitation, error)) *InvitationUseCase_GetInvitations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeInvitation provides a mock function with given fields: c, invitationID
+func (_m *InvitationUseCase) RevokeInvitation(c context.Context, invitationID string) error {
+	ret := _m.Called(c, invitationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, invitationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvitationUseCase_RevokeInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeInvitation'
+type InvitationUseCase_RevokeInvitation_Call struct {
+	*mock.Call
+}
+
+// RevokeInvitation is a helper method to define mock.On call
+//   - c context.Context
+//   - invitationID string
+func (_e *InvitationUseCase_Expecter) RevokeInvitation(c interface{}, invitationID interface{}) *InvitationUseCase_RevokeInvitation_Call {
+	return &InvitationUseCase_RevokeInvitation_Call{Call: _e.mock.On("RevokeInvitation", c, invitationID)}
+}
+
+func (_c *InvitationUseCase_RevokeInvitation_Call) Run(run func(c context.Context, invitationID string)) *InvitationUseCase_RevokeInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *InvitationUseCase_RevokeInvitation_Call) Return(_a0 error) *InvitationUseCase_RevokeInvitation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *InvitationUseCase_RevokeInvitation_Call) RunAndReturn(run func(context.Context, string) error) *InvitationUseCase_RevokeInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewInvitationUseCase creates a new instance of InvitationUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInvitationUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *InvitationUseCase {
+	mock := &InvitationUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
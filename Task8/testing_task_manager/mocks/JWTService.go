@@ -2,7 +2,11 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	domain "test_task_manager/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // JWTService is an autogenerated mock type for the JWTService type
 type JWTService struct {
@@ -17,27 +21,140 @@ func (_m *JWTService) EXPECT() *JWTService_Expecter {
 	return &JWTService_Expecter{mock: &_m.Mock}
 }
 
-// GenerateToken provides a mock function with given fields: username, role
-func (_m *JWTService) GenerateToken(username string, role string) (string, error) {
-	ret := _m.Called(username, role)
+// GenerateEmailToken provides a mock function with given fields: username, email
+func (_m *JWTService) GenerateEmailToken(username string, email string) (string, error) {
+	ret := _m.Called(username, email)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GenerateToken")
+		panic("no return value specified for GenerateEmailToken")
 	}
 
 	var r0 string
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
-		return rf(username, role)
+		return rf(username, email)
 	}
 	if rf, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = rf(username, role)
+		r0 = rf(username, email)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(username, role)
+		r1 = rf(username, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JWTService_GenerateEmailToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateEmailToken'
+type JWTService_GenerateEmailToken_Call struct {
+	*mock.Call
+}
+
+// GenerateEmailToken is a helper method to define mock.On call
+//   - username string
+//   - email string
+func (_e *JWTService_Expecter) GenerateEmailToken(username interface{}, email interface{}) *JWTService_GenerateEmailToken_Call {
+	return &JWTService_GenerateEmailToken_Call{Call: _e.mock.On("GenerateEmailToken", username, email)}
+}
+
+func (_c *JWTService_GenerateEmailToken_Call) Run(run func(username string, email string)) *JWTService_GenerateEmailToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *JWTService_GenerateEmailToken_Call) Return(_a0 string, _a1 error) *JWTService_GenerateEmailToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *JWTService_GenerateEmailToken_Call) RunAndReturn(run func(string, string) (string, error)) *JWTService_GenerateEmailToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateMFAToken provides a mock function with given fields: username
+func (_m *JWTService) GenerateMFAToken(username string) (string, error) {
+	ret := _m.Called(username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateMFAToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(username)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(username)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JWTService_GenerateMFAToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateMFAToken'
+type JWTService_GenerateMFAToken_Call struct {
+	*mock.Call
+}
+
+// GenerateMFAToken is a helper method to define mock.On call
+//   - username string
+func (_e *JWTService_Expecter) GenerateMFAToken(username interface{}) *JWTService_GenerateMFAToken_Call {
+	return &JWTService_GenerateMFAToken_Call{Call: _e.mock.On("GenerateMFAToken", username)}
+}
+
+func (_c *JWTService_GenerateMFAToken_Call) Run(run func(username string)) *JWTService_GenerateMFAToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *JWTService_GenerateMFAToken_Call) Return(_a0 string, _a1 error) *JWTService_GenerateMFAToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *JWTService_GenerateMFAToken_Call) RunAndReturn(run func(string) (string, error)) *JWTService_GenerateMFAToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateToken provides a mock function with given fields: username, memberships
+func (_m *JWTService) GenerateToken(username string, memberships []domain.Membership) (string, error) {
+	ret := _m.Called(username, memberships)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []domain.Membership) (string, error)); ok {
+		return rf(username, memberships)
+	}
+	if rf, ok := ret.Get(0).(func(string, []domain.Membership) string); ok {
+		r0 = rf(username, memberships)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []domain.Membership) error); ok {
+		r1 = rf(username, memberships)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -52,14 +169,14 @@ type JWTService_GenerateToken_Call struct {
 
 // GenerateToken is a helper method to define mock.On call
 //   - username string
-//   - role string
-func (_e *JWTService_Expecter) GenerateToken(username interface{}, role interface{}) *JWTService_GenerateToken_Call {
-	return &JWTService_GenerateToken_Call{Call: _e.mock.On("GenerateToken", username, role)}
+//   - memberships []domain.Membership
+func (_e *JWTService_Expecter) GenerateToken(username interface{}, memberships interface{}) *JWTService_GenerateToken_Call {
+	return &JWTService_GenerateToken_Call{Call: _e.mock.On("GenerateToken", username, memberships)}
 }
 
-func (_c *JWTService_GenerateToken_Call) Run(run func(username string, role string)) *JWTService_GenerateToken_Call {
+func (_c *JWTService_GenerateToken_Call) Run(run func(username string, memberships []domain.Membership)) *JWTService_GenerateToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string), args[1].([]domain.Membership))
 	})
 	return _c
 }
@@ -69,7 +186,126 @@ func (_c *JWTService_GenerateToken_Call) Return(_a0 string, _a1 error) *JWTServi
 	return _c
 }
 
-func (_c *JWTService_GenerateToken_Call) RunAndReturn(run func(string, string) (string, error)) *JWTService_GenerateToken_Call {
+func (_c *JWTService_GenerateToken_Call) RunAndReturn(run func(string, []domain.Membership) (string, error)) *JWTService_GenerateToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateEmailToken provides a mock function with given fields: token
+func (_m *JWTService) ValidateEmailToken(token string) (string, string, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateEmailToken")
+	}
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string) (string, string, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) string); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(token)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// JWTService_ValidateEmailToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateEmailToken'
+type JWTService_ValidateEmailToken_Call struct {
+	*mock.Call
+}
+
+// ValidateEmailToken is a helper method to define mock.On call
+//   - token string
+func (_e *JWTService_Expecter) ValidateEmailToken(token interface{}) *JWTService_ValidateEmailToken_Call {
+	return &JWTService_ValidateEmailToken_Call{Call: _e.mock.On("ValidateEmailToken", token)}
+}
+
+func (_c *JWTService_ValidateEmailToken_Call) Run(run func(token string)) *JWTService_ValidateEmailToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *JWTService_ValidateEmailToken_Call) Return(_a0 string, _a1 string, _a2 error) *JWTService_ValidateEmailToken_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *JWTService_ValidateEmailToken_Call) RunAndReturn(run func(string) (string, string, error)) *JWTService_ValidateEmailToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateMFAToken provides a mock function with given fields: token
+func (_m *JWTService) ValidateMFAToken(token string) (string, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateMFAToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JWTService_ValidateMFAToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateMFAToken'
+type JWTService_ValidateMFAToken_Call struct {
+	*mock.Call
+}
+
+// ValidateMFAToken is a helper method to define mock.On call
+//   - token string
+func (_e *JWTService_Expecter) ValidateMFAToken(token interface{}) *JWTService_ValidateMFAToken_Call {
+	return &JWTService_ValidateMFAToken_Call{Call: _e.mock.On("ValidateMFAToken", token)}
+}
+
+func (_c *JWTService_ValidateMFAToken_Call) Run(run func(token string)) *JWTService_ValidateMFAToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *JWTService_ValidateMFAToken_Call) Return(_a0 string, _a1 error) *JWTService_ValidateMFAToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *JWTService_ValidateMFAToken_Call) RunAndReturn(run func(string) (string, error)) *JWTService_ValidateMFAToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
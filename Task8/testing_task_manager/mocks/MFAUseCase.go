@@ -0,0 +1,262 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "test_task_manager/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MFAUseCase is an autogenerated mock type for the MFAUseCase type
+type MFAUseCase struct {
+	mock.Mock
+}
+
+type MFAUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MFAUseCase) EXPECT() *MFAUseCase_Expecter {
+	return &MFAUseCase_Expecter{mock: &_m.Mock}
+}
+
+// ConfirmEnrollment provides a mock function with given fields: c, username, code
+func (_m *MFAUseCase) ConfirmEnrollment(c context.Context, username string, code string) ([]string, error) {
+	ret := _m.Called(c, username, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmEnrollment")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]string, error)); ok {
+		return rf(c, username, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = rf(c, username, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, username, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MFAUseCase_ConfirmEnrollment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmEnrollment'
+type MFAUseCase_ConfirmEnrollment_Call struct {
+	*mock.Call
+}
+
+// ConfirmEnrollment is a helper method to define mock.On call
+//   - c context.Context
+//   - username string
+//   - code string
+func (_e *MFAUseCase_Expecter) ConfirmEnrollment(c interface{}, username interface{}, code interface{}) *MFAUseCase_ConfirmEnrollment_Call {
+	return &MFAUseCase_ConfirmEnrollment_Call{Call: _e.mock.On("ConfirmEnrollment", c, username, code)}
+}
+
+func (_c *MFAUseCase_ConfirmEnrollment_Call) Run(run func(c context.Context, username string, code string)) *MFAUseCase_ConfirmEnrollment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MFAUseCase_ConfirmEnrollment_Call) Return(_a0 []string, _a1 error) *MFAUseCase_ConfirmEnrollment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MFAUseCase_ConfirmEnrollment_Call) RunAndReturn(run func(context.Context, string, string) ([]string, error)) *MFAUseCase_ConfirmEnrollment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Disable provides a mock function with given fields: c, username, code
+func (_m *MFAUseCase) Disable(c context.Context, username string, code string) error {
+	ret := _m.Called(c, username, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Disable")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, username, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MFAUseCase_Disable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Disable'
+type MFAUseCase_Disable_Call struct {
+	*mock.Call
+}
+
+// Disable is a helper method to define mock.On call
+//   - c context.Context
+//   - username string
+//   - code string
+func (_e *MFAUseCase_Expecter) Disable(c interface{}, username interface{}, code interface{}) *MFAUseCase_Disable_Call {
+	return &MFAUseCase_Disable_Call{Call: _e.mock.On("Disable", c, username, code)}
+}
+
+func (_c *MFAUseCase_Disable_Call) Run(run func(c context.Context, username string, code string)) *MFAUseCase_Disable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MFAUseCase_Disable_Call) Return(_a0 error) *MFAUseCase_Disable_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MFAUseCase_Disable_Call) RunAndReturn(run func(context.Context, string, string) error) *MFAUseCase_Disable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Enroll provides a mock function with given fields: c, username
+func (_m *MFAUseCase) Enroll(c context.Context, username string) (*domain.MFAEnrollment, error) {
+	ret := _m.Called(c, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enroll")
+	}
+
+	var r0 *domain.MFAEnrollment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.MFAEnrollment, error)); ok {
+		return rf(c, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.MFAEnrollment); ok {
+		r0 = rf(c, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.MFAEnrollment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MFAUseCase_Enroll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enroll'
+type MFAUseCase_Enroll_Call struct {
+	*mock.Call
+}
+
+// Enroll is a helper method to define mock.On call
+//   - c context.Context
+//   - username string
+func (_e *MFAUseCase_Expecter) Enroll(c interface{}, username interface{}) *MFAUseCase_Enroll_Call {
+	return &MFAUseCase_Enroll_Call{Call: _e.mock.On("Enroll", c, username)}
+}
+
+func (_c *MFAUseCase_Enroll_Call) Run(run func(c context.Context, username string)) *MFAUseCase_Enroll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MFAUseCase_Enroll_Call) Return(_a0 *domain.MFAEnrollment, _a1 error) *MFAUseCase_Enroll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MFAUseCase_Enroll_Call) RunAndReturn(run func(context.Context, string) (*domain.MFAEnrollment, error)) *MFAUseCase_Enroll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyLogin provides a mock function with given fields: c, mfaToken, code
+func (_m *MFAUseCase) VerifyLogin(c context.Context, mfaToken string, code string) (string, error) {
+	ret := _m.Called(c, mfaToken, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyLogin")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(c, mfaToken, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(c, mfaToken, code)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, mfaToken, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MFAUseCase_VerifyLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyLogin'
+type MFAUseCase_VerifyLogin_Call struct {
+	*mock.Call
+}
+
+// VerifyLogin is a helper method to define mock.On call
+//   - c context.Context
+//   - mfaToken string
+//   - code string
+func (_e *MFAUseCase_Expecter) VerifyLogin(c interface{}, mfaToken interface{}, code interface{}) *MFAUseCase_VerifyLogin_Call {
+	return &MFAUseCase_VerifyLogin_Call{Call: _e.mock.On("VerifyLogin", c, mfaToken, code)}
+}
+
+func (_c *MFAUseCase_VerifyLogin_Call) Run(run func(c context.Context, mfaToken string, code string)) *MFAUseCase_VerifyLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MFAUseCase_VerifyLogin_Call) Return(_a0 string, _a1 error) *MFAUseCase_VerifyLogin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MFAUseCase_VerifyLogin_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *MFAUseCase_VerifyLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMFAUseCase creates a new instance of MFAUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMFAUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MFAUseCase {
+	mock := &MFAUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
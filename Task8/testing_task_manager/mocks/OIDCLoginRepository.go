@@ -0,0 +1,143 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "test_task_manager/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OIDCLoginRepository is an autogenerated mock type for the OIDCLoginRepository type
+type OIDCLoginRepository struct {
+	mock.Mock
+}
+
+type OIDCLoginRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OIDCLoginRepository) EXPECT() *OIDCLoginRepository_Expecter {
+	return &OIDCLoginRepository_Expecter{mock: &_m.Mock}
+}
+
+// SaveLogin provides a mock function with given fields: c, login
+func (_m *OIDCLoginRepository) SaveLogin(c context.Context, login domain.OIDCLogin) error {
+	ret := _m.Called(c, login)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveLogin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OIDCLogin) error); ok {
+		r0 = rf(c, login)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OIDCLoginRepository_SaveLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveLogin'
+type OIDCLoginRepository_SaveLogin_Call struct {
+	*mock.Call
+}
+
+// SaveLogin is a helper method to define mock.On call
+//   - c context.Context
+//   - login domain.OIDCLogin
+func (_e *OIDCLoginRepository_Expecter) SaveLogin(c interface{}, login interface{}) *OIDCLoginRepository_SaveLogin_Call {
+	return &OIDCLoginRepository_SaveLogin_Call{Call: _e.mock.On("SaveLogin", c, login)}
+}
+
+func (_c *OIDCLoginRepository_SaveLogin_Call) Run(run func(c context.Context, login domain.OIDCLogin)) *OIDCLoginRepository_SaveLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.OIDCLogin))
+	})
+	return _c
+}
+
+func (_c *OIDCLoginRepository_SaveLogin_Call) Return(_a0 error) *OIDCLoginRepository_SaveLogin_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OIDCLoginRepository_SaveLogin_Call) RunAndReturn(run func(context.Context, domain.OIDCLogin) error) *OIDCLoginRepository_SaveLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TakeLogin provides a mock function with given fields: c, state
+func (_m *OIDCLoginRepository) TakeLogin(c context.Context, state string) (*domain.OIDCLogin, error) {
+	ret := _m.Called(c, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TakeLogin")
+	}
+
+	var r0 *domain.OIDCLogin
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.OIDCLogin, error)); ok {
+		return rf(c, state)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.OIDCLogin); ok {
+		r0 = rf(c, state)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OIDCLogin)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCLoginRepository_TakeLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TakeLogin'
+type OIDCLoginRepository_TakeLogin_Call struct {
+	*mock.Call
+}
+
+// TakeLogin is a helper method to define mock.On call
+//   - c context.Context
+//   - state string
+func (_e *OIDCLoginRepository_Expecter) TakeLogin(c interface{}, state interface{}) *OIDCLoginRepository_TakeLogin_Call {
+	return &OIDCLoginRepository_TakeLogin_Call{Call: _e.mock.On("TakeLogin", c, state)}
+}
+
+func (_c *OIDCLoginRepository_TakeLogin_Call) Run(run func(c context.Context, state string)) *OIDCLoginRepository_TakeLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *OIDCLoginRepository_TakeLogin_Call) Return(_a0 *domain.OIDCLogin, _a1 error) *OIDCLoginRepository_TakeLogin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCLoginRepository_TakeLogin_Call) RunAndReturn(run func(context.Context, string) (*domain.OIDCLogin, error)) *OIDCLoginRepository_TakeLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOIDCLoginRepository creates a new instance of OIDCLoginRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOIDCLoginRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OIDCLoginRepository {
+	mock := &OIDCLoginRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,156 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OIDCProvider is an autogenerated mock type for the OIDCProvider type
+type OIDCProvider struct {
+	mock.Mock
+}
+
+type OIDCProvider_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OIDCProvider) EXPECT() *OIDCProvider_Expecter {
+	return &OIDCProvider_Expecter{mock: &_m.Mock}
+}
+
+// AuthCodeURL provides a mock function with given fields: c, state, nonce, codeChallenge
+func (_m *OIDCProvider) AuthCodeURL(c context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	ret := _m.Called(c, state, nonce, codeChallenge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthCodeURL")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (string, error)); ok {
+		return rf(c, state, nonce, codeChallenge)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = rf(c, state, nonce, codeChallenge)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(c, state, nonce, codeChallenge)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCProvider_AuthCodeURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthCodeURL'
+type OIDCProvider_AuthCodeURL_Call struct {
+	*mock.Call
+}
+
+// AuthCodeURL is a helper method to define mock.On call
+//   - c context.Context
+//   - state string
+//   - nonce string
+//   - codeChallenge string
+func (_e *OIDCProvider_Expecter) AuthCodeURL(c interface{}, state interface{}, nonce interface{}, codeChallenge interface{}) *OIDCProvider_AuthCodeURL_Call {
+	return &OIDCProvider_AuthCodeURL_Call{Call: _e.mock.On("AuthCodeURL", c, state, nonce, codeChallenge)}
+}
+
+func (_c *OIDCProvider_AuthCodeURL_Call) Run(run func(c context.Context, state string, nonce string, codeChallenge string)) *OIDCProvider_AuthCodeURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *OIDCProvider_AuthCodeURL_Call) Return(_a0 string, _a1 error) *OIDCProvider_AuthCodeURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCProvider_AuthCodeURL_Call) RunAndReturn(run func(context.Context, string, string, string) (string, error)) *OIDCProvider_AuthCodeURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Exchange provides a mock function with given fields: c, code, codeVerifier, nonce
+func (_m *OIDCProvider) Exchange(c context.Context, code string, codeVerifier string, nonce string) (map[string]interface{}, error) {
+	ret := _m.Called(c, code, codeVerifier, nonce)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exchange")
+	}
+
+	var r0 map[string]interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (map[string]interface{}, error)); ok {
+		return rf(c, code, codeVerifier, nonce)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) map[string]interface{}); ok {
+		r0 = rf(c, code, codeVerifier, nonce)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(c, code, codeVerifier, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCProvider_Exchange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exchange'
+type OIDCProvider_Exchange_Call struct {
+	*mock.Call
+}
+
+// Exchange is a helper method to define mock.On call
+//   - c context.Context
+//   - code string
+//   - codeVerifier string
+//   - nonce string
+func (_e *OIDCProvider_Expecter) Exchange(c interface{}, code interface{}, codeVerifier interface{}, nonce interface{}) *OIDCProvider_Exchange_Call {
+	return &OIDCProvider_Exchange_Call{Call: _e.mock.On("Exchange", c, code, codeVerifier, nonce)}
+}
+
+func (_c *OIDCProvider_Exchange_Call) Run(run func(c context.Context, code string, codeVerifier string, nonce string)) *OIDCProvider_Exchange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *OIDCProvider_Exchange_Call) Return(_a0 map[string]interface{}, _a1 error) *OIDCProvider_Exchange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCProvider_Exchange_Call) RunAndReturn(run func(context.Context, string, string, string) (map[string]interface{}, error)) *OIDCProvider_Exchange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOIDCProvider creates a new instance of OIDCProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOIDCProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OIDCProvider {
+	mock := &OIDCProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,160 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "test_task_manager/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OIDCUseCase is an autogenerated mock type for the OIDCUseCase type
+type OIDCUseCase struct {
+	mock.Mock
+}
+
+type OIDCUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OIDCUseCase) EXPECT() *OIDCUseCase_Expecter {
+	return &OIDCUseCase_Expecter{mock: &_m.Mock}
+}
+
+// BeginLogin provides a mock function with given fields: c
+func (_m *OIDCUseCase) BeginLogin(c context.Context) (string, string, error) {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BeginLogin")
+	}
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) (string, string, error)); ok {
+		return rf(c)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) string); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(c)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// OIDCUseCase_BeginLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BeginLogin'
+type OIDCUseCase_BeginLogin_Call struct {
+	*mock.Call
+}
+
+// BeginLogin is a helper method to define mock.On call
+//   - c context.Context
+func (_e *OIDCUseCase_Expecter) BeginLogin(c interface{}) *OIDCUseCase_BeginLogin_Call {
+	return &OIDCUseCase_BeginLogin_Call{Call: _e.mock.On("BeginLogin", c)}
+}
+
+func (_c *OIDCUseCase_BeginLogin_Call) Run(run func(c context.Context)) *OIDCUseCase_BeginLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *OIDCUseCase_BeginLogin_Call) Return(authURL string, state string, err error) *OIDCUseCase_BeginLogin_Call {
+	_c.Call.Return(authURL, state, err)
+	return _c
+}
+
+func (_c *OIDCUseCase_BeginLogin_Call) RunAndReturn(run func(context.Context) (string, string, error)) *OIDCUseCase_BeginLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CompleteLogin provides a mock function with given fields: c, state, code
+func (_m *OIDCUseCase) CompleteLogin(c context.Context, state string, code string) (*domain.LoginResult, error) {
+	ret := _m.Called(c, state, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteLogin")
+	}
+
+	var r0 *domain.LoginResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.LoginResult, error)); ok {
+		return rf(c, state, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.LoginResult); ok {
+		r0 = rf(c, state, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.LoginResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, state, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCUseCase_CompleteLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteLogin'
+type OIDCUseCase_CompleteLogin_Call struct {
+	*mock.Call
+}
+
+// CompleteLogin is a helper method to define mock.On call
+//   - c context.Context
+//   - state string
+//   - code string
+func (_e *OIDCUseCase_Expecter) CompleteLogin(c interface{}, state interface{}, code interface{}) *OIDCUseCase_CompleteLogin_Call {
+	return &OIDCUseCase_CompleteLogin_Call{Call: _e.mock.On("CompleteLogin", c, state, code)}
+}
+
+func (_c *OIDCUseCase_CompleteLogin_Call) Run(run func(c context.Context, state string, code string)) *OIDCUseCase_CompleteLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *OIDCUseCase_CompleteLogin_Call) Return(_a0 *domain.LoginResult, _a1 error) *OIDCUseCase_CompleteLogin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCUseCase_CompleteLogin_Call) RunAndReturn(run func(context.Context, string, string) (*domain.LoginResult, error)) *OIDCUseCase_CompleteLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOIDCUseCase creates a new instance of OIDCUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOIDCUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OIDCUseCase {
+	mock := &OIDCUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,143 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "test_task_manager/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OrganizationRepository is an autogenerated mock type for the OrganizationRepository type
+type OrganizationRepository struct {
+	mock.Mock
+}
+
+type OrganizationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OrganizationRepository) EXPECT() *OrganizationRepository_Expecter {
+	return &OrganizationRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateOrganization provides a mock function with given fields: c, org
+func (_m *OrganizationRepository) CreateOrganization(c context.Context, org domain.Organization) error {
+	ret := _m.Called(c, org)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrganization")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Organization) error); ok {
+		r0 = rf(c, org)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OrganizationRepository_CreateOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrganization'
+type OrganizationRepository_CreateOrganization_Call struct {
+	*mock.Call
+}
+
+// CreateOrganization is a helper method to define mock.On call
+//   - c context.Context
+//   - org domain.Organization
+func (_e *OrganizationRepository_Expecter) CreateOrganization(c interface{}, org interface{}) *OrganizationRepository_CreateOrganization_Call {
+	return &OrganizationRepository_CreateOrganization_Call{Call: _e.mock.On("CreateOrganization", c, org)}
+}
+
+func (_c *OrganizationRepository_CreateOrganization_Call) Run(run func(c context.Context, org domain.Organization)) *OrganizationRepository_CreateOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Organization))
+	})
+	return _c
+}
+
+func (_c *OrganizationRepository_CreateOrganization_Call) Return(_a0 error) *OrganizationRepository_CreateOrganization_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OrganizationRepository_CreateOrganization_Call) RunAndReturn(run func(context.Context, domain.Organization) error) *OrganizationRepository_CreateOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOrganizationsByIDs provides a mock function with given fields: c, orgIDs
+func (_m *OrganizationRepository) GetOrganizationsByIDs(c context.Context, orgIDs []string) ([]domain.Organization, error) {
+	ret := _m.Called(c, orgIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrganizationsByIDs")
+	}
+
+	var r0 []domain.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]domain.Organization, error)); ok {
+		return rf(c, orgIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []domain.Organization); ok {
+		r0 = rf(c, orgIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Organization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(c, orgIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationRepository_GetOrganizationsByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrganizationsByIDs'
+type OrganizationRepository_GetOrganizationsByIDs_Call struct {
+	*mock.Call
+}
+
+// GetOrganizationsByIDs is a helper method to define mock.On call
+//   - c context.Context
+//   - orgIDs []string
+func (_e *OrganizationRepository_Expecter) GetOrganizationsByIDs(c interface{}, orgIDs interface{}) *OrganizationRepository_GetOrganizationsByIDs_Call {
+	return &OrganizationRepository_GetOrganizationsByIDs_Call{Call: _e.mock.On("GetOrganizationsByIDs", c, orgIDs)}
+}
+
+func (_c *OrganizationRepository_GetOrganizationsByIDs_Call) Run(run func(c context.Context, orgIDs []string)) *OrganizationRepository_GetOrganizationsByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *OrganizationRepository_GetOrganizationsByIDs_Call) Return(_a0 []domain.Organization, _a1 error) *OrganizationRepository_GetOrganizationsByIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationRepository_GetOrganizationsByIDs_Call) RunAndReturn(run func(context.Context, []string) ([]domain.Organization, error)) *OrganizationRepository_GetOrganizationsByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOrganizationRepository creates a new instance of OrganizationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOrganizationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OrganizationRepository {
+	mock := &OrganizationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
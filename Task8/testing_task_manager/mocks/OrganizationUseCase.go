@@ -0,0 +1,204 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "test_task_manager/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OrganizationUseCase is an autogenerated mock type for the OrganizationUseCase type
+type OrganizationUseCase struct {
+	mock.Mock
+}
+
+type OrganizationUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OrganizationUseCase) EXPECT() *OrganizationUseCase_Expecter {
+	return &OrganizationUseCase_Expecter{mock: &_m.Mock}
+}
+
+// AddMember provides a mock function with given fields: c, username, role
+func (_m *OrganizationUseCase) AddMember(c context.Context, username string, role string) error {
+	ret := _m.Called(c, username, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, username, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OrganizationUseCase_AddMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddMember'
+type OrganizationUseCase_AddMember_Call struct {
+	*mock.Call
+}
+
+// AddMember is a helper method to define mock.On call
+//   - c context.Context
+//   - username string
+//   - role string
+func (_e *OrganizationUseCase_Expecter) AddMember(c interface{}, username interface{}, role interface{}) *OrganizationUseCase_AddMember_Call {
+	return &OrganizationUseCase_AddMember_Call{Call: _e.mock.On("AddMember", c, username, role)}
+}
+
+func (_c *OrganizationUseCase_AddMember_Call) Run(run func(c context.Context, username string, role string)) *OrganizationUseCase_AddMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *OrganizationUseCase_AddMember_Call) Return(_a0 error) *OrganizationUseCase_AddMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OrganizationUseCase_AddMember_Call) RunAndReturn(run func(context.Context, string, string) error) *OrganizationUseCase_AddMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrganization provides a mock function with given fields: c, name, username
+func (_m *OrganizationUseCase) CreateOrganization(c context.Context, name string, username string) (*domain.Organization, error) {
+	ret := _m.Called(c, name, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrganization")
+	}
+
+	var r0 *domain.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Organization, error)); ok {
+		return rf(c, name, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.Organization); ok {
+		r0 = rf(c, name, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Organization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, name, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationUseCase_CreateOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrganization'
+type OrganizationUseCase_CreateOrganization_Call struct {
+	*mock.Call
+}
+
+// CreateOrganization is a helper method to define mock.On call
+//   - c context.Context
+//   - name string
+//   - username string
+func (_e *OrganizationUseCase_Expecter) CreateOrganization(c interface{}, name interface{}, username interface{}) *OrganizationUseCase_CreateOrganization_Call {
+	return &OrganizationUseCase_CreateOrganization_Call{Call: _e.mock.On("CreateOrganization", c, name, username)}
+}
+
+func (_c *OrganizationUseCase_CreateOrganization_Call) Run(run func(c context.Context, name string, username string)) *OrganizationUseCase_CreateOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *OrganizationUseCase_CreateOrganization_Call) Return(_a0 *domain.Organization, _a1 error) *OrganizationUseCase_CreateOrganization_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationUseCase_CreateOrganization_Call) RunAndReturn(run func(context.Context, string, string) (*domain.Organization, error)) *OrganizationUseCase_CreateOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOrganizations provides a mock function with given fields: c, username
+func (_m *OrganizationUseCase) GetOrganizations(c context.Context, username string) ([]domain.Organization, error) {
+	ret := _m.Called(c, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrganizations")
+	}
+
+	var r0 []domain.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Organization, error)); ok {
+		return rf(c, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Organization); ok {
+		r0 = rf(c, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Organization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationUseCase_GetOrganizations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrganizations'
+type OrganizationUseCase_GetOrganizations_Call struct {
+	*mock.Call
+}
+
+// GetOrganizations is a helper method to define mock.On call
+//   - c context.Context
+//   - username string
+func (_e *OrganizationUseCase_Expecter) GetOrganizations(c interface{}, username interface{}) *OrganizationUseCase_GetOrganizations_Call {
+	return &OrganizationUseCase_GetOrganizations_Call{Call: _e.mock.On("GetOrganizations", c, username)}
+}
+
+func (_c *OrganizationUseCase_GetOrganizations_Call) Run(run func(c context.Context, username string)) *OrganizationUseCase_GetOrganizations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *OrganizationUseCase_GetOrganizations_Call) Return(_a0 []domain.Organization, _a1 error) *OrganizationUseCase_GetOrganizations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationUseCase_GetOrganizations_Call) RunAndReturn(run func(context.Context, string) ([]domain.Organization, error)) *OrganizationUseCase_GetOrganizations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOrganizationUseCase creates a new instance of OrganizationUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOrganizationUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OrganizationUseCase {
+	mock := &OrganizationUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
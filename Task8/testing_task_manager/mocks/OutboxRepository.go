@@ -0,0 +1,240 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "test_task_manager/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// OutboxRepository is an autogenerated mock type for the OutboxRepository type
+type OutboxRepository struct {
+	mock.Mock
+}
+
+type OutboxRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OutboxRepository) EXPECT() *OutboxRepository_Expecter {
+	return &OutboxRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimPending provides a mock function with given fields: c, now, until
+func (_m *OutboxRepository) ClaimPending(c context.Context, now time.Time, until time.Time) (*domain.Event, error) {
+	ret := _m.Called(c, now, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimPending")
+	}
+
+	var r0 *domain.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) (*domain.Event, error)); ok {
+		return rf(c, now, until)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) *domain.Event); ok {
+		r0 = rf(c, now, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(c, now, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OutboxRepository_ClaimPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimPending'
+type OutboxRepository_ClaimPending_Call struct {
+	*mock.Call
+}
+
+// ClaimPending is a helper method to define mock.On call
+//   - c context.Context
+//   - now time.Time
+//   - until time.Time
+func (_e *OutboxRepository_Expecter) ClaimPending(c interface{}, now interface{}, until interface{}) *OutboxRepository_ClaimPending_Call {
+	return &OutboxRepository_ClaimPending_Call{Call: _e.mock.On("ClaimPending", c, now, until)}
+}
+
+func (_c *OutboxRepository_ClaimPending_Call) Run(run func(c context.Context, now time.Time, until time.Time)) *OutboxRepository_ClaimPending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_ClaimPending_Call) Return(_a0 *domain.Event, _a1 error) *OutboxRepository_ClaimPending_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OutboxRepository_ClaimPending_Call) RunAndReturn(run func(context.Context, time.Time, time.Time) (*domain.Event, error)) *OutboxRepository_ClaimPending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Enqueue provides a mock function with given fields: c, event
+func (_m *OutboxRepository) Enqueue(c context.Context, event domain.Event) error {
+	ret := _m.Called(c, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enqueue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Event) error); ok {
+		r0 = rf(c, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRepository_Enqueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enqueue'
+type OutboxRepository_Enqueue_Call struct {
+	*mock.Call
+}
+
+// Enqueue is a helper method to define mock.On call
+//   - c context.Context
+//   - event domain.Event
+func (_e *OutboxRepository_Expecter) Enqueue(c interface{}, event interface{}) *OutboxRepository_Enqueue_Call {
+	return &OutboxRepository_Enqueue_Call{Call: _e.mock.On("Enqueue", c, event)}
+}
+
+func (_c *OutboxRepository_Enqueue_Call) Run(run func(c context.Context, event domain.Event)) *OutboxRepository_Enqueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Event))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_Enqueue_Call) Return(_a0 error) *OutboxRepository_Enqueue_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRepository_Enqueue_Call) RunAndReturn(run func(context.Context, domain.Event) error) *OutboxRepository_Enqueue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnqueueMany provides a mock function with given fields: c, events
+func (_m *OutboxRepository) EnqueueMany(c context.Context, events []domain.Event) error {
+	ret := _m.Called(c, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueMany")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Event) error); ok {
+		r0 = rf(c, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRepository_EnqueueMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueMany'
+type OutboxRepository_EnqueueMany_Call struct {
+	*mock.Call
+}
+
+// EnqueueMany is a helper method to define mock.On call
+//   - c context.Context
+//   - events []domain.Event
+func (_e *OutboxRepository_Expecter) EnqueueMany(c interface{}, events interface{}) *OutboxRepository_EnqueueMany_Call {
+	return &OutboxRepository_EnqueueMany_Call{Call: _e.mock.On("EnqueueMany", c, events)}
+}
+
+func (_c *OutboxRepository_EnqueueMany_Call) Run(run func(c context.Context, events []domain.Event)) *OutboxRepository_EnqueueMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.Event))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_EnqueueMany_Call) Return(_a0 error) *OutboxRepository_EnqueueMany_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRepository_EnqueueMany_Call) RunAndReturn(run func(context.Context, []domain.Event) error) *OutboxRepository_EnqueueMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkDispatched provides a mock function with given fields: c, eventID
+func (_m *OutboxRepository) MarkDispatched(c context.Context, eventID string) error {
+	ret := _m.Called(c, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkDispatched")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, eventID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRepository_MarkDispatched_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkDispatched'
+type OutboxRepository_MarkDispatched_Call struct {
+	*mock.Call
+}
+
+// MarkDispatched is a helper method to define mock.On call
+//   - c context.Context
+//   - eventID string
+func (_e *OutboxRepository_Expecter) MarkDispatched(c interface{}, eventID interface{}) *OutboxRepository_MarkDispatched_Call {
+	return &OutboxRepository_MarkDispatched_Call{Call: _e.mock.On("MarkDispatched", c, eventID)}
+}
+
+func (_c *OutboxRepository_MarkDispatched_Call) Run(run func(c context.Context, eventID string)) *OutboxRepository_MarkDispatched_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_MarkDispatched_Call) Return(_a0 error) *OutboxRepository_MarkDispatched_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRepository_MarkDispatched_Call) RunAndReturn(run func(context.Context, string) error) *OutboxRepository_MarkDispatched_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOutboxRepository creates a new instance of OutboxRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOutboxRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OutboxRepository {
+	mock := &OutboxRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
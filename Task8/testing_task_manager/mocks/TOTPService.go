@@ -0,0 +1,254 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TOTPService is an autogenerated mock type for the TOTPService type
+type TOTPService struct {
+	mock.Mock
+}
+
+type TOTPService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TOTPService) EXPECT() *TOTPService_Expecter {
+	return &TOTPService_Expecter{mock: &_m.Mock}
+}
+
+// GenerateCode provides a mock function with given fields: secret, t
+func (_m *TOTPService) GenerateCode(secret string, t time.Time) (string, error) {
+	ret := _m.Called(secret, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateCode")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) (string, error)); ok {
+		return rf(secret, t)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time) string); ok {
+		r0 = rf(secret, t)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(secret, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TOTPService_GenerateCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateCode'
+type TOTPService_GenerateCode_Call struct {
+	*mock.Call
+}
+
+// GenerateCode is a helper method to define mock.On call
+//   - secret string
+//   - t time.Time
+func (_e *TOTPService_Expecter) GenerateCode(secret interface{}, t interface{}) *TOTPService_GenerateCode_Call {
+	return &TOTPService_GenerateCode_Call{Call: _e.mock.On("GenerateCode", secret, t)}
+}
+
+func (_c *TOTPService_GenerateCode_Call) Run(run func(secret string, t time.Time)) *TOTPService_GenerateCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *TOTPService_GenerateCode_Call) Return(_a0 string, _a1 error) *TOTPService_GenerateCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TOTPService_GenerateCode_Call) RunAndReturn(run func(string, time.Time) (string, error)) *TOTPService_GenerateCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateSecret provides a mock function with given fields:
+func (_m *TOTPService) GenerateSecret() (string, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateSecret")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TOTPService_GenerateSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateSecret'
+type TOTPService_GenerateSecret_Call struct {
+	*mock.Call
+}
+
+// GenerateSecret is a helper method to define mock.On call
+func (_e *TOTPService_Expecter) GenerateSecret() *TOTPService_GenerateSecret_Call {
+	return &TOTPService_GenerateSecret_Call{Call: _e.mock.On("GenerateSecret")}
+}
+
+func (_c *TOTPService_GenerateSecret_Call) Run(run func()) *TOTPService_GenerateSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *TOTPService_GenerateSecret_Call) Return(_a0 string, _a1 error) *TOTPService_GenerateSecret_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TOTPService_GenerateSecret_Call) RunAndReturn(run func() (string, error)) *TOTPService_GenerateSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProvisioningURI provides a mock function with given fields: secret, issuer, account
+func (_m *TOTPService) ProvisioningURI(secret string, issuer string, account string) string {
+	ret := _m.Called(secret, issuer, account)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProvisioningURI")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string, string) string); ok {
+		r0 = rf(secret, issuer, account)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// TOTPService_ProvisioningURI_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProvisioningURI'
+type TOTPService_ProvisioningURI_Call struct {
+	*mock.Call
+}
+
+// ProvisioningURI is a helper method to define mock.On call
+//   - secret string
+//   - issuer string
+//   - account string
+func (_e *TOTPService_Expecter) ProvisioningURI(secret interface{}, issuer interface{}, account interface{}) *TOTPService_ProvisioningURI_Call {
+	return &TOTPService_ProvisioningURI_Call{Call: _e.mock.On("ProvisioningURI", secret, issuer, account)}
+}
+
+func (_c *TOTPService_ProvisioningURI_Call) Run(run func(secret string, issuer string, account string)) *TOTPService_ProvisioningURI_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *TOTPService_ProvisioningURI_Call) Return(_a0 string) *TOTPService_ProvisioningURI_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TOTPService_ProvisioningURI_Call) RunAndReturn(run func(string, string, string) string) *TOTPService_ProvisioningURI_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Validate provides a mock function with given fields: secret, code, t
+func (_m *TOTPService) Validate(secret string, code string, t time.Time) (int64, bool) {
+	ret := _m.Called(secret, code, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Validate")
+	}
+
+	var r0 int64
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) (int64, bool)); ok {
+		return rf(secret, code, t)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) int64); ok {
+		r0 = rf(secret, code, t)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, time.Time) bool); ok {
+		r1 = rf(secret, code, t)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// TOTPService_Validate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Validate'
+type TOTPService_Validate_Call struct {
+	*mock.Call
+}
+
+// Validate is a helper method to define mock.On call
+//   - secret string
+//   - code string
+//   - t time.Time
+func (_e *TOTPService_Expecter) Validate(secret interface{}, code interface{}, t interface{}) *TOTPService_Validate_Call {
+	return &TOTPService_Validate_Call{Call: _e.mock.On("Validate", secret, code, t)}
+}
+
+func (_c *TOTPService_Validate_Call) Run(run func(secret string, code string, t time.Time)) *TOTPService_Validate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *TOTPService_Validate_Call) Return(_a0 int64, _a1 bool) *TOTPService_Validate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TOTPService_Validate_Call) RunAndReturn(run func(string, string, time.Time) (int64, bool)) *TOTPService_Validate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTOTPService creates a new instance of TOTPService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTOTPService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TOTPService {
+	mock := &TOTPService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -7,6 +7,8 @@ import (
 	domain "test_task_manager/Domain"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // TaskRepository is an autogenerated mock type for the TaskRepository type
@@ -22,6 +24,65 @@ func (_m *TaskRepository) EXPECT() *TaskRepository_Expecter {
 	return &TaskRepository_Expecter{mock: &_m.Mock}
 }
 
+// BulkWrite provides a mock function with given fields: c, operations
+func (_m *TaskRepository) BulkWrite(c context.Context, operations []domain.BulkOperation) ([]domain.BulkResult, error) {
+	ret := _m.Called(c, operations)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkWrite")
+	}
+
+	var r0 []domain.BulkResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.BulkOperation) ([]domain.BulkResult, error)); ok {
+		return rf(c, operations)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.BulkOperation) []domain.BulkResult); ok {
+		r0 = rf(c, operations)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BulkResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.BulkOperation) error); ok {
+		r1 = rf(c, operations)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskRepository_BulkWrite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkWrite'
+type TaskRepository_BulkWrite_Call struct {
+	*mock.Call
+}
+
+// BulkWrite is a helper method to define mock.On call
+//   - c context.Context
+//   - operations []domain.BulkOperation
+func (_e *TaskRepository_Expecter) BulkWrite(c interface{}, operations interface{}) *TaskRepository_BulkWrite_Call {
+	return &TaskRepository_BulkWrite_Call{Call: _e.mock.On("BulkWrite", c, operations)}
+}
+
+func (_c *TaskRepository_BulkWrite_Call) Run(run func(c context.Context, operations []domain.BulkOperation)) *TaskRepository_BulkWrite_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.BulkOperation))
+	})
+	return _c
+}
+
+func (_c *TaskRepository_BulkWrite_Call) Return(_a0 []domain.BulkResult, _a1 error) *TaskRepository_BulkWrite_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskRepository_BulkWrite_Call) RunAndReturn(run func(context.Context, []domain.BulkOperation) ([]domain.BulkResult, error)) *TaskRepository_BulkWrite_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateTask provides a mock function with given fields: c, newTask
 func (_m *TaskRepository) CreateTask(c context.Context, newTask domain.Task) (*domain.Task, error) {
 	ret := _m.Called(c, newTask)
@@ -128,6 +189,111 @@ func (_c *TaskRepository_DeleteTask_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// ForEachTask provides a mock function with given fields: c, fn
+func (_m *TaskRepository) ForEachTask(c context.Context, fn func(domain.Task) error) error {
+	ret := _m.Called(c, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForEachTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(domain.Task) error) error); ok {
+		r0 = rf(c, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TaskRepository_ForEachTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForEachTask'
+type TaskRepository_ForEachTask_Call struct {
+	*mock.Call
+}
+
+// ForEachTask is a helper method to define mock.On call
+//   - c context.Context
+//   - fn func(domain.Task) error
+func (_e *TaskRepository_Expecter) ForEachTask(c interface{}, fn interface{}) *TaskRepository_ForEachTask_Call {
+	return &TaskRepository_ForEachTask_Call{Call: _e.mock.On("ForEachTask", c, fn)}
+}
+
+func (_c *TaskRepository_ForEachTask_Call) Run(run func(c context.Context, fn func(domain.Task) error)) *TaskRepository_ForEachTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(domain.Task) error))
+	})
+	return _c
+}
+
+func (_c *TaskRepository_ForEachTask_Call) Return(_a0 error) *TaskRepository_ForEachTask_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TaskRepository_ForEachTask_Call) RunAndReturn(run func(context.Context, func(domain.Task) error) error) *TaskRepository_ForEachTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeletedTasks provides a mock function with given fields: c
+func (_m *TaskRepository) GetDeletedTasks(c context.Context) ([]domain.Task, error) {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedTasks")
+	}
+
+	var r0 []domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Task, error)); ok {
+		return rf(c)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Task); ok {
+		r0 = rf(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskRepository_GetDeletedTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedTasks'
+type TaskRepository_GetDeletedTasks_Call struct {
+	*mock.Call
+}
+
+// GetDeletedTasks is a helper method to define mock.On call
+//   - c context.Context
+func (_e *TaskRepository_Expecter) GetDeletedTasks(c interface{}) *TaskRepository_GetDeletedTasks_Call {
+	return &TaskRepository_GetDeletedTasks_Call{Call: _e.mock.On("GetDeletedTasks", c)}
+}
+
+func (_c *TaskRepository_GetDeletedTasks_Call) Run(run func(c context.Context)) *TaskRepository_GetDeletedTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *TaskRepository_GetDeletedTasks_Call) Return(_a0 []domain.Task, _a1 error) *TaskRepository_GetDeletedTasks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskRepository_GetDeletedTasks_Call) RunAndReturn(run func(context.Context) ([]domain.Task, error)) *TaskRepository_GetDeletedTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetTaskByID provides a mock function with given fields: c, taskID
 func (_m *TaskRepository) GetTaskByID(c context.Context, taskID string) (*domain.Task, error) {
 	ret := _m.Called(c, taskID)
@@ -245,6 +411,416 @@ func (_c *TaskRepository_GetTasks_Call) RunAndReturn(run func(context.Context) (
 	return _c
 }
 
+// GetTasksByIDs provides a mock function with given fields: c, taskIDs
+func (_m *TaskRepository) GetTasksByIDs(c context.Context, taskIDs []string) ([]domain.Task, error) {
+	ret := _m.Called(c, taskIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTasksByIDs")
+	}
+
+	var r0 []domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]domain.Task, error)); ok {
+		return rf(c, taskIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []domain.Task); ok {
+		r0 = rf(c, taskIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(c, taskIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskRepository_GetTasksByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTasksByIDs'
+type TaskRepository_GetTasksByIDs_Call struct {
+	*mock.Call
+}
+
+// GetTasksByIDs is a helper method to define mock.On call
+//   - c context.Context
+//   - taskIDs []string
+func (_e *TaskRepository_Expecter) GetTasksByIDs(c interface{}, taskIDs interface{}) *TaskRepository_GetTasksByIDs_Call {
+	return &TaskRepository_GetTasksByIDs_Call{Call: _e.mock.On("GetTasksByIDs", c, taskIDs)}
+}
+
+func (_c *TaskRepository_GetTasksByIDs_Call) Run(run func(c context.Context, taskIDs []string)) *TaskRepository_GetTasksByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *TaskRepository_GetTasksByIDs_Call) Return(_a0 []domain.Task, _a1 error) *TaskRepository_GetTasksByIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskRepository_GetTasksByIDs_Call) RunAndReturn(run func(context.Context, []string) ([]domain.Task, error)) *TaskRepository_GetTasksByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeDeletedBefore provides a mock function with given fields: c, cutoff
+func (_m *TaskRepository) PurgeDeletedBefore(c context.Context, cutoff time.Time) ([]domain.Task, error) {
+	ret := _m.Called(c, cutoff)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedBefore")
+	}
+
+	var r0 []domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]domain.Task, error)); ok {
+		return rf(c, cutoff)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []domain.Task); ok {
+		r0 = rf(c, cutoff)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(c, cutoff)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskRepository_PurgeDeletedBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeletedBefore'
+type TaskRepository_PurgeDeletedBefore_Call struct {
+	*mock.Call
+}
+
+// PurgeDeletedBefore is a helper method to define mock.On call
+//   - c context.Context
+//   - cutoff time.Time
+func (_e *TaskRepository_Expecter) PurgeDeletedBefore(c interface{}, cutoff interface{}) *TaskRepository_PurgeDeletedBefore_Call {
+	return &TaskRepository_PurgeDeletedBefore_Call{Call: _e.mock.On("PurgeDeletedBefore", c, cutoff)}
+}
+
+func (_c *TaskRepository_PurgeDeletedBefore_Call) Run(run func(c context.Context, cutoff time.Time)) *TaskRepository_PurgeDeletedBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *TaskRepository_PurgeDeletedBefore_Call) Return(_a0 []domain.Task, _a1 error) *TaskRepository_PurgeDeletedBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskRepository_PurgeDeletedBefore_Call) RunAndReturn(run func(context.Context, time.Time) ([]domain.Task, error)) *TaskRepository_PurgeDeletedBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeTask provides a mock function with given fields: c, taskID
+func (_m *TaskRepository) PurgeTask(c context.Context, taskID string) error {
+	ret := _m.Called(c, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, taskID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TaskRepository_PurgeTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeTask'
+type TaskRepository_PurgeTask_Call struct {
+	*mock.Call
+}
+
+// PurgeTask is a helper method to define mock.On call
+//   - c context.Context
+//   - taskID string
+func (_e *TaskRepository_Expecter) PurgeTask(c interface{}, taskID interface{}) *TaskRepository_PurgeTask_Call {
+	return &TaskRepository_PurgeTask_Call{Call: _e.mock.On("PurgeTask", c, taskID)}
+}
+
+func (_c *TaskRepository_PurgeTask_Call) Run(run func(c context.Context, taskID string)) *TaskRepository_PurgeTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TaskRepository_PurgeTask_Call) Return(_a0 error) *TaskRepository_PurgeTask_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TaskRepository_PurgeTask_Call) RunAndReturn(run func(context.Context, string) error) *TaskRepository_PurgeTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreTask provides a mock function with given fields: c, taskID
+func (_m *TaskRepository) RestoreTask(c context.Context, taskID string) (*domain.Task, error) {
+	ret := _m.Called(c, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreTask")
+	}
+
+	var r0 *domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Task, error)); ok {
+		return rf(c, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Task); ok {
+		r0 = rf(c, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskRepository_RestoreTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreTask'
+type TaskRepository_RestoreTask_Call struct {
+	*mock.Call
+}
+
+// RestoreTask is a helper method to define mock.On call
+//   - c context.Context
+//   - taskID string
+func (_e *TaskRepository_Expecter) RestoreTask(c interface{}, taskID interface{}) *TaskRepository_RestoreTask_Call {
+	return &TaskRepository_RestoreTask_Call{Call: _e.mock.On("RestoreTask", c, taskID)}
+}
+
+func (_c *TaskRepository_RestoreTask_Call) Run(run func(c context.Context, taskID string)) *TaskRepository_RestoreTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TaskRepository_RestoreTask_Call) Return(_a0 *domain.Task, _a1 error) *TaskRepository_RestoreTask_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskRepository_RestoreTask_Call) RunAndReturn(run func(context.Context, string) (*domain.Task, error)) *TaskRepository_RestoreTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchTasks provides a mock function with given fields: c, query, offset, limit
+func (_m *TaskRepository) SearchTasks(c context.Context, query string, offset int, limit int) ([]domain.SearchHit, int, error) {
+	ret := _m.Called(c, query, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchTasks")
+	}
+
+	var r0 []domain.SearchHit
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]domain.SearchHit, int, error)); ok {
+		return rf(c, query, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []domain.SearchHit); ok {
+		r0 = rf(c, query, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SearchHit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int); ok {
+		r1 = rf(c, query, offset, limit)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
+		r2 = rf(c, query, offset, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// TaskRepository_SearchTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchTasks'
+type TaskRepository_SearchTasks_Call struct {
+	*mock.Call
+}
+
+// SearchTasks is a helper method to define mock.On call
+//   - c context.Context
+//   - query string
+//   - offset int
+//   - limit int
+func (_e *TaskRepository_Expecter) SearchTasks(c interface{}, query interface{}, offset interface{}, limit interface{}) *TaskRepository_SearchTasks_Call {
+	return &TaskRepository_SearchTasks_Call{Call: _e.mock.On("SearchTasks", c, query, offset, limit)}
+}
+
+func (_c *TaskRepository_SearchTasks_Call) Run(run func(c context.Context, query string, offset int, limit int)) *TaskRepository_SearchTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *TaskRepository_SearchTasks_Call) Return(_a0 []domain.SearchHit, _a1 int, _a2 error) *TaskRepository_SearchTasks_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *TaskRepository_SearchTasks_Call) RunAndReturn(run func(context.Context, string, int, int) ([]domain.SearchHit, int, error)) *TaskRepository_SearchTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TaskActivity provides a mock function with given fields: c, until
+func (_m *TaskRepository) TaskActivity(c context.Context, until time.Time) ([]domain.DailyActivity, error) {
+	ret := _m.Called(c, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TaskActivity")
+	}
+
+	var r0 []domain.DailyActivity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]domain.DailyActivity, error)); ok {
+		return rf(c, until)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []domain.DailyActivity); ok {
+		r0 = rf(c, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.DailyActivity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(c, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskRepository_TaskActivity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TaskActivity'
+type TaskRepository_TaskActivity_Call struct {
+	*mock.Call
+}
+
+// TaskActivity is a helper method to define mock.On call
+//   - c context.Context
+//   - until time.Time
+func (_e *TaskRepository_Expecter) TaskActivity(c interface{}, until interface{}) *TaskRepository_TaskActivity_Call {
+	return &TaskRepository_TaskActivity_Call{Call: _e.mock.On("TaskActivity", c, until)}
+}
+
+func (_c *TaskRepository_TaskActivity_Call) Run(run func(c context.Context, until time.Time)) *TaskRepository_TaskActivity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *TaskRepository_TaskActivity_Call) Return(_a0 []domain.DailyActivity, _a1 error) *TaskRepository_TaskActivity_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskRepository_TaskActivity_Call) RunAndReturn(run func(context.Context, time.Time) ([]domain.DailyActivity, error)) *TaskRepository_TaskActivity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TaskStats provides a mock function with given fields: c, now
+func (_m *TaskRepository) TaskStats(c context.Context, now time.Time) (*domain.TaskStats, error) {
+	ret := _m.Called(c, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TaskStats")
+	}
+
+	var r0 *domain.TaskStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (*domain.TaskStats, error)); ok {
+		return rf(c, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) *domain.TaskStats); ok {
+		r0 = rf(c, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TaskStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(c, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskRepository_TaskStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TaskStats'
+type TaskRepository_TaskStats_Call struct {
+	*mock.Call
+}
+
+// TaskStats is a helper method to define mock.On call
+//   - c context.Context
+//   - now time.Time
+func (_e *TaskRepository_Expecter) TaskStats(c interface{}, now interface{}) *TaskRepository_TaskStats_Call {
+	return &TaskRepository_TaskStats_Call{Call: _e.mock.On("TaskStats", c, now)}
+}
+
+func (_c *TaskRepository_TaskStats_Call) Run(run func(c context.Context, now time.Time)) *TaskRepository_TaskStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *TaskRepository_TaskStats_Call) Return(_a0 *domain.TaskStats, _a1 error) *TaskRepository_TaskStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskRepository_TaskStats_Call) RunAndReturn(run func(context.Context, time.Time) (*domain.TaskStats, error)) *TaskRepository_TaskStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateTask provides a mock function with given fields: c, taskID, updatedTask
 func (_m *TaskRepository) UpdateTask(c context.Context, taskID string, updatedTask domain.Task) (*domain.Task, error) {
 	ret := _m.Called(c, taskID, updatedTask)
@@ -7,6 +7,8 @@ import (
 	domain "test_task_manager/Domain"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // TaskUseCase is an autogenerated mock type for the TaskUseCase type
@@ -22,6 +24,66 @@ func (_m *TaskUseCase) EXPECT() *TaskUseCase_Expecter {
 	return &TaskUseCase_Expecter{mock: &_m.Mock}
 }
 
+// BulkWrite provides a mock function with given fields: c, operations, atomic
+func (_m *TaskUseCase) BulkWrite(c context.Context, operations []domain.BulkOperation, atomic bool) ([]domain.BulkResult, error) {
+	ret := _m.Called(c, operations, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkWrite")
+	}
+
+	var r0 []domain.BulkResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.BulkOperation, bool) ([]domain.BulkResult, error)); ok {
+		return rf(c, operations, atomic)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.BulkOperation, bool) []domain.BulkResult); ok {
+		r0 = rf(c, operations, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BulkResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.BulkOperation, bool) error); ok {
+		r1 = rf(c, operations, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUseCase_BulkWrite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkWrite'
+type TaskUseCase_BulkWrite_Call struct {
+	*mock.Call
+}
+
+// BulkWrite is a helper method to define mock.On call
+//   - c context.Context
+//   - operations []domain.BulkOperation
+//   - atomic bool
+func (_e *TaskUseCase_Expecter) BulkWrite(c interface{}, operations interface{}, atomic interface{}) *TaskUseCase_BulkWrite_Call {
+	return &TaskUseCase_BulkWrite_Call{Call: _e.mock.On("BulkWrite", c, operations, atomic)}
+}
+
+func (_c *TaskUseCase_BulkWrite_Call) Run(run func(c context.Context, operations []domain.BulkOperation, atomic bool)) *TaskUseCase_BulkWrite_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.BulkOperation), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_BulkWrite_Call) Return(_a0 []domain.BulkResult, _a1 error) *TaskUseCase_BulkWrite_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskUseCase_BulkWrite_Call) RunAndReturn(run func(context.Context, []domain.BulkOperation, bool) ([]domain.BulkResult, error)) *TaskUseCase_BulkWrite_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateTask provides a mock function with given fields: c, newTask
 func (_m *TaskUseCase) CreateTask(c context.Context, newTask domain.Task) (*domain.Task, error) {
 	ret := _m.Called(c, newTask)
@@ -128,6 +190,173 @@ func (_c *TaskUseCase_DeleteTask_Call) RunAndReturn(run func(context.Context, st
 	return _c
 }
 
+// ExportTasks provides a mock function with given fields: c, fn
+func (_m *TaskUseCase) ExportTasks(c context.Context, fn func(domain.Task) error) error {
+	ret := _m.Called(c, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportTasks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(domain.Task) error) error); ok {
+		r0 = rf(c, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TaskUseCase_ExportTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportTasks'
+type TaskUseCase_ExportTasks_Call struct {
+	*mock.Call
+}
+
+// ExportTasks is a helper method to define mock.On call
+//   - c context.Context
+//   - fn func(domain.Task) error
+func (_e *TaskUseCase_Expecter) ExportTasks(c interface{}, fn interface{}) *TaskUseCase_ExportTasks_Call {
+	return &TaskUseCase_ExportTasks_Call{Call: _e.mock.On("ExportTasks", c, fn)}
+}
+
+func (_c *TaskUseCase_ExportTasks_Call) Run(run func(c context.Context, fn func(domain.Task) error)) *TaskUseCase_ExportTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(domain.Task) error))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_ExportTasks_Call) Return(_a0 error) *TaskUseCase_ExportTasks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TaskUseCase_ExportTasks_Call) RunAndReturn(run func(context.Context, func(domain.Task) error) error) *TaskUseCase_ExportTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBurndown provides a mock function with given fields: c, from, to
+func (_m *TaskUseCase) GetBurndown(c context.Context, from time.Time, to time.Time) (*domain.Burndown, error) {
+	ret := _m.Called(c, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBurndown")
+	}
+
+	var r0 *domain.Burndown
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) (*domain.Burndown, error)); ok {
+		return rf(c, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) *domain.Burndown); ok {
+		r0 = rf(c, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Burndown)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(c, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUseCase_GetBurndown_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBurndown'
+type TaskUseCase_GetBurndown_Call struct {
+	*mock.Call
+}
+
+// GetBurndown is a helper method to define mock.On call
+//   - c context.Context
+//   - from time.Time
+//   - to time.Time
+func (_e *TaskUseCase_Expecter) GetBurndown(c interface{}, from interface{}, to interface{}) *TaskUseCase_GetBurndown_Call {
+	return &TaskUseCase_GetBurndown_Call{Call: _e.mock.On("GetBurndown", c, from, to)}
+}
+
+func (_c *TaskUseCase_GetBurndown_Call) Run(run func(c context.Context, from time.Time, to time.Time)) *TaskUseCase_GetBurndown_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_GetBurndown_Call) Return(_a0 *domain.Burndown, _a1 error) *TaskUseCase_GetBurndown_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskUseCase_GetBurndown_Call) RunAndReturn(run func(context.Context, time.Time, time.Time) (*domain.Burndown, error)) *TaskUseCase_GetBurndown_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSummary provides a mock function with given fields: c, from, to
+func (_m *TaskUseCase) GetSummary(c context.Context, from time.Time, to time.Time) (*domain.TaskSummary, error) {
+	ret := _m.Called(c, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSummary")
+	}
+
+	var r0 *domain.TaskSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) (*domain.TaskSummary, error)); ok {
+		return rf(c, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) *domain.TaskSummary); ok {
+		r0 = rf(c, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TaskSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(c, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUseCase_GetSummary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSummary'
+type TaskUseCase_GetSummary_Call struct {
+	*mock.Call
+}
+
+// GetSummary is a helper method to define mock.On call
+//   - c context.Context
+//   - from time.Time
+//   - to time.Time
+func (_e *TaskUseCase_Expecter) GetSummary(c interface{}, from interface{}, to interface{}) *TaskUseCase_GetSummary_Call {
+	return &TaskUseCase_GetSummary_Call{Call: _e.mock.On("GetSummary", c, from, to)}
+}
+
+func (_c *TaskUseCase_GetSummary_Call) Run(run func(c context.Context, from time.Time, to time.Time)) *TaskUseCase_GetSummary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_GetSummary_Call) Return(_a0 *domain.TaskSummary, _a1 error) *TaskUseCase_GetSummary_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskUseCase_GetSummary_Call) RunAndReturn(run func(context.Context, time.Time, time.Time) (*domain.TaskSummary, error)) *TaskUseCase_GetSummary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetTaskByID provides a mock function with given fields: c, taskID
 func (_m *TaskUseCase) GetTaskByID(c context.Context, taskID string) (*domain.Task, error) {
 	ret := _m.Called(c, taskID)
@@ -245,6 +474,348 @@ func (_c *TaskUseCase_GetTasks_Call) RunAndReturn(run func(context.Context) ([]d
 	return _c
 }
 
+// GetTrash provides a mock function with given fields: c
+func (_m *TaskUseCase) GetTrash(c context.Context) ([]domain.Task, error) {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrash")
+	}
+
+	var r0 []domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Task, error)); ok {
+		return rf(c)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Task); ok {
+		r0 = rf(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUseCase_GetTrash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTrash'
+type TaskUseCase_GetTrash_Call struct {
+	*mock.Call
+}
+
+// GetTrash is a helper method to define mock.On call
+//   - c context.Context
+func (_e *TaskUseCase_Expecter) GetTrash(c interface{}) *TaskUseCase_GetTrash_Call {
+	return &TaskUseCase_GetTrash_Call{Call: _e.mock.On("GetTrash", c)}
+}
+
+func (_c *TaskUseCase_GetTrash_Call) Run(run func(c context.Context)) *TaskUseCase_GetTrash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_GetTrash_Call) Return(_a0 []domain.Task, _a1 error) *TaskUseCase_GetTrash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskUseCase_GetTrash_Call) RunAndReturn(run func(context.Context) ([]domain.Task, error)) *TaskUseCase_GetTrash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportTasks provides a mock function with given fields: c, rows, dryRun
+func (_m *TaskUseCase) ImportTasks(c context.Context, rows []domain.ImportRow, dryRun bool) (*domain.ImportReport, error) {
+	ret := _m.Called(c, rows, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportTasks")
+	}
+
+	var r0 *domain.ImportReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.ImportRow, bool) (*domain.ImportReport, error)); ok {
+		return rf(c, rows, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.ImportRow, bool) *domain.ImportReport); ok {
+		r0 = rf(c, rows, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ImportReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.ImportRow, bool) error); ok {
+		r1 = rf(c, rows, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUseCase_ImportTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportTasks'
+type TaskUseCase_ImportTasks_Call struct {
+	*mock.Call
+}
+
+// ImportTasks is a helper method to define mock.On call
+//   - c context.Context
+//   - rows []domain.ImportRow
+//   - dryRun bool
+func (_e *TaskUseCase_Expecter) ImportTasks(c interface{}, rows interface{}, dryRun interface{}) *TaskUseCase_ImportTasks_Call {
+	return &TaskUseCase_ImportTasks_Call{Call: _e.mock.On("ImportTasks", c, rows, dryRun)}
+}
+
+func (_c *TaskUseCase_ImportTasks_Call) Run(run func(c context.Context, rows []domain.ImportRow, dryRun bool)) *TaskUseCase_ImportTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.ImportRow), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_ImportTasks_Call) Return(_a0 *domain.ImportReport, _a1 error) *TaskUseCase_ImportTasks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskUseCase_ImportTasks_Call) RunAndReturn(run func(context.Context, []domain.ImportRow, bool) (*domain.ImportReport, error)) *TaskUseCase_ImportTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeExpiredTasks provides a mock function with given fields: c, retention
+func (_m *TaskUseCase) PurgeExpiredTasks(c context.Context, retention time.Duration) (int, error) {
+	ret := _m.Called(c, retention)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeExpiredTasks")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) (int, error)); ok {
+		return rf(c, retention)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) int); ok {
+		r0 = rf(c, retention)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(c, retention)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUseCase_PurgeExpiredTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeExpiredTasks'
+type TaskUseCase_PurgeExpiredTasks_Call struct {
+	*mock.Call
+}
+
+// PurgeExpiredTasks is a helper method to define mock.On call
+//   - c context.Context
+//   - retention time.Duration
+func (_e *TaskUseCase_Expecter) PurgeExpiredTasks(c interface{}, retention interface{}) *TaskUseCase_PurgeExpiredTasks_Call {
+	return &TaskUseCase_PurgeExpiredTasks_Call{Call: _e.mock.On("PurgeExpiredTasks", c, retention)}
+}
+
+func (_c *TaskUseCase_PurgeExpiredTasks_Call) Run(run func(c context.Context, retention time.Duration)) *TaskUseCase_PurgeExpiredTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_PurgeExpiredTasks_Call) Return(_a0 int, _a1 error) *TaskUseCase_PurgeExpiredTasks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskUseCase_PurgeExpiredTasks_Call) RunAndReturn(run func(context.Context, time.Duration) (int, error)) *TaskUseCase_PurgeExpiredTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeTask provides a mock function with given fields: c, taskID
+func (_m *TaskUseCase) PurgeTask(c context.Context, taskID string) error {
+	ret := _m.Called(c, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, taskID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TaskUseCase_PurgeTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeTask'
+type TaskUseCase_PurgeTask_Call struct {
+	*mock.Call
+}
+
+// PurgeTask is a helper method to define mock.On call
+//   - c context.Context
+//   - taskID string
+func (_e *TaskUseCase_Expecter) PurgeTask(c interface{}, taskID interface{}) *TaskUseCase_PurgeTask_Call {
+	return &TaskUseCase_PurgeTask_Call{Call: _e.mock.On("PurgeTask", c, taskID)}
+}
+
+func (_c *TaskUseCase_PurgeTask_Call) Run(run func(c context.Context, taskID string)) *TaskUseCase_PurgeTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_PurgeTask_Call) Return(_a0 error) *TaskUseCase_PurgeTask_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TaskUseCase_PurgeTask_Call) RunAndReturn(run func(context.Context, string) error) *TaskUseCase_PurgeTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreTask provides a mock function with given fields: c, taskID
+func (_m *TaskUseCase) RestoreTask(c context.Context, taskID string) (*domain.Task, error) {
+	ret := _m.Called(c, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreTask")
+	}
+
+	var r0 *domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Task, error)); ok {
+		return rf(c, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Task); ok {
+		r0 = rf(c, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUseCase_RestoreTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreTask'
+type TaskUseCase_RestoreTask_Call struct {
+	*mock.Call
+}
+
+// RestoreTask is a helper method to define mock.On call
+//   - c context.Context
+//   - taskID string
+func (_e *TaskUseCase_Expecter) RestoreTask(c interface{}, taskID interface{}) *TaskUseCase_RestoreTask_Call {
+	return &TaskUseCase_RestoreTask_Call{Call: _e.mock.On("RestoreTask", c, taskID)}
+}
+
+func (_c *TaskUseCase_RestoreTask_Call) Run(run func(c context.Context, taskID string)) *TaskUseCase_RestoreTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_RestoreTask_Call) Return(_a0 *domain.Task, _a1 error) *TaskUseCase_RestoreTask_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskUseCase_RestoreTask_Call) RunAndReturn(run func(context.Context, string) (*domain.Task, error)) *TaskUseCase_RestoreTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchTasks provides a mock function with given fields: c, query, page, limit
+func (_m *TaskUseCase) SearchTasks(c context.Context, query string, page int, limit int) (*domain.SearchResult, error) {
+	ret := _m.Called(c, query, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchTasks")
+	}
+
+	var r0 *domain.SearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) (*domain.SearchResult, error)); ok {
+		return rf(c, query, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) *domain.SearchResult); ok {
+		r0 = rf(c, query, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(c, query, page, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUseCase_SearchTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchTasks'
+type TaskUseCase_SearchTasks_Call struct {
+	*mock.Call
+}
+
+// SearchTasks is a helper method to define mock.On call
+//   - c context.Context
+//   - query string
+//   - page int
+//   - limit int
+func (_e *TaskUseCase_Expecter) SearchTasks(c interface{}, query interface{}, page interface{}, limit interface{}) *TaskUseCase_SearchTasks_Call {
+	return &TaskUseCase_SearchTasks_Call{Call: _e.mock.On("SearchTasks", c, query, page, limit)}
+}
+
+func (_c *TaskUseCase_SearchTasks_Call) Run(run func(c context.Context, query string, page int, limit int)) *TaskUseCase_SearchTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_SearchTasks_Call) Return(_a0 *domain.SearchResult, _a1 error) *TaskUseCase_SearchTasks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskUseCase_SearchTasks_Call) RunAndReturn(run func(context.Context, string, int, int) (*domain.SearchResult, error)) *TaskUseCase_SearchTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateTask provides a mock function with given fields: c, taskID, updatedTask
 func (_m *TaskUseCase) UpdateTask(c context.Context, taskID string, updatedTask domain.Task) (*domain.Task, error) {
 	ret := _m.Called(c, taskID, updatedTask)
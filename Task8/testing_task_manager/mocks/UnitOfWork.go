@@ -0,0 +1,131 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "test_task_manager/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UnitOfWork is an autogenerated mock type for the UnitOfWork type
+type UnitOfWork struct {
+	mock.Mock
+}
+
+type UnitOfWork_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UnitOfWork) EXPECT() *UnitOfWork_Expecter {
+	return &UnitOfWork_Expecter{mock: &_m.Mock}
+}
+
+// Do provides a mock function with given fields: c, fn
+func (_m *UnitOfWork) Do(c context.Context, fn func(context.Context, domain.Stores) error) error {
+	ret := _m.Called(c, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Do")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context, domain.Stores) error) error); ok {
+		r0 = rf(c, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnitOfWork_Do_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Do'
+type UnitOfWork_Do_Call struct {
+	*mock.Call
+}
+
+// Do is a helper method to define mock.On call
+//   - c context.Context
+//   - fn func(context.Context , domain.Stores) error
+func (_e *UnitOfWork_Expecter) Do(c interface{}, fn interface{}) *UnitOfWork_Do_Call {
+	return &UnitOfWork_Do_Call{Call: _e.mock.On("Do", c, fn)}
+}
+
+func (_c *UnitOfWork_Do_Call) Run(run func(c context.Context, fn func(context.Context, domain.Stores) error)) *UnitOfWork_Do_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(context.Context, domain.Stores) error))
+	})
+	return _c
+}
+
+func (_c *UnitOfWork_Do_Call) Return(_a0 error) *UnitOfWork_Do_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UnitOfWork_Do_Call) RunAndReturn(run func(context.Context, func(context.Context, domain.Stores) error) error) *UnitOfWork_Do_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DoAtomic provides a mock function with given fields: c, fn
+func (_m *UnitOfWork) DoAtomic(c context.Context, fn func(context.Context, domain.Stores) error) error {
+	ret := _m.Called(c, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DoAtomic")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context, domain.Stores) error) error); ok {
+		r0 = rf(c, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnitOfWork_DoAtomic_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DoAtomic'
+type UnitOfWork_DoAtomic_Call struct {
+	*mock.Call
+}
+
+// DoAtomic is a helper method to define mock.On call
+//   - c context.Context
+//   - fn func(context.Context , domain.Stores) error
+func (_e *UnitOfWork_Expecter) DoAtomic(c interface{}, fn interface{}) *UnitOfWork_DoAtomic_Call {
+	return &UnitOfWork_DoAtomic_Call{Call: _e.mock.On("DoAtomic", c, fn)}
+}
+
+func (_c *UnitOfWork_DoAtomic_Call) Run(run func(c context.Context, fn func(context.Context, domain.Stores) error)) *UnitOfWork_DoAtomic_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(context.Context, domain.Stores) error))
+	})
+	return _c
+}
+
+func (_c *UnitOfWork_DoAtomic_Call) Return(_a0 error) *UnitOfWork_DoAtomic_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UnitOfWork_DoAtomic_Call) RunAndReturn(run func(context.Context, func(context.Context, domain.Stores) error) error) *UnitOfWork_DoAtomic_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUnitOfWork creates a new instance of UnitOfWork. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUnitOfWork(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UnitOfWork {
+	mock := &UnitOfWork{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -22,6 +22,54 @@ func (_m *UserRepository) EXPECT() *UserRepository_Expecter {
 	return &UserRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddMembership provides a mock function with given fields: c, username, role
+func (_m *UserRepository) AddMembership(c context.Context, username string, role string) error {
+	ret := _m.Called(c, username, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMembership")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, username, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepository_AddMembership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddMembership'
+type UserRepository_AddMembership_Call struct {
+	*mock.Call
+}
+
+// AddMembership is a helper method to define mock.On call
+//   - c context.Context
+//   - username string
+//   - role string
+func (_e *UserRepository_Expecter) AddMembership(c interface{}, username interface{}, role interface{}) *UserRepository_AddMembership_Call {
+	return &UserRepository_AddMembership_Call{Call: _e.mock.On("AddMembership", c, username, role)}
+}
+
+func (_c *UserRepository_AddMembership_Call) Run(run func(c context.Context, username string, role string)) *UserRepository_AddMembership_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_AddMembership_Call) Return(_a0 error) *UserRepository_AddMembership_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepository_AddMembership_Call) RunAndReturn(run func(context.Context, string, string) error) *UserRepository_AddMembership_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateUser provides a mock function with given fields: c, user
 func (_m *UserRepository) CreateUser(c context.Context, user domain.User) error {
 	ret := _m.Called(c, user)
@@ -69,6 +117,124 @@ func (_c *UserRepository_CreateUser_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// FindByEmail provides a mock function with given fields: c, email
+func (_m *UserRepository) FindByEmail(c context.Context, email string) (*domain.User, error) {
+	ret := _m.Called(c, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByEmail")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return rf(c, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = rf(c, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_FindByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByEmail'
+type UserRepository_FindByEmail_Call struct {
+	*mock.Call
+}
+
+// FindByEmail is a helper method to define mock.On call
+//   - c context.Context
+//   - email string
+func (_e *UserRepository_Expecter) FindByEmail(c interface{}, email interface{}) *UserRepository_FindByEmail_Call {
+	return &UserRepository_FindByEmail_Call{Call: _e.mock.On("FindByEmail", c, email)}
+}
+
+func (_c *UserRepository_FindByEmail_Call) Run(run func(c context.Context, email string)) *UserRepository_FindByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_FindByEmail_Call) Return(_a0 *domain.User, _a1 error) *UserRepository_FindByEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_FindByEmail_Call) RunAndReturn(run func(context.Context, string) (*domain.User, error)) *UserRepository_FindByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByOIDCSubject provides a mock function with given fields: c, subject
+func (_m *UserRepository) FindByOIDCSubject(c context.Context, subject string) (*domain.User, error) {
+	ret := _m.Called(c, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByOIDCSubject")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return rf(c, subject)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = rf(c, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_FindByOIDCSubject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByOIDCSubject'
+type UserRepository_FindByOIDCSubject_Call struct {
+	*mock.Call
+}
+
+// FindByOIDCSubject is a helper method to define mock.On call
+//   - c context.Context
+//   - subject string
+func (_e *UserRepository_Expecter) FindByOIDCSubject(c interface{}, subject interface{}) *UserRepository_FindByOIDCSubject_Call {
+	return &UserRepository_FindByOIDCSubject_Call{Call: _e.mock.On("FindByOIDCSubject", c, subject)}
+}
+
+func (_c *UserRepository_FindByOIDCSubject_Call) Run(run func(c context.Context, subject string)) *UserRepository_FindByOIDCSubject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_FindByOIDCSubject_Call) Return(_a0 *domain.User, _a1 error) *UserRepository_FindByOIDCSubject_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_FindByOIDCSubject_Call) RunAndReturn(run func(context.Context, string) (*domain.User, error)) *UserRepository_FindByOIDCSubject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindByUsername provides a mock function with given fields: c, username
 func (_m *UserRepository) FindByUsername(c context.Context, username string) (*domain.User, error) {
 	ret := _m.Called(c, username)
@@ -186,6 +352,102 @@ func (_c *UserRepository_GetUsers_Call) RunAndReturn(run func(context.Context) (
 	return _c
 }
 
+// LinkOIDCSubject provides a mock function with given fields: c, username, subject
+func (_m *UserRepository) LinkOIDCSubject(c context.Context, username string, subject string) error {
+	ret := _m.Called(c, username, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LinkOIDCSubject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, username, subject)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepository_LinkOIDCSubject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LinkOIDCSubject'
+type UserRepository_LinkOIDCSubject_Call struct {
+	*mock.Call
+}
+
+// LinkOIDCSubject is a helper method to define mock.On call
+//   - c context.Context
+//   - username string
+//   - subject string
+func (_e *UserRepository_Expecter) LinkOIDCSubject(c interface{}, username interface{}, subject interface{}) *UserRepository_LinkOIDCSubject_Call {
+	return &UserRepository_LinkOIDCSubject_Call{Call: _e.mock.On("LinkOIDCSubject", c, username, subject)}
+}
+
+func (_c *UserRepository_LinkOIDCSubject_Call) Run(run func(c context.Context, username string, subject string)) *UserRepository_LinkOIDCSubject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_LinkOIDCSubject_Call) Return(_a0 error) *UserRepository_LinkOIDCSubject_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepository_LinkOIDCSubject_Call) RunAndReturn(run func(context.Context, string, string) error) *UserRepository_LinkOIDCSubject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkEmailVerified provides a mock function with given fields: c, username, email
+func (_m *UserRepository) MarkEmailVerified(c context.Context, username string, email string) error {
+	ret := _m.Called(c, username, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkEmailVerified")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, username, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepository_MarkEmailVerified_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkEmailVerified'
+type UserRepository_MarkEmailVerified_Call struct {
+	*mock.Call
+}
+
+// MarkEmailVerified is a helper method to define mock.On call
+//   - c context.Context
+//   - username string
+//   - email string
+func (_e *UserRepository_Expecter) MarkEmailVerified(c interface{}, username interface{}, email interface{}) *UserRepository_MarkEmailVerified_Call {
+	return &UserRepository_MarkEmailVerified_Call{Call: _e.mock.On("MarkEmailVerified", c, username, email)}
+}
+
+func (_c *UserRepository_MarkEmailVerified_Call) Run(run func(c context.Context, username string, email string)) *UserRepository_MarkEmailVerified_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_MarkEmailVerified_Call) Return(_a0 error) *UserRepository_MarkEmailVerified_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepository_MarkEmailVerified_Call) RunAndReturn(run func(context.Context, string, string) error) *UserRepository_MarkEmailVerified_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PromoteUser provides a mock function with given fields: c, username
 func (_m *UserRepository) PromoteUser(c context.Context, username string) (*domain.User, error) {
 	ret := _m.Called(c, username)
@@ -245,6 +507,103 @@ func (_c *UserRepository_PromoteUser_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// SetRole provides a mock function with given fields: c, username, role
+func (_m *UserRepository) SetRole(c context.Context, username string, role string) error {
+	ret := _m.Called(c, username, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, username, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepository_SetRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRole'
+type UserRepository_SetRole_Call struct {
+	*mock.Call
+}
+
+// SetRole is a helper method to define mock.On call
+//   - c context.Context
+//   - username string
+//   - role string
+func (_e *UserRepository_Expecter) SetRole(c interface{}, username interface{}, role interface{}) *UserRepository_SetRole_Call {
+	return &UserRepository_SetRole_Call{Call: _e.mock.On("SetRole", c, username, role)}
+}
+
+func (_c *UserRepository_SetRole_Call) Run(run func(c context.Context, username string, role string)) *UserRepository_SetRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_SetRole_Call) Return(_a0 error) *UserRepository_SetRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepository_SetRole_Call) RunAndReturn(run func(context.Context, string, string) error) *UserRepository_SetRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMFA provides a mock function with given fields: c, username, current, updated
+func (_m *UserRepository) UpdateMFA(c context.Context, username string, current *domain.MFA, updated *domain.MFA) error {
+	ret := _m.Called(c, username, current, updated)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMFA")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.MFA, *domain.MFA) error); ok {
+		r0 = rf(c, username, current, updated)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepository_UpdateMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMFA'
+type UserRepository_UpdateMFA_Call struct {
+	*mock.Call
+}
+
+// UpdateMFA is a helper method to define mock.On call
+//   - c context.Context
+//   - username string
+//   - current *domain.MFA
+//   - updated *domain.MFA
+func (_e *UserRepository_Expecter) UpdateMFA(c interface{}, username interface{}, current interface{}, updated interface{}) *UserRepository_UpdateMFA_Call {
+	return &UserRepository_UpdateMFA_Call{Call: _e.mock.On("UpdateMFA", c, username, current, updated)}
+}
+
+func (_c *UserRepository_UpdateMFA_Call) Run(run func(c context.Context, username string, current *domain.MFA, updated *domain.MFA)) *UserRepository_UpdateMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*domain.MFA), args[3].(*domain.MFA))
+	})
+	return _c
+}
+
+func (_c *UserRepository_UpdateMFA_Call) Return(_a0 error) *UserRepository_UpdateMFA_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepository_UpdateMFA_Call) RunAndReturn(run func(context.Context, string, *domain.MFA, *domain.MFA) error) *UserRepository_UpdateMFA_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewUserRepository creates a new instance of UserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepository(t interface {
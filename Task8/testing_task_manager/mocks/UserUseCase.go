@@ -22,6 +22,53 @@ func (_m *UserUseCase) EXPECT() *UserUseCase_Expecter {
 	return &UserUseCase_Expecter{mock: &_m.Mock}
 }
 
+// CreateAdmin provides a mock function with given fields: c, user
+func (_m *UserUseCase) CreateAdmin(c context.Context, user domain.User) error {
+	ret := _m.Called(c, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAdmin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.User) error); ok {
+		r0 = rf(c, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserUseCase_CreateAdmin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAdmin'
+type UserUseCase_CreateAdmin_Call struct {
+	*mock.Call
+}
+
+// CreateAdmin is a helper method to define mock.On call
+//   - c context.Context
+//   - user domain.User
+func (_e *UserUseCase_Expecter) CreateAdmin(c interface{}, user interface{}) *UserUseCase_CreateAdmin_Call {
+	return &UserUseCase_CreateAdmin_Call{Call: _e.mock.On("CreateAdmin", c, user)}
+}
+
+func (_c *UserUseCase_CreateAdmin_Call) Run(run func(c context.Context, user domain.User)) *UserUseCase_CreateAdmin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.User))
+	})
+	return _c
+}
+
+func (_c *UserUseCase_CreateAdmin_Call) Return(_a0 error) *UserUseCase_CreateAdmin_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserUseCase_CreateAdmin_Call) RunAndReturn(run func(context.Context, domain.User) error) *UserUseCase_CreateAdmin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateUser provides a mock function with given fields: c, user
 func (_m *UserUseCase) CreateUser(c context.Context, user domain.User) error {
 	ret := _m.Called(c, user)
@@ -128,22 +175,24 @@ func (_c *UserUseCase_GetUsers_Call) RunAndReturn(run func(context.Context) ([]d
 }
 
 // Login provides a mock function with given fields: c, user
-func (_m *UserUseCase) Login(c context.Context, user domain.User) (string, error) {
+func (_m *UserUseCase) Login(c context.Context, user domain.User) (*domain.LoginResult, error) {
 	ret := _m.Called(c, user)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
 	}
 
-	var r0 string
+	var r0 *domain.LoginResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.User) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, domain.User) (*domain.LoginResult, error)); ok {
 		return rf(c, user)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.User) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, domain.User) *domain.LoginResult); ok {
 		r0 = rf(c, user)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.LoginResult)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, domain.User) error); ok {
@@ -174,12 +223,12 @@ func (_c *UserUseCase_Login_Call) Run(run func(c context.Context, user domain.Us
 	return _c
 }
 
-func (_c *UserUseCase_Login_Call) Return(_a0 string, _a1 error) *UserUseCase_Login_Call {
+func (_c *UserUseCase_Login_Call) Return(_a0 *domain.LoginResult, _a1 error) *UserUseCase_Login_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *UserUseCase_Login_Call) RunAndReturn(run func(context.Context, domain.User) (string, error)) *UserUseCase_Login_Call {
+func (_c *UserUseCase_Login_Call) RunAndReturn(run func(context.Context, domain.User) (*domain.LoginResult, error)) *UserUseCase_Login_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -243,6 +292,100 @@ func (_c *UserUseCase_PromoteUser_Call) RunAndReturn(run func(context.Context, s
 	return _c
 }
 
+// ResendVerification provides a mock function with given fields: c, email
+func (_m *UserUseCase) ResendVerification(c context.Context, email string) error {
+	ret := _m.Called(c, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResendVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserUseCase_ResendVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResendVerification'
+type UserUseCase_ResendVerification_Call struct {
+	*mock.Call
+}
+
+// ResendVerification is a helper method to define mock.On call
+//   - c context.Context
+//   - email string
+func (_e *UserUseCase_Expecter) ResendVerification(c interface{}, email interface{}) *UserUseCase_ResendVerification_Call {
+	return &UserUseCase_ResendVerification_Call{Call: _e.mock.On("ResendVerification", c, email)}
+}
+
+func (_c *UserUseCase_ResendVerification_Call) Run(run func(c context.Context, email string)) *UserUseCase_ResendVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserUseCase_ResendVerification_Call) Return(_a0 error) *UserUseCase_ResendVerification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserUseCase_ResendVerification_Call) RunAndReturn(run func(context.Context, string) error) *UserUseCase_ResendVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyEmail provides a mock function with given fields: c, token
+func (_m *UserUseCase) VerifyEmail(c context.Context, token string) error {
+	ret := _m.Called(c, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserUseCase_VerifyEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyEmail'
+type UserUseCase_VerifyEmail_Call struct {
+	*mock.Call
+}
+
+// VerifyEmail is a helper method to define mock.On call
+//   - c context.Context
+//   - token string
+func (_e *UserUseCase_Expecter) VerifyEmail(c interface{}, token interface{}) *UserUseCase_VerifyEmail_Call {
+	return &UserUseCase_VerifyEmail_Call{Call: _e.mock.On("VerifyEmail", c, token)}
+}
+
+func (_c *UserUseCase_VerifyEmail_Call) Run(run func(c context.Context, token string)) *UserUseCase_VerifyEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserUseCase_VerifyEmail_Call) Return(_a0 error) *UserUseCase_VerifyEmail_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserUseCase_VerifyEmail_Call) RunAndReturn(run func(context.Context, string) error) *UserUseCase_VerifyEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewUserUseCase creates a new instance of UserUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserUseCase(t interface {
@@ -0,0 +1,357 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "test_task_manager/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// WebhookDeliveryRepository is an autogenerated mock type for the WebhookDeliveryRepository type
+type WebhookDeliveryRepository struct {
+	mock.Mock
+}
+
+type WebhookDeliveryRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WebhookDeliveryRepository) EXPECT() *WebhookDeliveryRepository_Expecter {
+	return &WebhookDeliveryRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimDueDelivery provides a mock function with given fields: c, now, until
+func (_m *WebhookDeliveryRepository) ClaimDueDelivery(c context.Context, now time.Time, until time.Time) (*domain.WebhookDelivery, error) {
+	ret := _m.Called(c, now, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDueDelivery")
+	}
+
+	var r0 *domain.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) (*domain.WebhookDelivery, error)); ok {
+		return rf(c, now, until)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) *domain.WebhookDelivery); ok {
+		r0 = rf(c, now, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(c, now, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookDeliveryRepository_ClaimDueDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDueDelivery'
+type WebhookDeliveryRepository_ClaimDueDelivery_Call struct {
+	*mock.Call
+}
+
+// ClaimDueDelivery is a helper method to define mock.On call
+//   - c context.Context
+//   - now time.Time
+//   - until time.Time
+func (_e *WebhookDeliveryRepository_Expecter) ClaimDueDelivery(c interface{}, now interface{}, until interface{}) *WebhookDeliveryRepository_ClaimDueDelivery_Call {
+	return &WebhookDeliveryRepository_ClaimDueDelivery_Call{Call: _e.mock.On("ClaimDueDelivery", c, now, until)}
+}
+
+func (_c *WebhookDeliveryRepository_ClaimDueDelivery_Call) Run(run func(c context.Context, now time.Time, until time.Time)) *WebhookDeliveryRepository_ClaimDueDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_ClaimDueDelivery_Call) Return(_a0 *domain.WebhookDelivery, _a1 error) *WebhookDeliveryRepository_ClaimDueDelivery_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_ClaimDueDelivery_Call) RunAndReturn(run func(context.Context, time.Time, time.Time) (*domain.WebhookDelivery, error)) *WebhookDeliveryRepository_ClaimDueDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateDeadLetter provides a mock function with given fields: c, deadLetter
+func (_m *WebhookDeliveryRepository) CreateDeadLetter(c context.Context, deadLetter domain.WebhookDeadLetter) error {
+	ret := _m.Called(c, deadLetter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDeadLetter")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.WebhookDeadLetter) error); ok {
+		r0 = rf(c, deadLetter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveryRepository_CreateDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDeadLetter'
+type WebhookDeliveryRepository_CreateDeadLetter_Call struct {
+	*mock.Call
+}
+
+// CreateDeadLetter is a helper method to define mock.On call
+//   - c context.Context
+//   - deadLetter domain.WebhookDeadLetter
+func (_e *WebhookDeliveryRepository_Expecter) CreateDeadLetter(c interface{}, deadLetter interface{}) *WebhookDeliveryRepository_CreateDeadLetter_Call {
+	return &WebhookDeliveryRepository_CreateDeadLetter_Call{Call: _e.mock.On("CreateDeadLetter", c, deadLetter)}
+}
+
+func (_c *WebhookDeliveryRepository_CreateDeadLetter_Call) Run(run func(c context.Context, deadLetter domain.WebhookDeadLetter)) *WebhookDeliveryRepository_CreateDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.WebhookDeadLetter))
+	})
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_CreateDeadLetter_Call) Return(_a0 error) *WebhookDeliveryRepository_CreateDeadLetter_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_CreateDeadLetter_Call) RunAndReturn(run func(context.Context, domain.WebhookDeadLetter) error) *WebhookDeliveryRepository_CreateDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateDelivery provides a mock function with given fields: c, delivery
+func (_m *WebhookDeliveryRepository) CreateDelivery(c context.Context, delivery domain.WebhookDelivery) error {
+	ret := _m.Called(c, delivery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.WebhookDelivery) error); ok {
+		r0 = rf(c, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveryRepository_CreateDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDelivery'
+type WebhookDeliveryRepository_CreateDelivery_Call struct {
+	*mock.Call
+}
+
+// CreateDelivery is a helper method to define mock.On call
+//   - c context.Context
+//   - delivery domain.WebhookDelivery
+func (_e *WebhookDeliveryRepository_Expecter) CreateDelivery(c interface{}, delivery interface{}) *WebhookDeliveryRepository_CreateDelivery_Call {
+	return &WebhookDeliveryRepository_CreateDelivery_Call{Call: _e.mock.On("CreateDelivery", c, delivery)}
+}
+
+func (_c *WebhookDeliveryRepository_CreateDelivery_Call) Run(run func(c context.Context, delivery domain.WebhookDelivery)) *WebhookDeliveryRepository_CreateDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.WebhookDelivery))
+	})
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_CreateDelivery_Call) Return(_a0 error) *WebhookDeliveryRepository_CreateDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_CreateDelivery_Call) RunAndReturn(run func(context.Context, domain.WebhookDelivery) error) *WebhookDeliveryRepository_CreateDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeadLetters provides a mock function with given fields: c
+func (_m *WebhookDeliveryRepository) GetDeadLetters(c context.Context) ([]domain.WebhookDeadLetter, error) {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeadLetters")
+	}
+
+	var r0 []domain.WebhookDeadLetter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.WebhookDeadLetter, error)); ok {
+		return rf(c)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.WebhookDeadLetter); ok {
+		r0 = rf(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.WebhookDeadLetter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookDeliveryRepository_GetDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeadLetters'
+type WebhookDeliveryRepository_GetDeadLetters_Call struct {
+	*mock.Call
+}
+
+// GetDeadLetters is a helper method to define mock.On call
+//   - c context.Context
+func (_e *WebhookDeliveryRepository_Expecter) GetDeadLetters(c interface{}) *WebhookDeliveryRepository_GetDeadLetters_Call {
+	return &WebhookDeliveryRepository_GetDeadLetters_Call{Call: _e.mock.On("GetDeadLetters", c)}
+}
+
+func (_c *WebhookDeliveryRepository_GetDeadLetters_Call) Run(run func(c context.Context)) *WebhookDeliveryRepository_GetDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_GetDeadLetters_Call) Return(_a0 []domain.WebhookDeadLetter, _a1 error) *WebhookDeliveryRepository_GetDeadLetters_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_GetDeadLetters_Call) RunAndReturn(run func(context.Context) ([]domain.WebhookDeadLetter, error)) *WebhookDeliveryRepository_GetDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeliveriesByWebhook provides a mock function with given fields: c, webhookID
+func (_m *WebhookDeliveryRepository) GetDeliveriesByWebhook(c context.Context, webhookID string) ([]domain.WebhookDelivery, error) {
+	ret := _m.Called(c, webhookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeliveriesByWebhook")
+	}
+
+	var r0 []domain.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.WebhookDelivery, error)); ok {
+		return rf(c, webhookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.WebhookDelivery); ok {
+		r0 = rf(c, webhookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookDeliveryRepository_GetDeliveriesByWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeliveriesByWebhook'
+type WebhookDeliveryRepository_GetDeliveriesByWebhook_Call struct {
+	*mock.Call
+}
+
+// GetDeliveriesByWebhook is a helper method to define mock.On call
+//   - c context.Context
+//   - webhookID string
+func (_e *WebhookDeliveryRepository_Expecter) GetDeliveriesByWebhook(c interface{}, webhookID interface{}) *WebhookDeliveryRepository_GetDeliveriesByWebhook_Call {
+	return &WebhookDeliveryRepository_GetDeliveriesByWebhook_Call{Call: _e.mock.On("GetDeliveriesByWebhook", c, webhookID)}
+}
+
+func (_c *WebhookDeliveryRepository_GetDeliveriesByWebhook_Call) Run(run func(c context.Context, webhookID string)) *WebhookDeliveryRepository_GetDeliveriesByWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_GetDeliveriesByWebhook_Call) Return(_a0 []domain.WebhookDelivery, _a1 error) *WebhookDeliveryRepository_GetDeliveriesByWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_GetDeliveriesByWebhook_Call) RunAndReturn(run func(context.Context, string) ([]domain.WebhookDelivery, error)) *WebhookDeliveryRepository_GetDeliveriesByWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateDelivery provides a mock function with given fields: c, delivery
+func (_m *WebhookDeliveryRepository) UpdateDelivery(c context.Context, delivery domain.WebhookDelivery) error {
+	ret := _m.Called(c, delivery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.WebhookDelivery) error); ok {
+		r0 = rf(c, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveryRepository_UpdateDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateDelivery'
+type WebhookDeliveryRepository_UpdateDelivery_Call struct {
+	*mock.Call
+}
+
+// UpdateDelivery is a helper method to define mock.On call
+//   - c context.Context
+//   - delivery domain.WebhookDelivery
+func (_e *WebhookDeliveryRepository_Expecter) UpdateDelivery(c interface{}, delivery interface{}) *WebhookDeliveryRepository_UpdateDelivery_Call {
+	return &WebhookDeliveryRepository_UpdateDelivery_Call{Call: _e.mock.On("UpdateDelivery", c, delivery)}
+}
+
+func (_c *WebhookDeliveryRepository_UpdateDelivery_Call) Run(run func(c context.Context, delivery domain.WebhookDelivery)) *WebhookDeliveryRepository_UpdateDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.WebhookDelivery))
+	})
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_UpdateDelivery_Call) Return(_a0 error) *WebhookDeliveryRepository_UpdateDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_UpdateDelivery_Call) RunAndReturn(run func(context.Context, domain.WebhookDelivery) error) *WebhookDeliveryRepository_UpdateDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWebhookDeliveryRepository creates a new instance of WebhookDeliveryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookDeliveryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookDeliveryRepository {
+	mock := &WebhookDeliveryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,307 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "test_task_manager/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebhookRepository is an autogenerated mock type for the WebhookRepository type
+type WebhookRepository struct {
+	mock.Mock
+}
+
+type WebhookRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WebhookRepository) EXPECT() *WebhookRepository_Expecter {
+	return &WebhookRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateWebhook provides a mock function with given fields: c, webhook
+func (_m *WebhookRepository) CreateWebhook(c context.Context, webhook domain.Webhook) error {
+	ret := _m.Called(c, webhook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Webhook) error); ok {
+		r0 = rf(c, webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookRepository_CreateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhook'
+type WebhookRepository_CreateWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWebhook is a helper method to define mock.On call
+//   - c context.Context
+//   - webhook domain.Webhook
+func (_e *WebhookRepository_Expecter) CreateWebhook(c interface{}, webhook interface{}) *WebhookRepository_CreateWebhook_Call {
+	return &WebhookRepository_CreateWebhook_Call{Call: _e.mock.On("CreateWebhook", c, webhook)}
+}
+
+func (_c *WebhookRepository_CreateWebhook_Call) Run(run func(c context.Context, webhook domain.Webhook)) *WebhookRepository_CreateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Webhook))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_CreateWebhook_Call) Return(_a0 error) *WebhookRepository_CreateWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookRepository_CreateWebhook_Call) RunAndReturn(run func(context.Context, domain.Webhook) error) *WebhookRepository_CreateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhook provides a mock function with given fields: c, webhookID
+func (_m *WebhookRepository) DeleteWebhook(c context.Context, webhookID string) error {
+	ret := _m.Called(c, webhookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, webhookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookRepository_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type WebhookRepository_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - c context.Context
+//   - webhookID string
+func (_e *WebhookRepository_Expecter) DeleteWebhook(c interface{}, webhookID interface{}) *WebhookRepository_DeleteWebhook_Call {
+	return &WebhookRepository_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", c, webhookID)}
+}
+
+func (_c *WebhookRepository_DeleteWebhook_Call) Run(run func(c context.Context, webhookID string)) *WebhookRepository_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_DeleteWebhook_Call) Return(_a0 error) *WebhookRepository_DeleteWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookRepository_DeleteWebhook_Call) RunAndReturn(run func(context.Context, string) error) *WebhookRepository_DeleteWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhookByID provides a mock function with given fields: c, webhookID
+func (_m *WebhookRepository) GetWebhookByID(c context.Context, webhookID string) (*domain.Webhook, error) {
+	ret := _m.Called(c, webhookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookByID")
+	}
+
+	var r0 *domain.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Webhook, error)); ok {
+		return rf(c, webhookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Webhook); ok {
+		r0 = rf(c, webhookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookRepository_GetWebhookByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookByID'
+type WebhookRepository_GetWebhookByID_Call struct {
+	*mock.Call
+}
+
+// GetWebhookByID is a helper method to define mock.On call
+//   - c context.Context
+//   - webhookID string
+func (_e *WebhookRepository_Expecter) GetWebhookByID(c interface{}, webhookID interface{}) *WebhookRepository_GetWebhookByID_Call {
+	return &WebhookRepository_GetWebhookByID_Call{Call: _e.mock.On("GetWebhookByID", c, webhookID)}
+}
+
+func (_c *WebhookRepository_GetWebhookByID_Call) Run(run func(c context.Context, webhookID string)) *WebhookRepository_GetWebhookByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_GetWebhookByID_Call) Return(_a0 *domain.Webhook, _a1 error) *WebhookRepository_GetWebhookByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookRepository_GetWebhookByID_Call) RunAndReturn(run func(context.Context, string) (*domain.Webhook, error)) *WebhookRepository_GetWebhookByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhooks provides a mock function with given fields: c
+func (_m *WebhookRepository) GetWebhooks(c context.Context) ([]domain.Webhook, error) {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhooks")
+	}
+
+	var r0 []domain.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Webhook, error)); ok {
+		return rf(c)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Webhook); ok {
+		r0 = rf(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookRepository_GetWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhooks'
+type WebhookRepository_GetWebhooks_Call struct {
+	*mock.Call
+}
+
+// GetWebhooks is a helper method to define mock.On call
+//   - c context.Context
+func (_e *WebhookRepository_Expecter) GetWebhooks(c interface{}) *WebhookRepository_GetWebhooks_Call {
+	return &WebhookRepository_GetWebhooks_Call{Call: _e.mock.On("GetWebhooks", c)}
+}
+
+func (_c *WebhookRepository_GetWebhooks_Call) Run(run func(c context.Context)) *WebhookRepository_GetWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_GetWebhooks_Call) Return(_a0 []domain.Webhook, _a1 error) *WebhookRepository_GetWebhooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookRepository_GetWebhooks_Call) RunAndReturn(run func(context.Context) ([]domain.Webhook, error)) *WebhookRepository_GetWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhooksForEvent provides a mock function with given fields: c, eventType
+func (_m *WebhookRepository) GetWebhooksForEvent(c context.Context, eventType string) ([]domain.Webhook, error) {
+	ret := _m.Called(c, eventType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhooksForEvent")
+	}
+
+	var r0 []domain.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Webhook, error)); ok {
+		return rf(c, eventType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Webhook); ok {
+		r0 = rf(c, eventType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, eventType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookRepository_GetWebhooksForEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhooksForEvent'
+type WebhookRepository_GetWebhooksForEvent_Call struct {
+	*mock.Call
+}
+
+// GetWebhooksForEvent is a helper method to define mock.On call
+//   - c context.Context
+//   - eventType string
+func (_e *WebhookRepository_Expecter) GetWebhooksForEvent(c interface{}, eventType interface{}) *WebhookRepository_GetWebhooksForEvent_Call {
+	return &WebhookRepository_GetWebhooksForEvent_Call{Call: _e.mock.On("GetWebhooksForEvent", c, eventType)}
+}
+
+func (_c *WebhookRepository_GetWebhooksForEvent_Call) Run(run func(c context.Context, eventType string)) *WebhookRepository_GetWebhooksForEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_GetWebhooksForEvent_Call) Return(_a0 []domain.Webhook, _a1 error) *WebhookRepository_GetWebhooksForEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookRepository_GetWebhooksForEvent_Call) RunAndReturn(run func(context.Context, string) ([]domain.Webhook, error)) *WebhookRepository_GetWebhooksForEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWebhookRepository creates a new instance of WebhookRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookRepository {
+	mock := &WebhookRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	infrastructure "test_task_manager/Infrastructure"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebhookSender is an autogenerated mock type for the WebhookSender type
+type WebhookSender struct {
+	mock.Mock
+}
+
+type WebhookSender_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WebhookSender) EXPECT() *WebhookSender_Expecter {
+	return &WebhookSender_Expecter{mock: &_m.Mock}
+}
+
+// Send provides a mock function with given fields: c, request
+func (_m *WebhookSender) Send(c context.Context, request infrastructure.WebhookRequest) (int, error) {
+	ret := _m.Called(c, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, infrastructure.WebhookRequest) (int, error)); ok {
+		return rf(c, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, infrastructure.WebhookRequest) int); ok {
+		r0 = rf(c, request)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, infrastructure.WebhookRequest) error); ok {
+		r1 = rf(c, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookSender_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type WebhookSender_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - c context.Context
+//   - request infrastructure.WebhookRequest
+func (_e *WebhookSender_Expecter) Send(c interface{}, request interface{}) *WebhookSender_Send_Call {
+	return &WebhookSender_Send_Call{Call: _e.mock.On("Send", c, request)}
+}
+
+func (_c *WebhookSender_Send_Call) Run(run func(c context.Context, request infrastructure.WebhookRequest)) *WebhookSender_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(infrastructure.WebhookRequest))
+	})
+	return _c
+}
+
+func (_c *WebhookSender_Send_Call) Return(_a0 int, _a1 error) *WebhookSender_Send_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookSender_Send_Call) RunAndReturn(run func(context.Context, infrastructure.WebhookRequest) (int, error)) *WebhookSender_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWebhookSender creates a new instance of WebhookSender. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookSender(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookSender {
+	mock := &WebhookSender{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
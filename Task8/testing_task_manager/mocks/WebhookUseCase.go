@@ -0,0 +1,318 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "test_task_manager/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebhookUseCase is an autogenerated mock type for the WebhookUseCase type
+type WebhookUseCase struct {
+	mock.Mock
+}
+
+type WebhookUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WebhookUseCase) EXPECT() *WebhookUseCase_Expecter {
+	return &WebhookUseCase_Expecter{mock: &_m.Mock}
+}
+
+// CreateWebhook provides a mock function with given fields: c, webhook
+func (_m *WebhookUseCase) CreateWebhook(c context.Context, webhook domain.Webhook) (*domain.Webhook, error) {
+	ret := _m.Called(c, webhook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 *domain.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Webhook) (*domain.Webhook, error)); ok {
+		return rf(c, webhook)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Webhook) *domain.Webhook); ok {
+		r0 = rf(c, webhook)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Webhook) error); ok {
+		r1 = rf(c, webhook)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookUseCase_CreateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhook'
+type WebhookUseCase_CreateWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWebhook is a helper method to define mock.On call
+//   - c context.Context
+//   - webhook domain.Webhook
+func (_e *WebhookUseCase_Expecter) CreateWebhook(c interface{}, webhook interface{}) *WebhookUseCase_CreateWebhook_Call {
+	return &WebhookUseCase_CreateWebhook_Call{Call: _e.mock.On("CreateWebhook", c, webhook)}
+}
+
+func (_c *WebhookUseCase_CreateWebhook_Call) Run(run func(c context.Context, webhook domain.Webhook)) *WebhookUseCase_CreateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Webhook))
+	})
+	return _c
+}
+
+func (_c *WebhookUseCase_CreateWebhook_Call) Return(_a0 *domain.Webhook, _a1 error) *WebhookUseCase_CreateWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookUseCase_CreateWebhook_Call) RunAndReturn(run func(context.Context, domain.Webhook) (*domain.Webhook, error)) *WebhookUseCase_CreateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhook provides a mock function with given fields: c, webhookID
+func (_m *WebhookUseCase) DeleteWebhook(c context.Context, webhookID string) error {
+	ret := _m.Called(c, webhookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, webhookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookUseCase_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type WebhookUseCase_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - c context.Context
+//   - webhookID string
+func (_e *WebhookUseCase_Expecter) DeleteWebhook(c interface{}, webhookID interface{}) *WebhookUseCase_DeleteWebhook_Call {
+	return &WebhookUseCase_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", c, webhookID)}
+}
+
+func (_c *WebhookUseCase_DeleteWebhook_Call) Run(run func(c context.Context, webhookID string)) *WebhookUseCase_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *WebhookUseCase_DeleteWebhook_Call) Return(_a0 error) *WebhookUseCase_DeleteWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookUseCase_DeleteWebhook_Call) RunAndReturn(run func(context.Context, string) error) *WebhookUseCase_DeleteWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeadLetters provides a mock function with given fields: c
+func (_m *WebhookUseCase) GetDeadLetters(c context.Context) ([]domain.WebhookDeadLetter, error) {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeadLetters")
+	}
+
+	var r0 []domain.WebhookDeadLetter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.WebhookDeadLetter, error)); ok {
+		return rf(c)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.WebhookDeadLetter); ok {
+		r0 = rf(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.WebhookDeadLetter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookUseCase_GetDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeadLetters'
+type WebhookUseCase_GetDeadLetters_Call struct {
+	*mock.Call
+}
+
+// GetDeadLetters is a helper method to define mock.On call
+//   - c context.Context
+func (_e *WebhookUseCase_Expecter) GetDeadLetters(c interface{}) *WebhookUseCase_GetDeadLetters_Call {
+	return &WebhookUseCase_GetDeadLetters_Call{Call: _e.mock.On("GetDeadLetters", c)}
+}
+
+func (_c *WebhookUseCase_GetDeadLetters_Call) Run(run func(c context.Context)) *WebhookUseCase_GetDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *WebhookUseCase_GetDeadLetters_Call) Return(_a0 []domain.WebhookDeadLetter, _a1 error) *WebhookUseCase_GetDeadLetters_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookUseCase_GetDeadLetters_Call) RunAndReturn(run func(context.Context) ([]domain.WebhookDeadLetter, error)) *WebhookUseCase_GetDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeliveries provides a mock function with given fields: c, webhookID
+func (_m *WebhookUseCase) GetDeliveries(c context.Context, webhookID string) ([]domain.WebhookDelivery, error) {
+	ret := _m.Called(c, webhookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeliveries")
+	}
+
+	var r0 []domain.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.WebhookDelivery, error)); ok {
+		return rf(c, webhookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.WebhookDelivery); ok {
+		r0 = rf(c, webhookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookUseCase_GetDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeliveries'
+type WebhookUseCase_GetDeliveries_Call struct {
+	*mock.Call
+}
+
+// GetDeliveries is a helper method to define mock.On call
+//   - c context.Context
+//   - webhookID string
+func (_e *WebhookUseCase_Expecter) GetDeliveries(c interface{}, webhookID interface{}) *WebhookUseCase_GetDeliveries_Call {
+	return &WebhookUseCase_GetDeliveries_Call{Call: _e.mock.On("GetDeliveries", c, webhookID)}
+}
+
+func (_c *WebhookUseCase_GetDeliveries_Call) Run(run func(c context.Context, webhookID string)) *WebhookUseCase_GetDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *WebhookUseCase_GetDeliveries_Call) Return(_a0 []domain.WebhookDelivery, _a1 error) *WebhookUseCase_GetDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookUseCase_GetDeliveries_Call) RunAndReturn(run func(context.Context, string) ([]domain.WebhookDelivery, error)) *WebhookUseCase_GetDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhooks provides a mock function with given fields: c
+func (_m *WebhookUseCase) GetWebhooks(c context.Context) ([]domain.Webhook, error) {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhooks")
+	}
+
+	var r0 []domain.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Webhook, error)); ok {
+		return rf(c)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Webhook); ok {
+		r0 = rf(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookUseCase_GetWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhooks'
+type WebhookUseCase_GetWebhooks_Call struct {
+	*mock.Call
+}
+
+// GetWebhooks is a helper method to define mock.On call
+//   - c context.Context
+func (_e *WebhookUseCase_Expecter) GetWebhooks(c interface{}) *WebhookUseCase_GetWebhooks_Call {
+	return &WebhookUseCase_GetWebhooks_Call{Call: _e.mock.On("GetWebhooks", c)}
+}
+
+func (_c *WebhookUseCase_GetWebhooks_Call) Run(run func(c context.Context)) *WebhookUseCase_GetWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *WebhookUseCase_GetWebhooks_Call) Return(_a0 []domain.Webhook, _a1 error) *WebhookUseCase_GetWebhooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookUseCase_GetWebhooks_Call) RunAndReturn(run func(context.Context) ([]domain.Webhook, error)) *WebhookUseCase_GetWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWebhookUseCase creates a new instance of WebhookUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookUseCase {
+	mock := &WebhookUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}